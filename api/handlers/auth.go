@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+)
+
+// PrincipalHeader contains the name of the authenticated principal. The API gateway forwards it to the services behind it,
+// e.g. to record the principal as author of the changes made to the resources of a project
+const PrincipalHeader = "X-Keptn-Principal"
+
+// PrincipalEmailHeader contains the email address of the authenticated principal, if known
+const PrincipalEmailHeader = "X-Keptn-Principal-Email"
+
+// GetAuthHandlerFunc confirms a successful authentication and returns the subject and email of the authenticated principal.
+// If OAuth is enabled, the API is expected to be exposed via an OAuth proxy that validates the access token of the request
+// and passes on the subject and email of the token in oauthSubjectHeader and oauthEmailHeader. These take precedence over
+// the principal of the API token
+func GetAuthHandlerFunc(oauthSubjectHeader, oauthEmailHeader string) func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
+	return func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
+		authenticated := models.Principal{}
+		if principal != nil {
+			authenticated = *principal
+		}
+		if oauthSubject := getOAuthHeader(params.HTTPRequest, oauthSubjectHeader); oauthSubject != "" {
+			authenticated = models.Principal{
+				Subject: oauthSubject,
+				Email:   getOAuthHeader(params.HTTPRequest, oauthEmailHeader),
+			}
+		}
+
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, producer runtime.Producer) {
+			rw.Header().Set(PrincipalHeader, authenticated.Subject)
+			rw.Header().Set(PrincipalEmailHeader, authenticated.Email)
+			auth.NewAuthOK().WriteResponse(rw, producer)
+		})
+	}
+}
+
+func getOAuthHeader(req *http.Request, header string) string {
+	if req == nil || header == "" {
+		return ""
+	}
+	return req.Header.Get(header)
+}
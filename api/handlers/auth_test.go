@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keptn/keptn/api/models"
+	"github.com/keptn/keptn/api/restapi/operations/auth"
+)
+
+func TestGetAuthHandlerFunc(t *testing.T) {
+	principal := models.Principal{Subject: "keptn-api-token"}
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth", nil)
+	req.Header.Set("X-Forwarded-User", "jane")
+	responder := GetAuthHandlerFunc("", "")(auth.AuthParams{HTTPRequest: req}, &principal)
+
+	recorder := httptest.NewRecorder()
+	responder.WriteResponse(recorder, runtime.JSONProducer())
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "keptn-api-token", recorder.Header().Get(PrincipalHeader))
+	require.Empty(t, recorder.Header().Get(PrincipalEmailHeader))
+}
+
+func TestGetAuthHandlerFunc_OAuth(t *testing.T) {
+	principal := models.Principal{Subject: "keptn-api-token"}
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth", nil)
+	req.Header.Set("X-Forwarded-User", "jane")
+	req.Header.Set("X-Forwarded-Email", "jane@example.com")
+	responder := GetAuthHandlerFunc("X-Forwarded-User", "X-Forwarded-Email")(auth.AuthParams{HTTPRequest: req}, &principal)
+
+	recorder := httptest.NewRecorder()
+	responder.WriteResponse(recorder, runtime.JSONProducer())
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "jane", recorder.Header().Get(PrincipalHeader))
+	require.Equal(t, "jane@example.com", recorder.Header().Get(PrincipalEmailHeader))
+}
+
+func TestGetAuthHandlerFunc_OAuthWithoutSubject(t *testing.T) {
+	principal := models.Principal{Subject: "keptn-api-token"}
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth", nil)
+	req.Header.Set("X-Forwarded-Email", "jane@example.com")
+	responder := GetAuthHandlerFunc("X-Forwarded-User", "X-Forwarded-Email")(auth.AuthParams{HTTPRequest: req}, &principal)
+
+	recorder := httptest.NewRecorder()
+	responder.WriteResponse(recorder, runtime.JSONProducer())
+
+	require.Equal(t, "keptn-api-token", recorder.Header().Get(PrincipalHeader))
+	require.Empty(t, recorder.Header().Get(PrincipalEmailHeader))
+}
@@ -13,12 +13,15 @@ type TokenValidator interface {
 	ValidateToken(token string) (*models.Principal, error)
 }
 
-type BasicTokenValidator struct{}
+// BasicTokenValidator validates the API token configured via SECRET_TOKEN. The principal of a valid token is identified by
+// TokenName, so that the token itself is never passed on
+type BasicTokenValidator struct {
+	TokenName string
+}
 
 func (b *BasicTokenValidator) ValidateToken(token string) (*models.Principal, error) {
 	if token == os.Getenv("SECRET_TOKEN") {
-		prin := models.Principal(token)
-		return &prin, nil
+		return &models.Principal{Subject: b.TokenName}, nil
 	}
 	log.Warn("Access attempt with incorrect API token")
 	return nil, openapierrors.New(http.StatusUnauthorized, "incorrect api key auth")
@@ -24,7 +24,7 @@ func TestValidateToken(t *testing.T) {
 				token: "my-token",
 			},
 			configuredToken: "my-token",
-			want:            models.Principal("my-token"),
+			want:            models.Principal{Subject: "keptn-api-token"},
 			wantErr:         false,
 		},
 		{
@@ -39,13 +39,13 @@ func TestValidateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Setenv("SECRET_TOKEN", tt.configuredToken)
-			tv := &BasicTokenValidator{}
+			tv := &BasicTokenValidator{TokenName: "keptn-api-token"}
 			got, err := tv.ValidateToken(tt.args.token)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if tt.want != "" {
+			if !tt.wantErr {
 				if !reflect.DeepEqual(*got, tt.want) {
 					t.Errorf("ValidateToken() got = %v, want %v", got, tt.want)
 				}
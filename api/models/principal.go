@@ -11,9 +11,16 @@ import (
 
 // Principal principal
 // swagger:model principal
-type Principal string
+type Principal struct {
+
+	// subject
+	Subject string `json:"subject,omitempty"`
+
+	// email
+	Email string `json:"email,omitempty"`
+}
 
 // Validate validates this principal
-func (m Principal) Validate(formats strfmt.Registry) error {
+func (m *Principal) Validate(formats strfmt.Registry) error {
 	return nil
 }
@@ -16,14 +16,12 @@ import (
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime"
-	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/keptn/keptn/api/handlers"
 	"github.com/keptn/keptn/api/importer"
 	"github.com/keptn/keptn/api/importer/execute"
 	"github.com/keptn/keptn/api/importer/model"
 	custommiddleware "github.com/keptn/keptn/api/middleware"
-	"github.com/keptn/keptn/api/models"
 	"github.com/keptn/keptn/api/restapi/operations"
 	"github.com/keptn/keptn/api/restapi/operations/auth"
 	"github.com/keptn/keptn/api/restapi/operations/event"
@@ -46,6 +44,9 @@ type EnvConfig struct {
 	MaxEventSizeKB            int64   `envconfig:"MAX_EVENT_SIZE_KB" default:"64"`
 	OAuthEnabled              bool    `envconfig:"OAUTH_ENABLED" default:"false"`
 	OAuthPrefix               string  `envconfig:"OAUTH_PREFIX" default:"keptn:"`
+	APITokenName              string  `envconfig:"API_TOKEN_NAME" default:"keptn-api-token"`
+	OAuthSubjectHeader        string  `envconfig:"OAUTH_SUBJECT_HEADER" default:"X-Forwarded-User"`
+	OAuthEmailHeader          string  `envconfig:"OAUTH_EMAIL_HEADER" default:"X-Forwarded-Email"`
 }
 
 // MaxEventSizeBytes returns MaxEventSizeKB in bytes
@@ -86,7 +87,7 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	api.JSONProducer = runtime.JSONProducer()
 
 	// Applies when the "x-token" header is set
-	tokenValidator := &custommiddleware.BasicTokenValidator{}
+	tokenValidator := &custommiddleware.BasicTokenValidator{TokenName: env.APITokenName}
 	api.KeyAuth = tokenValidator.ValidateToken
 
 	// Set your custom authorizer if needed. Default one is security.Authorized()
@@ -94,11 +95,11 @@ func configureAPI(api *operations.KeptnAPI) http.Handler {
 	//
 	// Example:
 	// api.APIAuthorizer = security.Authorized()
-	api.AuthAuthHandler = auth.AuthHandlerFunc(
-		func(params auth.AuthParams, principal *models.Principal) middleware.Responder {
-			return auth.NewAuthOK()
-		},
-	)
+	oauthSubjectHeader, oauthEmailHeader := "", ""
+	if env.OAuthEnabled {
+		oauthSubjectHeader, oauthEmailHeader = env.OAuthSubjectHeader, env.OAuthEmailHeader
+	}
+	api.AuthAuthHandler = auth.AuthHandlerFunc(handlers.GetAuthHandlerFunc(oauthSubjectHeader, oauthEmailHeader))
 
 	api.EventPostEventHandler = event.PostEventHandlerFunc(handlers.PostEventHandlerFunc(env.EventValidationEnabled))
 	// api.EventGetEventHandler = event.GetEventHandlerFunc(handlers.GetEventHandlerFunc)
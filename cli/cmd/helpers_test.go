@@ -91,3 +91,11 @@ func fullTrim(str string) string {
 func stringp(s string) *string {
 	return &s
 }
+
+func intp(i int) *int {
+	return &i
+}
+
+func boolp(b bool) *bool {
+	return &b
+}
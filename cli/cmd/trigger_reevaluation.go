@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// reEvaluationOfLabel is added to the new evaluation and contains the ID of the invalidated evaluation.finished event
+	reEvaluationOfLabel = "reevaluationOf"
+	// reEvaluationOfContextLabel is added to the new evaluation and contains the Keptn context of the invalidated evaluation
+	reEvaluationOfContextLabel = "reevaluationOfContext"
+)
+
+// reEvaluationRetryTime is the interval in which the result of the re-evaluation is fetched
+var reEvaluationRetryTime = 5 * time.Second
+
+type triggerReEvaluationStruct struct {
+	Project        *string            `json:"project"`
+	Stage          *string            `json:"stage"`
+	Service        *string            `json:"service"`
+	KeptnContext   *string            `json:"keptnContext"`
+	UseOriginalSLO *bool              `json:"useOriginalSLO"`
+	Labels         *map[string]string `json:"labels"`
+	Timeout        *int               `json:"timeout"`
+	Watch          *bool
+	WatchTime      *int
+	Output         *string
+}
+
+var triggerReEvaluation triggerReEvaluationStruct
+
+var triggerReEvaluationCmd = &cobra.Command{
+	Use:   "reevaluation",
+	Args:  cobra.NoArgs,
+	Short: "Re-runs a previous evaluation of a service and invalidates the previous result",
+	Long: `Re-runs a previous evaluation of a service and invalidates the previous result.
+
+* This command takes the project (--project), stage (--stage), and the service (--service), as well as the Keptn context (--keptn-context) of the evaluation that should be re-run.
+* The new evaluation uses the same time frame and labels as the previous one. The SLIs are fetched again from the SLI provider.
+* By default, the current SLO file is used. To use the SLO file that was used for the previous evaluation, please specify --use-original-slo.
+* The new evaluation result contains the labels 'reevaluationOf' and 'reevaluationOfContext' referencing the previous result.
+* The previous result is invalidated, and therefore no longer used for comparisons, once the new evaluation has finished. If the new evaluation fails with an error or does not finish within --timeout seconds, the previous result is kept.
+`,
+	Example:      `keptn trigger reevaluation --project=sockshop --stage=hardening --service=carts --keptn-context=1234-5678-90ab-cdef [--use-original-slo] [--labels=reason=fixed-sli-query]`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doTriggerReEvaluation(triggerReEvaluation)
+	},
+}
+
+func doTriggerReEvaluation(reEvaluationData triggerReEvaluationStruct) error {
+	const userFriendlyDateLayout = "2006-01-02T15:04:05.000Z"
+
+	var endPoint url.URL
+	var apiToken string
+	var err error
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = ""
+	}
+	if err != nil {
+		return errors.New(authErrorMsg)
+	}
+
+	logging.PrintLog("Starting to re-run evaluation of the service "+
+		*reEvaluationData.Service+" in project "+*reEvaluationData.Project, logging.InfoLevel)
+
+	api, err := internal.APIProvider(endPoint.String(), apiToken)
+	if err != nil {
+		return err
+	}
+
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+	previousEvent, previousEvaluation, err := getEvaluationToReEvaluate(api.EventsV1(), reEvaluationData)
+	if err != nil {
+		return err
+	}
+
+	start, err := timeutils.ParseTimestamp(previousEvaluation.Evaluation.TimeStart)
+	if err != nil {
+		return fmt.Errorf("could not parse start time of previous evaluation: %s", err.Error())
+	}
+	end, err := timeutils.ParseTimestamp(previousEvaluation.Evaluation.TimeEnd)
+	if err != nil {
+		return fmt.Errorf("could not parse end time of previous evaluation: %s", err.Error())
+	}
+
+	gitCommitID := ""
+	if *reEvaluationData.UseOriginalSLO {
+		if previousEvent.GitCommitID == "" {
+			return errors.New("the previous evaluation does not contain a git commit ID, please re-run the evaluation without --use-original-slo")
+		}
+		gitCommitID = previousEvent.GitCommitID
+	}
+
+	response, errObj := api.APIV1().TriggerEvaluation(
+		*reEvaluationData.Project,
+		*reEvaluationData.Stage,
+		*reEvaluationData.Service,
+		apimodels.Evaluation{
+			Start:       start.Format(userFriendlyDateLayout),
+			End:         end.Format(userFriendlyDateLayout),
+			Labels:      getReEvaluationLabels(previousEvent, previousEvaluation, reEvaluationData.Labels),
+			GitCommitID: gitCommitID,
+		},
+	)
+	if errObj != nil {
+		logging.PrintLog("trigger re-evaluation was unsuccessful", logging.QuietLevel)
+		return fmt.Errorf("trigger re-evaluation was unsuccessful. %s", *errObj.Message)
+	}
+	if response == nil || response.KeptnContext == nil {
+		logging.PrintLog("No event returned", logging.QuietLevel)
+		return nil
+	}
+
+	logging.PrintLog("ID of Keptn context: "+*response.KeptnContext, logging.InfoLevel)
+	logging.PrintLog("Waiting for the result of the re-evaluation", logging.InfoLevel)
+
+	// the previous result is only invalidated once the new result has been stored, so that a valid result is left
+	// if the re-evaluation fails
+	if err := waitForReEvaluation(api.EventsV1(), reEvaluationData, *response.KeptnContext); err != nil {
+		return fmt.Errorf("%s, therefore the previous result has not been invalidated", err.Error())
+	}
+
+	invalidatedEvent := keptnv2.EventData{
+		Project: previousEvaluation.Project,
+		Stage:   previousEvaluation.Stage,
+		Service: previousEvaluation.Service,
+		Labels:  previousEvaluation.Labels,
+		Status:  keptnv2.StatusSucceeded,
+		Message: "re-evaluated in Keptn context " + *response.KeptnContext,
+	}
+	if _, err := sendEvent(previousEvent.Shkeptncontext, previousEvent.Triggeredid, keptnv2.GetInvalidatedEventType(keptnv2.EvaluationTaskName), invalidatedEvent, api.APIV1()); err != nil {
+		return fmt.Errorf("re-evaluation in Keptn context %s has finished, but the previous result could not be invalidated: %s", *response.KeptnContext, err.Error())
+	}
+	logging.PrintLog("The previous result has been invalidated", logging.InfoLevel)
+
+	if *reEvaluationData.Watch {
+		filter := apiutils.EventFilter{
+			KeptnContext: *response.KeptnContext,
+			Project:      *reEvaluationData.Project,
+		}
+		watcher := NewDefaultWatcher(api.EventsV1(), filter, time.Duration(*reEvaluationData.WatchTime)*time.Second)
+		PrintEventWatcher(rootCmd.Context(), watcher, *reEvaluationData.Output, os.Stdout)
+	}
+	return nil
+}
+
+// getEvaluationToReEvaluate returns the latest evaluation.finished event of the service within the given Keptn context
+func getEvaluationToReEvaluate(eventHandler apiutils.EventsV1Interface, reEvaluationData triggerReEvaluationStruct) (*apimodels.KeptnContextExtendedCE, *keptnv2.EvaluationFinishedEventData, error) {
+	events, errObj := eventHandler.GetEvents(&apiutils.EventFilter{
+		KeptnContext: *reEvaluationData.KeptnContext,
+		EventType:    keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+		Project:      *reEvaluationData.Project,
+		Stage:        *reEvaluationData.Stage,
+		Service:      *reEvaluationData.Service,
+	})
+	if errObj != nil {
+		logging.PrintLog("Get evaluation.finished event was unsuccessful", logging.QuietLevel)
+		return nil, nil, fmt.Errorf("%s", *errObj.Message)
+	}
+	if len(events) == 0 {
+		return nil, nil, fmt.Errorf("no evaluation.finished event found for service %s in stage %s of project %s in Keptn context %s",
+			*reEvaluationData.Service, *reEvaluationData.Stage, *reEvaluationData.Project, *reEvaluationData.KeptnContext)
+	}
+
+	previousEvaluation := &keptnv2.EvaluationFinishedEventData{}
+	if err := keptnv2.Decode(events[0].Data, previousEvaluation); err != nil {
+		return nil, nil, fmt.Errorf("cannot decode evaluation.finished event: %s", err.Error())
+	}
+	if previousEvaluation.Evaluation.TimeStart == "" || previousEvaluation.Evaluation.TimeEnd == "" {
+		return nil, nil, errors.New("the previous evaluation does not contain a time frame")
+	}
+	return events[0], previousEvaluation, nil
+}
+
+// waitForReEvaluation waits until the evaluation.finished event of the re-evaluation has been stored, and returns an
+// error if it has not been received within the timeout or the re-evaluation has failed with an error
+func waitForReEvaluation(eventHandler apiutils.EventsV1Interface, reEvaluationData triggerReEvaluationStruct, keptnContext string) error {
+	maxRetries := int(time.Duration(*reEvaluationData.Timeout) * time.Second / reEvaluationRetryTime)
+	if maxRetries < 1 {
+		maxRetries = 1
+	}
+	events, err := eventHandler.GetEventsWithRetry(&apiutils.EventFilter{
+		KeptnContext: keptnContext,
+		EventType:    keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+		Project:      *reEvaluationData.Project,
+		Stage:        *reEvaluationData.Stage,
+		Service:      *reEvaluationData.Service,
+	}, maxRetries, reEvaluationRetryTime)
+	if err != nil {
+		return fmt.Errorf("the re-evaluation in Keptn context %s has not finished within %d seconds", keptnContext, *reEvaluationData.Timeout)
+	}
+
+	evaluation := &keptnv2.EvaluationFinishedEventData{}
+	if err := keptnv2.Decode(events[0].Data, evaluation); err != nil {
+		return fmt.Errorf("cannot decode evaluation.finished event of the re-evaluation: %s", err.Error())
+	}
+	if evaluation.Status == keptnv2.StatusErrored {
+		return fmt.Errorf("the re-evaluation in Keptn context %s has failed: %s", keptnContext, evaluation.Message)
+	}
+	return nil
+}
+
+// getReEvaluationLabels returns the labels of the previous evaluation, extended by the additional labels
+// and the labels referencing the previous evaluation
+func getReEvaluationLabels(previousEvent *apimodels.KeptnContextExtendedCE, previousEvaluation *keptnv2.EvaluationFinishedEventData, additionalLabels *map[string]string) map[string]string {
+	labels := map[string]string{}
+	for key, value := range previousEvaluation.Labels {
+		labels[key] = value
+	}
+	if additionalLabels != nil {
+		for key, value := range *additionalLabels {
+			labels[key] = value
+		}
+	}
+	labels[reEvaluationOfLabel] = previousEvent.ID
+	labels[reEvaluationOfContextLabel] = previousEvent.Shkeptncontext
+	return labels
+}
+
+func init() {
+	triggerCmd.AddCommand(triggerReEvaluationCmd)
+
+	triggerReEvaluation.Project = triggerReEvaluationCmd.Flags().StringP("project", "", "",
+		"The project containing the service to be evaluated")
+	triggerReEvaluationCmd.MarkFlagRequired("project")
+
+	triggerReEvaluation.Stage = triggerReEvaluationCmd.Flags().StringP("stage", "", "",
+		"The stage containing the service to be evaluated")
+	triggerReEvaluationCmd.MarkFlagRequired("stage")
+
+	triggerReEvaluation.Service = triggerReEvaluationCmd.Flags().StringP("service", "", "",
+		"The service to be evaluated")
+	triggerReEvaluationCmd.MarkFlagRequired("service")
+
+	triggerReEvaluation.KeptnContext = triggerReEvaluationCmd.Flags().StringP("keptn-context", "", "",
+		"The ID of the Keptn context containing the evaluation that should be re-run")
+	triggerReEvaluationCmd.MarkFlagRequired("keptn-context")
+
+	triggerReEvaluation.UseOriginalSLO = triggerReEvaluationCmd.Flags().BoolP("use-original-slo", "", false,
+		"Use the SLO file of the git commit the previous evaluation has been performed with, instead of the current one")
+
+	triggerReEvaluation.Labels = triggerReEvaluationCmd.Flags().StringToStringP("labels", "l", nil, "Additional labels to be provided to the lighthouse service")
+
+	triggerReEvaluation.Timeout = triggerReEvaluationCmd.Flags().Int("timeout", 600,
+		"Timeout (in seconds) for the re-evaluation to finish, after which the previous result is kept")
+
+	triggerReEvaluation.Output = AddOutputFormatFlag(triggerReEvaluationCmd)
+	triggerReEvaluation.Watch = AddWatchFlag(triggerReEvaluationCmd)
+	triggerReEvaluation.WatchTime = AddWatchTimeFlag(triggerReEvaluationCmd)
+}
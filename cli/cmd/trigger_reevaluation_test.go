@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reEvaluationFinishedMockResponse = `{
+    "events": [
+        {
+		  "contenttype": "application/json",
+		  "data": {
+			"evaluation": {
+			  "result": "fail",
+			  "score": 0,
+			  "timeStart": "2021-11-01T09:55:00.000Z",
+			  "timeEnd": "2021-11-01T10:00:00.000Z"
+			},
+			"labels": {
+			  "buildId": "1.2.3"
+			},
+			"project": "sockshop",
+			"result": "fail",
+			"service": "carts",
+			"stage": "hardening"
+		  },
+		  "id": "evaluation-finished-id",
+		  "triggeredid": "evaluation-triggered-id",
+		  "gitcommitid": "original-commit",
+		  "source": "lighthouse-service",
+		  "specversion": "1.0",
+		  "time": "2021-11-01T10:00:10.000Z",
+		  "type": "sh.keptn.event.evaluation.finished",
+		  "shkeptncontext": "original-context"
+		}
+    ],
+	"nextPageKey": "0",
+    "pageSize": 1,
+    "totalCount": 1
+}`
+
+func reEvaluationResultMockResponse(status keptnv2.StatusType) string {
+	return `{"events":[{"data":{"project":"sockshop","stage":"hardening","service":"carts","status":"` + string(status) + `","result":"pass","message":"my-message"},
+"id":"new-evaluation-finished-id","type":"sh.keptn.event.evaluation.finished","shkeptncontext":"new-context"}],"nextPageKey":"0","pageSize":1,"totalCount":1}`
+}
+
+func TestTriggerReEvaluation(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	reEvaluationRetryTime = 10 * time.Millisecond
+	defer func() { reEvaluationRetryTime = 5 * time.Second }()
+
+	var triggeredEvaluation apimodels.Evaluation
+	var sentEvent apimodels.KeptnContextExtendedCE
+	eventsResponse := reEvaluationFinishedMockResponse
+	newEventsResponse := reEvaluationResultMockResponse(keptnv2.StatusSucceeded)
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			body, _ := ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodGet && strings.Contains(r.RequestURI, "new-context"):
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(newEventsResponse))
+			case r.Method == http.MethodGet && strings.Contains(r.RequestURI, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)):
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(eventsResponse))
+			case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/evaluation"):
+				_ = json.Unmarshal(body, &triggeredEvaluation)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"keptnContext":"new-context"}`))
+			case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/event"):
+				_ = json.Unmarshal(body, &sentEvent)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"keptnContext":"original-context"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer ts.Close()
+	t.Setenv("MOCK_SERVER", ts.URL)
+
+	newOptions := func(useOriginalSLO bool) triggerReEvaluationStruct {
+		return triggerReEvaluationStruct{
+			Project:        stringp("sockshop"),
+			Stage:          stringp("hardening"),
+			Service:        stringp("carts"),
+			KeptnContext:   stringp("original-context"),
+			UseOriginalSLO: boolp(useOriginalSLO),
+			Labels:         &map[string]string{"reason": "fixed-sli-query"},
+			Timeout:        intp(1),
+			Watch:          boolp(false),
+		}
+	}
+
+	t.Run("re-evaluate with original SLO file", func(t *testing.T) {
+		err := doTriggerReEvaluation(newOptions(true))
+		require.Nil(t, err)
+
+		assert.Equal(t, "2021-11-01T09:55:00.000Z", triggeredEvaluation.Start)
+		assert.Equal(t, "2021-11-01T10:00:00.000Z", triggeredEvaluation.End)
+		assert.Equal(t, "original-commit", triggeredEvaluation.GitCommitID)
+		assert.Equal(t, map[string]string{
+			"buildId":               "1.2.3",
+			"reason":                "fixed-sli-query",
+			"reevaluationOf":        "evaluation-finished-id",
+			"reevaluationOfContext": "original-context",
+		}, triggeredEvaluation.Labels)
+
+		assert.Equal(t, keptnv2.GetInvalidatedEventType(keptnv2.EvaluationTaskName), *sentEvent.Type)
+		assert.Equal(t, "original-context", sentEvent.Shkeptncontext)
+		assert.Equal(t, "evaluation-triggered-id", sentEvent.Triggeredid)
+		invalidatedData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.Decode(sentEvent.Data, invalidatedData))
+		assert.Equal(t, "re-evaluated in Keptn context new-context", invalidatedData.Message)
+	})
+
+	t.Run("re-evaluate with current SLO file", func(t *testing.T) {
+		triggeredEvaluation = apimodels.Evaluation{}
+		err := doTriggerReEvaluation(newOptions(false))
+		require.Nil(t, err)
+		assert.Empty(t, triggeredEvaluation.GitCommitID)
+	})
+
+	t.Run("re-evaluation failed", func(t *testing.T) {
+		newEventsResponse = reEvaluationResultMockResponse(keptnv2.StatusErrored)
+		sentEvent = apimodels.KeptnContextExtendedCE{}
+		err := doTriggerReEvaluation(newOptions(false))
+		require.ErrorContains(t, err, "the re-evaluation in Keptn context new-context has failed: my-message, therefore the previous result has not been invalidated")
+		assert.Nil(t, sentEvent.Type)
+	})
+
+	t.Run("re-evaluation not finished", func(t *testing.T) {
+		newEventsResponse = `{"events":[],"nextPageKey":"0","pageSize":0,"totalCount":0}`
+		sentEvent = apimodels.KeptnContextExtendedCE{}
+		err := doTriggerReEvaluation(newOptions(false))
+		require.ErrorContains(t, err, "the re-evaluation in Keptn context new-context has not finished within 1 seconds")
+		assert.Nil(t, sentEvent.Type)
+	})
+
+	t.Run("no evaluation found", func(t *testing.T) {
+		eventsResponse = `{"events":[],"nextPageKey":"0","pageSize":0,"totalCount":0}`
+		sentEvent = apimodels.KeptnContextExtendedCE{}
+		err := doTriggerReEvaluation(newOptions(false))
+		require.NotNil(t, err)
+		assert.Nil(t, sentEvent.Type)
+	})
+}
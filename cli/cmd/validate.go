@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// validateCmd implements the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [webhook-config]",
+	Short: `Validates a configuration before it is added to a project`,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
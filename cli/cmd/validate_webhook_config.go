@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/internal"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+const webhookConfigValidationPath = "/webhook-service/v1/webhook-config/validate"
+
+type validateWebhookConfigStruct struct {
+	File           *string
+	SubscriptionID *string
+	Project        *string
+	Stage          *string
+	Service        *string
+	KeptnContext   *string
+	EventType      *string
+	Execute        *bool
+}
+
+// webhookConfigValidationRequest is the payload of the validation endpoint of the webhook-service
+type webhookConfigValidationRequest struct {
+	WebhookConfig  string                            `json:"webhookConfig"`
+	SubscriptionID string                            `json:"subscriptionID,omitempty"`
+	Event          *apimodels.KeptnContextExtendedCE `json:"event,omitempty"`
+	Execute        bool                              `json:"execute,omitempty"`
+}
+
+type webhookConfigValidationResponse struct {
+	Valid    bool              `json:"valid"`
+	Error    string            `json:"error,omitempty"`
+	Webhooks []json.RawMessage `json:"webhooks,omitempty"`
+}
+
+var validateWebhookConfig validateWebhookConfigStruct
+
+var validateWebhookConfigCmd = &cobra.Command{
+	Use:   "webhook-config",
+	Args:  cobra.NoArgs,
+	Short: "Validates a webhook configuration, and renders its requests against an event",
+	Long: `Validates a webhook configuration (webhook.yaml), and renders its requests against an event, without sending any events.
+
+* The webhook configuration (--file) is validated by the webhook-service.
+* To render the requests, either provide the project (--project), stage (--stage) and service (--service) for a sample event, or additionally the Keptn context (--keptn-context) and the type (--event-type) of a previous event.
+* By default, the requests are only rendered. Secrets referenced by the webhooks are not read, the rendered requests contain placeholders like <secret:name.key> instead.
+* To execute the requests, please specify --execute. The webhook-service then reads the referenced secrets and sends the requests including their values to the configured URLs. Executed requests only report the status code of their response, since they might contain secrets. Requests to denied URLs are not executed.
+`,
+	Example: `keptn validate webhook-config --file=webhook.yaml
+keptn validate webhook-config --file=webhook.yaml --project=sockshop --stage=dev --service=carts [--subscription-id=my-subscription-id]
+keptn validate webhook-config --file=webhook.yaml --project=sockshop --stage=dev --service=carts --keptn-context=1234-5678-90ab-cdef --event-type=sh.keptn.event.deployment.triggered [--execute]`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doValidateWebhookConfig(validateWebhookConfig, os.Stdout)
+	},
+}
+
+func doValidateWebhookConfig(options validateWebhookConfigStruct, out io.Writer) error {
+	webhookConfig, err := os.ReadFile(*options.File)
+	if err != nil {
+		return fmt.Errorf("could not read webhook configuration: %s", err.Error())
+	}
+
+	var endPoint url.URL
+	var apiToken string
+	if !mocking {
+		endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+	} else {
+		endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+		endPoint = *endPointPtr
+		apiToken = ""
+	}
+	if err != nil {
+		return errors.New(authErrorMsg)
+	}
+
+	api, err := internal.APIProvider(endPoint.String(), apiToken)
+	if err != nil {
+		return err
+	}
+
+	event, err := getEventForWebhookValidation(api.EventsV1(), options)
+	if err != nil {
+		return err
+	}
+	if *options.Execute && event == nil {
+		return errors.New("the requests can only be executed if an event is provided, please specify --project, --stage and --service")
+	}
+
+	logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+	validationResponse, err := postWebhookConfigValidation(endPoint, apiToken, webhookConfigValidationRequest{
+		WebhookConfig:  string(webhookConfig),
+		SubscriptionID: *options.SubscriptionID,
+		Event:          event,
+		Execute:        *options.Execute,
+	})
+	if err != nil {
+		return err
+	}
+	if !validationResponse.Valid {
+		return fmt.Errorf("webhook configuration is invalid: %s", validationResponse.Error)
+	}
+
+	logging.PrintLog("Webhook configuration is valid", logging.InfoLevel)
+	if event == nil {
+		return nil
+	}
+	output, err := json.MarshalIndent(validationResponse.Webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, string(output))
+	return nil
+}
+
+// getEventForWebhookValidation returns the previous event of the given Keptn context, or a sample event for the service.
+// If no service is given, the webhook configuration is only validated
+func getEventForWebhookValidation(eventHandler apiutils.EventsV1Interface, options validateWebhookConfigStruct) (*apimodels.KeptnContextExtendedCE, error) {
+	if *options.Project == "" && *options.Stage == "" && *options.Service == "" && *options.KeptnContext == "" {
+		return nil, nil
+	}
+	if *options.Project == "" || *options.Stage == "" || *options.Service == "" {
+		return nil, errors.New("please specify --project, --stage and --service")
+	}
+
+	if *options.KeptnContext == "" {
+		return &apimodels.KeptnContextExtendedCE{
+			Data: map[string]interface{}{
+				"project": *options.Project,
+				"stage":   *options.Stage,
+				"service": *options.Service,
+			},
+			Shkeptncontext: "sample-keptn-context",
+		}, nil
+	}
+
+	if *options.EventType == "" {
+		return nil, errors.New("please specify the type of the event in the Keptn context with --event-type")
+	}
+	events, errObj := eventHandler.GetEvents(&apiutils.EventFilter{
+		KeptnContext: *options.KeptnContext,
+		EventType:    *options.EventType,
+		Project:      *options.Project,
+		Stage:        *options.Stage,
+		Service:      *options.Service,
+	})
+	if errObj != nil {
+		return nil, fmt.Errorf("could not retrieve event: %s", *errObj.Message)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("no event of type %s found for service %s in stage %s of project %s in Keptn context %s",
+			*options.EventType, *options.Service, *options.Stage, *options.Project, *options.KeptnContext)
+	}
+	return events[0], nil
+}
+
+func postWebhookConfigValidation(endPoint url.URL, apiToken string, validationRequest webhookConfigValidationRequest) (*webhookConfigValidationResponse, error) {
+	payload, err := json.Marshal(validationRequest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endPoint.String(), "/")+webhookConfigValidationPath, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiToken != "" {
+		req.Header.Set("x-token", apiToken)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not validate webhook configuration: %s", err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not validate webhook configuration: "+internal.ErrWithStatusCode+": %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	validationResponse := &webhookConfigValidationResponse{}
+	if err := json.Unmarshal(body, validationResponse); err != nil {
+		return nil, fmt.Errorf("could not decode response: %s", err.Error())
+	}
+	return validationResponse, nil
+}
+
+func init() {
+	validateCmd.AddCommand(validateWebhookConfigCmd)
+
+	validateWebhookConfig.File = validateWebhookConfigCmd.Flags().StringP("file", "f", "",
+		"The webhook configuration to be validated")
+	validateWebhookConfigCmd.MarkFlagRequired("file")
+
+	validateWebhookConfig.SubscriptionID = validateWebhookConfigCmd.Flags().StringP("subscription-id", "", "",
+		"Only validate the webhook of this subscription")
+	validateWebhookConfig.Project = validateWebhookConfigCmd.Flags().StringP("project", "", "",
+		"The project of the event the requests are rendered against")
+	validateWebhookConfig.Stage = validateWebhookConfigCmd.Flags().StringP("stage", "", "",
+		"The stage of the event the requests are rendered against")
+	validateWebhookConfig.Service = validateWebhookConfigCmd.Flags().StringP("service", "", "",
+		"The service of the event the requests are rendered against")
+	validateWebhookConfig.KeptnContext = validateWebhookConfigCmd.Flags().StringP("keptn-context", "", "",
+		"The Keptn context of a previous event the requests are rendered against")
+	validateWebhookConfig.EventType = validateWebhookConfigCmd.Flags().StringP("event-type", "", "",
+		"The type of the previous event in the Keptn context")
+	validateWebhookConfig.Execute = validateWebhookConfigCmd.Flags().BoolP("execute", "", false,
+		"Execute the requests with the values of the referenced secrets, without sending any events")
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookConfigEventsMockResponse = `{
+    "events": [
+        {
+		  "contenttype": "application/json",
+		  "data": {
+			"project": "sockshop",
+			"service": "carts",
+			"stage": "dev",
+			"image": "carts:1.2.3"
+		  },
+		  "id": "deployment-triggered-id",
+		  "source": "shipyard-controller",
+		  "specversion": "1.0",
+		  "type": "sh.keptn.event.deployment.triggered",
+		  "shkeptncontext": "my-context"
+		}
+    ],
+	"nextPageKey": "0",
+    "pageSize": 1,
+    "totalCount": 1
+}`
+
+func TestValidateWebhookConfig(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+
+	var validationRequest webhookConfigValidationRequest
+	validationResponse := `{"valid": true, "webhooks": [{"type": "sh.keptn.event.deployment.triggered", "subscriptionID": "my-subscription-id", "requests": [{"request": "curl http://local:8080/carts:1.2.3"}]}]}`
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			body, _ := ioutil.ReadAll(r.Body)
+			switch {
+			case r.Method == http.MethodGet && strings.Contains(r.RequestURI, "sh.keptn.event.deployment.triggered"):
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(webhookConfigEventsMockResponse))
+			case r.Method == http.MethodPost && r.URL.Path == webhookConfigValidationPath:
+				validationRequest = webhookConfigValidationRequest{}
+				_ = json.Unmarshal(body, &validationRequest)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(validationResponse))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}),
+	)
+	defer ts.Close()
+	t.Setenv("MOCK_SERVER", ts.URL)
+
+	webhookConfigFile := filepath.Join(t.TempDir(), "webhook.yaml")
+	require.Nil(t, os.WriteFile(webhookConfigFile, []byte("apiVersion: webhookconfig.keptn.sh/v1alpha1"), 0644))
+
+	newOptions := func(project, stage, service, keptnContext string, execute bool) validateWebhookConfigStruct {
+		return validateWebhookConfigStruct{
+			File:           stringp(webhookConfigFile),
+			SubscriptionID: stringp(""),
+			Project:        stringp(project),
+			Stage:          stringp(stage),
+			Service:        stringp(service),
+			KeptnContext:   stringp(keptnContext),
+			EventType:      stringp("sh.keptn.event.deployment.triggered"),
+			Execute:        boolp(execute),
+		}
+	}
+
+	t.Run("validate only", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := doValidateWebhookConfig(newOptions("", "", "", "", false), out)
+		require.Nil(t, err)
+		assert.Equal(t, "apiVersion: webhookconfig.keptn.sh/v1alpha1", validationRequest.WebhookConfig)
+		assert.Nil(t, validationRequest.Event)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("render against sample event", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := doValidateWebhookConfig(newOptions("sockshop", "dev", "carts", "", false), out)
+		require.Nil(t, err)
+		require.NotNil(t, validationRequest.Event)
+		assert.Nil(t, validationRequest.Event.Type)
+		assert.Equal(t, map[string]interface{}{"project": "sockshop", "stage": "dev", "service": "carts"}, validationRequest.Event.Data)
+		assert.Contains(t, out.String(), "curl http://local:8080/carts:1.2.3")
+	})
+
+	t.Run("execute against previous event", func(t *testing.T) {
+		err := doValidateWebhookConfig(newOptions("sockshop", "dev", "carts", "my-context", true), &bytes.Buffer{})
+		require.Nil(t, err)
+		require.NotNil(t, validationRequest.Event)
+		assert.Equal(t, "deployment-triggered-id", validationRequest.Event.ID)
+		assert.True(t, validationRequest.Execute)
+	})
+
+	t.Run("execute without event", func(t *testing.T) {
+		err := doValidateWebhookConfig(newOptions("", "", "", "", true), &bytes.Buffer{})
+		require.NotNil(t, err)
+	})
+
+	t.Run("incomplete service", func(t *testing.T) {
+		err := doValidateWebhookConfig(newOptions("sockshop", "", "", "", false), &bytes.Buffer{})
+		require.NotNil(t, err)
+	})
+
+	t.Run("invalid webhook configuration", func(t *testing.T) {
+		validationResponse = `{"valid": false, "error": "Webhook configuration invalid: missing 'webhooks[]' part"}`
+		err := doValidateWebhookConfig(newOptions("", "", "", "", false), &bytes.Buffer{})
+		require.NotNil(t, err)
+		assert.Equal(t, "webhook configuration is invalid: Webhook configuration invalid: missing 'webhooks[]' part", err.Error())
+	})
+}
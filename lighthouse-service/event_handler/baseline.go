@@ -0,0 +1,145 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// maxBaselineCandidates is the maximum page size accepted by the mongodb-datastore
+const maxBaselineCandidates = 100
+
+// reservedFilterCharacters separate the clauses, keys and values of the filter of the mongodb-datastore, which does
+// not support escaping them
+const reservedFilterCharacters = ":, "
+
+// ErrInvalidBaseline is returned if the baseline section of the SLO file contains invalid values
+var ErrInvalidBaseline = errors.New("invalid baseline configuration")
+
+// SLOBaseline describes how previous evaluation results are selected as comparison source.
+// It is read from the (optional) comparison.baseline property of the slo.yaml file
+type SLOBaseline struct {
+	// Labels only selects previous evaluations containing all of the given labels
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// EvaluationID pins the comparison to a single "golden" evaluation.finished event
+	EvaluationID string `json:"evaluation_id,omitempty" yaml:"evaluation_id,omitempty"`
+	// TimeWindow restricts the previous evaluations to a time window relative to the current evaluation
+	TimeWindow *SLOBaselineTimeWindow `json:"time_window,omitempty" yaml:"time_window,omitempty"`
+}
+
+// SLOBaselineTimeWindow describes a time window in which previous evaluations are considered
+type SLOBaselineTimeWindow struct {
+	// Days is the number of days before the current evaluation
+	Days int `json:"days,omitempty" yaml:"days,omitempty"`
+	// Weeks is the number of weeks before the current evaluation
+	Weeks int `json:"weeks,omitempty" yaml:"weeks,omitempty"`
+	// SameWeekday only considers evaluations that started on the same weekday as the current evaluation
+	SameWeekday bool `json:"same_weekday,omitempty" yaml:"same_weekday,omitempty"`
+}
+
+func (w *SLOBaselineTimeWindow) duration() time.Duration {
+	return time.Duration(w.Days)*24*time.Hour + time.Duration(w.Weeks)*7*24*time.Hour
+}
+
+type sloBaselineConfig struct {
+	Comparison *struct {
+		Baseline *SLOBaseline `yaml:"baseline"`
+	} `yaml:"comparison"`
+}
+
+// parseSLOBaseline reads the baseline configuration from the content of a slo.yaml file.
+// If no baseline has been configured, nil is returned
+func parseSLOBaseline(input []byte) (*SLOBaseline, error) {
+	config := &sloBaselineConfig{}
+	if err := yaml.Unmarshal(input, config); err != nil {
+		return nil, err
+	}
+	if config.Comparison == nil || config.Comparison.Baseline == nil {
+		return nil, nil
+	}
+	baseline := config.Comparison.Baseline
+	if strings.ContainsAny(baseline.EvaluationID, reservedFilterCharacters) {
+		return nil, fmt.Errorf("%w: evaluation_id must not contain ':', ',' or spaces", ErrInvalidBaseline)
+	}
+	for key, value := range baseline.Labels {
+		if strings.ContainsAny(key, reservedFilterCharacters+".$") {
+			return nil, fmt.Errorf("%w: label key '%s' must not contain ':', ',', '.', '$' or spaces", ErrInvalidBaseline, key)
+		}
+		if strings.ContainsAny(value, reservedFilterCharacters) {
+			return nil, fmt.Errorf("%w: value '%s' of label '%s' must not contain ':', ',' or spaces", ErrInvalidBaseline, value, key)
+		}
+	}
+	if baseline.TimeWindow != nil {
+		if baseline.TimeWindow.Days < 0 || baseline.TimeWindow.Weeks < 0 {
+			return nil, fmt.Errorf("%w: time_window must not be negative", ErrInvalidBaseline)
+		}
+		if baseline.TimeWindow.duration() == 0 {
+			return nil, fmt.Errorf("%w: time_window requires days or weeks to be set", ErrInvalidBaseline)
+		}
+	}
+	return baseline, nil
+}
+
+// getFilter returns the additional datastore filter clauses for the baseline
+func (b *SLOBaseline) getFilter() string {
+	if b == nil {
+		return ""
+	}
+	filter := ""
+	if b.EvaluationID != "" {
+		filter = filter + "%20AND%20id:" + url.QueryEscape(b.EvaluationID)
+	}
+
+	// sort the label keys to get a deterministic query
+	labelKeys := make([]string, 0, len(b.Labels))
+	for key := range b.Labels {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+	for _, key := range labelKeys {
+		filter = filter + "%20AND%20data.labels." + url.QueryEscape(key) + ":" + url.QueryEscape(b.Labels[key])
+	}
+	return filter
+}
+
+// getFromTime returns the time stamp after which previous evaluations are considered, or an empty string if no time window is set
+func (b *SLOBaseline) getFromTime(evaluationStart time.Time) string {
+	if b == nil || b.TimeWindow == nil {
+		return ""
+	}
+	return timeutils.GetKeptnTimeStamp(evaluationStart.UTC().Add(-b.TimeWindow.duration()))
+}
+
+// getLimit returns the number of events that need to be fetched from the datastore to select numberOfPreviousResults results
+func (b *SLOBaseline) getLimit(numberOfPreviousResults int) int {
+	if b == nil {
+		return numberOfPreviousResults
+	}
+	if b.EvaluationID != "" {
+		return 1
+	}
+	if b.TimeWindow != nil && b.TimeWindow.SameWeekday {
+		// events are filtered after retrieval, therefore more candidates are needed
+		return maxBaselineCandidates
+	}
+	return numberOfPreviousResults
+}
+
+// matches checks if a previous evaluation matches the constraints of the baseline that cannot be evaluated by the datastore
+func (b *SLOBaseline) matches(evaluation *keptnv2.EvaluationFinishedEventData, evaluationStart time.Time) bool {
+	if b == nil || b.TimeWindow == nil || !b.TimeWindow.SameWeekday {
+		return true
+	}
+	previousStart, err := timeutils.ParseTimestamp(evaluation.Evaluation.TimeStart)
+	if err != nil {
+		return false
+	}
+	return previousStart.UTC().Weekday() == evaluationStart.UTC().Weekday()
+}
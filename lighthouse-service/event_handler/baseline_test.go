@@ -0,0 +1,197 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSLOBaseline(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    *SLOBaseline
+		wantErr bool
+	}{
+		{
+			name:  "no baseline",
+			input: "comparison:\n  compare_with: single_result\n",
+			want:  nil,
+		},
+		{
+			name:  "no comparison",
+			input: "spec_version: '1.0'\n",
+			want:  nil,
+		},
+		{
+			name: "labels, evaluation id and time window",
+			input: `comparison:
+  compare_with: several_results
+  baseline:
+    labels:
+      env: perf-lab
+    evaluation_id: my-golden-id
+    time_window:
+      weeks: 4
+      same_weekday: true
+`,
+			want: &SLOBaseline{
+				Labels:       map[string]string{"env": "perf-lab"},
+				EvaluationID: "my-golden-id",
+				TimeWindow: &SLOBaselineTimeWindow{
+					Weeks:       4,
+					SameWeekday: true,
+				},
+			},
+		},
+		{
+			name:    "empty time window",
+			input:   "comparison:\n  baseline:\n    time_window:\n      same_weekday: true\n",
+			wantErr: true,
+		},
+		{
+			name:    "negative time window",
+			input:   "comparison:\n  baseline:\n    time_window:\n      days: -1\n",
+			wantErr: true,
+		},
+		{
+			name:    "label value containing filter separator",
+			input:   "comparison:\n  baseline:\n    labels:\n      env: 'perf:lab,prod'\n",
+			wantErr: true,
+		},
+		{
+			name:    "label value containing space",
+			input:   "comparison:\n  baseline:\n    labels:\n      env: perf AND data.project:other\n",
+			wantErr: true,
+		},
+		{
+			name:    "label key containing dot",
+			input:   "comparison:\n  baseline:\n    labels:\n      env.name: perf-lab\n",
+			wantErr: true,
+		},
+		{
+			name:    "evaluation id containing filter separator",
+			input:   "comparison:\n  baseline:\n    evaluation_id: 'my-id,other-id'\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSLOBaseline([]byte(tt.input))
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidBaseline)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateSLIHandler_getPreviousEvaluationsWithBaseline(t *testing.T) {
+	var receivedQuery string
+	var returnedResult datastoreResult
+
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedQuery = r.URL.Query().Encode()
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+
+			marshal, _ := json.Marshal(&returnedResult)
+			w.Write(marshal)
+		}),
+	)
+	defer ts.Close()
+
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	// 2021-11-01 is a Monday
+	evaluationStart := time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC)
+	e := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: "sockshop",
+			Stage:   "dev",
+			Service: "carts",
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: evaluationStart.Format(time.RFC3339),
+		},
+	}
+
+	newEvaluation := func(start time.Time) *keptnv2.EvaluationFinishedEventData {
+		return &keptnv2.EvaluationFinishedEventData{
+			EventData: keptnv2.EventData{
+				Project: "sockshop",
+				Stage:   "dev",
+				Service: "carts",
+			},
+			Evaluation: keptnv2.EvaluationDetails{
+				TimeStart: start.Format(time.RFC3339),
+			},
+		}
+	}
+
+	eh := &EvaluateSLIHandler{HTTPClient: &http.Client{}}
+
+	t.Run("label filter", func(t *testing.T) {
+		returnedResult = datastoreResult{}
+		_, _, err := eh.getPreviousEvaluations(e, 3, "pass", &SLOBaseline{Labels: map[string]string{"env": "perf-lab"}})
+		require.Nil(t, err)
+		assert.Contains(t, receivedQuery, "data.labels.env%3Aperf-lab")
+		assert.Contains(t, receivedQuery, "data.result%3Apass")
+		assert.Contains(t, receivedQuery, "limit=3")
+	})
+
+	t.Run("pinned evaluation", func(t *testing.T) {
+		returnedResult = datastoreResult{
+			Events: []struct {
+				Data interface{} `json:"data"`
+				ID   string      `json:"id"`
+			}{
+				{Data: newEvaluation(evaluationStart.Add(-24 * time.Hour)), ID: "golden"},
+			},
+		}
+		got, ids, err := eh.getPreviousEvaluations(e, 3, "pass", &SLOBaseline{EvaluationID: "golden"})
+		require.Nil(t, err)
+		assert.Len(t, got, 1)
+		assert.Equal(t, []string{"golden"}, ids)
+		assert.Contains(t, receivedQuery, "id%3Agolden")
+		assert.Contains(t, receivedQuery, "limit=1")
+		assert.NotContains(t, receivedQuery, "data.result")
+	})
+
+	t.Run("pinned evaluation not found", func(t *testing.T) {
+		returnedResult = datastoreResult{}
+		_, _, err := eh.getPreviousEvaluations(e, 1, "all", &SLOBaseline{EvaluationID: "unknown"})
+		require.NotNil(t, err)
+	})
+
+	t.Run("same weekday within time window", func(t *testing.T) {
+		returnedResult = datastoreResult{
+			Events: []struct {
+				Data interface{} `json:"data"`
+				ID   string      `json:"id"`
+			}{
+				{Data: newEvaluation(evaluationStart.Add(-1 * 24 * time.Hour)), ID: "sunday"},
+				{Data: newEvaluation(evaluationStart.Add(-7 * 24 * time.Hour)), ID: "monday-1"},
+				{Data: newEvaluation(evaluationStart.Add(-8 * 24 * time.Hour)), ID: "sunday-1"},
+				{Data: newEvaluation(evaluationStart.Add(-14 * 24 * time.Hour)), ID: "monday-2"},
+			},
+		}
+		baseline := &SLOBaseline{TimeWindow: &SLOBaselineTimeWindow{Weeks: 4, SameWeekday: true}}
+		got, ids, err := eh.getPreviousEvaluations(e, 4, "all", baseline)
+		require.Nil(t, err)
+		assert.Len(t, got, 2)
+		assert.Equal(t, []string{"monday-1", "monday-2"}, ids)
+		assert.Contains(t, receivedQuery, "fromTime=2021-10-04T10%3A00%3A00.000Z")
+		assert.Contains(t, receivedQuery, "limit=100")
+	})
+}
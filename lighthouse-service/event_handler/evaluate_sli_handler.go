@@ -9,13 +9,16 @@ import (
 	"io/ioutil"
 	"math"
 	"net/http"
+	"net/url"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
 	logger "github.com/sirupsen/logrus"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
@@ -119,8 +122,11 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		logger.Error(msg)
 		return sendErroredFinishedEventWithMessage(shkeptncontext, "", commitID, msg, "", eh.KeptnHandler, e)
 	}
-	triggeredID := triggeredEvents[0].ID
+	return eh.evaluateSLIs(shkeptncontext, triggeredEvents[0].ID, commitID, e)
+}
 
+// evaluateSLIs evaluates the retrieved SLI values against the SLO file and sends the evaluation.finished event
+func (eh *EvaluateSLIHandler) evaluateSLIs(shkeptncontext string, triggeredID string, commitID string, e *keptnv2.GetSLIFinishedEventData) error {
 	logger.Debug("Start to evaluate SLIs")
 
 	evaluationDetails := keptnv2.EvaluationDetails{
@@ -176,7 +182,18 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
 	}
 
-	previousEvaluationEvents, comparisonEventIDs, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore)
+	baseline, err := parseSLOBaseline(sloFileContent)
+	if err != nil {
+		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+	}
+
+	objectiveExtensions, err := parseSLOObjectiveExtensions(sloFileContent)
+	if err != nil {
+		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+	}
+	sloConfig, informationalSLIs := applyObjectiveConditions(sloConfig, objectiveExtensions, e.Stage, e.Labels, getEvaluationTime(e))
+
+	previousEvaluationEvents, comparisonEventIDs, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore, baseline)
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, commitID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
@@ -188,7 +205,7 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		filteredPreviousEvaluationEvents = append(filteredPreviousEvaluationEvents, val)
 	}
 
-	evaluationResult, maximumAchievableScore, keySli, err := evaluateObjectives(e, sloConfig, filteredPreviousEvaluationEvents)
+	evaluationResult, maximumAchievableScore, keySli, err := evaluateObjectives(e, sloConfig, filteredPreviousEvaluationEvents, informationalSLIs)
 	evaluationResult.Labels = e.Labels
 	evaluationResult.Evaluation.ComparedEvents = comparisonEventIDs
 	evaluationResult.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
@@ -222,7 +239,9 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 	return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, evaluationResult)
 }
 
-func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData) (*keptnv2.EvaluationFinishedEventData, float64, keySLI, error) {
+// evaluateObjectives evaluates the SLI values against the objectives of the SLO file. Objectives contained in
+// informationalSLIs are evaluated and reported, but do not count toward the total score and cannot fail the evaluation as key SLI
+func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData, informationalSLIs map[string]bool) (*keptnv2.EvaluationFinishedEventData, float64, keySLI, error) {
 	evaluationResult := &keptnv2.EvaluationFinishedEventData{
 		EventData: keptnv2.EventData{
 			Status:  "",
@@ -248,8 +267,9 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 		return evaluationResult, 100, keySli, nil
 	}
 	for _, objective := range sloConfig.Objectives {
+		informational := informationalSLIs[objective.SLI]
 		// only consider the SLI for the total score if pass criteria have been included
-		if len(objective.Pass) > 0 {
+		if len(objective.Pass) > 0 && !informational {
 			maximumAchievableScore += float64(objective.Weight)
 		}
 
@@ -291,7 +311,7 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 			sliEvaluationResult.DisplayName = objective.DisplayName
 			sliEvaluationResult.PassTargets = getEmptyTargets(sloConfig, objective.Pass, previousSLIResults)
 			sliEvaluationResult.WarningTargets = getEmptyTargets(sloConfig, objective.Warning, previousSLIResults)
-			if objective.KeySLI {
+			if objective.KeySLI && !informational {
 				keySli = keySLI{
 					Failed:  true,
 					Name:    objective.DisplayName,
@@ -344,7 +364,7 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 		sliEvaluationResult.DisplayName = objective.DisplayName
 
 		if !isPassed && !isWarning {
-			if objective.KeySLI {
+			if objective.KeySLI && !informational {
 				keySli = keySLI{
 					Failed:  true,
 					Name:    objective.DisplayName,
@@ -354,6 +374,12 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 			sliEvaluationResult.Status = "fail"
 			sliEvaluationResult.Score = 0
 		}
+
+		if informational {
+			// the status is reported, but the objective does not contribute to the total score
+			sliEvaluationResult.Score = 0
+			sliEvaluationResult.KeySLI = false
+		}
 	}
 
 	// now we check if any metric from the SLI has not been handled
@@ -740,18 +766,39 @@ func parseCriteriaString(criteria string) (*criteriaObject, error) {
 	return c, nil
 }
 
+// getEvaluationTime returns the start of the evaluation timeframe, or the current time if no valid start is available
+func getEvaluationTime(e *keptnv2.GetSLIFinishedEventData) time.Time {
+	if e.GetSLI.Start != "" {
+		if start, err := timeutils.ParseTimestamp(e.GetSLI.Start); err == nil {
+			return *start
+		}
+	}
+	return time.Now().UTC()
+}
+
 // gets previous evaluation.finished events from mongodb-datastore
-func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string) ([]*keptnv2.EvaluationFinishedEventData, []string, error) {
+// the optional baseline further restricts which of the previous evaluations are used for the comparison
+func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string, baseline *SLOBaseline) ([]*keptnv2.EvaluationFinishedEventData, []string, error) {
 	var evaluationDoneEvents []*keptnv2.EvaluationFinishedEventData
 	var eventIDs []string
 
+	evaluationStart := getEvaluationTime(e)
+
 	// previous results are fetched from mongodb datastore with source=lighthouse-service
 	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&",
-		"lighthouse-service", numberOfPreviousResults)
+		"lighthouse-service", baseline.getLimit(numberOfPreviousResults))
+
+	if fromTime := baseline.getFromTime(evaluationStart); fromTime != "" {
+		queryString = queryString + "fromTime=" + url.QueryEscape(fromTime) + "&"
+	}
 
 	includeResult = strings.ToLower(includeResult)
 
 	filter := "filter=data.project:" + e.Project + "%20AND%20data.stage:" + e.Stage + "%20AND%20data.service:" + e.Service
+	if baseline != nil && baseline.EvaluationID != "" {
+		// a pinned evaluation is used regardless of its result
+		includeResult = "all"
+	}
 	switch includeResult {
 	case "pass":
 		filter = filter + "%20AND%20data.result:pass"
@@ -762,6 +809,7 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 	default:
 		break
 	}
+	filter = filter + baseline.getFilter()
 
 	queryString = queryString + filter
 
@@ -795,6 +843,9 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 		if err != nil {
 			continue
 		}
+		if !baseline.matches(&evaluationDoneEvent, evaluationStart) {
+			continue
+		}
 		evaluationDoneEvents = append(evaluationDoneEvents, &evaluationDoneEvent)
 		eventIDs = append(eventIDs, event.ID)
 		if len(evaluationDoneEvents) == numberOfPreviousResults {
@@ -802,5 +853,9 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 		}
 	}
 
+	if baseline != nil && baseline.EvaluationID != "" && len(evaluationDoneEvents) == 0 {
+		return nil, nil, fmt.Errorf("could not find baseline evaluation with ID %s", baseline.EvaluationID)
+	}
+
 	return evaluationDoneEvents, eventIDs, nil
 }
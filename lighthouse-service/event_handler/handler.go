@@ -54,6 +54,7 @@ func NewEventHandler(ctx context.Context, event cloudevents.Event, kubeAPI kuber
 				ResourceHandler: resourceHandler,
 				ServiceHandler:  serviceHandler,
 			},
+			SLIProviders: NewBuiltinSLIProviderRegistry(resourceHandler),
 		}, nil
 	case keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName):
 		return &EvaluateSLIHandler{
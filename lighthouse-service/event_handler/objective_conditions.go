@@ -0,0 +1,192 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	"gopkg.in/yaml.v3"
+)
+
+const timeOfDayFormat = "15:04"
+
+// ErrInvalidObjectiveCondition is returned if the conditions of an objective in the SLO file contain invalid values
+var ErrInvalidObjectiveCondition = errors.New("invalid objective condition")
+
+// SLOObjectiveExtension contains the properties of an objective that are not part of the keptn.SLO type.
+// It is read from the (optional) informational and conditions properties of an objective in the slo.yaml file
+type SLOObjectiveExtension struct {
+	SLI string `json:"sli" yaml:"sli"`
+	// Informational objectives are evaluated and reported, but never count toward the total score
+	Informational bool `json:"informational,omitempty" yaml:"informational,omitempty"`
+	// Conditions are checked in the given order, the first matching condition overrides the weight and criteria of the objective
+	Conditions []*SLOObjectiveCondition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+}
+
+// SLOObjectiveCondition overrides the weight and/or criteria of an objective if its selector matches the evaluation
+type SLOObjectiveCondition struct {
+	// When selects the evaluations the condition applies to. A condition without selector always matches
+	When    *SLOConditionSelector `json:"when,omitempty" yaml:"when,omitempty"`
+	Weight  *int                  `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Pass    []*keptn.SLOCriteria  `json:"pass,omitempty" yaml:"pass,omitempty"`
+	Warning []*keptn.SLOCriteria  `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+// SLOConditionSelector matches an evaluation if all of its properties match
+type SLOConditionSelector struct {
+	// Stages matches if the evaluation is performed in one of the given stages
+	Stages []string `json:"stages,omitempty" yaml:"stages,omitempty"`
+	// Labels matches if the evaluation contains all of the given labels
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// TimeOfDay matches if the evaluation timeframe starts within the given time of day
+	TimeOfDay *SLOTimeOfDay `json:"time_of_day,omitempty" yaml:"time_of_day,omitempty"`
+}
+
+// SLOTimeOfDay describes a time range within a day, e.g. from 08:00 to 18:00. If From is after To, the range spans midnight
+type SLOTimeOfDay struct {
+	From string `json:"from" yaml:"from"`
+	To   string `json:"to" yaml:"to"`
+	// Timezone is the IANA name of the time zone From and To refer to. Defaults to UTC
+	Timezone string `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+}
+
+type sloObjectiveExtensionConfig struct {
+	Objectives []*SLOObjectiveExtension `yaml:"objectives"`
+}
+
+// parseSLOObjectiveExtensions reads the informational flags and conditions of the objectives from the content of a slo.yaml file
+func parseSLOObjectiveExtensions(input []byte) ([]*SLOObjectiveExtension, error) {
+	config := &sloObjectiveExtensionConfig{}
+	if err := yaml.Unmarshal(input, config); err != nil {
+		return nil, err
+	}
+	extensions := []*SLOObjectiveExtension{}
+	for _, extension := range config.Objectives {
+		if extension == nil {
+			continue
+		}
+		for _, condition := range extension.Conditions {
+			if condition == nil {
+				continue
+			}
+			if condition.Weight != nil && *condition.Weight < 0 {
+				return nil, fmt.Errorf("%w: weight of objective %s must not be negative", ErrInvalidObjectiveCondition, extension.SLI)
+			}
+			if condition.When != nil && condition.When.TimeOfDay != nil {
+				if _, _, _, err := condition.When.TimeOfDay.parse(); err != nil {
+					return nil, fmt.Errorf("%w: time_of_day of objective %s: %v", ErrInvalidObjectiveCondition, extension.SLI, err)
+				}
+			}
+		}
+		extensions = append(extensions, extension)
+	}
+	return extensions, nil
+}
+
+func (t *SLOTimeOfDay) parse() (from time.Time, to time.Time, location *time.Location, err error) {
+	from, err = time.Parse(timeOfDayFormat, t.From)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("could not parse from time %s, expected format HH:MM", t.From)
+	}
+	to, err = time.Parse(timeOfDayFormat, t.To)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("could not parse to time %s, expected format HH:MM", t.To)
+	}
+	location, err = time.LoadLocation(t.Timezone)
+	if err != nil {
+		return from, to, nil, fmt.Errorf("unknown timezone %s", t.Timezone)
+	}
+	return from, to, location, nil
+}
+
+func (t *SLOTimeOfDay) matches(evaluationTime time.Time) bool {
+	from, to, location, err := t.parse()
+	if err != nil {
+		return false
+	}
+	localTime := evaluationTime.In(location)
+	minuteOfDay := localTime.Hour()*60 + localTime.Minute()
+	fromMinute := from.Hour()*60 + from.Minute()
+	toMinute := to.Hour()*60 + to.Minute()
+	if fromMinute <= toMinute {
+		return minuteOfDay >= fromMinute && minuteOfDay < toMinute
+	}
+	// the time range spans midnight
+	return minuteOfDay >= fromMinute || minuteOfDay < toMinute
+}
+
+func (s *SLOConditionSelector) matches(stage string, labels map[string]string, evaluationTime time.Time) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.Stages) > 0 {
+		stageMatches := false
+		for _, selectedStage := range s.Stages {
+			if selectedStage == stage {
+				stageMatches = true
+				break
+			}
+		}
+		if !stageMatches {
+			return false
+		}
+	}
+	for key, value := range s.Labels {
+		if labelValue, ok := labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	if s.TimeOfDay != nil && !s.TimeOfDay.matches(evaluationTime) {
+		return false
+	}
+	return true
+}
+
+// applyObjectiveConditions returns a copy of the SLO configuration in which the weight and criteria of each objective
+// are replaced by the ones of the first matching condition. Additionally, the set of informational SLIs is returned
+func applyObjectiveConditions(sloConfig *keptn.ServiceLevelObjectives, extensions []*SLOObjectiveExtension, stage string, labels map[string]string, evaluationTime time.Time) (*keptn.ServiceLevelObjectives, map[string]bool) {
+	informational := map[string]bool{}
+	if len(extensions) == 0 {
+		return sloConfig, informational
+	}
+
+	extensionsBySLI := map[string]*SLOObjectiveExtension{}
+	for _, extension := range extensions {
+		if _, ok := extensionsBySLI[extension.SLI]; !ok {
+			extensionsBySLI[extension.SLI] = extension
+		}
+	}
+
+	resolvedConfig := *sloConfig
+	resolvedConfig.Objectives = make([]*keptn.SLO, 0, len(sloConfig.Objectives))
+	for _, objective := range sloConfig.Objectives {
+		extension, ok := extensionsBySLI[objective.SLI]
+		if !ok {
+			resolvedConfig.Objectives = append(resolvedConfig.Objectives, objective)
+			continue
+		}
+		if extension.Informational {
+			informational[objective.SLI] = true
+		}
+
+		resolvedObjective := *objective
+		for _, condition := range extension.Conditions {
+			if condition == nil || !condition.When.matches(stage, labels, evaluationTime) {
+				continue
+			}
+			if condition.Weight != nil {
+				resolvedObjective.Weight = *condition.Weight
+			}
+			if condition.Pass != nil {
+				resolvedObjective.Pass = condition.Pass
+			}
+			if condition.Warning != nil {
+				resolvedObjective.Warning = condition.Warning
+			}
+			break
+		}
+		resolvedConfig.Objectives = append(resolvedConfig.Objectives, &resolvedObjective)
+	}
+	return &resolvedConfig, informational
+}
@@ -0,0 +1,181 @@
+package event_handler
+
+import (
+	"testing"
+	"time"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const conditionalSLO = `spec_version: '1.0'
+comparison:
+  compare_with: single_result
+objectives:
+  - sli: response_time_p95
+    pass:
+      - criteria:
+          - "<600"
+    conditions:
+      - when:
+          stages: [production]
+          labels:
+            traffic: peak
+        weight: 3
+        pass:
+          - criteria:
+              - "<300"
+      - when:
+          time_of_day:
+            from: "22:00"
+            to: "06:00"
+            timezone: Europe/Vienna
+        pass:
+          - criteria:
+              - "<1000"
+  - sli: throughput
+    informational: true
+    pass:
+      - criteria:
+          - ">100"
+  - sli: error_rate
+    pass:
+      - criteria:
+          - "<1"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+func Test_parseSLOObjectiveExtensions(t *testing.T) {
+	extensions, err := parseSLOObjectiveExtensions([]byte(conditionalSLO))
+	require.Nil(t, err)
+	require.Len(t, extensions, 3)
+	assert.Equal(t, "response_time_p95", extensions[0].SLI)
+	require.Len(t, extensions[0].Conditions, 2)
+	assert.Equal(t, []string{"production"}, extensions[0].Conditions[0].When.Stages)
+	assert.Equal(t, 3, *extensions[0].Conditions[0].Weight)
+	assert.Equal(t, "Europe/Vienna", extensions[0].Conditions[1].When.TimeOfDay.Timezone)
+	assert.True(t, extensions[1].Informational)
+
+	invalidInputs := map[string]string{
+		"invalid time":     "objectives:\n  - sli: a\n    conditions:\n      - when:\n          time_of_day:\n            from: '25:00'\n            to: '06:00'\n",
+		"invalid timezone": "objectives:\n  - sli: a\n    conditions:\n      - when:\n          time_of_day:\n            from: '22:00'\n            to: '06:00'\n            timezone: Mars/Olympus\n",
+		"negative weight":  "objectives:\n  - sli: a\n    conditions:\n      - weight: -1\n",
+	}
+	for name, input := range invalidInputs {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseSLOObjectiveExtensions([]byte(input))
+			require.ErrorIs(t, err, ErrInvalidObjectiveCondition)
+		})
+	}
+}
+
+func Test_applyObjectiveConditions(t *testing.T) {
+	sloConfig, err := parseSLO([]byte(conditionalSLO))
+	require.Nil(t, err)
+	extensions, err := parseSLOObjectiveExtensions([]byte(conditionalSLO))
+	require.Nil(t, err)
+
+	noon := time.Date(2021, 11, 1, 12, 0, 0, 0, time.UTC)
+	// 23:30 in Vienna (UTC+1)
+	night := time.Date(2021, 11, 1, 22, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		stage          string
+		labels         map[string]string
+		evaluationTime time.Time
+		wantWeight     int
+		wantCriteria   string
+	}{
+		{
+			name:           "no condition matches",
+			stage:          "production",
+			evaluationTime: noon,
+			wantWeight:     1,
+			wantCriteria:   "<600",
+		},
+		{
+			name:           "stage and label match",
+			stage:          "production",
+			labels:         map[string]string{"traffic": "peak", "buildId": "1"},
+			evaluationTime: noon,
+			wantWeight:     3,
+			wantCriteria:   "<300",
+		},
+		{
+			name:           "label matches in other stage",
+			stage:          "staging",
+			labels:         map[string]string{"traffic": "peak"},
+			evaluationTime: noon,
+			wantWeight:     1,
+			wantCriteria:   "<600",
+		},
+		{
+			name:           "time of day spanning midnight",
+			stage:          "staging",
+			evaluationTime: night,
+			wantWeight:     1,
+			wantCriteria:   "<1000",
+		},
+		{
+			name:           "first matching condition wins",
+			stage:          "production",
+			labels:         map[string]string{"traffic": "peak"},
+			evaluationTime: night,
+			wantWeight:     3,
+			wantCriteria:   "<300",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, informational := applyObjectiveConditions(sloConfig, extensions, tt.stage, tt.labels, tt.evaluationTime)
+			require.Len(t, resolved.Objectives, 3)
+			assert.Equal(t, tt.wantWeight, resolved.Objectives[0].Weight)
+			assert.Equal(t, tt.wantCriteria, resolved.Objectives[0].Pass[0].Criteria[0])
+			assert.Equal(t, map[string]bool{"throughput": true}, informational)
+		})
+	}
+
+	// the original configuration must not be modified
+	assert.Equal(t, 1, sloConfig.Objectives[0].Weight)
+	assert.Equal(t, "<600", sloConfig.Objectives[0].Pass[0].Criteria[0])
+}
+
+func Test_evaluateObjectivesWithInformationalObjective(t *testing.T) {
+	sloConfig := &keptn.ServiceLevelObjectives{
+		Comparison: &keptn.SLOComparison{CompareWith: "single_result", IncludeResultWithScore: "all", NumberOfComparisonResults: 1, AggregateFunction: "avg"},
+		Objectives: []*keptn.SLO{
+			{SLI: "error_rate", Weight: 1, Pass: []*keptn.SLOCriteria{{Criteria: []string{"<1"}}}},
+			{SLI: "throughput", Weight: 1, KeySLI: true, Pass: []*keptn.SLOCriteria{{Criteria: []string{">100"}}}},
+		},
+		TotalScore: &keptn.SLOScore{Pass: "90%", Warning: "75%"},
+	}
+	e := &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "error_rate", Value: 0, Success: true},
+				{Metric: "throughput", Value: 10, Success: true},
+			},
+		},
+	}
+
+	evaluationResult, maximumScore, keySli, err := evaluateObjectives(e, sloConfig, nil, map[string]bool{"throughput": true})
+	require.Nil(t, err)
+	assert.Equal(t, 1.0, maximumScore)
+	assert.False(t, keySli.Failed)
+	require.Len(t, evaluationResult.Evaluation.IndicatorResults, 2)
+
+	throughput := evaluationResult.Evaluation.IndicatorResults[1]
+	assert.Equal(t, "fail", throughput.Status)
+	assert.Equal(t, 0.0, throughput.Score)
+	assert.False(t, throughput.KeySLI)
+
+	err = calculateScore(maximumScore, evaluationResult, sloConfig, keySli)
+	require.Nil(t, err)
+	assert.Equal(t, 100.0, evaluationResult.Evaluation.Score)
+	assert.Equal(t, keptnv2.ResultPass, evaluationResult.Result)
+}
@@ -0,0 +1,123 @@
+package event_handler
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	utils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// SLIRequest contains all information an SLIProvider needs to retrieve the values of a set of SLIs
+type SLIRequest struct {
+	Project       string
+	Stage         string
+	Service       string
+	Deployment    string
+	Labels        map[string]string
+	Indicators    []string
+	CustomFilters []*keptnv2.SLIFilter
+	Start         string
+	End           string
+}
+
+// SLIProvider retrieves SLI values within the lighthouse-service, i.e. without the round-trip
+// of a get-sli.triggered event to an external SLI provider
+type SLIProvider interface {
+	GetSLIs(ctx context.Context, request SLIRequest) ([]*keptnv2.SLIResult, error)
+}
+
+// SLIProviderRegistry holds the SLIProviders that are executed in-process.
+// Providers are identified by the name that is configured as 'sli-provider' in the lighthouse-config ConfigMaps
+type SLIProviderRegistry struct {
+	mutex     sync.RWMutex
+	providers map[string]SLIProvider
+}
+
+// NewSLIProviderRegistry creates a new, empty SLIProviderRegistry
+func NewSLIProviderRegistry() *SLIProviderRegistry {
+	return &SLIProviderRegistry{
+		providers: map[string]SLIProvider{},
+	}
+}
+
+// NewBuiltinSLIProviderRegistry creates a SLIProviderRegistry containing the SLI providers that are shipped with the lighthouse-service
+func NewBuiltinSLIProviderRegistry(resourceHandler ResourceHandler) *SLIProviderRegistry {
+	registry := NewSLIProviderRegistry()
+	registry.Register(PrometheusSLIProviderName, NewPrometheusSLIProvider(resourceHandler))
+	registry.Register(StaticSLIProviderName, NewStaticSLIProvider(resourceHandler))
+	return registry
+}
+
+// Register adds a provider to the registry. An already registered provider with the same name is replaced
+func (r *SLIProviderRegistry) Register(name string, provider SLIProvider) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.providers[name] = provider
+}
+
+// Get returns the provider registered with the given name
+func (r *SLIProviderRegistry) Get(name string) (SLIProvider, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// getSLIConfigResource retrieves the configuration of an in-process SLI provider. The resource on service level takes
+// precedence over the one on stage level, which in turn takes precedence over the one on project level
+func getSLIConfigResource(resourceHandler ResourceHandler, project, stage, service, resourceURI string) (*apimodels.Resource, error) {
+	scopes := []*utils.ResourceScope{
+		utils.NewResourceScope().Project(project).Stage(stage).Service(service).Resource(resourceURI),
+		utils.NewResourceScope().Project(project).Stage(stage).Resource(resourceURI),
+		utils.NewResourceScope().Project(project).Resource(resourceURI),
+	}
+	for _, scope := range scopes {
+		resource, err := resourceHandler.GetResource(*scope)
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "not found") {
+				continue
+			}
+			return nil, err
+		}
+		if resource != nil && resource.ResourceContent != "" {
+			return resource, nil
+		}
+	}
+	return nil, nil
+}
+
+// getSLIResults maps the given values to the requested indicators. Indicators without a value are marked as failed
+func getSLIResults(indicators []string, values map[string]float64, errs map[string]error) []*keptnv2.SLIResult {
+	results := []*keptnv2.SLIResult{}
+	for _, indicator := range indicators {
+		if err, ok := errs[indicator]; ok {
+			results = append(results, &keptnv2.SLIResult{
+				Metric:  indicator,
+				Success: false,
+				Message: err.Error(),
+			})
+			continue
+		}
+		value, ok := values[indicator]
+		if !ok {
+			results = append(results, &keptnv2.SLIResult{
+				Metric:  indicator,
+				Success: false,
+				Message: "no value available for SLI " + indicator,
+			})
+			continue
+		}
+		results = append(results, &keptnv2.SLIResult{
+			Metric:  indicator,
+			Value:   value,
+			Success: true,
+		})
+	}
+	return results
+}
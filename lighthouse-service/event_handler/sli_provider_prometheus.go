@@ -0,0 +1,165 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// PrometheusSLIProviderName is the name of the built-in provider for Prometheus-compatible query APIs
+const PrometheusSLIProviderName = "builtin-prometheus"
+
+// PrometheusSLIConfigURI is the URI of the resource containing the configuration of the built-in Prometheus provider
+const PrometheusSLIConfigURI = "builtin-prometheus/sli.yaml"
+
+// PrometheusSLIConfig is the configuration of the built-in Prometheus provider
+type PrometheusSLIConfig struct {
+	// URL is the base URL of the Prometheus-compatible HTTP API, e.g. http://prometheus-server.monitoring:80
+	URL string `yaml:"url"`
+	// Indicators maps the names of the SLIs to PromQL queries
+	Indicators map[string]string `yaml:"indicators"`
+}
+
+type prometheusQueryResponse struct {
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	ErrorType string `json:"errorType"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// PrometheusSLIProvider retrieves SLI values from a Prometheus-compatible HTTP API
+type PrometheusSLIProvider struct {
+	ResourceHandler ResourceHandler
+	HTTPClient      *http.Client
+}
+
+// NewPrometheusSLIProvider creates a new PrometheusSLIProvider
+func NewPrometheusSLIProvider(resourceHandler ResourceHandler) *PrometheusSLIProvider {
+	return &PrometheusSLIProvider{
+		ResourceHandler: resourceHandler,
+		HTTPClient:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// GetSLIs executes the configured query for each requested indicator at the end of the evaluation timeframe
+func (p *PrometheusSLIProvider) GetSLIs(ctx context.Context, request SLIRequest) ([]*keptnv2.SLIResult, error) {
+	resource, err := getSLIConfigResource(p.ResourceHandler, request.Project, request.Stage, request.Service, PrometheusSLIConfigURI)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", PrometheusSLIConfigURI, err)
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("no %s found for service %s in stage %s of project %s", PrometheusSLIConfigURI, request.Service, request.Stage, request.Project)
+	}
+	config := &PrometheusSLIConfig{}
+	if err := yaml.Unmarshal([]byte(resource.ResourceContent), config); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", PrometheusSLIConfigURI, err)
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("no url configured in %s", PrometheusSLIConfigURI)
+	}
+
+	start, err := timeutils.ParseTimestamp(request.Start)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse start time %s: %w", request.Start, err)
+	}
+	end, err := timeutils.ParseTimestamp(request.End)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse end time %s: %w", request.End, err)
+	}
+
+	values := map[string]float64{}
+	errs := map[string]error{}
+	for _, indicator := range request.Indicators {
+		query, ok := config.Indicators[indicator]
+		if !ok {
+			errs[indicator] = fmt.Errorf("no query defined for SLI %s", indicator)
+			continue
+		}
+		value, err := p.executeQuery(ctx, config.URL, replaceQueryPlaceholders(query, request, end.Sub(*start)), *end)
+		if err != nil {
+			errs[indicator] = err
+			continue
+		}
+		values[indicator] = value
+	}
+	return getSLIResults(request.Indicators, values, errs), nil
+}
+
+func (p *PrometheusSLIProvider) executeQuery(ctx context.Context, baseURL, query string, evaluationTime time.Time) (float64, error) {
+	queryParams := url.Values{}
+	queryParams.Add("query", query)
+	queryParams.Add("time", strconv.FormatInt(evaluationTime.Unix(), 10))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/api/v1/query?"+queryParams.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not execute query: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	result := &prometheusQueryResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return 0, fmt.Errorf("could not parse query response: %w", err)
+	}
+	if result.Status != "success" {
+		return 0, fmt.Errorf("query failed with status code %d: %s", resp.StatusCode, result.Error)
+	}
+	if result.Data.ResultType != "vector" {
+		return 0, fmt.Errorf("unsupported result type %s", result.Data.ResultType)
+	}
+	if len(result.Data.Result) != 1 {
+		return 0, fmt.Errorf("query returned %d results, expected exactly one", len(result.Data.Result))
+	}
+	return parsePrometheusSample(result.Data.Result[0].Value)
+}
+
+func parsePrometheusSample(sample []interface{}) (float64, error) {
+	// a sample is represented as [<unix timestamp>, "<value>"]
+	if len(sample) != 2 {
+		return 0, errors.New("invalid sample in query response")
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.New("invalid sample value in query response")
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// replaceQueryPlaceholders replaces the placeholders that are also supported by the prometheus-service
+func replaceQueryPlaceholders(query string, request SLIRequest, duration time.Duration) string {
+	query = strings.ReplaceAll(query, "$PROJECT", request.Project)
+	query = strings.ReplaceAll(query, "$STAGE", request.Stage)
+	query = strings.ReplaceAll(query, "$SERVICE", request.Service)
+	query = strings.ReplaceAll(query, "$DEPLOYMENT", request.Deployment)
+	query = strings.ReplaceAll(query, "$DURATION_SECONDS", fmt.Sprintf("%.0fs", duration.Seconds()))
+	for key, value := range request.Labels {
+		query = strings.ReplaceAll(query, "$LABEL."+key, value)
+	}
+	for _, filter := range request.CustomFilters {
+		query = strings.ReplaceAll(query, "$"+strings.ToUpper(filter.Key), filter.Value)
+	}
+	return query
+}
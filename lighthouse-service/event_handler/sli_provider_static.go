@@ -0,0 +1,98 @@
+package event_handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"gopkg.in/yaml.v3"
+)
+
+// StaticSLIProviderName is the name of the built-in provider that reads SLI values from a static file
+const StaticSLIProviderName = "builtin-static"
+
+// StaticSLIYAMLURI is the URI of the YAML resource containing static SLI values
+const StaticSLIYAMLURI = "builtin-static/sli.yaml"
+
+// StaticSLICSVURI is the URI of the CSV resource containing static SLI values
+const StaticSLICSVURI = "builtin-static/sli.csv"
+
+// StaticSLIConfig is the content of the YAML resource used by the built-in static provider
+type StaticSLIConfig struct {
+	// Indicators maps the names of the SLIs to their values
+	Indicators map[string]float64 `yaml:"indicators"`
+}
+
+// StaticSLIProvider reads SLI values from a resource, which is useful for offline and test scenarios.
+// The values are either read from builtin-static/sli.yaml, or from builtin-static/sli.csv containing lines of the format <sli>,<value>
+type StaticSLIProvider struct {
+	ResourceHandler ResourceHandler
+}
+
+// NewStaticSLIProvider creates a new StaticSLIProvider
+func NewStaticSLIProvider(resourceHandler ResourceHandler) *StaticSLIProvider {
+	return &StaticSLIProvider{
+		ResourceHandler: resourceHandler,
+	}
+}
+
+// GetSLIs returns the static values of the requested indicators
+func (p *StaticSLIProvider) GetSLIs(ctx context.Context, request SLIRequest) ([]*keptnv2.SLIResult, error) {
+	resource, err := getSLIConfigResource(p.ResourceHandler, request.Project, request.Stage, request.Service, StaticSLIYAMLURI)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", StaticSLIYAMLURI, err)
+	}
+	if resource != nil {
+		config := &StaticSLIConfig{}
+		if err := yaml.Unmarshal([]byte(resource.ResourceContent), config); err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", StaticSLIYAMLURI, err)
+		}
+		return getSLIResults(request.Indicators, config.Indicators, nil), nil
+	}
+
+	resource, err = getSLIConfigResource(p.ResourceHandler, request.Project, request.Stage, request.Service, StaticSLICSVURI)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", StaticSLICSVURI, err)
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("neither %s nor %s found for service %s in stage %s of project %s", StaticSLIYAMLURI, StaticSLICSVURI, request.Service, request.Stage, request.Project)
+	}
+	values, err := parseStaticSLICSV([]byte(resource.ResourceContent))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", StaticSLICSVURI, err)
+	}
+	return getSLIResults(request.Indicators, values, nil), nil
+}
+
+func parseStaticSLICSV(content []byte) (map[string]float64, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	values := map[string]float64{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			if line == 1 {
+				// the first line may contain a header
+				continue
+			}
+			return nil, fmt.Errorf("invalid value for SLI %s: %w", record[0], err)
+		}
+		values[strings.TrimSpace(record[0])] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,144 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	api "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getResourceHandlerMock(resources map[string]string) *event_handler_mock.ResourceHandlerMock {
+	return &event_handler_mock.ResourceHandlerMock{
+		GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*apimodels.Resource, error) {
+			key := scope.GetProject() + "/" + scope.GetStage() + "/" + scope.GetService() + "/" + scope.GetResource()
+			content, ok := resources[key]
+			if !ok {
+				return nil, errors.New("resource not found")
+			}
+			return &apimodels.Resource{ResourceContent: content}, nil
+		},
+	}
+}
+
+func TestSLIProviderRegistry(t *testing.T) {
+	registry := NewBuiltinSLIProviderRegistry(getResourceHandlerMock(nil))
+
+	_, ok := registry.Get(PrometheusSLIProviderName)
+	require.True(t, ok)
+	_, ok = registry.Get(StaticSLIProviderName)
+	require.True(t, ok)
+	_, ok = registry.Get("dynatrace")
+	require.False(t, ok)
+
+	var nilRegistry *SLIProviderRegistry
+	_, ok = nilRegistry.Get(PrometheusSLIProviderName)
+	require.False(t, ok)
+}
+
+func TestStaticSLIProvider_GetSLIs(t *testing.T) {
+	request := SLIRequest{
+		Project:    "sockshop",
+		Stage:      "dev",
+		Service:    "carts",
+		Indicators: []string{"response_time_p95", "error_rate", "throughput"},
+	}
+
+	t.Run("yaml on service level", func(t *testing.T) {
+		provider := NewStaticSLIProvider(getResourceHandlerMock(map[string]string{
+			"sockshop/dev/carts/builtin-static/sli.yaml": "indicators:\n  response_time_p95: 250.5\n  error_rate: 0\n",
+			"sockshop///builtin-static/sli.yaml":         "indicators:\n  response_time_p95: 1000\n",
+		}))
+		results, err := provider.GetSLIs(context.TODO(), request)
+		require.Nil(t, err)
+		assert.Equal(t, []*keptnv2.SLIResult{
+			{Metric: "response_time_p95", Value: 250.5, Success: true},
+			{Metric: "error_rate", Value: 0, Success: true},
+			{Metric: "throughput", Success: false, Message: "no value available for SLI throughput"},
+		}, results)
+	})
+
+	t.Run("csv on project level", func(t *testing.T) {
+		provider := NewStaticSLIProvider(getResourceHandlerMock(map[string]string{
+			"sockshop///builtin-static/sli.csv": "sli,value\n# comment\nresponse_time_p95, 300\nthroughput,42\n",
+		}))
+		results, err := provider.GetSLIs(context.TODO(), request)
+		require.Nil(t, err)
+		assert.Equal(t, []*keptnv2.SLIResult{
+			{Metric: "response_time_p95", Value: 300, Success: true},
+			{Metric: "error_rate", Success: false, Message: "no value available for SLI error_rate"},
+			{Metric: "throughput", Value: 42, Success: true},
+		}, results)
+	})
+
+	t.Run("invalid csv", func(t *testing.T) {
+		provider := NewStaticSLIProvider(getResourceHandlerMock(map[string]string{
+			"sockshop///builtin-static/sli.csv": "response_time_p95,300\nthroughput,abc\n",
+		}))
+		_, err := provider.GetSLIs(context.TODO(), request)
+		require.NotNil(t, err)
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		provider := NewStaticSLIProvider(getResourceHandlerMock(nil))
+		_, err := provider.GetSLIs(context.TODO(), request)
+		require.NotNil(t, err)
+	})
+}
+
+func TestPrometheusSLIProvider_GetSLIs(t *testing.T) {
+	var receivedQueries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		receivedQueries = append(receivedQueries, query)
+		w.Header().Add("Content-Type", "application/json")
+		switch query {
+		case "rate(errors{job='carts-sockshop-dev'}[300s])":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1635760800,"0.05"]}]}}`))
+		case "empty":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		}
+	}))
+	defer ts.Close()
+
+	provider := NewPrometheusSLIProvider(getResourceHandlerMock(map[string]string{
+		"sockshop/dev//builtin-prometheus/sli.yaml": "url: " + ts.URL + "\nindicators:\n  error_rate: rate(errors{job='$SERVICE-$PROJECT-$STAGE'}[$DURATION_SECONDS])\n  empty: empty\n  invalid: invalid(\n",
+	}))
+
+	results, err := provider.GetSLIs(context.TODO(), SLIRequest{
+		Project:    "sockshop",
+		Stage:      "dev",
+		Service:    "carts",
+		Indicators: []string{"error_rate", "empty", "invalid", "undefined"},
+		Start:      "2021-11-01T09:55:00.000Z",
+		End:        "2021-11-01T10:00:00.000Z",
+	})
+	require.Nil(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, &keptnv2.SLIResult{Metric: "error_rate", Value: 0.05, Success: true}, results[0])
+	assert.False(t, results[1].Success)
+	assert.Equal(t, "query returned 0 results, expected exactly one", results[1].Message)
+	assert.False(t, results[2].Success)
+	assert.Equal(t, "query failed with status code 400: parse error", results[2].Message)
+	assert.False(t, results[3].Success)
+	assert.Equal(t, "no query defined for SLI undefined", results[3].Message)
+	assert.Len(t, receivedQueries, 3)
+}
+
+func TestPrometheusSLIProvider_GetSLIsWithoutURL(t *testing.T) {
+	provider := NewPrometheusSLIProvider(getResourceHandlerMock(map[string]string{
+		"sockshop/dev/carts/builtin-prometheus/sli.yaml": "indicators:\n  error_rate: rate(errors[1m])\n",
+	}))
+	_, err := provider.GetSLIs(context.TODO(), SLIRequest{Project: "sockshop", Stage: "dev", Service: "carts"})
+	require.NotNil(t, err)
+}
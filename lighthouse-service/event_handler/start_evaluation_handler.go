@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	logger "github.com/sirupsen/logrus"
+	"net/http"
 	"net/url"
 	"sync"
 
@@ -20,6 +21,8 @@ type StartEvaluationHandler struct {
 	KeptnHandler      *keptnv2.Keptn
 	SLIProviderConfig SLIProviderConfig
 	SLOFileRetriever  SLOFileRetriever `deep:"-"`
+	// SLIProviders contains the SLI providers that are executed in-process instead of sending a get-sli.triggered event
+	SLIProviders *SLIProviderRegistry `deep:"-"`
 }
 
 func (eh *StartEvaluationHandler) HandleEvent(ctx context.Context) error {
@@ -118,12 +121,64 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 			return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), commitID, eh.KeptnHandler, &evaluationFinishedData)
 		}
 	}
-	// send a new event to trigger the SLI retrieval
 	logger.Debugf("SLI provider for project '%s' is: '%s'", e.Project, sliProvider)
+	if provider, ok := eh.SLIProviders.Get(sliProvider); ok {
+		return eh.retrieveSLIsInProcess(ctx, provider, keptnContext, commitID, e, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
+	}
+	// send a new event to trigger the SLI retrieval
 	err = eh.sendInternalGetSLIEvent(keptnContext, commitID, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
 	return nil
 }
 
+// retrieveSLIsInProcess retrieves the SLI values using an in-process SLI provider and evaluates them directly
+func (eh *StartEvaluationHandler) retrieveSLIsInProcess(ctx context.Context, provider SLIProvider, keptnContext string, commitID string, e *keptnv2.EvaluationTriggeredEventData, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
+	request := SLIRequest{
+		Project:       e.Project,
+		Stage:         e.Stage,
+		Service:       e.Service,
+		Labels:        e.Labels,
+		Indicators:    indicators,
+		CustomFilters: filters,
+		Start:         start,
+		End:           end,
+	}
+	if len(e.Deployment.DeploymentNames) > 0 {
+		request.Deployment = e.Deployment.DeploymentNames[0]
+	}
+
+	getSLIFinishedEventData := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{
+			Project: e.Project,
+			Stage:   e.Stage,
+			Service: e.Service,
+			Labels:  e.Labels,
+			Status:  keptnv2.StatusSucceeded,
+			Result:  keptnv2.ResultPass,
+		},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: start,
+			End:   end,
+		},
+	}
+
+	results, err := provider.GetSLIs(ctx, request)
+	if err != nil {
+		logger.Errorf("Could not retrieve SLIs for project '%s': %v", e.Project, err)
+		getSLIFinishedEventData.Status = keptnv2.StatusErrored
+		getSLIFinishedEventData.Result = keptnv2.ResultFailed
+		getSLIFinishedEventData.Message = err.Error()
+	}
+	getSLIFinishedEventData.GetSLI.IndicatorValues = results
+
+	evaluator := &EvaluateSLIHandler{
+		Event:            eh.Event,
+		HTTPClient:       &http.Client{},
+		KeptnHandler:     eh.KeptnHandler,
+		SLOFileRetriever: eh.SLOFileRetriever,
+	}
+	return evaluator.evaluateSLIs(keptnContext, eh.Event.ID(), commitID, getSLIFinishedEventData)
+}
+
 func (eh *StartEvaluationHandler) computeObjectives(e *keptnv2.EvaluationTriggeredEventData, commitID string, indicators *[]string, filters *[]*keptnv2.SLIFilter, evaluationStartTimestamp string, evaluationEndTimestamp string) (error, bool) {
 	objectives, _, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service, commitID)
 	if err == nil && objectives != nil {
@@ -492,3 +492,91 @@ func Test_getEvaluationTimestamps(t *testing.T) {
 		})
 	}
 }
+
+type fakeSLIProvider struct {
+	request SLIRequest
+}
+
+func (f *fakeSLIProvider) GetSLIs(ctx context.Context, request SLIRequest) ([]*keptnv2.SLIResult, error) {
+	f.request = request
+	return []*keptnv2.SLIResult{{Metric: "response_time_p95", Value: 200, Success: true}}, nil
+}
+
+func TestStartEvaluationHandler_HandleEventWithInProcessSLIProvider(t *testing.T) {
+	receivedEvents := make(chan *keptnapi.KeptnContextExtendedCE)
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.Method == http.MethodPost && strings.Contains(r.RequestURI, "/events") {
+				body, _ := ioutil.ReadAll(r.Body)
+				event := &keptnapi.KeptnContextExtendedCE{}
+				_ = json.Unmarshal(body, event)
+				go func() { receivedEvents <- event }()
+			}
+			w.WriteHeader(200)
+			w.Write([]byte(`{}`))
+		}),
+	)
+	defer ts.Close()
+
+	t.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	wg := &sync.WaitGroup{}
+	ctx := cloudevents.WithEncodingStructured(context.WithValue(context.Background(), GracefulShutdownKey, wg))
+
+	event := getStartEvaluationEvent()
+	event.SetID("my-triggered-id")
+	keptnHandler, _ := keptnv2.NewKeptn(&event, keptncommon.KeptnOpts{
+		EventBrokerURL: ts.URL + "/events",
+	})
+
+	provider := &fakeSLIProvider{}
+	registry := NewSLIProviderRegistry()
+	registry.Register("in-process", provider)
+
+	eh := &StartEvaluationHandler{
+		Event:        event,
+		KeptnHandler: keptnHandler,
+		SLIProviderConfig: &MockSLIProviderConfig{
+			ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "in-process"},
+		},
+		SLOFileRetriever: SLOFileRetriever{
+			ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+				GetResourceFunc: func(scope api.ResourceScope, options ...api.URIOption) (*keptnapi.Resource, error) {
+					return &keptnapi.Resource{ResourceContent: "objectives:\n  - sli: response_time_p95\n    pass:\n      - criteria:\n          - \"<300\"\ntotal_score:\n  pass: \"90%\"\n"}, nil
+				},
+			},
+		},
+		SLIProviders: registry,
+	}
+
+	require.Nil(t, eh.HandleEvent(ctx))
+
+	var eventTypes []string
+	var finishedEvent *keptnapi.KeptnContextExtendedCE
+	for finishedEvent == nil {
+		select {
+		case e := <-receivedEvents:
+			eventTypes = append(eventTypes, *e.Type)
+			if *e.Type == keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName) {
+				finishedEvent = e
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("did not receive evaluation.finished event, received %v", eventTypes)
+		}
+	}
+	wg.Wait()
+
+	require.NotContains(t, eventTypes, keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName))
+	require.Equal(t, "my-triggered-id", finishedEvent.Triggeredid)
+	require.Equal(t, []string{"response_time_p95"}, provider.request.Indicators)
+	require.Equal(t, "sockshop", provider.request.Project)
+
+	data := &keptnv2.EvaluationFinishedEventData{}
+	require.Nil(t, keptnv2.Decode(finishedEvent.Data, data))
+	require.Equal(t, keptnv2.ResultPass, data.Result)
+	require.Equal(t, 100.0, data.Evaluation.Score)
+}
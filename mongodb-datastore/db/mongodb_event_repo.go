@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"github.com/jeremywohl/flatten"
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	logger "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -34,6 +36,7 @@ const (
 	projectPropertyPath      = "data.project"
 	stagePropertyPath        = "data.stage"
 	servicePropertyPath      = "data.service"
+	labelsPropertyPath       = "data.labels"
 )
 
 var (
@@ -234,6 +237,47 @@ func (mr *MongoDBEventRepo) GetEventsByType(params event.GetEventsByTypeParams)
 	return events, nil
 }
 
+// GetEvaluationResults returns the evaluation.finished events of a service in a stage, ordered by time (newest first)
+func (mr *MongoDBEventRepo) GetEvaluationResults(params evaluation.GetEvaluationHistoryParams) (*EventsResult, error) {
+	matchFields := bson.M{
+		typePropertyPath:    keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+		projectPropertyPath: params.Project,
+		stagePropertyPath:   params.Stage,
+		servicePropertyPath: params.Service,
+	}
+
+	if params.Labels != nil {
+		labels, err := ParseLabelFilter(*params.Labels)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range labels {
+			matchFields[labelsPropertyPath+"."+key] = value
+		}
+	}
+
+	timeRange := bson.M{}
+	if params.FromTime != nil {
+		timeRange["$gt"] = *params.FromTime
+	}
+	if params.BeforeTime != nil {
+		timeRange["$lt"] = *params.BeforeTime
+	}
+	if len(timeRange) > 0 {
+		matchFields[timePropertyPath] = timeRange
+	}
+
+	var limit int64
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	if params.ExcludeInvalidated != nil && *params.ExcludeInvalidated && mr.invalidatedCollectionAvailable(params.Project) {
+		return mr.aggregateFromDB(params.Project, getInvalidatedEventPipeline(params.Project, matchFields, limit))
+	}
+	return mr.aggregateFromDB(params.Project, getEventPipeline(matchFields, limit))
+}
+
 func (mr *MongoDBEventRepo) storeEvaluationInvalidatedEvent(ctx context.Context, collection *mongo.Collection, eventInterface interface{}) error {
 	invalidatedCollectionName := getInvalidatedCollectionName(collection.Name())
 	logger.Debug("Storing invalidated event to dedicated collection " + invalidatedCollectionName)
@@ -609,8 +653,17 @@ func formatEventResults(ctx context.Context, cur *mongo.Cursor) []keptnapi.Keptn
 }
 
 func getInvalidatedEventQuery(params event.GetEventsByTypeParams, collectionName string, matchFields bson.M) mongo.Pipeline {
+	var limit int64
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	return getInvalidatedEventPipeline(collectionName, matchFields, limit)
+}
+
+// getInvalidatedEventPipeline returns a pipeline that retrieves the events matching the given fields, except the ones
+// that have been invalidated, ordered by time (newest first). A limit <= 0 returns all matching events
+func getInvalidatedEventPipeline(collectionName string, matchFields bson.M, limit int64) mongo.Pipeline {
 	const matchExpr = "$match"
-	const triggeredIDVar = "$triggeredid"
 
 	matchStage := bson.D{
 		{Key: matchExpr, Value: matchFields},
@@ -632,22 +685,53 @@ func getInvalidatedEventQuery(params event.GetEventsByTypeParams, collectionName
 			},
 		}},
 	}
-	sortStage := bson.D{
+
+	aggregationPipeline := mongo.Pipeline{matchStage, lookupStage, matchInvalidatedStage, getSortByTimeStage()}
+	if limit > 0 {
+		aggregationPipeline = append(aggregationPipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	return aggregationPipeline
+}
+
+// getEventPipeline returns a pipeline that retrieves the events matching the given fields, ordered by time (newest first).
+// A limit <= 0 returns all matching events
+func getEventPipeline(matchFields bson.M, limit int64) mongo.Pipeline {
+	aggregationPipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: matchFields}},
+		getSortByTimeStage(),
+	}
+	if limit > 0 {
+		aggregationPipeline = append(aggregationPipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	return aggregationPipeline
+}
+
+func getSortByTimeStage() bson.D {
+	return bson.D{
 		{Key: "$sort", Value: bson.M{
 			timePropertyPath: -1,
 		}},
 	}
-	var aggregationPipeline mongo.Pipeline
-	if params.Limit != nil && *params.Limit > 0 {
-		limitStage := bson.D{
-			{Key: "$limit", Value: *params.Limit},
+}
+
+// ParseLabelFilter parses a label filter of the format key1:value1,key2:value2
+func ParseLabelFilter(filter string) (map[string]string, error) {
+	labels := map[string]string{}
+	if strings.TrimSpace(filter) == "" {
+		return labels, nil
+	}
+	for _, keyValuePair := range strings.Split(filter, ",") {
+		split := strings.SplitN(keyValuePair, ":", 2)
+		if len(split) != 2 || strings.TrimSpace(split[0]) == "" {
+			return nil, fmt.Errorf("%w: invalid label filter '%s', expected format key:value", common.ErrInvalidEventFilter, keyValuePair)
 		}
-		aggregationPipeline = mongo.Pipeline{matchStage, lookupStage, matchInvalidatedStage, sortStage, limitStage}
-	} else {
-		aggregationPipeline = mongo.Pipeline{matchStage, lookupStage, matchInvalidatedStage, sortStage}
+		key := strings.TrimSpace(split[0])
+		if strings.ContainsAny(key, ".$") {
+			return nil, fmt.Errorf("%w: label key '%s' must not contain '.' or '$'", common.ErrInvalidEventFilter, key)
+		}
+		labels[key] = strings.TrimSpace(split[1])
 	}
-
-	return aggregationPipeline
+	return labels, nil
 }
 
 func parseFilter(filter string) bson.M {
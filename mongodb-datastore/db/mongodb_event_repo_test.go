@@ -644,3 +644,44 @@ func Test_getAggregationPipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLabelFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "empty filter",
+			filter: "",
+			want:   map[string]string{},
+		},
+		{
+			name:   "multiple labels",
+			filter: "buildId:1.2.3, env:perf-lab",
+			want:   map[string]string{"buildId": "1.2.3", "env": "perf-lab"},
+		},
+		{
+			name:    "missing value separator",
+			filter:  "buildId",
+			wantErr: true,
+		},
+		{
+			name:    "invalid key",
+			filter:  "$where:1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabelFilter(tt.filter)
+			if tt.wantErr {
+				require.ErrorIs(t, err, common.ErrInvalidEventFilter)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
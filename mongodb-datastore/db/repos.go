@@ -2,6 +2,7 @@ package db
 
 import (
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 )
 
@@ -21,4 +22,5 @@ type EventRepo interface {
 	DropProjectCollections(event keptnapi.KeptnContextExtendedCE) error
 	GetEvents(params event.GetEventsParams) (*EventsResult, error)
 	GetEventsByType(params event.GetEventsByTypeParams) (*EventsResult, error)
+	GetEvaluationResults(params evaluation.GetEvaluationHistoryParams) (*EventsResult, error)
 }
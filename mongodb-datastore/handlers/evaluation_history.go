@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/mongodb-datastore/common"
+	"github.com/keptn/keptn/mongodb-datastore/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultMovingAverageWindow = 3
+
+// GetEvaluationHistory returns the evaluation results of a service in a stage, together with moving averages
+// of the total score and of each SLI, as well as a summary of the pass/warning/fail ratio
+func (erh *EventRequestHandler) GetEvaluationHistory(params evaluation.GetEvaluationHistoryParams) (*models.EvaluationHistory, error) {
+	events, err := erh.eventRepo.GetEvaluationResults(params)
+	if err != nil {
+		errMsg := fmt.Sprintf("Could not get evaluation results: %v", err)
+		if errors.Is(err, common.ErrInvalidEventFilter) {
+			log.Warn(errMsg)
+		} else {
+			log.Error(errMsg)
+		}
+		return nil, err
+	}
+
+	window := int64(defaultMovingAverageWindow)
+	if params.MovingAverageWindow != nil {
+		window = *params.MovingAverageWindow
+	}
+	return getEvaluationHistory(events.Events, int(window)), nil
+}
+
+// getEvaluationHistory aggregates the given evaluation.finished events, which are expected to be ordered by time (newest first).
+// The entries of the resulting history are ordered by time (oldest first)
+func getEvaluationHistory(events []keptnapi.KeptnContextExtendedCE, window int) *models.EvaluationHistory {
+	if window < 1 {
+		window = 1
+	}
+	history := &models.EvaluationHistory{
+		Evaluations: []*models.EvaluationHistoryEntry{},
+		Summary:     &models.EvaluationHistorySummary{},
+	}
+
+	var scores []float64
+	sliValues := map[string][]float64{}
+	scoreSum := 0.0
+
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		data := &keptnv2.EvaluationFinishedEventData{}
+		if err := keptnv2.Decode(event.Data, data); err != nil {
+			log.Errorf("Could not decode data of event %s: %v", event.ID, err)
+			continue
+		}
+
+		result := data.Evaluation.Result
+		if result == "" {
+			result = string(data.Result)
+		}
+
+		scores = append(scores, data.Evaluation.Score)
+		entry := &models.EvaluationHistoryEntry{
+			EventID:            event.ID,
+			KeptnContext:       event.Shkeptncontext,
+			Time:               timeutils.GetKeptnTimeStamp(event.Time),
+			Result:             result,
+			Score:              data.Evaluation.Score,
+			MovingAverageScore: average(lastN(scores, window)),
+			Labels:             data.Labels,
+			Indicators:         []*models.IndicatorHistoryValue{},
+		}
+
+		for _, indicator := range data.Evaluation.IndicatorResults {
+			if indicator == nil || indicator.Value == nil {
+				continue
+			}
+			value := &models.IndicatorHistoryValue{
+				Metric:      indicator.Value.Metric,
+				DisplayName: indicator.DisplayName,
+				Value:       indicator.Value.Value,
+				Success:     indicator.Value.Success,
+				Status:      indicator.Status,
+				Score:       indicator.Score,
+			}
+			// only successfully retrieved values are taken into account for the moving average
+			if indicator.Value.Success {
+				sliValues[value.Metric] = append(sliValues[value.Metric], value.Value)
+			}
+			value.MovingAverage = average(lastN(sliValues[value.Metric], window))
+			entry.Indicators = append(entry.Indicators, value)
+		}
+
+		history.Evaluations = append(history.Evaluations, entry)
+
+		history.Summary.Total++
+		scoreSum += data.Evaluation.Score
+		switch result {
+		case string(keptnv2.ResultPass):
+			history.Summary.Pass++
+		case string(keptnv2.ResultWarning):
+			history.Summary.Warning++
+		case string(keptnv2.ResultFailed):
+			history.Summary.Fail++
+		}
+	}
+
+	if history.Summary.Total > 0 {
+		history.Summary.AverageScore = scoreSum / float64(history.Summary.Total)
+	}
+	return history
+}
+
+func lastN(values []float64, n int) []float64 {
+	if len(values) <= n {
+		return values
+	}
+	return values[len(values)-n:]
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
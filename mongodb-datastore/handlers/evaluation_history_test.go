@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/swag"
+	keptnapi "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/mongodb-datastore/db"
+	"github.com/keptn/keptn/mongodb-datastore/models"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEventRepo struct {
+	evaluationResults *db.EventsResult
+	err               error
+}
+
+func (f *fakeEventRepo) InsertEvent(event keptnapi.KeptnContextExtendedCE) error {
+	return nil
+}
+
+func (f *fakeEventRepo) DropProjectCollections(event keptnapi.KeptnContextExtendedCE) error {
+	return nil
+}
+
+func (f *fakeEventRepo) GetEvents(params event.GetEventsParams) (*db.EventsResult, error) {
+	return nil, nil
+}
+
+func (f *fakeEventRepo) GetEventsByType(params event.GetEventsByTypeParams) (*db.EventsResult, error) {
+	return nil, nil
+}
+
+func (f *fakeEventRepo) GetEvaluationResults(params evaluation.GetEvaluationHistoryParams) (*db.EventsResult, error) {
+	return f.evaluationResults, f.err
+}
+
+func newEvaluationFinishedEvent(id string, timestamp time.Time, result keptnv2.ResultType, score float64, responseTime *float64) keptnapi.KeptnContextExtendedCE {
+	indicators := []*keptnv2.SLIEvaluationResult{}
+	if responseTime != nil {
+		indicators = append(indicators, &keptnv2.SLIEvaluationResult{
+			Score:       1,
+			DisplayName: "Response time",
+			Status:      "pass",
+			Value:       &keptnv2.SLIResult{Metric: "response_time_p95", Value: *responseTime, Success: true},
+		})
+	} else {
+		indicators = append(indicators, &keptnv2.SLIEvaluationResult{
+			Status: "fail",
+			Value:  &keptnv2.SLIResult{Metric: "response_time_p95", Success: false, Message: "no data"},
+		})
+	}
+	return keptnapi.KeptnContextExtendedCE{
+		ID:             id,
+		Shkeptncontext: "context-" + id,
+		Time:           timestamp,
+		Type:           swag.String(keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)),
+		Data: keptnv2.EvaluationFinishedEventData{
+			EventData: keptnv2.EventData{
+				Project: "sockshop",
+				Stage:   "dev",
+				Service: "carts",
+				Result:  result,
+				Labels:  map[string]string{"buildId": id},
+			},
+			Evaluation: keptnv2.EvaluationDetails{
+				Result:           string(result),
+				Score:            score,
+				IndicatorResults: indicators,
+			},
+		},
+	}
+}
+
+func TestEventRequestHandler_GetEvaluationHistory(t *testing.T) {
+	start := time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC)
+
+	// events are returned by the repository ordered by time, newest first
+	repo := &fakeEventRepo{
+		evaluationResults: &db.EventsResult{
+			Events: []keptnapi.KeptnContextExtendedCE{
+				newEvaluationFinishedEvent("4", start.Add(3*time.Hour), keptnv2.ResultPass, 100, swag.Float64(100)),
+				newEvaluationFinishedEvent("3", start.Add(2*time.Hour), keptnv2.ResultFailed, 0, nil),
+				newEvaluationFinishedEvent("2", start.Add(1*time.Hour), keptnv2.ResultWarning, 50, swag.Float64(300)),
+				newEvaluationFinishedEvent("1", start, keptnv2.ResultPass, 90, swag.Float64(200)),
+			},
+		},
+	}
+	erh := &EventRequestHandler{eventRepo: repo}
+
+	history, err := erh.GetEvaluationHistory(evaluation.GetEvaluationHistoryParams{
+		Project:             "sockshop",
+		Stage:               "dev",
+		Service:             "carts",
+		MovingAverageWindow: swag.Int64(2),
+	})
+	require.Nil(t, err)
+	require.Len(t, history.Evaluations, 4)
+
+	ids := []string{}
+	movingAverages := []float64{}
+	sliMovingAverages := []float64{}
+	for _, entry := range history.Evaluations {
+		ids = append(ids, entry.EventID)
+		movingAverages = append(movingAverages, entry.MovingAverageScore)
+		require.Len(t, entry.Indicators, 1)
+		sliMovingAverages = append(sliMovingAverages, entry.Indicators[0].MovingAverage)
+	}
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+	assert.Equal(t, []float64{90, 70, 25, 50}, movingAverages)
+	// failed SLI retrievals are not taken into account
+	assert.Equal(t, []float64{200, 250, 250, 200}, sliMovingAverages)
+
+	assert.Equal(t, "2021-11-01T10:00:00.000Z", history.Evaluations[0].Time)
+	assert.Equal(t, "context-1", history.Evaluations[0].KeptnContext)
+	assert.Equal(t, map[string]string{"buildId": "1"}, history.Evaluations[0].Labels)
+	assert.Equal(t, &models.IndicatorHistoryValue{
+		Metric:        "response_time_p95",
+		DisplayName:   "Response time",
+		Value:         200,
+		Success:       true,
+		Status:        "pass",
+		Score:         1,
+		MovingAverage: 200,
+	}, history.Evaluations[0].Indicators[0])
+
+	assert.Equal(t, &models.EvaluationHistorySummary{
+		Total:        4,
+		Pass:         2,
+		Warning:      1,
+		Fail:         1,
+		AverageScore: 60,
+	}, history.Summary)
+}
+
+func TestEventRequestHandler_GetEvaluationHistoryNoResults(t *testing.T) {
+	erh := &EventRequestHandler{eventRepo: &fakeEventRepo{evaluationResults: &db.EventsResult{}}}
+
+	history, err := erh.GetEvaluationHistory(evaluation.GetEvaluationHistoryParams{Project: "sockshop", Stage: "dev", Service: "carts"})
+	require.Nil(t, err)
+	assert.Empty(t, history.Evaluations)
+	assert.Equal(t, &models.EvaluationHistorySummary{}, history.Summary)
+}
+
+func TestEventRequestHandler_GetEvaluationHistoryError(t *testing.T) {
+	repoErr := errors.New("connection refused")
+	erh := &EventRequestHandler{eventRepo: &fakeEventRepo{err: repoErr}}
+
+	_, err := erh.GetEvaluationHistory(evaluation.GetEvaluationHistoryParams{Project: "sockshop", Stage: "dev", Service: "carts"})
+	require.ErrorIs(t, err, repoErr)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/keptn/keptn/mongodb-datastore/handlers"
 	"github.com/keptn/keptn/mongodb-datastore/models"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations"
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
 	log "github.com/sirupsen/logrus"
@@ -79,6 +80,17 @@ func configureAPI(api *operations.MongodbDatastoreAPI) http.Handler {
 		return event.NewGetEventsByTypeOK().WithPayload(events)
 	})
 
+	api.EvaluationGetEvaluationHistoryHandler = evaluation.GetEvaluationHistoryHandlerFunc(func(params evaluation.GetEvaluationHistoryParams) middleware.Responder {
+		history, err := eventRequestHandler.GetEvaluationHistory(params)
+		if err != nil {
+			if errors.Is(err, common.ErrInvalidEventFilter) {
+				return evaluation.NewGetEvaluationHistoryBadRequest().WithPayload(&models.Error{Code: http.StatusBadRequest, Message: swag.String(err.Error())})
+			}
+			return evaluation.NewGetEvaluationHistoryInternalServerError().WithPayload(&models.Error{Code: http.StatusInternalServerError, Message: swag.String(err.Error())})
+		}
+		return evaluation.NewGetEvaluationHistoryOK().WithPayload(history)
+	})
+
 	api.HealthGetHealthHandler = health.GetHealthHandlerFunc(func(params health.GetHealthParams) middleware.Responder {
 		return health.NewGetHealthOK()
 	})
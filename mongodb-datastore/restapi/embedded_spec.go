@@ -32,6 +32,104 @@ func init() {
   },
   "basePath": "/",
   "paths": {
+    "/evaluation/{project}/{stage}/{service}/history": {
+      "get": {
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Gets the history of the evaluation results of a service, including pass/warning/fail counts and moving averages",
+        "description": "\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}events:read\u003c/span\u003e\n",
+        "operationId": "getEvaluationHistory",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only include evaluations that have been finished after this time",
+            "name": "fromTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only include evaluations that have been finished before this time",
+            "name": "beforeTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Comma-separated list of labels the evaluations must contain, in the format key:value (e.g. 'env:perf-lab,team:carts')",
+            "name": "labels",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Exclude evaluations that have been invalidated",
+            "name": "excludeInvalidated",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 3,
+            "description": "Number of evaluations used to calculate the moving averages",
+            "name": "movingAverageWindow",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "default": 100,
+            "description": "Maximum number of evaluations to be returned, starting with the most recent one",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/EvaluationHistory"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "project",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stage",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "service",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/event": {
       "get": {
         "tags": [
@@ -226,6 +324,114 @@ func init() {
     }
   },
   "definitions": {
+    "EvaluationHistory": {
+      "type": "object",
+      "properties": {
+        "evaluations": {
+          "description": "Evaluation results, ordered by time (oldest first)",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/EvaluationHistoryEntry"
+          }
+        },
+        "summary": {
+          "$ref": "#/definitions/EvaluationHistorySummary"
+        }
+      }
+    },
+    "EvaluationHistoryEntry": {
+      "type": "object",
+      "properties": {
+        "eventId": {
+          "type": "string"
+        },
+        "indicators": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IndicatorHistoryValue"
+          }
+        },
+        "keptnContext": {
+          "type": "string"
+        },
+        "labels": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "movingAverageScore": {
+          "description": "Average score of this and the previous evaluations within the moving average window",
+          "type": "number",
+          "format": "double"
+        },
+        "result": {
+          "type": "string"
+        },
+        "score": {
+          "type": "number",
+          "format": "double"
+        },
+        "time": {
+          "type": "string"
+        }
+      }
+    },
+    "EvaluationHistorySummary": {
+      "type": "object",
+      "properties": {
+        "averageScore": {
+          "type": "number",
+          "format": "double"
+        },
+        "fail": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "pass": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "warning": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "IndicatorHistoryValue": {
+      "type": "object",
+      "properties": {
+        "displayName": {
+          "type": "string"
+        },
+        "metric": {
+          "type": "string"
+        },
+        "movingAverage": {
+          "description": "Average value of this SLI in this and the previous successful evaluations within the moving average window",
+          "type": "number",
+          "format": "double"
+        },
+        "score": {
+          "type": "number",
+          "format": "double"
+        },
+        "status": {
+          "type": "string"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "value": {
+          "type": "number",
+          "format": "double"
+        }
+      }
+    },
     "KeptnContextExtendedCE": {
       "type": "object",
       "x-go-type": {
@@ -303,6 +509,104 @@ func init() {
   },
   "basePath": "/",
   "paths": {
+    "/evaluation/{project}/{stage}/{service}/history": {
+      "get": {
+        "tags": [
+          "evaluation"
+        ],
+        "summary": "Gets the history of the evaluation results of a service, including pass/warning/fail counts and moving averages",
+        "description": "\u003cspan class=\"oauth-scopes\"\u003eRequired OAuth scopes: ${prefix}events:read\u003c/span\u003e\n",
+        "operationId": "getEvaluationHistory",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only include evaluations that have been finished after this time",
+            "name": "fromTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only include evaluations that have been finished before this time",
+            "name": "beforeTime",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Comma-separated list of labels the evaluations must contain, in the format key:value (e.g. 'env:perf-lab,team:carts')",
+            "name": "labels",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": true,
+            "description": "Exclude evaluations that have been invalidated",
+            "name": "excludeInvalidated",
+            "in": "query"
+          },
+          {
+            "maximum": 100,
+            "minimum": 1,
+            "type": "integer",
+            "default": 3,
+            "description": "Number of evaluations used to calculate the moving averages",
+            "name": "movingAverageWindow",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "minimum": 1,
+            "type": "integer",
+            "default": 100,
+            "description": "Maximum number of evaluations to be returned, starting with the most recent one",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "ok",
+            "schema": {
+              "$ref": "#/definitions/EvaluationHistory"
+            }
+          },
+          "400": {
+            "description": "Bad Request",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "500": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "string",
+          "description": "Name of the project",
+          "name": "project",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the stage",
+          "name": "stage",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "Name of the service",
+          "name": "service",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/event": {
       "get": {
         "tags": [
@@ -512,6 +816,114 @@ func init() {
     }
   },
   "definitions": {
+    "EvaluationHistory": {
+      "type": "object",
+      "properties": {
+        "evaluations": {
+          "description": "Evaluation results, ordered by time (oldest first)",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/EvaluationHistoryEntry"
+          }
+        },
+        "summary": {
+          "$ref": "#/definitions/EvaluationHistorySummary"
+        }
+      }
+    },
+    "EvaluationHistoryEntry": {
+      "type": "object",
+      "properties": {
+        "eventId": {
+          "type": "string"
+        },
+        "indicators": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/IndicatorHistoryValue"
+          }
+        },
+        "keptnContext": {
+          "type": "string"
+        },
+        "labels": {
+          "type": "object",
+          "additionalProperties": {
+            "type": "string"
+          }
+        },
+        "movingAverageScore": {
+          "description": "Average score of this and the previous evaluations within the moving average window",
+          "type": "number",
+          "format": "double"
+        },
+        "result": {
+          "type": "string"
+        },
+        "score": {
+          "type": "number",
+          "format": "double"
+        },
+        "time": {
+          "type": "string"
+        }
+      }
+    },
+    "EvaluationHistorySummary": {
+      "type": "object",
+      "properties": {
+        "averageScore": {
+          "type": "number",
+          "format": "double"
+        },
+        "fail": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "pass": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "warning": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "IndicatorHistoryValue": {
+      "type": "object",
+      "properties": {
+        "displayName": {
+          "type": "string"
+        },
+        "metric": {
+          "type": "string"
+        },
+        "movingAverage": {
+          "description": "Average value of this SLI in this and the previous successful evaluations within the moving average window",
+          "type": "number",
+          "format": "double"
+        },
+        "score": {
+          "type": "number",
+          "format": "double"
+        },
+        "status": {
+          "type": "string"
+        },
+        "success": {
+          "type": "boolean"
+        },
+        "value": {
+          "type": "number",
+          "format": "double"
+        }
+      }
+    },
     "KeptnContextExtendedCE": {
       "type": "object",
       "x-go-type": {
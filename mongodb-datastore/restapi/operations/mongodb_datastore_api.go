@@ -19,6 +19,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/evaluation"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/event"
 	"github.com/keptn/keptn/mongodb-datastore/restapi/operations/health"
 )
@@ -45,6 +46,9 @@ func NewMongodbDatastoreAPI(spec *loads.Document) *MongodbDatastoreAPI {
 
 		JSONProducer: runtime.JSONProducer(),
 
+		EvaluationGetEvaluationHistoryHandler: evaluation.GetEvaluationHistoryHandlerFunc(func(params evaluation.GetEvaluationHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation evaluation.GetEvaluationHistory has not yet been implemented")
+		}),
 		EventGetEventsHandler: event.GetEventsHandlerFunc(func(params event.GetEventsParams) middleware.Responder {
 			return middleware.NotImplemented("operation event.GetEvents has not yet been implemented")
 		}),
@@ -91,6 +95,8 @@ type MongodbDatastoreAPI struct {
 	//   - application/json
 	JSONProducer runtime.Producer
 
+	// EvaluationGetEvaluationHistoryHandler sets the operation handler for the get evaluation history operation
+	EvaluationGetEvaluationHistoryHandler evaluation.GetEvaluationHistoryHandler
 	// EventGetEventsHandler sets the operation handler for the get events operation
 	EventGetEventsHandler event.GetEventsHandler
 	// EventGetEventsByTypeHandler sets the operation handler for the get events by type operation
@@ -174,6 +180,9 @@ func (o *MongodbDatastoreAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.EvaluationGetEvaluationHistoryHandler == nil {
+		unregistered = append(unregistered, "evaluation.GetEvaluationHistoryHandler")
+	}
 	if o.EventGetEventsHandler == nil {
 		unregistered = append(unregistered, "event.GetEventsHandler")
 	}
@@ -273,6 +282,10 @@ func (o *MongodbDatastoreAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/evaluation/{project}/{stage}/{service}/history"] = evaluation.NewGetEvaluationHistory(o.context, o.EvaluationGetEvaluationHistoryHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -0,0 +1,163 @@
+package common
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+// ArchiveWriter writes files into a tar.gz or zip archive
+type ArchiveWriter struct {
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+	zipWriter  *zip.Writer
+}
+
+func NewArchiveWriter(w io.Writer, format string) (*ArchiveWriter, error) {
+	switch format {
+	case models.ArchiveFormatTarGz:
+		gzipWriter := gzip.NewWriter(w)
+		return &ArchiveWriter{gzipWriter: gzipWriter, tarWriter: tar.NewWriter(gzipWriter)}, nil
+	case models.ArchiveFormatZip:
+		return &ArchiveWriter{zipWriter: zip.NewWriter(w)}, nil
+	default:
+		return nil, kerrors.ErrUnknownArchiveFormat
+	}
+}
+
+// Add writes a regular file with the given slash-separated name into the archive
+func (a *ArchiveWriter) Add(name string, content io.Reader, size int64, modTime time.Time) error {
+	if a.zipWriter != nil {
+		w, err := a.zipWriter.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, content)
+		return err
+	}
+
+	err := a.tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0644,
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(a.tarWriter, content)
+	return err
+}
+
+func (a *ArchiveWriter) Close() error {
+	if a.zipWriter != nil {
+		return a.zipWriter.Close()
+	}
+	if err := a.tarWriter.Close(); err != nil {
+		return err
+	}
+	return a.gzipWriter.Close()
+}
+
+// WalkArchive calls walkFn for each regular file of the archive with the given path. Other entries, e.g. directories
+// or symbolic links, are skipped. An error is returned for names that point outside of the archive
+func WalkArchive(archivePath string, format string, walkFn func(name string, content io.Reader) error) error {
+	switch format {
+	case models.ArchiveFormatTarGz:
+		return walkTarGz(archivePath, walkFn)
+	case models.ArchiveFormatZip:
+		return walkZip(archivePath, walkFn)
+	default:
+		return kerrors.ErrUnknownArchiveFormat
+	}
+}
+
+func walkTarGz(archivePath string, walkFn func(name string, content io.Reader) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			logger.Debugf("Skipping archive entry %s of type %c", header.Name, header.Typeflag)
+			continue
+		}
+		name, err := cleanArchiveEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if err := walkFn(name, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func walkZip(archivePath string, walkFn func(name string, content io.Reader) error) error {
+	zipReader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() {
+			logger.Debugf("Skipping archive entry %s with mode %s", file.Name, file.Mode())
+			continue
+		}
+		name, err := cleanArchiveEntryName(file.Name)
+		if err != nil {
+			return err
+		}
+		if err := walkZipFile(file, name, walkFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkZipFile(file *zip.File, name string, walkFn func(name string, content io.Reader) error) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", kerrors.ErrInvalidArchive, err)
+	}
+	defer content.Close()
+	return walkFn(name, content)
+}
+
+// cleanArchiveEntryName returns the slash-separated name of an archive entry relative to the root of the archive
+func cleanArchiveEntryName(name string) (string, error) {
+	cleaned := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	cleaned = strings.TrimPrefix(cleaned, "./")
+	if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: entry %s points outside of the archive", kerrors.ErrInvalidArchive, name)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,112 @@
+package common
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestArchive(t *testing.T, format string, files map[string]string) string {
+	archivePath := filepath.Join(t.TempDir(), "archive."+format)
+	file, err := os.Create(archivePath)
+	require.Nil(t, err)
+	defer file.Close()
+
+	archive, err := NewArchiveWriter(file, format)
+	require.Nil(t, err)
+	for name, content := range files {
+		require.Nil(t, archive.Add(name, strings.NewReader(content), int64(len(content)), time.Now()))
+	}
+	require.Nil(t, archive.Close())
+	return archivePath
+}
+
+func readTestArchive(t *testing.T, archivePath string, format string) map[string]string {
+	files := map[string]string{}
+	err := WalkArchive(archivePath, format, func(name string, content io.Reader) error {
+		data, err := ioutil.ReadAll(content)
+		files[name] = string(data)
+		return err
+	})
+	require.Nil(t, err)
+	return files
+}
+
+func TestArchive(t *testing.T) {
+	files := map[string]string{
+		"shipyard.yaml":                        "my-shipyard",
+		".keptn-stages/dev/carts/helm/values":  "my-values",
+		".keptn-stages/prod/carts/model.bin":   string(make([]byte, 1024)),
+		".keptn-stages/prod/carts/empty.yaml":  "",
+		".keptn-stages/prod/carts/nested/file": "my-file",
+	}
+
+	for _, format := range []string{models.ArchiveFormatTarGz, models.ArchiveFormatZip} {
+		t.Run(format, func(t *testing.T) {
+			archivePath := writeTestArchive(t, format, files)
+			require.Equal(t, files, readTestArchive(t, archivePath, format))
+		})
+	}
+}
+
+func TestArchive_UnknownFormat(t *testing.T) {
+	_, err := NewArchiveWriter(&bytes.Buffer{}, "rar")
+	require.ErrorIs(t, err, kerrors.ErrUnknownArchiveFormat)
+
+	err = WalkArchive("archive.rar", "rar", nil)
+	require.ErrorIs(t, err, kerrors.ErrUnknownArchiveFormat)
+}
+
+func TestWalkArchive_InvalidArchive(t *testing.T) {
+	archivePath := writeTestArchive(t, models.ArchiveFormatZip, map[string]string{"shipyard.yaml": "my-shipyard"})
+
+	err := WalkArchive(archivePath, models.ArchiveFormatTarGz, func(name string, content io.Reader) error { return nil })
+	require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+}
+
+func TestWalkArchive_SkipsOtherEntries(t *testing.T) {
+	archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+	file, err := os.Create(archivePath)
+	require.Nil(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	require.Nil(t, tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "carts/", Mode: 0755}))
+	require.Nil(t, tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: "carts/passwd", Linkname: "/etc/passwd"}))
+	require.Nil(t, tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "./carts/values.yaml", Size: 2, Mode: 0644}))
+	_, err = tarWriter.Write([]byte("ok"))
+	require.Nil(t, err)
+	require.Nil(t, tarWriter.Close())
+	require.Nil(t, gzipWriter.Close())
+	require.Nil(t, file.Close())
+
+	require.Equal(t, map[string]string{"carts/values.yaml": "ok"}, readTestArchive(t, archivePath, models.ArchiveFormatTarGz))
+}
+
+func TestWalkArchive_PathTraversal(t *testing.T) {
+	for _, name := range []string{"../shipyard.yaml", "carts/../../shipyard.yaml", "/etc/passwd", "..\\shipyard.yaml"} {
+		for _, format := range []string{models.ArchiveFormatTarGz, models.ArchiveFormatZip} {
+			t.Run(format+" "+name, func(t *testing.T) {
+				archivePath := writeTestArchive(t, format, map[string]string{name: "content"})
+
+				called := false
+				err := WalkArchive(archivePath, format, func(name string, content io.Reader) error {
+					called = true
+					return nil
+				})
+				require.ErrorIs(t, err, kerrors.ErrInvalidArchive)
+				require.False(t, called)
+			})
+		}
+	}
+}
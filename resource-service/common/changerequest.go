@@ -0,0 +1,203 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const changeRequestSettingsPrefix = "git-change-request-"
+const changeRequestSettingsKey = "change-request"
+
+// changeRequestSettingsCacheTTL defines how long the settings of a project are cached, to not read the secret on every request
+const changeRequestSettingsCacheTTL = 30 * time.Second
+
+// ChangeRequestProvider opens and inspects change requests using the API of a git hosting service
+type ChangeRequestProvider interface {
+	CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error)
+	GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error)
+}
+
+// IChangeRequestManager provides the change request settings of projects and forwards change requests to the configured provider
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/change_request_manager_mock.go . IChangeRequestManager
+type IChangeRequestManager interface {
+	GetSettings(project string) (*common_models.ChangeRequestSettings, error)
+	CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error)
+	GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error)
+}
+
+// ChangeRequestManager reads the change request settings of a project from the 'git-change-request-<project>' secret.
+// Projects without such a secret push their changes to the upstream branch directly
+type ChangeRequestManager struct {
+	k8sClient     kubernetes.Interface
+	providers     map[string]ChangeRequestProvider
+	settingsCache map[string]cachedChangeRequestSettings
+	mtx           sync.RWMutex
+}
+
+type cachedChangeRequestSettings struct {
+	settings  *common_models.ChangeRequestSettings
+	expiresAt time.Time
+}
+
+func NewChangeRequestManager(k8sClient kubernetes.Interface) *ChangeRequestManager {
+	m := &ChangeRequestManager{
+		k8sClient:     k8sClient,
+		providers:     map[string]ChangeRequestProvider{},
+		settingsCache: map[string]cachedChangeRequestSettings{},
+	}
+	m.RegisterProvider("github", NewGitHubProvider())
+	m.RegisterProvider("gitlab", NewGitLabProvider())
+	m.RegisterProvider("gitea", NewGiteaProvider())
+	return m
+}
+
+// RegisterProvider makes the given provider available for projects with the given provider name in their settings
+func (m *ChangeRequestManager) RegisterProvider(name string, provider ChangeRequestProvider) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.providers[name] = provider
+}
+
+// GetSettings returns the change request settings of the project, or nil if the change request flow is not enabled for the project.
+// The settings are cached for a short time, since they are required for every request
+func (m *ChangeRequestManager) GetSettings(project string) (*common_models.ChangeRequestSettings, error) {
+	if m.k8sClient == nil {
+		return nil, nil
+	}
+	m.mtx.RLock()
+	cached, ok := m.settingsCache[project]
+	m.mtx.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return copyChangeRequestSettings(cached.settings), nil
+	}
+
+	settings, err := m.readSettings(project)
+	if err != nil {
+		return nil, err
+	}
+	m.mtx.Lock()
+	m.settingsCache[project] = cachedChangeRequestSettings{settings: settings, expiresAt: time.Now().Add(changeRequestSettingsCacheTTL)}
+	m.mtx.Unlock()
+	return copyChangeRequestSettings(settings), nil
+}
+
+func (m *ChangeRequestManager) readSettings(project string) (*common_models.ChangeRequestSettings, error) {
+	secretName := GetChangeRequestSettingsSecretName(project)
+	secret, err := m.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		// the change request flow is opt-in
+		return nil, nil
+	}
+	if err != nil {
+		logger.Debugf("Could not retrieve change request settings named: %s, error: %s", secretName, err.Error())
+		return nil, err
+	}
+
+	settings := &common_models.ChangeRequestSettings{}
+	if err := json.Unmarshal(secret.Data[changeRequestSettingsKey], settings); err != nil {
+		return nil, kerrors.ErrMalformedChangeRequestSettings
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := m.getProvider(settings.Provider); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (m *ChangeRequestManager) CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+	provider, err := m.getProvider(settings.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreateChangeRequest(settings, request)
+}
+
+func (m *ChangeRequestManager) GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+	provider, err := m.getProvider(settings.Provider)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetChangeRequest(settings, id)
+}
+
+func (m *ChangeRequestManager) getProvider(name string) (ChangeRequestProvider, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	provider, ok := m.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", kerrors.ErrUnknownChangeRequestProvider, name)
+	}
+	return provider, nil
+}
+
+// copyChangeRequestSettings returns a copy of the settings, so that callers cannot modify the cached settings
+func copyChangeRequestSettings(settings *common_models.ChangeRequestSettings) *common_models.ChangeRequestSettings {
+	if settings == nil {
+		return nil
+	}
+	result := *settings
+	return &result
+}
+
+func GetChangeRequestSettingsSecretName(projectName string) string {
+	return fmt.Sprintf("%s%s", changeRequestSettingsPrefix, projectName)
+}
+
+// FakeChangeRequestProvider keeps change requests in memory. It can be used to test the change request flow without a git hosting service
+type FakeChangeRequestProvider struct {
+	changeRequests []models.ChangeRequest
+	mtx            sync.Mutex
+}
+
+func NewFakeChangeRequestProvider() *FakeChangeRequestProvider {
+	return &FakeChangeRequestProvider{}
+}
+
+func (f *FakeChangeRequestProvider) CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	request.ID = fmt.Sprintf("%d", len(f.changeRequests)+1)
+	request.State = models.ChangeRequestStateOpen
+	f.changeRequests = append(f.changeRequests, request)
+	return &request, nil
+}
+
+func (f *FakeChangeRequestProvider) GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, changeRequest := range f.changeRequests {
+		if changeRequest.ID == id {
+			result := changeRequest
+			return &result, nil
+		}
+	}
+	return nil, kerrors.ErrChangeRequestNotFound
+}
+
+// SetState changes the state of a change request, e.g. to simulate a merge
+func (f *FakeChangeRequestProvider) SetState(id string, state models.ChangeRequestState) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for i := range f.changeRequests {
+		if f.changeRequests[i].ID == id {
+			f.changeRequests[i].State = state
+			return nil
+		}
+	}
+	return kerrors.ErrChangeRequestNotFound
+}
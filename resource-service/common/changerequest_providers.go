@@ -0,0 +1,274 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+const defaultGitHubAPIURL = "https://api.github.com"
+const defaultGitLabAPIURL = "https://gitlab.com/api/v4"
+
+const changeRequestProviderTimeout = 30 * time.Second
+
+// GitHubProvider opens pull requests using the GitHub REST API
+type GitHubProvider struct {
+	httpClient *http.Client
+}
+
+func NewGitHubProvider() *GitHubProvider {
+	return &GitHubProvider{httpClient: &http.Client{Timeout: changeRequestProviderTimeout}}
+}
+
+type gitHubPullRequest struct {
+	Number  int    `json:"number,omitempty"`
+	HTMLURL string `json:"html_url,omitempty"`
+	State   string `json:"state,omitempty"`
+	Merged  bool   `json:"merged,omitempty"`
+	Title   string `json:"title"`
+	Body    string `json:"body,omitempty"`
+	Head    string `json:"head,omitempty"`
+	Base    string `json:"base,omitempty"`
+}
+
+func (g *GitHubProvider) CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+	pullRequest := &gitHubPullRequest{}
+	err := doChangeRequestAPICall(g.httpClient, http.MethodPost, g.apiURL(settings)+"/repos/"+settings.Repository+"/pulls", gitHubHeaders(settings), &gitHubPullRequest{
+		Title: request.Title,
+		Body:  request.Description,
+		Head:  request.SourceBranch,
+		Base:  request.TargetBranch,
+	}, pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	return gitHubToChangeRequest(pullRequest, request), nil
+}
+
+func (g *GitHubProvider) GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+	pullRequest := &struct {
+		gitHubPullRequest
+		Head gitHubBranch `json:"head"`
+		Base gitHubBranch `json:"base"`
+	}{}
+	if err := doChangeRequestAPICall(g.httpClient, http.MethodGet, g.apiURL(settings)+"/repos/"+settings.Repository+"/pulls/"+url.PathEscape(id), gitHubHeaders(settings), nil, pullRequest); err != nil {
+		return nil, err
+	}
+	return gitHubToChangeRequest(&pullRequest.gitHubPullRequest, models.ChangeRequest{
+		SourceBranch: pullRequest.Head.Ref,
+		TargetBranch: pullRequest.Base.Ref,
+	}), nil
+}
+
+func (g *GitHubProvider) apiURL(settings common_models.ChangeRequestSettings) string {
+	if settings.APIURL == "" {
+		return defaultGitHubAPIURL
+	}
+	return strings.TrimSuffix(settings.APIURL, "/")
+}
+
+type gitHubBranch struct {
+	Ref string `json:"ref"`
+}
+
+func gitHubHeaders(settings common_models.ChangeRequestSettings) map[string]string {
+	return map[string]string{
+		"Accept":        "application/vnd.github+json",
+		"Authorization": "Bearer " + settings.Token,
+	}
+}
+
+// gitHubToChangeRequest converts GitHub and Gitea pull requests, which share the same representation of the relevant properties
+func gitHubToChangeRequest(pullRequest *gitHubPullRequest, request models.ChangeRequest) *models.ChangeRequest {
+	state := models.ChangeRequestStateOpen
+	if pullRequest.Merged {
+		state = models.ChangeRequestStateMerged
+	} else if pullRequest.State == "closed" {
+		state = models.ChangeRequestStateClosed
+	}
+	return &models.ChangeRequest{
+		ID:           strconv.Itoa(pullRequest.Number),
+		URL:          pullRequest.HTMLURL,
+		State:        state,
+		SourceBranch: request.SourceBranch,
+		TargetBranch: request.TargetBranch,
+		Title:        pullRequest.Title,
+		Description:  pullRequest.Body,
+	}
+}
+
+// GiteaProvider opens pull requests using the API of Gitea, or any other service compatible with it (e.g. Forgejo).
+// Since there is no public default instance, the APIURL (e.g. https://gitea.example.com/api/v1) must be set in the settings
+type GiteaProvider struct {
+	httpClient *http.Client
+}
+
+func NewGiteaProvider() *GiteaProvider {
+	return &GiteaProvider{httpClient: &http.Client{Timeout: changeRequestProviderTimeout}}
+}
+
+func (g *GiteaProvider) CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+	pullRequest := &gitHubPullRequest{}
+	err := doChangeRequestAPICall(g.httpClient, http.MethodPost, strings.TrimSuffix(settings.APIURL, "/")+"/repos/"+settings.Repository+"/pulls", giteaHeaders(settings), &gitHubPullRequest{
+		Title: request.Title,
+		Body:  request.Description,
+		Head:  request.SourceBranch,
+		Base:  request.TargetBranch,
+	}, pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	return gitHubToChangeRequest(pullRequest, request), nil
+}
+
+func (g *GiteaProvider) GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+	pullRequest := &struct {
+		gitHubPullRequest
+		Head gitHubBranch `json:"head"`
+		Base gitHubBranch `json:"base"`
+	}{}
+	if err := doChangeRequestAPICall(g.httpClient, http.MethodGet, strings.TrimSuffix(settings.APIURL, "/")+"/repos/"+settings.Repository+"/pulls/"+url.PathEscape(id), giteaHeaders(settings), nil, pullRequest); err != nil {
+		return nil, err
+	}
+	return gitHubToChangeRequest(&pullRequest.gitHubPullRequest, models.ChangeRequest{
+		SourceBranch: pullRequest.Head.Ref,
+		TargetBranch: pullRequest.Base.Ref,
+	}), nil
+}
+
+func giteaHeaders(settings common_models.ChangeRequestSettings) map[string]string {
+	return map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "token " + settings.Token,
+	}
+}
+
+// GitLabProvider opens merge requests using the GitLab REST API
+type GitLabProvider struct {
+	httpClient *http.Client
+}
+
+func NewGitLabProvider() *GitLabProvider {
+	return &GitLabProvider{httpClient: &http.Client{Timeout: changeRequestProviderTimeout}}
+}
+
+type gitLabMergeRequest struct {
+	IID          int    `json:"iid,omitempty"`
+	WebURL       string `json:"web_url,omitempty"`
+	State        string `json:"state,omitempty"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+}
+
+func (g *GitLabProvider) CreateChangeRequest(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+	mergeRequest := &gitLabMergeRequest{}
+	err := doChangeRequestAPICall(g.httpClient, http.MethodPost, g.projectURL(settings)+"/merge_requests", gitLabHeaders(settings), &gitLabMergeRequest{
+		Title:        request.Title,
+		Description:  request.Description,
+		SourceBranch: request.SourceBranch,
+		TargetBranch: request.TargetBranch,
+	}, mergeRequest)
+	if err != nil {
+		return nil, err
+	}
+	return gitLabToChangeRequest(mergeRequest), nil
+}
+
+func (g *GitLabProvider) GetChangeRequest(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+	mergeRequest := &gitLabMergeRequest{}
+	if err := doChangeRequestAPICall(g.httpClient, http.MethodGet, g.projectURL(settings)+"/merge_requests/"+url.PathEscape(id), gitLabHeaders(settings), nil, mergeRequest); err != nil {
+		return nil, err
+	}
+	return gitLabToChangeRequest(mergeRequest), nil
+}
+
+func (g *GitLabProvider) projectURL(settings common_models.ChangeRequestSettings) string {
+	apiURL := defaultGitLabAPIURL
+	if settings.APIURL != "" {
+		apiURL = strings.TrimSuffix(settings.APIURL, "/")
+	}
+	// GitLab expects the URL-encoded path of the project, e.g. group%2Fproject
+	return apiURL + "/projects/" + url.PathEscape(settings.Repository)
+}
+
+func gitLabHeaders(settings common_models.ChangeRequestSettings) map[string]string {
+	return map[string]string{
+		"Accept":        "application/json",
+		"PRIVATE-TOKEN": settings.Token,
+	}
+}
+
+func gitLabToChangeRequest(mergeRequest *gitLabMergeRequest) *models.ChangeRequest {
+	state := models.ChangeRequestStateOpen
+	switch mergeRequest.State {
+	case "merged":
+		state = models.ChangeRequestStateMerged
+	case "closed", "locked":
+		state = models.ChangeRequestStateClosed
+	}
+	return &models.ChangeRequest{
+		ID:           strconv.Itoa(mergeRequest.IID),
+		URL:          mergeRequest.WebURL,
+		State:        state,
+		SourceBranch: mergeRequest.SourceBranch,
+		TargetBranch: mergeRequest.TargetBranch,
+		Title:        mergeRequest.Title,
+		Description:  mergeRequest.Description,
+	}
+}
+
+func doChangeRequestAPICall(httpClient *http.Client, method string, requestURL string, headers map[string]string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(payloadBytes)
+	}
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return kerrors.ErrChangeRequestNotFound
+	case resp.StatusCode == http.StatusUnauthorized:
+		return kerrors.ErrAuthenticationRequired
+	case resp.StatusCode == http.StatusForbidden:
+		return kerrors.ErrAuthorizationFailed
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("%s %s returned status %d: %s", method, requestURL, resp.StatusCode, string(respBody))
+	}
+	return json.Unmarshal(respBody, result)
+}
@@ -0,0 +1,218 @@
+package common
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestChangeRequestManager_GetSettings(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	manager := NewChangeRequestManager(fake.NewSimpleClientset(
+		getChangeRequestSettingsSecret("my-project", `{"provider":"github","repository":"my-org/my-repo"}`),
+		getChangeRequestSettingsSecret("invalid-project", `invalid`),
+		getChangeRequestSettingsSecret("unknown-provider-project", `{"provider":"svn","repository":"my-repo"}`),
+		getChangeRequestSettingsSecret("invalid-wait-project", `{"provider":"github","repository":"my-repo","waitForMerge":"soon"}`),
+	))
+
+	settings, err := manager.GetSettings("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ChangeRequestSettings{Provider: "github", Repository: "my-org/my-repo"}, settings)
+
+	settings, err = manager.GetSettings("other-project")
+	require.Nil(t, err)
+	require.Nil(t, settings)
+
+	_, err = manager.GetSettings("invalid-project")
+	require.ErrorIs(t, err, kerrors.ErrMalformedChangeRequestSettings)
+
+	_, err = manager.GetSettings("unknown-provider-project")
+	require.ErrorIs(t, err, kerrors.ErrUnknownChangeRequestProvider)
+
+	_, err = manager.GetSettings("invalid-wait-project")
+	require.ErrorIs(t, err, kerrors.ErrInvalidChangeRequestWaitForMerge)
+}
+
+func TestChangeRequestManager_GetSettings_Cached(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	k8sClient := fake.NewSimpleClientset(getChangeRequestSettingsSecret("my-project", `{"provider":"github","repository":"my-org/my-repo"}`))
+	manager := NewChangeRequestManager(k8sClient)
+
+	settings, err := manager.GetSettings("my-project")
+	require.Nil(t, err)
+	// modifying the returned settings must not affect the cached ones
+	settings.Token = "token"
+
+	settings, err = manager.GetSettings("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.ChangeRequestSettings{Provider: "github", Repository: "my-org/my-repo"}, settings)
+
+	settings, err = manager.GetSettings("other-project")
+	require.Nil(t, err)
+	require.Nil(t, settings)
+	settings, err = manager.GetSettings("other-project")
+	require.Nil(t, err)
+	require.Nil(t, settings)
+
+	// the secret of each project is only read once
+	require.Len(t, k8sClient.Actions(), 2)
+}
+
+func TestChangeRequestManager_FakeProvider(t *testing.T) {
+	manager := NewChangeRequestManager(nil)
+	manager.RegisterProvider("fake", NewFakeChangeRequestProvider())
+	settings := common_models.ChangeRequestSettings{Provider: "fake", Repository: "my-repo"}
+
+	created, err := manager.CreateChangeRequest(settings, models.ChangeRequest{SourceBranch: "keptn/dev-1", TargetBranch: "dev", Title: "Updated resource"})
+	require.Nil(t, err)
+	require.Equal(t, "1", created.ID)
+	require.Equal(t, models.ChangeRequestStateOpen, created.State)
+
+	fakeProvider, _ := manager.getProvider("fake")
+	require.Nil(t, fakeProvider.(*FakeChangeRequestProvider).SetState(created.ID, models.ChangeRequestStateMerged))
+
+	changeRequest, err := manager.GetChangeRequest(settings, created.ID)
+	require.Nil(t, err)
+	require.Equal(t, models.ChangeRequestStateMerged, changeRequest.State)
+	require.Equal(t, "keptn/dev-1", changeRequest.SourceBranch)
+
+	_, err = manager.GetChangeRequest(settings, "2")
+	require.ErrorIs(t, err, kerrors.ErrChangeRequestNotFound)
+
+	_, err = manager.GetChangeRequest(common_models.ChangeRequestSettings{Provider: "unknown"}, "1")
+	require.ErrorIs(t, err, kerrors.ErrUnknownChangeRequestProvider)
+}
+
+func TestGitHubProvider(t *testing.T) {
+	var receivedPayload map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/repos/my-org/my-repo/pulls":
+			body, _ := ioutil.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &receivedPayload)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":12,"html_url":"https://github.com/my-org/my-repo/pull/12","state":"open","title":"Updated resource"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/my-org/my-repo/pulls/12":
+			_, _ = w.Write([]byte(`{"number":12,"state":"closed","merged":true,"title":"Updated resource","head":{"ref":"keptn/dev-1"},"base":{"ref":"dev"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	settings := common_models.ChangeRequestSettings{Provider: "github", APIURL: ts.URL, Repository: "my-org/my-repo", Token: "my-token"}
+	provider := NewGitHubProvider()
+
+	created, err := provider.CreateChangeRequest(settings, models.ChangeRequest{SourceBranch: "keptn/dev-1", TargetBranch: "dev", Title: "Updated resource"})
+	require.Nil(t, err)
+	require.Equal(t, &models.ChangeRequest{
+		ID:           "12",
+		URL:          "https://github.com/my-org/my-repo/pull/12",
+		State:        models.ChangeRequestStateOpen,
+		SourceBranch: "keptn/dev-1",
+		TargetBranch: "dev",
+		Title:        "Updated resource",
+	}, created)
+	require.Equal(t, "keptn/dev-1", receivedPayload["head"])
+	require.Equal(t, "dev", receivedPayload["base"])
+
+	changeRequest, err := provider.GetChangeRequest(settings, "12")
+	require.Nil(t, err)
+	require.Equal(t, models.ChangeRequestStateMerged, changeRequest.State)
+	require.Equal(t, "keptn/dev-1", changeRequest.SourceBranch)
+	require.Equal(t, "dev", changeRequest.TargetBranch)
+
+	_, err = provider.GetChangeRequest(settings, "13")
+	require.ErrorIs(t, err, kerrors.ErrChangeRequestNotFound)
+}
+
+func TestGitLabProvider(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "my-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/projects/my-group%2Fmy-repo/merge_requests":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid":3,"web_url":"https://gitlab.com/my-group/my-repo/-/merge_requests/3","state":"opened","title":"Updated resource","source_branch":"keptn/dev-1","target_branch":"dev"}`))
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/projects/my-group%2Fmy-repo/merge_requests/3":
+			_, _ = w.Write([]byte(`{"iid":3,"state":"merged","title":"Updated resource","source_branch":"keptn/dev-1","target_branch":"dev"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	settings := common_models.ChangeRequestSettings{Provider: "gitlab", APIURL: ts.URL, Repository: "my-group/my-repo", Token: "my-token"}
+	provider := NewGitLabProvider()
+
+	created, err := provider.CreateChangeRequest(settings, models.ChangeRequest{SourceBranch: "keptn/dev-1", TargetBranch: "dev", Title: "Updated resource"})
+	require.Nil(t, err)
+	require.Equal(t, "3", created.ID)
+	require.Equal(t, models.ChangeRequestStateOpen, created.State)
+	require.Equal(t, "https://gitlab.com/my-group/my-repo/-/merge_requests/3", created.URL)
+
+	changeRequest, err := provider.GetChangeRequest(settings, "3")
+	require.Nil(t, err)
+	require.Equal(t, models.ChangeRequestStateMerged, changeRequest.State)
+
+	settings.Token = "invalid"
+	_, err = provider.GetChangeRequest(settings, "3")
+	require.ErrorIs(t, err, kerrors.ErrAuthenticationRequired)
+}
+
+func TestGiteaProvider(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "token my-token", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/my-org/my-repo/pulls":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"number":5,"html_url":"https://gitea.example.com/my-org/my-repo/pulls/5","state":"open","title":"Updated resource"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/repos/my-org/my-repo/pulls/5":
+			_, _ = w.Write([]byte(`{"number":5,"state":"closed","merged":false,"title":"Updated resource","head":{"ref":"keptn/dev-1"},"base":{"ref":"dev"}}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	settings := common_models.ChangeRequestSettings{Provider: "gitea", APIURL: ts.URL + "/api/v1/", Repository: "my-org/my-repo", Token: "my-token"}
+	provider := NewGiteaProvider()
+
+	created, err := provider.CreateChangeRequest(settings, models.ChangeRequest{SourceBranch: "keptn/dev-1", TargetBranch: "dev", Title: "Updated resource"})
+	require.Nil(t, err)
+	require.Equal(t, "5", created.ID)
+	require.Equal(t, "keptn/dev-1", created.SourceBranch)
+
+	changeRequest, err := provider.GetChangeRequest(settings, "5")
+	require.Nil(t, err)
+	require.Equal(t, models.ChangeRequestStateClosed, changeRequest.State)
+
+	_, err = provider.GetChangeRequest(settings, "6")
+	require.NotNil(t, err)
+}
+
+func getChangeRequestSettingsSecret(project string, settings string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetChangeRequestSettingsSecretName(project),
+			Namespace: "keptn",
+		},
+		Data: map[string][]byte{
+			"change-request": []byte(settings),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+}
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"bytes"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// FileChange describes the change of the content of a file. From is nil if the file is created, To is nil if the file is deleted
+type FileChange struct {
+	Path string
+	From []byte
+	To   []byte
+}
+
+// GetUnifiedDiff returns the unified diff of the given file changes, using the same format as the diffs between git revisions
+func GetUnifiedDiff(changes []FileChange) (string, error) {
+	patch := &filesPatch{}
+	for _, change := range changes {
+		if change.From != nil && change.To != nil && bytes.Equal(change.From, change.To) {
+			continue
+		}
+		patch.filePatches = append(patch.filePatches, newFilePatch(change))
+	}
+	if len(patch.filePatches) == 0 {
+		return "", nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := fdiff.NewUnifiedEncoder(buf, fdiff.DefaultContextLines).Encode(patch); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func newFilePatch(change FileChange) *filePatch {
+	fp := &filePatch{}
+	if change.From != nil {
+		fp.from = &changedFile{path: change.Path, hash: plumbing.ComputeHash(plumbing.BlobObject, change.From)}
+	}
+	if change.To != nil {
+		fp.to = &changedFile{path: change.Path, hash: plumbing.ComputeHash(plumbing.BlobObject, change.To)}
+	}
+	if isBinary(change.From) || isBinary(change.To) {
+		fp.binary = true
+		return fp
+	}
+
+	for _, d := range diff.Do(string(change.From), string(change.To)) {
+		var op fdiff.Operation
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = fdiff.Equal
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		}
+		fp.chunks = append(fp.chunks, &textChunk{content: d.Text, op: op})
+	}
+	return fp
+}
+
+func isBinary(content []byte) bool {
+	if content == nil {
+		return false
+	}
+	result, err := binary.IsBinary(bytes.NewReader(content))
+	return err != nil || result
+}
+
+// filesPatch is an implementation of the fdiff.Patch interface
+type filesPatch struct {
+	filePatches []fdiff.FilePatch
+}
+
+func (p *filesPatch) FilePatches() []fdiff.FilePatch {
+	return p.filePatches
+}
+
+func (p *filesPatch) Message() string {
+	return ""
+}
+
+// filePatch is an implementation of the fdiff.FilePatch interface
+type filePatch struct {
+	from   *changedFile
+	to     *changedFile
+	binary bool
+	chunks []fdiff.Chunk
+}
+
+func (fp *filePatch) IsBinary() bool {
+	return fp.binary
+}
+
+func (fp *filePatch) Files() (from fdiff.File, to fdiff.File) {
+	// the interface values must stay nil if there is no file, otherwise the encoder treats them as existing files
+	if fp.from != nil {
+		from = fp.from
+	}
+	if fp.to != nil {
+		to = fp.to
+	}
+	return from, to
+}
+
+func (fp *filePatch) Chunks() []fdiff.Chunk {
+	return fp.chunks
+}
+
+// changedFile is an implementation of the fdiff.File interface
+type changedFile struct {
+	path string
+	hash plumbing.Hash
+}
+
+func (f *changedFile) Hash() plumbing.Hash {
+	return f.hash
+}
+
+func (f *changedFile) Mode() filemode.FileMode {
+	return filemode.Regular
+}
+
+func (f *changedFile) Path() string {
+	return f.path
+}
+
+// textChunk is an implementation of the fdiff.Chunk interface
+type textChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c *textChunk) Content() string {
+	return c.content
+}
+
+func (c *textChunk) Type() fdiff.Operation {
+	return c.op
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []FileChange
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "modified file",
+			changes: []FileChange{
+				{Path: "carts/values.yaml", From: []byte("replicas: 1\nimage: carts\n"), To: []byte("replicas: 2\nimage: carts\n")},
+			},
+			want: []string{"diff --git a/carts/values.yaml b/carts/values.yaml", "-replicas: 1", "+replicas: 2", " image: carts"},
+		},
+		{
+			name: "created file",
+			changes: []FileChange{
+				{Path: "carts/slo.yaml", To: []byte("spec_version: '1.0'\n")},
+			},
+			want: []string{"new file mode 100644", "--- /dev/null", "+++ b/carts/slo.yaml", "+spec_version: '1.0'"},
+		},
+		{
+			name: "unchanged file is omitted",
+			changes: []FileChange{
+				{Path: "carts/values.yaml", From: []byte("replicas: 1\n"), To: []byte("replicas: 1\n")},
+				{Path: "carts/slo.yaml", From: []byte("a\n"), To: []byte("b\n")},
+			},
+			want:    []string{"carts/slo.yaml"},
+			notWant: []string{"carts/values.yaml"},
+		},
+		{
+			name: "binary file",
+			changes: []FileChange{
+				{Path: "carts/chart.tgz", From: []byte{0, 1, 2}, To: []byte{0, 1, 3}},
+			},
+			want: []string{"Binary files a/carts/chart.tgz and b/carts/chart.tgz differ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUnifiedDiff(tt.changes)
+			require.Nil(t, err)
+			for _, want := range tt.want {
+				require.Contains(t, got, want)
+			}
+			for _, notWant := range tt.notWant {
+				require.NotContains(t, got, notWant)
+			}
+		})
+	}
+
+	got, err := GetUnifiedDiff([]FileChange{{Path: "a", From: []byte("a"), To: []byte("a")}})
+	require.Nil(t, err)
+	require.Empty(t, got)
+}
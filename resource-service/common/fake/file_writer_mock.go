@@ -4,6 +4,7 @@
 package common_mock
 
 import (
+	"io"
 	"path/filepath"
 	"sync"
 )
@@ -14,6 +15,9 @@ import (
 //
 // 		// make and configure a mocked common.IFileSystem
 // 		mockedIFileSystem := &IFileSystemMock{
+// 			CopyFileFunc: func(source string, target string) error {
+// 				panic("mock out the CopyFile method")
+// 			},
 // 			DeleteFileFunc: func(path string) error {
 // 				panic("mock out the DeleteFile method")
 // 			},
@@ -23,6 +27,12 @@ import (
 // 			MakeDirFunc: func(path string) error {
 // 				panic("mock out the MakeDir method")
 // 			},
+// 			MoveFileFunc: func(source string, target string) error {
+// 				panic("mock out the MoveFile method")
+// 			},
+// 			OpenFileFunc: func(filename string) (io.ReadCloser, int64, error) {
+// 				panic("mock out the OpenFile method")
+// 			},
 // 			ReadFileFunc: func(filename string) ([]byte, error) {
 // 				panic("mock out the ReadFile method")
 // 			},
@@ -38,6 +48,9 @@ import (
 // 			WriteHelmChartFunc: func(path string) error {
 // 				panic("mock out the WriteHelmChart method")
 // 			},
+// 			WriteTempFileFunc: func(content io.Reader) (string, int64, error) {
+// 				panic("mock out the WriteTempFile method")
+// 			},
 // 		}
 //
 // 		// use mockedIFileSystem in code that requires common.IFileSystem
@@ -45,6 +58,9 @@ import (
 //
 // 	}
 type IFileSystemMock struct {
+	// CopyFileFunc mocks the CopyFile method.
+	CopyFileFunc func(source string, target string) error
+
 	// DeleteFileFunc mocks the DeleteFile method.
 	DeleteFileFunc func(path string) error
 
@@ -54,6 +70,12 @@ type IFileSystemMock struct {
 	// MakeDirFunc mocks the MakeDir method.
 	MakeDirFunc func(path string) error
 
+	// MoveFileFunc mocks the MoveFile method.
+	MoveFileFunc func(source string, target string) error
+
+	// OpenFileFunc mocks the OpenFile method.
+	OpenFileFunc func(filename string) (io.ReadCloser, int64, error)
+
 	// ReadFileFunc mocks the ReadFile method.
 	ReadFileFunc func(filename string) ([]byte, error)
 
@@ -69,8 +91,18 @@ type IFileSystemMock struct {
 	// WriteHelmChartFunc mocks the WriteHelmChart method.
 	WriteHelmChartFunc func(path string) error
 
+	// WriteTempFileFunc mocks the WriteTempFile method.
+	WriteTempFileFunc func(content io.Reader) (string, int64, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CopyFile holds details about calls to the CopyFile method.
+		CopyFile []struct {
+			// Source is the source argument value.
+			Source string
+			// Target is the target argument value.
+			Target string
+		}
 		// DeleteFile holds details about calls to the DeleteFile method.
 		DeleteFile []struct {
 			// Path is the path argument value.
@@ -86,6 +118,18 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// MoveFile holds details about calls to the MoveFile method.
+		MoveFile []struct {
+			// Source is the source argument value.
+			Source string
+			// Target is the target argument value.
+			Target string
+		}
+		// OpenFile holds details about calls to the OpenFile method.
+		OpenFile []struct {
+			// Filename is the filename argument value.
+			Filename string
+		}
 		// ReadFile holds details about calls to the ReadFile method.
 		ReadFile []struct {
 			// Filename is the filename argument value.
@@ -117,15 +161,59 @@ type IFileSystemMock struct {
 			// Path is the path argument value.
 			Path string
 		}
+		// WriteTempFile holds details about calls to the WriteTempFile method.
+		WriteTempFile []struct {
+			// Content is the content argument value.
+			Content io.Reader
+		}
 	}
+	lockCopyFile               sync.RWMutex
 	lockDeleteFile             sync.RWMutex
 	lockFileExists             sync.RWMutex
 	lockMakeDir                sync.RWMutex
+	lockMoveFile               sync.RWMutex
+	lockOpenFile               sync.RWMutex
 	lockReadFile               sync.RWMutex
 	lockWalkPath               sync.RWMutex
 	lockWriteBase64EncodedFile sync.RWMutex
 	lockWriteFile              sync.RWMutex
 	lockWriteHelmChart         sync.RWMutex
+	lockWriteTempFile          sync.RWMutex
+}
+
+// CopyFile calls CopyFileFunc.
+func (mock *IFileSystemMock) CopyFile(source string, target string) error {
+	if mock.CopyFileFunc == nil {
+		panic("IFileSystemMock.CopyFileFunc: method is nil but IFileSystem.CopyFile was just called")
+	}
+	callInfo := struct {
+		Source string
+		Target string
+	}{
+		Source: source,
+		Target: target,
+	}
+	mock.lockCopyFile.Lock()
+	mock.calls.CopyFile = append(mock.calls.CopyFile, callInfo)
+	mock.lockCopyFile.Unlock()
+	return mock.CopyFileFunc(source, target)
+}
+
+// CopyFileCalls gets all the calls that were made to CopyFile.
+// Check the length with:
+//     len(mockedIFileSystem.CopyFileCalls())
+func (mock *IFileSystemMock) CopyFileCalls() []struct {
+	Source string
+	Target string
+} {
+	var calls []struct {
+		Source string
+		Target string
+	}
+	mock.lockCopyFile.RLock()
+	calls = mock.calls.CopyFile
+	mock.lockCopyFile.RUnlock()
+	return calls
 }
 
 // DeleteFile calls DeleteFileFunc.
@@ -221,6 +309,72 @@ func (mock *IFileSystemMock) MakeDirCalls() []struct {
 	return calls
 }
 
+// MoveFile calls MoveFileFunc.
+func (mock *IFileSystemMock) MoveFile(source string, target string) error {
+	if mock.MoveFileFunc == nil {
+		panic("IFileSystemMock.MoveFileFunc: method is nil but IFileSystem.MoveFile was just called")
+	}
+	callInfo := struct {
+		Source string
+		Target string
+	}{
+		Source: source,
+		Target: target,
+	}
+	mock.lockMoveFile.Lock()
+	mock.calls.MoveFile = append(mock.calls.MoveFile, callInfo)
+	mock.lockMoveFile.Unlock()
+	return mock.MoveFileFunc(source, target)
+}
+
+// MoveFileCalls gets all the calls that were made to MoveFile.
+// Check the length with:
+//     len(mockedIFileSystem.MoveFileCalls())
+func (mock *IFileSystemMock) MoveFileCalls() []struct {
+	Source string
+	Target string
+} {
+	var calls []struct {
+		Source string
+		Target string
+	}
+	mock.lockMoveFile.RLock()
+	calls = mock.calls.MoveFile
+	mock.lockMoveFile.RUnlock()
+	return calls
+}
+
+// OpenFile calls OpenFileFunc.
+func (mock *IFileSystemMock) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	if mock.OpenFileFunc == nil {
+		panic("IFileSystemMock.OpenFileFunc: method is nil but IFileSystem.OpenFile was just called")
+	}
+	callInfo := struct {
+		Filename string
+	}{
+		Filename: filename,
+	}
+	mock.lockOpenFile.Lock()
+	mock.calls.OpenFile = append(mock.calls.OpenFile, callInfo)
+	mock.lockOpenFile.Unlock()
+	return mock.OpenFileFunc(filename)
+}
+
+// OpenFileCalls gets all the calls that were made to OpenFile.
+// Check the length with:
+//     len(mockedIFileSystem.OpenFileCalls())
+func (mock *IFileSystemMock) OpenFileCalls() []struct {
+	Filename string
+} {
+	var calls []struct {
+		Filename string
+	}
+	mock.lockOpenFile.RLock()
+	calls = mock.calls.OpenFile
+	mock.lockOpenFile.RUnlock()
+	return calls
+}
+
 // ReadFile calls ReadFileFunc.
 func (mock *IFileSystemMock) ReadFile(filename string) ([]byte, error) {
 	if mock.ReadFileFunc == nil {
@@ -387,3 +541,34 @@ func (mock *IFileSystemMock) WriteHelmChartCalls() []struct {
 	mock.lockWriteHelmChart.RUnlock()
 	return calls
 }
+
+// WriteTempFile calls WriteTempFileFunc.
+func (mock *IFileSystemMock) WriteTempFile(content io.Reader) (string, int64, error) {
+	if mock.WriteTempFileFunc == nil {
+		panic("IFileSystemMock.WriteTempFileFunc: method is nil but IFileSystem.WriteTempFile was just called")
+	}
+	callInfo := struct {
+		Content io.Reader
+	}{
+		Content: content,
+	}
+	mock.lockWriteTempFile.Lock()
+	mock.calls.WriteTempFile = append(mock.calls.WriteTempFile, callInfo)
+	mock.lockWriteTempFile.Unlock()
+	return mock.WriteTempFileFunc(content)
+}
+
+// WriteTempFileCalls gets all the calls that were made to WriteTempFile.
+// Check the length with:
+//     len(mockedIFileSystem.WriteTempFileCalls())
+func (mock *IFileSystemMock) WriteTempFileCalls() []struct {
+	Content io.Reader
+} {
+	var calls []struct {
+		Content io.Reader
+	}
+	mock.lockWriteTempFile.RLock()
+	calls = mock.calls.WriteTempFile
+	mock.lockWriteTempFile.RUnlock()
+	return calls
+}
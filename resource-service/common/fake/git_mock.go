@@ -5,6 +5,7 @@ package common_mock
 
 import (
 	"github.com/keptn/keptn/resource-service/common_models"
+	"io"
 	"sync"
 )
 
@@ -26,15 +27,45 @@ import (
 // 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 // 				panic("mock out the CreateBranch method")
 // 			},
+// 			CreateTagFunc: func(gitContext common_models.GitContext, tag string, branch string) error {
+// 				panic("mock out the CreateTag method")
+// 			},
+// 			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+// 				panic("mock out the DeleteBranch method")
+// 			},
+// 			GetBranchRevisionAtFunc: func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+// 				panic("mock out the GetBranchRevisionAt method")
+// 			},
+// 			GetCurrentBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+// 				panic("mock out the GetCurrentBranch method")
+// 			},
 // 			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetCurrentRevision method")
 // 			},
 // 			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
 // 				panic("mock out the GetDefaultBranch method")
 // 			},
+// 			GetDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error) {
+// 				panic("mock out the GetDiff method")
+// 			},
+// 			GetFileHistoryFunc: func(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error) {
+// 				panic("mock out the GetFileHistory method")
+// 			},
 // 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 // 				panic("mock out the GetFileRevision method")
 // 			},
+// 			GetFileRevisionReaderFunc: func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+// 				panic("mock out the GetFileRevisionReader method")
+// 			},
+// 			GetRemoteURLFunc: func(gitContext common_models.GitContext) (string, error) {
+// 				panic("mock out the GetRemoteURL method")
+// 			},
+// 			ListBranchesFunc: func(gitContext common_models.GitContext) ([]string, error) {
+// 				panic("mock out the ListBranches method")
+// 			},
+// 			ListFilesFunc: func(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+// 				panic("mock out the ListFiles method")
+// 			},
 // 			MigrateProjectFunc: func(gitContext common_models.GitContext, newMetadatacontent []byte) error {
 // 				panic("mock out the MigrateProject method")
 // 			},
@@ -59,6 +90,9 @@ import (
 // 			StageAndCommitAllFunc: func(gitContext common_models.GitContext, message string) (string, error) {
 // 				panic("mock out the StageAndCommitAll method")
 // 			},
+// 			StageAndCommitToBranchFunc: func(gitContext common_models.GitContext, message string, branch string) (string, error) {
+// 				panic("mock out the StageAndCommitToBranch method")
+// 			},
 // 		}
 //
 // 		// use mockedIGit in code that requires common.IGit
@@ -78,15 +112,45 @@ type IGitMock struct {
 	// CreateBranchFunc mocks the CreateBranch method.
 	CreateBranchFunc func(gitContext common_models.GitContext, branch string, sourceBranch string) error
 
+	// CreateTagFunc mocks the CreateTag method.
+	CreateTagFunc func(gitContext common_models.GitContext, tag string, branch string) error
+
+	// DeleteBranchFunc mocks the DeleteBranch method.
+	DeleteBranchFunc func(gitContext common_models.GitContext, branch string) error
+
+	// GetBranchRevisionAtFunc mocks the GetBranchRevisionAt method.
+	GetBranchRevisionAtFunc func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error)
+
+	// GetCurrentBranchFunc mocks the GetCurrentBranch method.
+	GetCurrentBranchFunc func(gitContext common_models.GitContext) (string, error)
+
 	// GetCurrentRevisionFunc mocks the GetCurrentRevision method.
 	GetCurrentRevisionFunc func(gitContext common_models.GitContext) (string, error)
 
 	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
 	GetDefaultBranchFunc func(gitContext common_models.GitContext) (string, error)
 
+	// GetDiffFunc mocks the GetDiff method.
+	GetDiffFunc func(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error)
+
+	// GetFileHistoryFunc mocks the GetFileHistory method.
+	GetFileHistoryFunc func(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error)
+
 	// GetFileRevisionFunc mocks the GetFileRevision method.
 	GetFileRevisionFunc func(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
 
+	// GetFileRevisionReaderFunc mocks the GetFileRevisionReader method.
+	GetFileRevisionReaderFunc func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error)
+
+	// GetRemoteURLFunc mocks the GetRemoteURL method.
+	GetRemoteURLFunc func(gitContext common_models.GitContext) (string, error)
+
+	// ListBranchesFunc mocks the ListBranches method.
+	ListBranchesFunc func(gitContext common_models.GitContext) ([]string, error)
+
+	// ListFilesFunc mocks the ListFiles method.
+	ListFilesFunc func(gitContext common_models.GitContext, revision string, path string) ([]string, error)
+
 	// MigrateProjectFunc mocks the MigrateProject method.
 	MigrateProjectFunc func(gitContext common_models.GitContext, newMetadatacontent []byte) error
 
@@ -111,6 +175,9 @@ type IGitMock struct {
 	// StageAndCommitAllFunc mocks the StageAndCommitAll method.
 	StageAndCommitAllFunc func(gitContext common_models.GitContext, message string) (string, error)
 
+	// StageAndCommitToBranchFunc mocks the StageAndCommitToBranch method.
+	StageAndCommitToBranchFunc func(gitContext common_models.GitContext, message string, branch string) (string, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CheckUpstreamConnection holds details about calls to the CheckUpstreamConnection method.
@@ -139,6 +206,36 @@ type IGitMock struct {
 			// SourceBranch is the sourceBranch argument value.
 			SourceBranch string
 		}
+		// CreateTag holds details about calls to the CreateTag method.
+		CreateTag []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Tag is the tag argument value.
+			Tag string
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// DeleteBranch holds details about calls to the DeleteBranch method.
+		DeleteBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+		}
+		// GetBranchRevisionAt holds details about calls to the GetBranchRevisionAt method.
+		GetBranchRevisionAt []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Branch is the branch argument value.
+			Branch string
+			// Revision is the revision argument value.
+			Revision string
+		}
+		// GetCurrentBranch holds details about calls to the GetCurrentBranch method.
+		GetCurrentBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
 		// GetCurrentRevision holds details about calls to the GetCurrentRevision method.
 		GetCurrentRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -149,6 +246,26 @@ type IGitMock struct {
 			// GitContext is the gitContext argument value.
 			GitContext common_models.GitContext
 		}
+		// GetDiff holds details about calls to the GetDiff method.
+		GetDiff []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// FromRevision is the fromRevision argument value.
+			FromRevision string
+			// ToRevision is the toRevision argument value.
+			ToRevision string
+			// Path is the path argument value.
+			Path string
+		}
+		// GetFileHistory holds details about calls to the GetFileHistory method.
+		GetFileHistory []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Path is the path argument value.
+			Path string
+			// Limit is the limit argument value.
+			Limit int
+		}
 		// GetFileRevision holds details about calls to the GetFileRevision method.
 		GetFileRevision []struct {
 			// GitContext is the gitContext argument value.
@@ -158,6 +275,34 @@ type IGitMock struct {
 			// File is the file argument value.
 			File string
 		}
+		// GetFileRevisionReader holds details about calls to the GetFileRevisionReader method.
+		GetFileRevisionReader []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// File is the file argument value.
+			File string
+		}
+		// GetRemoteURL holds details about calls to the GetRemoteURL method.
+		GetRemoteURL []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
+		// ListBranches holds details about calls to the ListBranches method.
+		ListBranches []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+		}
+		// ListFiles holds details about calls to the ListFiles method.
+		ListFiles []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Revision is the revision argument value.
+			Revision string
+			// Path is the path argument value.
+			Path string
+		}
 		// MigrateProject holds details about calls to the MigrateProject method.
 		MigrateProject []struct {
 			// GitContext is the gitContext argument value.
@@ -206,14 +351,33 @@ type IGitMock struct {
 			// Message is the message argument value.
 			Message string
 		}
+		// StageAndCommitToBranch holds details about calls to the StageAndCommitToBranch method.
+		StageAndCommitToBranch []struct {
+			// GitContext is the gitContext argument value.
+			GitContext common_models.GitContext
+			// Message is the message argument value.
+			Message string
+			// Branch is the branch argument value.
+			Branch string
+		}
 	}
 	lockCheckUpstreamConnection sync.RWMutex
 	lockCheckoutBranch          sync.RWMutex
 	lockCloneRepo               sync.RWMutex
 	lockCreateBranch            sync.RWMutex
+	lockCreateTag               sync.RWMutex
+	lockDeleteBranch            sync.RWMutex
+	lockGetBranchRevisionAt     sync.RWMutex
+	lockGetCurrentBranch        sync.RWMutex
 	lockGetCurrentRevision      sync.RWMutex
 	lockGetDefaultBranch        sync.RWMutex
+	lockGetDiff                 sync.RWMutex
+	lockGetFileHistory          sync.RWMutex
 	lockGetFileRevision         sync.RWMutex
+	lockGetFileRevisionReader   sync.RWMutex
+	lockGetRemoteURL            sync.RWMutex
+	lockListBranches            sync.RWMutex
+	lockListFiles               sync.RWMutex
 	lockMigrateProject          sync.RWMutex
 	lockMoveToNewUpstream       sync.RWMutex
 	lockProjectExists           sync.RWMutex
@@ -222,6 +386,7 @@ type IGitMock struct {
 	lockPush                    sync.RWMutex
 	lockResetHard               sync.RWMutex
 	lockStageAndCommitAll       sync.RWMutex
+	lockStageAndCommitToBranch  sync.RWMutex
 }
 
 // CheckUpstreamConnection calls CheckUpstreamConnectionFunc.
@@ -360,6 +525,150 @@ func (mock *IGitMock) CreateBranchCalls() []struct {
 	return calls
 }
 
+// CreateTag calls CreateTagFunc.
+func (mock *IGitMock) CreateTag(gitContext common_models.GitContext, tag string, branch string) error {
+	if mock.CreateTagFunc == nil {
+		panic("IGitMock.CreateTagFunc: method is nil but IGit.CreateTag was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Tag        string
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Tag:        tag,
+		Branch:     branch,
+	}
+	mock.lockCreateTag.Lock()
+	mock.calls.CreateTag = append(mock.calls.CreateTag, callInfo)
+	mock.lockCreateTag.Unlock()
+	return mock.CreateTagFunc(gitContext, tag, branch)
+}
+
+// CreateTagCalls gets all the calls that were made to CreateTag.
+// Check the length with:
+//     len(mockedIGit.CreateTagCalls())
+func (mock *IGitMock) CreateTagCalls() []struct {
+	GitContext common_models.GitContext
+	Tag        string
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Tag        string
+		Branch     string
+	}
+	mock.lockCreateTag.RLock()
+	calls = mock.calls.CreateTag
+	mock.lockCreateTag.RUnlock()
+	return calls
+}
+
+// DeleteBranch calls DeleteBranchFunc.
+func (mock *IGitMock) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if mock.DeleteBranchFunc == nil {
+		panic("IGitMock.DeleteBranchFunc: method is nil but IGit.DeleteBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+	}
+	mock.lockDeleteBranch.Lock()
+	mock.calls.DeleteBranch = append(mock.calls.DeleteBranch, callInfo)
+	mock.lockDeleteBranch.Unlock()
+	return mock.DeleteBranchFunc(gitContext, branch)
+}
+
+// DeleteBranchCalls gets all the calls that were made to DeleteBranch.
+// Check the length with:
+//     len(mockedIGit.DeleteBranchCalls())
+func (mock *IGitMock) DeleteBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+	}
+	mock.lockDeleteBranch.RLock()
+	calls = mock.calls.DeleteBranch
+	mock.lockDeleteBranch.RUnlock()
+	return calls
+}
+
+// GetBranchRevisionAt calls GetBranchRevisionAtFunc.
+func (mock *IGitMock) GetBranchRevisionAt(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+	if mock.GetBranchRevisionAtFunc == nil {
+		panic("IGitMock.GetBranchRevisionAtFunc: method is nil but IGit.GetBranchRevisionAt was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Branch     string
+		Revision   string
+	}{
+		GitContext: gitContext,
+		Branch:     branch,
+		Revision:   revision,
+	}
+	mock.lockGetBranchRevisionAt.Lock()
+	mock.calls.GetBranchRevisionAt = append(mock.calls.GetBranchRevisionAt, callInfo)
+	mock.lockGetBranchRevisionAt.Unlock()
+	return mock.GetBranchRevisionAtFunc(gitContext, branch, revision)
+}
+
+// GetBranchRevisionAtCalls gets all the calls that were made to GetBranchRevisionAt.
+// Check the length with:
+//     len(mockedIGit.GetBranchRevisionAtCalls())
+func (mock *IGitMock) GetBranchRevisionAtCalls() []struct {
+	GitContext common_models.GitContext
+	Branch     string
+	Revision   string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Branch     string
+		Revision   string
+	}
+	mock.lockGetBranchRevisionAt.RLock()
+	calls = mock.calls.GetBranchRevisionAt
+	mock.lockGetBranchRevisionAt.RUnlock()
+	return calls
+}
+
+// GetCurrentBranch calls GetCurrentBranchFunc.
+func (mock *IGitMock) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	if mock.GetCurrentBranchFunc == nil {
+		panic("IGitMock.GetCurrentBranchFunc: method is nil but IGit.GetCurrentBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetCurrentBranch.Lock()
+	mock.calls.GetCurrentBranch = append(mock.calls.GetCurrentBranch, callInfo)
+	mock.lockGetCurrentBranch.Unlock()
+	return mock.GetCurrentBranchFunc(gitContext)
+}
+
+// GetCurrentBranchCalls gets all the calls that were made to GetCurrentBranch.
+// Check the length with:
+//     len(mockedIGit.GetCurrentBranchCalls())
+func (mock *IGitMock) GetCurrentBranchCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetCurrentBranch.RLock()
+	calls = mock.calls.GetCurrentBranch
+	mock.lockGetCurrentBranch.RUnlock()
+	return calls
+}
+
 // GetCurrentRevision calls GetCurrentRevisionFunc.
 func (mock *IGitMock) GetCurrentRevision(gitContext common_models.GitContext) (string, error) {
 	if mock.GetCurrentRevisionFunc == nil {
@@ -422,6 +731,88 @@ func (mock *IGitMock) GetDefaultBranchCalls() []struct {
 	return calls
 }
 
+// GetDiff calls GetDiffFunc.
+func (mock *IGitMock) GetDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error) {
+	if mock.GetDiffFunc == nil {
+		panic("IGitMock.GetDiffFunc: method is nil but IGit.GetDiff was just called")
+	}
+	callInfo := struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		Path         string
+	}{
+		GitContext:   gitContext,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+		Path:         path,
+	}
+	mock.lockGetDiff.Lock()
+	mock.calls.GetDiff = append(mock.calls.GetDiff, callInfo)
+	mock.lockGetDiff.Unlock()
+	return mock.GetDiffFunc(gitContext, fromRevision, toRevision, path)
+}
+
+// GetDiffCalls gets all the calls that were made to GetDiff.
+// Check the length with:
+//     len(mockedIGit.GetDiffCalls())
+func (mock *IGitMock) GetDiffCalls() []struct {
+	GitContext   common_models.GitContext
+	FromRevision string
+	ToRevision   string
+	Path         string
+} {
+	var calls []struct {
+		GitContext   common_models.GitContext
+		FromRevision string
+		ToRevision   string
+		Path         string
+	}
+	mock.lockGetDiff.RLock()
+	calls = mock.calls.GetDiff
+	mock.lockGetDiff.RUnlock()
+	return calls
+}
+
+// GetFileHistory calls GetFileHistoryFunc.
+func (mock *IGitMock) GetFileHistory(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error) {
+	if mock.GetFileHistoryFunc == nil {
+		panic("IGitMock.GetFileHistoryFunc: method is nil but IGit.GetFileHistory was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Path       string
+		Limit      int
+	}{
+		GitContext: gitContext,
+		Path:       path,
+		Limit:      limit,
+	}
+	mock.lockGetFileHistory.Lock()
+	mock.calls.GetFileHistory = append(mock.calls.GetFileHistory, callInfo)
+	mock.lockGetFileHistory.Unlock()
+	return mock.GetFileHistoryFunc(gitContext, path, limit)
+}
+
+// GetFileHistoryCalls gets all the calls that were made to GetFileHistory.
+// Check the length with:
+//     len(mockedIGit.GetFileHistoryCalls())
+func (mock *IGitMock) GetFileHistoryCalls() []struct {
+	GitContext common_models.GitContext
+	Path       string
+	Limit      int
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Path       string
+		Limit      int
+	}
+	mock.lockGetFileHistory.RLock()
+	calls = mock.calls.GetFileHistory
+	mock.lockGetFileHistory.RUnlock()
+	return calls
+}
+
 // GetFileRevision calls GetFileRevisionFunc.
 func (mock *IGitMock) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 	if mock.GetFileRevisionFunc == nil {
@@ -461,6 +852,146 @@ func (mock *IGitMock) GetFileRevisionCalls() []struct {
 	return calls
 }
 
+// GetFileRevisionReader calls GetFileRevisionReaderFunc.
+func (mock *IGitMock) GetFileRevisionReader(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+	if mock.GetFileRevisionReaderFunc == nil {
+		panic("IGitMock.GetFileRevisionReaderFunc: method is nil but IGit.GetFileRevisionReader was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		File:       file,
+	}
+	mock.lockGetFileRevisionReader.Lock()
+	mock.calls.GetFileRevisionReader = append(mock.calls.GetFileRevisionReader, callInfo)
+	mock.lockGetFileRevisionReader.Unlock()
+	return mock.GetFileRevisionReaderFunc(gitContext, revision, file)
+}
+
+// GetFileRevisionReaderCalls gets all the calls that were made to GetFileRevisionReader.
+// Check the length with:
+//     len(mockedIGit.GetFileRevisionReaderCalls())
+func (mock *IGitMock) GetFileRevisionReaderCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	File       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		File       string
+	}
+	mock.lockGetFileRevisionReader.RLock()
+	calls = mock.calls.GetFileRevisionReader
+	mock.lockGetFileRevisionReader.RUnlock()
+	return calls
+}
+
+// GetRemoteURL calls GetRemoteURLFunc.
+func (mock *IGitMock) GetRemoteURL(gitContext common_models.GitContext) (string, error) {
+	if mock.GetRemoteURLFunc == nil {
+		panic("IGitMock.GetRemoteURLFunc: method is nil but IGit.GetRemoteURL was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockGetRemoteURL.Lock()
+	mock.calls.GetRemoteURL = append(mock.calls.GetRemoteURL, callInfo)
+	mock.lockGetRemoteURL.Unlock()
+	return mock.GetRemoteURLFunc(gitContext)
+}
+
+// GetRemoteURLCalls gets all the calls that were made to GetRemoteURL.
+// Check the length with:
+//     len(mockedIGit.GetRemoteURLCalls())
+func (mock *IGitMock) GetRemoteURLCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockGetRemoteURL.RLock()
+	calls = mock.calls.GetRemoteURL
+	mock.lockGetRemoteURL.RUnlock()
+	return calls
+}
+
+// ListBranches calls ListBranchesFunc.
+func (mock *IGitMock) ListBranches(gitContext common_models.GitContext) ([]string, error) {
+	if mock.ListBranchesFunc == nil {
+		panic("IGitMock.ListBranchesFunc: method is nil but IGit.ListBranches was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+	}{
+		GitContext: gitContext,
+	}
+	mock.lockListBranches.Lock()
+	mock.calls.ListBranches = append(mock.calls.ListBranches, callInfo)
+	mock.lockListBranches.Unlock()
+	return mock.ListBranchesFunc(gitContext)
+}
+
+// ListBranchesCalls gets all the calls that were made to ListBranches.
+// Check the length with:
+//     len(mockedIGit.ListBranchesCalls())
+func (mock *IGitMock) ListBranchesCalls() []struct {
+	GitContext common_models.GitContext
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+	}
+	mock.lockListBranches.RLock()
+	calls = mock.calls.ListBranches
+	mock.lockListBranches.RUnlock()
+	return calls
+}
+
+// ListFiles calls ListFilesFunc.
+func (mock *IGitMock) ListFiles(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+	if mock.ListFilesFunc == nil {
+		panic("IGitMock.ListFilesFunc: method is nil but IGit.ListFiles was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}{
+		GitContext: gitContext,
+		Revision:   revision,
+		Path:       path,
+	}
+	mock.lockListFiles.Lock()
+	mock.calls.ListFiles = append(mock.calls.ListFiles, callInfo)
+	mock.lockListFiles.Unlock()
+	return mock.ListFilesFunc(gitContext, revision, path)
+}
+
+// ListFilesCalls gets all the calls that were made to ListFiles.
+// Check the length with:
+//     len(mockedIGit.ListFilesCalls())
+func (mock *IGitMock) ListFilesCalls() []struct {
+	GitContext common_models.GitContext
+	Revision   string
+	Path       string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Revision   string
+		Path       string
+	}
+	mock.lockListFiles.RLock()
+	calls = mock.calls.ListFiles
+	mock.lockListFiles.RUnlock()
+	return calls
+}
+
 // MigrateProject calls MigrateProjectFunc.
 func (mock *IGitMock) MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error {
 	if mock.MigrateProjectFunc == nil {
@@ -724,3 +1255,42 @@ func (mock *IGitMock) StageAndCommitAllCalls() []struct {
 	mock.lockStageAndCommitAll.RUnlock()
 	return calls
 }
+
+// StageAndCommitToBranch calls StageAndCommitToBranchFunc.
+func (mock *IGitMock) StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, error) {
+	if mock.StageAndCommitToBranchFunc == nil {
+		panic("IGitMock.StageAndCommitToBranchFunc: method is nil but IGit.StageAndCommitToBranch was just called")
+	}
+	callInfo := struct {
+		GitContext common_models.GitContext
+		Message    string
+		Branch     string
+	}{
+		GitContext: gitContext,
+		Message:    message,
+		Branch:     branch,
+	}
+	mock.lockStageAndCommitToBranch.Lock()
+	mock.calls.StageAndCommitToBranch = append(mock.calls.StageAndCommitToBranch, callInfo)
+	mock.lockStageAndCommitToBranch.Unlock()
+	return mock.StageAndCommitToBranchFunc(gitContext, message, branch)
+}
+
+// StageAndCommitToBranchCalls gets all the calls that were made to StageAndCommitToBranch.
+// Check the length with:
+//     len(mockedIGit.StageAndCommitToBranchCalls())
+func (mock *IGitMock) StageAndCommitToBranchCalls() []struct {
+	GitContext common_models.GitContext
+	Message    string
+	Branch     string
+} {
+	var calls []struct {
+		GitContext common_models.GitContext
+		Message    string
+		Branch     string
+	}
+	mock.lockStageAndCommitToBranch.RLock()
+	calls = mock.calls.StageAndCommitToBranch
+	mock.lockStageAndCommitToBranch.RUnlock()
+	return calls
+}
@@ -27,6 +27,10 @@ type IFileSystem interface {
 	FileExists(path string) bool
 	MakeDir(path string) error
 	WalkPath(path string, walkFunc filepath.WalkFunc) error
+	WriteTempFile(content io.Reader) (string, int64, error)
+	CopyFile(source string, target string) error
+	OpenFile(filename string) (io.ReadCloser, int64, error)
+	MoveFile(source string, target string) error
 }
 
 type FileSystem struct {
@@ -157,6 +161,111 @@ func (fw FileSystem) WalkPath(path string, walkFunc filepath.WalkFunc) error {
 	return filepath.Walk(path, walkFunc)
 }
 
+// WriteTempFile writes the content to a new temporary file without loading it into memory at once, and returns the path
+// and the size of the file. The file needs to be removed by the caller
+func (fw FileSystem) WriteTempFile(content io.Reader) (string, int64, error) {
+	file, err := ioutil.TempFile(fw.tmpDirLocation, ".upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	size, err := io.Copy(file, content)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", 0, err
+	}
+	return file.Name(), size, nil
+}
+
+// CopyFile copies the source file to the target path. Existing files are overwritten
+func (fw FileSystem) CopyFile(source string, target string) error {
+	sourceFile, err := os.Open(filepath.Clean(source))
+	if err != nil {
+		return err
+	}
+	defer sourceFile.Close()
+
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	targetFile, err := os.Create(filepath.Clean(target))
+	if err != nil {
+		return err
+	}
+	defer targetFile.Close()
+
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		return err
+	}
+	return targetFile.Sync()
+}
+
+// OpenFile opens the given file for reading and returns its size. Like ReadFile, Helm charts are packaged before they are returned
+func (fw FileSystem) OpenFile(filename string) (io.ReadCloser, int64, error) {
+	filename = filepath.Clean(filename)
+	cleanup := func() {}
+	if IsHelmChartPath(filename) {
+		tmpDir, err := ioutil.TempDir(fw.tmpDirLocation, "*")
+		if err != nil {
+			return nil, 0, err
+		}
+		cleanup = func() {
+			if err := os.RemoveAll(tmpDir); err != nil {
+				logger.Errorf("Could not remove directory %s: %v", tmpDir, err)
+			}
+		}
+		chartDir := strings.TrimSuffix(filename, ".tgz")
+		if isEmpty, err := IsEmpty(chartDir); err != nil || isEmpty {
+			cleanup()
+			return nil, 0, errors2.ErrResourceNotFound
+		}
+		filename = filepath.Join(tmpDir, filepath.Base(filename))
+		if err := archive.Archive([]string{chartDir}, filename); err != nil {
+			cleanup()
+			return nil, 0, err
+		}
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		cleanup()
+		if os.IsNotExist(err) {
+			return nil, 0, errors2.ErrResourceNotFound
+		}
+		return nil, 0, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		cleanup()
+		return nil, 0, err
+	}
+	return &cleanupReadCloser{ReadCloser: file, cleanup: cleanup}, info.Size(), nil
+}
+
+// MoveFile moves the source file or directory to the target path. The target must not exist yet
+func (fw FileSystem) MoveFile(source string, target string) error {
+	if fw.FileExists(target) {
+		return os.ErrExist
+	}
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Clean(source), filepath.Clean(target))
+}
+
+// cleanupReadCloser removes temporary files once the reader has been closed
+type cleanupReadCloser struct {
+	io.ReadCloser
+	cleanup func()
+}
+
+func (r *cleanupReadCloser) Close() error {
+	defer r.cleanup()
+	return r.ReadCloser.Close()
+}
+
 func (fw FileSystem) untarHelm(filePath string) error {
 	tmpDir, err := ioutil.TempDir(fw.tmpDirLocation, "*")
 	if err != nil {
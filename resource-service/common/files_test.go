@@ -1,8 +1,12 @@
 package common
 
 import (
+	"io"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/keptn/keptn/resource-service/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -102,3 +106,92 @@ func TestFileSystem_WriteHelmChart(t *testing.T) {
 
 	require.Equal(t, "test\n", string(res))
 }
+
+func TestFileSystem_WriteTempFileAndCopyFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	tmpFile, size, err := fs.WriteTempFile(strings.NewReader("content"))
+	require.Nil(t, err)
+	require.Equal(t, int64(7), size)
+
+	err = fs.CopyFile(tmpFile, dir+"/my-dir/my-file")
+	require.Nil(t, err)
+
+	res, err := fs.ReadFile(dir + "/my-dir/my-file")
+	require.Nil(t, err)
+	require.Equal(t, "content", string(res))
+
+	err = fs.DeleteFile(tmpFile)
+	require.Nil(t, err)
+}
+
+func TestFileSystem_MoveFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	err := fs.WriteFile(dir+"/my-service/metadata.yaml", []byte("content"))
+	require.Nil(t, err)
+
+	err = fs.MoveFile(dir+"/my-service", dir+"/my-renamed-service")
+	require.Nil(t, err)
+
+	require.False(t, fs.FileExists(dir+"/my-service"))
+	res, err := fs.ReadFile(dir + "/my-renamed-service/metadata.yaml")
+	require.Nil(t, err)
+	require.Equal(t, "content", string(res))
+
+	// existing files are not overwritten
+	err = fs.WriteFile(dir+"/my-service/metadata.yaml", []byte("other content"))
+	require.Nil(t, err)
+	err = fs.MoveFile(dir+"/my-service", dir+"/my-renamed-service")
+	require.NotNil(t, err)
+}
+
+func TestFileSystem_OpenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	err := fs.WriteFile(dir+"/my-file", []byte("content"))
+	require.Nil(t, err)
+
+	content, size, err := fs.OpenFile(dir + "/my-file")
+	require.Nil(t, err)
+	require.Equal(t, int64(7), size)
+	res, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, "content", string(res))
+	require.Nil(t, content.Close())
+
+	_, _, err = fs.OpenFile(dir + "/unknown-file")
+	require.ErrorIs(t, err, errors.ErrResourceNotFound)
+}
+
+func TestFileSystem_OpenFile_HelmChart(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := NewFileSystem(dir)
+
+	filePath := dir + "/helm/my-chart.tgz"
+	err := fs.WriteBase64EncodedFile(filePath, testTgzContent)
+	require.Nil(t, err)
+	err = fs.WriteHelmChart(filePath)
+	require.Nil(t, err)
+
+	content, size, err := fs.OpenFile(filePath)
+	require.Nil(t, err)
+	require.Greater(t, size, int64(0))
+	res, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, size, int64(len(res)))
+	require.Nil(t, content.Close())
+
+	// the packaged chart is removed once the content has been read
+	entries, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "helm", entries[0].Name())
+}
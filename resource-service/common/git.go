@@ -7,6 +7,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/keptn/keptn/resource-service/common_models"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	logger "github.com/sirupsen/logrus"
@@ -38,22 +40,39 @@ type IGit interface {
 	CreateBranch(gitContext common_models.GitContext, branch string, sourceBranch string) error
 	CheckoutBranch(gitContext common_models.GitContext, branch string) error
 	GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error)
+	GetFileRevisionReader(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error)
 	GetCurrentRevision(gitContext common_models.GitContext) (string, error)
 	GetDefaultBranch(gitContext common_models.GitContext) (string, error)
 	MigrateProject(gitContext common_models.GitContext, newMetadatacontent []byte) error
 	ResetHard(gitContext common_models.GitContext, revision string) error
 	MoveToNewUpstream(currentContext common_models.GitContext, newContext common_models.GitContext) error
 	CheckUpstreamConnection(gitContext common_models.GitContext) error
+	GetFileHistory(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error)
+	GetDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error)
+	ListFiles(gitContext common_models.GitContext, revision string, path string) ([]string, error)
+	GetCurrentBranch(gitContext common_models.GitContext) (string, error)
+	StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, error)
+	ListBranches(gitContext common_models.GitContext) ([]string, error)
+	GetBranchRevisionAt(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error)
+	CreateTag(gitContext common_models.GitContext, tag string, branch string) error
+	DeleteBranch(gitContext common_models.GitContext, branch string) error
+	GetRemoteURL(gitContext common_models.GitContext) (string, error)
 }
 
 type Git struct {
-	git Gogit
+	git              Gogit
+	signingKeyReader GitSigningKeyReader
 }
 
 func NewGit(git Gogit) *Git {
 	return &Git{git: git}
 }
 
+// SetSigningKeyReader enables signing the commits of projects for which the given reader provides a signing key
+func (g *Git) SetSigningKeyReader(reader GitSigningKeyReader) {
+	g.signingKeyReader = reader
+}
+
 func configureGitUser(repository *git.Repository) error {
 
 	c, err := repository.Config()
@@ -81,6 +100,11 @@ func getGitKeptnEmail() string {
 	return gitKeptnEmailDefault
 }
 
+// IsCommittedByKeptn checks whether the commit has been created by the resource-service, rather than pushed to the upstream directly
+func IsCommittedByKeptn(commit common_models.GitCommit) bool {
+	return commit.CommitterName == getGitKeptnUser() && commit.CommitterEmail == getGitKeptnEmail()
+}
+
 func (g Git) CloneRepo(gitContext common_models.GitContext) (bool, error) {
 	if (gitContext.Credentials == nil) || (*gitContext.Credentials == common_models.GitCredentials{}) {
 		logger.Debugf("CloneRepo(): Could not clone repository for project '%s': credentials missing", gitContext.Project)
@@ -256,7 +280,7 @@ func (g Git) init(gitContext common_models.GitContext, projectPath string) (*git
 }
 
 func (g Git) commitAll(gitContext common_models.GitContext, message string) (string, error) {
-	_, w, err := g.getWorkTree(gitContext)
+	repo, w, err := g.getWorkTree(gitContext)
 	if err != nil {
 		logger.Debugf("commitAll(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
 		return "", err
@@ -265,32 +289,89 @@ func (g Git) commitAll(gitContext common_models.GitContext, message string) (str
 		message = "commit changes"
 	}
 
+	// the signing key is checked before committing, so that an invalid key does not leave an unsigned commit behind
+	sign, err := g.getCommitSigner(gitContext.Project)
+	if err != nil {
+		logger.Debugf("commitAll(): Could not get signing key for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotSignCommit, gitContext.Project, err)
+	}
+
 	err = w.AddWithOptions(&git.AddOptions{All: true})
 	if err != nil {
 		logger.Debugf("commitAll(): Could not add --all for project '%s': %s", gitContext.Project, err.Error())
 		return "", err
 	}
+	committer, author := getCommitSignatures(gitContext.Author)
 	id, err := w.Commit(message,
 		&git.CommitOptions{
-			All: true,
-			Author: &object.Signature{
-				Name:  getGitKeptnUser(),
-				Email: getGitKeptnEmail(),
-				When:  time.Now(),
-			},
+			All:       true,
+			Author:    author,
+			Committer: committer,
 		})
 	if err != nil {
 		logger.Debugf("commitAll(): Could not commit for project '%s': %s", gitContext.Project, err.Error())
+		return id.String(), err
+	}
+	if sign == nil {
+		return id.String(), nil
+	}
+
+	signedID, err := signCommit(repo, id, sign)
+	if err != nil {
+		logger.Debugf("commitAll(): Could not sign commit for project '%s': %s", gitContext.Project, err.Error())
+		// the unsigned commit must not be pushed, so the changes are moved back to the index
+		if resetErr := w.Reset(&git.ResetOptions{Commit: getParentHash(repo, id), Mode: git.SoftReset}); resetErr != nil {
+			logger.Warnf("commitAll(): Could not reset unsigned commit: %v", resetErr)
+		}
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotSignCommit, gitContext.Project, err)
+	}
+	return signedID.String(), nil
+}
+
+// getCommitSignatures returns the Keptn user as committer, and the given principal as author of a commit.
+// Commits are authored by the Keptn user if no principal is given
+func getCommitSignatures(principal *common_models.GitAuthor) (*object.Signature, *object.Signature) {
+	now := time.Now()
+	committer := &object.Signature{
+		Name:  getGitKeptnUser(),
+		Email: getGitKeptnEmail(),
+		When:  now,
+	}
+	if principal == nil || principal.Name == "" {
+		return committer, committer
+	}
+	return committer, &object.Signature{
+		Name:  principal.Name,
+		Email: principal.Email,
+		When:  now,
+	}
+}
+
+func (g Git) getCommitSigner(project string) (commitSigner, error) {
+	if g.signingKeyReader == nil {
+		return nil, nil
+	}
+	key, err := g.signingKeyReader.GetSigningKey(project)
+	if err != nil || key == nil {
+		return nil, err
 	}
-	return id.String(), err
+	return newCommitSigner(*key)
+}
+
+func getParentHash(repo *git.Repository, hash plumbing.Hash) plumbing.Hash {
+	commit, err := repo.CommitObject(hash)
+	if err != nil || commit.NumParents() == 0 {
+		return hash
+	}
+	return commit.ParentHashes[0]
 }
 
 func (g Git) StageAndCommitAll(gitContext common_models.GitContext, message string) (string, error) {
 	id, err := g.commitAll(gitContext, message)
 	if err != nil {
 		logger.Debugf("StageAndCommitAll(): Could not commit for project '%s': %s", gitContext.Project, err.Error())
-		if err = g.ResetHard(gitContext, "HEAD~0"); err != nil {
-			logger.Warnf("StageAndCommitAll(): Could not reset after commitAll: %v", err)
+		if resetErr := g.ResetHard(gitContext, "HEAD~0"); resetErr != nil {
+			logger.Warnf("StageAndCommitAll(): Could not reset after commitAll: %v", resetErr)
 		} else {
 			logger.Warn("StageAndCommitAll(): Untracked changes were removed")
 		}
@@ -342,6 +423,10 @@ func (g Git) Push(gitContext common_models.GitContext) error {
 		logger.Debugf("Push(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
 		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
 	}
+	if err := CheckProjectLock(gitContext.Project); err != nil {
+		logger.Debugf("Push(): Could not push for project '%s': %s", gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
 	err = repo.Push(&git.PushOptions{
 		RemoteName:      "origin",
 		Auth:            gitContext.AuthMethod.GoGitAuth,
@@ -354,6 +439,61 @@ func (g Git) Push(gitContext common_models.GitContext) error {
 	return nil
 }
 
+// StageAndCommitToBranch commits all changes and pushes the commit to the given branch of the upstream repository,
+// instead of the currently checked out branch. Afterwards, the current branch is reset to its previous state, since
+// the changes only become part of it once the change request containing the branch has been merged
+func (g Git) StageAndCommitToBranch(gitContext common_models.GitContext, message string, branch string) (string, error) {
+	if gitContext.Credentials == nil {
+		logger.Debugf("StageAndCommitToBranch(): Could not push for project '%s': credentials missing", gitContext.Project)
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	id, err := g.commitAll(gitContext, message)
+	if err != nil {
+		logger.Debugf("StageAndCommitToBranch(): Could not commit for project '%s': %s", gitContext.Project, err.Error())
+		if resetErr := g.ResetHard(gitContext, "HEAD~0"); resetErr != nil {
+			logger.Warnf("StageAndCommitToBranch(): Could not reset after commitAll: %v", resetErr)
+		}
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCommit, gitContext.Project, mapError(err))
+	}
+	defer func() {
+		if err := g.ResetHard(gitContext, "HEAD~1"); err != nil {
+			logger.Warnf("StageAndCommitToBranch(): Could not reset: %v", err)
+		}
+	}()
+
+	repo, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("StageAndCommitToBranch(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(branchRef, plumbing.NewHash(id))); err != nil {
+		logger.Debugf("StageAndCommitToBranch(): Could not create branch '%s' for project '%s': %s", branch, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotCreate, branch, gitContext.Project, mapError(err))
+	}
+	defer func() {
+		if err := repo.Storer.RemoveReference(branchRef); err != nil {
+			logger.Warnf("StageAndCommitToBranch(): Could not remove local branch '%s': %v", branch, err)
+		}
+	}()
+
+	if err := CheckProjectLock(gitContext.Project); err != nil {
+		logger.Debugf("StageAndCommitToBranch(): Could not push branch '%s' for project '%s': %s", branch, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	err = repo.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(branchRef + ":" + branchRef)},
+		Auth:            gitContext.AuthMethod.GoGitAuth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logger.Debugf("StageAndCommitToBranch(): Could not push branch '%s' for project '%s': %s", branch, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+	return id, nil
+}
+
 func (g *Git) Pull(gitContext common_models.GitContext) error {
 	if !g.ProjectExists(gitContext) {
 		logger.Debugf("Pull(): Could not pull for project '%s': does not exist", gitContext.Project)
@@ -428,6 +568,20 @@ func (g *Git) GetCurrentRevision(gitContext common_models.GitContext) (string, e
 	return hash.String(), nil
 }
 
+func (g *Git) GetCurrentBranch(gitContext common_models.GitContext) (string, error) {
+	r, _, err := g.getWorkTree(gitContext)
+	if err != nil {
+		logger.Debugf("GetCurrentBranch(): Could not get worktree for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, mapError(err))
+	}
+	ref, err := r.Head()
+	if err != nil {
+		logger.Debugf("GetCurrentBranch(): Could not get head for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "get current branch of", gitContext.Project, mapError(err))
+	}
+	return ref.Name().Short(), nil
+}
+
 // returns what is the current commit id of remote and if the remote is up-to-date with the local branch
 func (g *Git) getCurrentRemoteRevision(gitContext common_models.GitContext) (string, bool, error) {
 	repo, _, err := g.getWorkTree(gitContext)
@@ -554,6 +708,96 @@ func (g *Git) CheckoutBranch(gitContext common_models.GitContext, branch string)
 	return nil
 }
 
+// CreateTag creates a tag pointing to the latest commit of the given branch, and pushes it to the upstream repository
+func (g *Git) CreateTag(gitContext common_models.GitContext, tag string, branch string) error {
+	if gitContext.Credentials == nil {
+		logger.Debugf("CreateTag(): Could not push for project '%s': credentials missing", gitContext.Project)
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("CreateTag(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	head, err := getBranchReference(r, branch)
+	if err != nil {
+		logger.Debugf("CreateTag(): Could not find branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotCheckout, branch, kerrors.ErrReferenceNotFound)
+	}
+	tagRef := plumbing.NewTagReferenceName(tag)
+	if _, err := r.Reference(tagRef, false); err == nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, kerrors.ErrTagExists)
+	}
+	if err := CheckProjectLock(gitContext.Project); err != nil {
+		logger.Debugf("CreateTag(): Could not push tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	if err := r.Storer.SetReference(plumbing.NewHashReference(tagRef, head.Hash())); err != nil {
+		logger.Debugf("CreateTag(): Could not create tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "tag", gitContext.Project, mapError(err))
+	}
+
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(tagRef + ":" + tagRef)},
+		Auth:            gitContext.AuthMethod.GoGitAuth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logger.Debugf("CreateTag(): Could not push tag '%s' for project '%s': %s", tag, gitContext.Project, err.Error())
+		if removeErr := r.Storer.RemoveReference(tagRef); removeErr != nil {
+			logger.Warnf("CreateTag(): Could not remove local tag '%s': %v", tag, removeErr)
+		}
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+	return nil
+}
+
+// DeleteBranch deletes the given branch from the upstream repository, as well as from the local repository. The branch
+// must not be checked out
+func (g *Git) DeleteBranch(gitContext common_models.GitContext, branch string) error {
+	if gitContext.Credentials == nil {
+		logger.Debugf("DeleteBranch(): Could not push for project '%s': credentials missing", gitContext.Project)
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, kerrors.ErrCredentialsNotFound)
+	}
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("DeleteBranch(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	branchRef := plumbing.NewBranchReferenceName(branch)
+	if head, err := r.Head(); err == nil && head.Name() == branchRef {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch in", gitContext.Project, kerrors.ErrInvalidReference)
+	}
+
+	if err := CheckProjectLock(gitContext.Project); err != nil {
+		logger.Debugf("DeleteBranch(): Could not delete branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, err)
+	}
+	err = r.Push(&git.PushOptions{
+		RemoteName:      "origin",
+		RefSpecs:        []config.RefSpec{config.RefSpec(":" + branchRef)},
+		Auth:            gitContext.AuthMethod.GoGitAuth,
+		InsecureSkipTLS: retrieveInsecureSkipTLS(gitContext.Credentials),
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		logger.Debugf("DeleteBranch(): Could not delete branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", gitContext.Project, mapError(err))
+	}
+
+	for _, ref := range []plumbing.ReferenceName{branchRef, plumbing.NewRemoteReferenceName("origin", branch)} {
+		if err := r.Storer.RemoveReference(ref); err != nil {
+			logger.Debugf("DeleteBranch(): Could not remove reference '%s' of project '%s': %s", ref, gitContext.Project, err.Error())
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch in", gitContext.Project, mapError(err))
+		}
+	}
+	if err := r.DeleteBranch(branch); err != nil && !errors.Is(err, git.ErrBranchNotFound) {
+		logger.Debugf("DeleteBranch(): Could not remove configuration of branch '%s' of project '%s': %s", branch, gitContext.Project, err.Error())
+		return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "delete branch in", gitContext.Project, mapError(err))
+	}
+	return nil
+}
+
 func (g *Git) checkoutBranch(gitContext common_models.GitContext, options *git.CheckoutOptions) error {
 	if g.ProjectExists(gitContext) {
 		_, w, err := g.getWorkTree(gitContext)
@@ -584,53 +828,341 @@ func (g *Git) fetch(gitContext common_models.GitContext, r *git.Repository) erro
 }
 
 func (g *Git) GetFileRevision(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+	blob, err := g.getFileBlob(gitContext, revision, file)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	var re (io.Reader)
+	re, err = blob.Reader()
+
+	if err != nil {
+		return []byte{},
+			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+
+	return ioutil.ReadAll(re)
+}
+
+// GetFileRevisionReader returns a reader for the content of the given file at the given revision, as well as its size.
+// In contrast to GetFileRevision, the content is not loaded into memory at once
+func (g *Git) GetFileRevisionReader(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+	blob, err := g.getFileBlob(gitContext, revision, file)
+	if err != nil {
+		return nil, 0, err
+	}
+	re, err := blob.Reader()
+	if err != nil {
+		return nil, 0, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+	}
+	return re, blob.Size, nil
+}
+
+func (g *Git) getFileBlob(gitContext common_models.GitContext, revision string, file string) (*object.Blob, error) {
 	path := GetProjectConfigPath(gitContext.Project)
 	r, err := g.git.PlainOpen(path)
 	if err != nil {
 		logger.Debugf("GetFileRevision(): Could not open project %s: %s", file, err.Error())
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
 	}
 	h, err := r.ResolveRevision(plumbing.Revision(revision))
 	if err != nil {
 		logger.Debugf("GetFileRevision(): Could not resolve revision for %s: %s", revision, err.Error())
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
 	if h == nil {
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, kerrors.ErrResolvedNilHash)
 	}
 
 	obj, err := r.Object(plumbing.CommitObject, *h)
 
 	if err != nil {
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
 	if obj == nil {
-		return []byte{}, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResolveRevision)
 	}
 	blob, err := resolve(obj, file)
 
 	if err != nil {
 		if errors.Is(err, object.ErrFileNotFound) {
-			return []byte{},
+			return nil,
 				fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, kerrors.ErrResourceNotFound)
 		}
-		return []byte{},
+		return nil,
 			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
 	}
+	return blob, nil
+}
 
-	var re (io.Reader)
-	re, err = blob.Reader()
+// GetFileHistory returns the commits of the currently checked out branch that changed the given file, or any file within
+// the given directory, starting with the most recent one. If path is empty, all commits of the branch are returned
+func (g *Git) GetFileHistory(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("GetFileHistory(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	head, err := r.Head()
+	if err != nil {
+		logger.Debugf("GetFileHistory(): Could not get head for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, mapError(err))
+	}
 
+	logOptions := &git.LogOptions{From: head.Hash()}
+	if path != "" {
+		logOptions.PathFilter = func(file string) bool {
+			return matchesPath(file, path)
+		}
+	}
+	commits, err := r.Log(logOptions)
 	if err != nil {
-		return []byte{},
-			fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in ", gitContext.Project, err)
+		logger.Debugf("GetFileHistory(): Could not get log for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, mapError(err))
 	}
+	defer commits.Close()
 
-	return ioutil.ReadAll(re)
+	history := []common_models.GitCommit{}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if limit > 0 && len(history) >= limit {
+			return storer.ErrStop
+		}
+		history = append(history, *newGitCommit(commit))
+		return nil
+	})
+	if err != nil {
+		logger.Debugf("GetFileHistory(): Could not iterate commits for project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, mapError(err))
+	}
+	if path != "" && len(history) == 0 {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, kerrors.ErrResourceNotFound)
+	}
+	return history, nil
+}
+
+// GetDiff returns the unified diff of the given file, or all files within the given directory, between two revisions.
+// If path is empty, the diff of the whole repository is returned
+func (g *Git) GetDiff(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("GetDiff(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	fromTree, err := getRevisionTree(r, fromRevision)
+	if err != nil {
+		logger.Debugf("GetDiff(): Could not get tree of revision %s for project '%s': %s", fromRevision, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	toTree, err := getRevisionTree(r, toRevision)
+	if err != nil {
+		logger.Debugf("GetDiff(): Could not get tree of revision %s for project '%s': %s", toRevision, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		logger.Debugf("GetDiff(): Could not diff revisions %s and %s for project '%s': %s", fromRevision, toRevision, gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	filteredChanges := object.Changes{}
+	for _, change := range changes {
+		if path == "" || matchesPath(change.From.Name, path) || matchesPath(change.To.Name, path) {
+			filteredChanges = append(filteredChanges, change)
+		}
+	}
+	if len(filteredChanges) == 0 {
+		return "", nil
+	}
+
+	patch, err := filteredChanges.Patch()
+	if err != nil {
+		logger.Debugf("GetDiff(): Could not create patch for project '%s': %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve diff in", gitContext.Project, err)
+	}
+	return patch.String(), nil
+}
+
+// ListFiles returns the paths of all files within the given directory at the given revision. If path is empty, all files
+// of the revision are returned
+func (g *Git) ListFiles(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("ListFiles(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	tree, err := getRevisionTree(r, revision)
+	if err != nil {
+		logger.Debugf("ListFiles(): Could not get tree of revision %s for project '%s': %s", revision, gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve files in", gitContext.Project, err)
+	}
+
+	files := []string{}
+	err = tree.Files().ForEach(func(file *object.File) error {
+		if path == "" || matchesPath(file.Name, path) {
+			files = append(files, file.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Debugf("ListFiles(): Could not iterate files of revision %s for project '%s': %s", revision, gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve files in", gitContext.Project, err)
+	}
+	return files, nil
+}
+
+// ListBranches returns the names of the local branches of a project, as well as of the branches of its upstream repository
+func (g *Git) ListBranches(gitContext common_models.GitContext) ([]string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("ListBranches(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	refs, err := r.References()
+	if err != nil {
+		logger.Debugf("ListBranches(): Could not get references of project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve branches in", gitContext.Project, err)
+	}
+	defer refs.Close()
+
+	remotePrefix := plumbing.NewRemoteReferenceName("origin", "").String()
+	branches := []string{}
+	known := map[string]bool{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		var branch string
+		if ref.Name().IsBranch() {
+			branch = ref.Name().Short()
+		} else if strings.HasPrefix(ref.Name().String(), remotePrefix) {
+			branch = strings.TrimPrefix(ref.Name().String(), remotePrefix)
+		}
+		if branch != "" && branch != "HEAD" && !known[branch] {
+			known[branch] = true
+			branches = append(branches, branch)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Debugf("ListBranches(): Could not iterate references of project '%s': %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve branches in", gitContext.Project, err)
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
+
+// GetBranchRevisionAt returns the newest commit of the branch that has not been committed after the given revision, which
+// may belong to another branch. The latest commit of the branch is returned if no revision is given, and nil if the branch
+// did not contain any commits at the time of the revision
+func (g *Git) GetBranchRevisionAt(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("GetBranchRevisionAt(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	branchRef, err := getBranchReference(r, branch)
+	if err != nil {
+		logger.Debugf("GetBranchRevisionAt(): Could not find branch %s of project '%s': %s", branch, gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve branch in", gitContext.Project, kerrors.ErrReferenceNotFound)
+	}
+
+	var at *time.Time
+	if revision != "" {
+		h, err := r.ResolveRevision(plumbing.Revision(revision))
+		if err != nil || h == nil {
+			return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, kerrors.ErrResolveRevision)
+		}
+		commit, err := r.CommitObject(*h)
+		if err != nil {
+			return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve revision in", gitContext.Project, kerrors.ErrResolveRevision)
+		}
+		// the revision itself is returned if it belongs to the branch, since commit times only have a precision of seconds
+		if belongsToBranch(r, commit, branchRef.Hash()) {
+			return newGitCommit(commit), nil
+		}
+		at = &commit.Committer.When
+	}
+
+	commits, err := r.Log(&git.LogOptions{From: branchRef.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		logger.Debugf("GetBranchRevisionAt(): Could not get log of branch %s for project '%s': %s", branch, gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, mapError(err))
+	}
+	defer commits.Close()
+
+	var result *common_models.GitCommit
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if at != nil && commit.Committer.When.After(*at) {
+			return nil
+		}
+		result = newGitCommit(commit)
+		return storer.ErrStop
+	})
+	if err != nil {
+		logger.Debugf("GetBranchRevisionAt(): Could not iterate commits of branch %s for project '%s': %s", branch, gitContext.Project, err.Error())
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "retrieve history in", gitContext.Project, mapError(err))
+	}
+	return result, nil
+}
+
+func newGitCommit(commit *object.Commit) *common_models.GitCommit {
+	return &common_models.GitCommit{
+		CommitID:       commit.Hash.String(),
+		AuthorName:     commit.Author.Name,
+		AuthorEmail:    commit.Author.Email,
+		CommitterName:  commit.Committer.Name,
+		CommitterEmail: commit.Committer.Email,
+		Message:        commit.Message,
+		Timestamp:      commit.Author.When,
+	}
+}
+
+// belongsToBranch checks whether the commit is part of the history of the branch with the given head
+func belongsToBranch(r *git.Repository, commit *object.Commit, head plumbing.Hash) bool {
+	if commit.Hash == head {
+		return true
+	}
+	headCommit, err := r.CommitObject(head)
+	if err != nil {
+		return false
+	}
+	isAncestor, err := commit.IsAncestor(headCommit)
+	return err == nil && isAncestor
+}
+
+// getBranchReference returns the local branch with the given name, or the branch of the upstream repository if it has not
+// been checked out yet
+func getBranchReference(r *git.Repository, branch string) (*plumbing.Reference, error) {
+	if strings.HasPrefix(branch, "refs") {
+		return r.Reference(plumbing.ReferenceName(branch), true)
+	}
+	ref, err := r.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err == nil {
+		return ref, nil
+	}
+	return r.Reference(plumbing.NewRemoteReferenceName("origin", branch), true)
+}
+
+func getRevisionTree(r *git.Repository, revision string) (*object.Tree, error) {
+	h, err := r.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, kerrors.ErrResolveRevision
+	}
+	if h == nil {
+		return nil, kerrors.ErrResolvedNilHash
+	}
+	commit, err := r.CommitObject(*h)
+	if err != nil {
+		return nil, kerrors.ErrResolveRevision
+	}
+	return commit.Tree()
+}
+
+// matchesPath checks whether the given file is equal to, or located within, the given path
+func matchesPath(file string, path string) bool {
+	path = strings.TrimSuffix(path, "/")
+	return file == path || strings.HasPrefix(file, path+"/")
 }
 
 func (g *Git) GetDefaultBranch(gitContext common_models.GitContext) (string, error) {
@@ -667,6 +1199,23 @@ func (g *Git) ProjectExists(gitContext common_models.GitContext) bool {
 	return clone
 }
 
+// GetRemoteURL returns the URL of the origin remote of the local repository of the project
+func (g *Git) GetRemoteURL(gitContext common_models.GitContext) (string, error) {
+	r, err := g.git.PlainOpen(GetProjectConfigPath(gitContext.Project))
+	if err != nil {
+		logger.Debugf("GetRemoteURL(): Could not open project %s: %s", gitContext.Project, err.Error())
+		return "", fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "open", gitContext.Project, err)
+	}
+	remote, err := r.Remote("origin")
+	if err != nil {
+		return "", mapError(err)
+	}
+	if len(remote.Config().URLs) == 0 {
+		return "", nil
+	}
+	return remote.Config().URLs[0], nil
+}
+
 func (g *Git) ProjectRepoExists(project string) bool {
 	path := GetProjectConfigPath(project)
 	_, err := os.Stat(path)
@@ -720,6 +1269,9 @@ func (g *Git) MoveToNewUpstream(currentContext common_models.GitContext, newCont
 			return err
 		}
 
+		if err := CheckProjectLock(currentContext.Project); err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotGitAction, "push", newContext.Project, err)
+		}
 		err = currentRepo.Push(&git.PushOptions{
 			RemoteName:      tmpOrigin,
 			Auth:            newContext.AuthMethod.GoGitAuth,
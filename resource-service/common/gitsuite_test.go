@@ -482,6 +482,78 @@ func (s *BaseSuite) TestGit_StageAndCommitAll_OverrideUserAndEmail(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_StageAndCommitAll_Author(c *C) {
+	g := NewGit(GogitReal{})
+	r := s.Repository
+	w, err := r.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	gitContext := s.NewGitContext()
+	gitContext.Author = &common_models.GitAuthor{Name: "my-token", Email: "my-token@keptn.sh"}
+	id, err := g.StageAndCommitAll(gitContext, "my commit")
+	c.Assert(err, IsNil)
+	s.checkCommit(c, r, id, getGitKeptnUser(), getGitKeptnEmail())
+
+	commit, err := r.CommitObject(plumbing.NewHash(id))
+	c.Assert(err, IsNil)
+	c.Assert(commit.Author.Name, Equals, "my-token")
+	c.Assert(commit.Author.Email, Equals, "my-token@keptn.sh")
+	c.Assert(commit.PGPSignature, Equals, "")
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_Signed(c *C) {
+	privateKey, publicKey := newTestGPGKey(c)
+	g := NewGit(GogitReal{})
+	g.SetSigningKeyReader(&common_mock.GitSigningKeyReaderMock{
+		GetSigningKeyFunc: func(project string) (*common_models.GitSigningKey, error) {
+			return &common_models.GitSigningKey{Format: common_models.GitSigningFormatGPG, PrivateKey: privateKey}, nil
+		},
+	})
+	r := s.Repository
+	w, err := r.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.StageAndCommitAll(s.NewGitContext(), "my commit")
+	c.Assert(err, IsNil)
+	s.checkCommit(c, r, id, getGitKeptnUser(), getGitKeptnEmail())
+
+	commit, err := r.CommitObject(plumbing.NewHash(id))
+	c.Assert(err, IsNil)
+	_, err = commit.Verify(publicKey)
+	c.Assert(err, IsNil)
+	b, err := g.GetFileRevision(s.NewGitContext(), id, "foo/file.txt")
+	c.Assert(err, IsNil)
+	c.Assert(string(b), Equals, "anycontent")
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitAll_InvalidSigningKey(c *C) {
+	g := NewGit(GogitReal{})
+	g.SetSigningKeyReader(&common_mock.GitSigningKeyReaderMock{
+		GetSigningKeyFunc: func(project string) (*common_models.GitSigningKey, error) {
+			return &common_models.GitSigningKey{Format: common_models.GitSigningFormatGPG, PrivateKey: "invalid"}, nil
+		},
+	})
+	r := s.Repository
+	h, err := r.Head()
+	c.Assert(err, IsNil)
+	w, err := r.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/file.txt", "anycontent", c, w)
+	c.Assert(err, IsNil)
+
+	_, err = g.StageAndCommitAll(s.NewGitContext(), "my commit")
+	c.Assert(errors.Is(err, kerrors.ErrInvalidGitSigningKey), Equals, true)
+
+	// no unsigned commit has been created
+	newHead, err := r.Head()
+	c.Assert(err, IsNil)
+	c.Assert(newHead.Hash(), Equals, h.Hash())
+}
+
 func (s *BaseSuite) checkCommit(c *C, r *git.Repository, id string, user string, email string) {
 	head, err := r.Head()
 	c.Assert(err, IsNil)
@@ -984,6 +1056,236 @@ func (s *BaseSuite) TestGit_GetFileRevision(c *C) {
 	}
 }
 
+func (s *BaseSuite) TestGit_GetFileRevisionReader(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/stream.yaml", "first", c)
+	s.commitAndPush("foo/stream.yaml", "second content", c)
+
+	reader, size, err := g.GetFileRevisionReader(s.NewGitContext(), first.String(), "foo/stream.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(size, Equals, int64(5))
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, IsNil)
+	c.Assert(reader.Close(), IsNil)
+	c.Assert(string(content), Equals, "first")
+
+	_, _, err = g.GetFileRevisionReader(s.NewGitContext(), first.String(), "foo/unknown.yaml")
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetFileHistory(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/history.yaml", "first", c)
+	s.commitAndPush("bar/other.yaml", "other", c)
+	second := s.commitAndPush("foo/history.yaml", "second", c)
+
+	history, err := g.GetFileHistory(s.NewGitContext(), "foo/history.yaml", 0)
+	c.Assert(err, IsNil)
+	c.Assert(history, HasLen, 2)
+	c.Assert(history[0].CommitID, Equals, second.String())
+	c.Assert(history[1].CommitID, Equals, first.String())
+	c.Assert(history[0].AuthorName, Equals, "Test Create Branch")
+	c.Assert(history[0].Message, Equals, "added a file")
+
+	// directories contain the history of all files within
+	history, err = g.GetFileHistory(s.NewGitContext(), "foo", 1)
+	c.Assert(err, IsNil)
+	c.Assert(history, HasLen, 1)
+	c.Assert(history[0].CommitID, Equals, second.String())
+
+	_, err = g.GetFileHistory(s.NewGitContext(), "foo/unknown.yaml", 0)
+	c.Assert(errors.Is(err, kerrors.ErrResourceNotFound), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_GetDiff(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/diff.yaml", "first\n", c)
+	s.commitAndPush("bar/other.yaml", "other\n", c)
+	second := s.commitAndPush("foo/diff.yaml", "second\n", c)
+
+	diff, err := g.GetDiff(s.NewGitContext(), first.String(), second.String(), "foo/diff.yaml")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "-first"), Equals, true)
+	c.Assert(strings.Contains(diff, "+second"), Equals, true)
+	c.Assert(strings.Contains(diff, "other"), Equals, false)
+
+	diff, err = g.GetDiff(s.NewGitContext(), first.String(), "HEAD", "")
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(diff, "+second"), Equals, true)
+	c.Assert(strings.Contains(diff, "+other"), Equals, true)
+
+	diff, err = g.GetDiff(s.NewGitContext(), first.String(), second.String(), "unknown")
+	c.Assert(err, IsNil)
+	c.Assert(diff, Equals, "")
+
+	_, err = g.GetDiff(s.NewGitContext(), "ciaoWrongId", second.String(), "")
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_ListFiles(c *C) {
+	g := NewGit(s.NewTestGit())
+
+	first := s.commitAndPush("foo/list.yaml", "first", c)
+	second := s.commitAndPush("foo/bar/list.yaml", "second", c)
+
+	files, err := g.ListFiles(s.NewGitContext(), second.String(), "foo")
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{"foo/bar/list.yaml", "foo/list.yaml"})
+
+	files, err = g.ListFiles(s.NewGitContext(), first.String(), "foo")
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{"foo/list.yaml"})
+
+	_, err = g.ListFiles(s.NewGitContext(), "ciaoWrongId", "")
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_ListBranches(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	// branches of the upstream are listed before they have been checked out
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+	s.createUpstreamBranch("staging", head.Hash(), c)
+
+	branches, err := g.ListBranches(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(branches, DeepEquals, []string{"master", "staging"})
+
+	err = g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+
+	branches, err = g.ListBranches(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(branches, DeepEquals, []string{"dev", "master", "staging"})
+}
+
+func (s *BaseSuite) TestGit_CreateTagAndDeleteBranch(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+	s.createUpstreamBranch("staging", head.Hash(), c)
+
+	err = g.CreateTag(gitContext, "archive/staging", "staging")
+	c.Assert(err, IsNil)
+
+	err = g.CreateTag(gitContext, "archive/staging", "staging")
+	c.Assert(errors.Is(err, kerrors.ErrTagExists), Equals, true)
+
+	err = g.CreateTag(gitContext, "archive/unknown", "unknown")
+	c.Assert(errors.Is(err, kerrors.ErrReferenceNotFound), Equals, true)
+
+	err = g.DeleteBranch(gitContext, "staging")
+	c.Assert(err, IsNil)
+
+	branches, err := g.ListBranches(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(branches, DeepEquals, []string{"master"})
+
+	// the archived revision is still available in the upstream repository
+	upstream, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	_, err = upstream.Reference(plumbing.NewBranchReferenceName("staging"), false)
+	c.Assert(err, Equals, plumbing.ErrReferenceNotFound)
+	tag, err := upstream.Reference(plumbing.NewTagReferenceName("archive/staging"), false)
+	c.Assert(err, IsNil)
+	c.Assert(tag.Hash(), Equals, head.Hash())
+
+	// the checked out branch can not be deleted
+	err = g.DeleteBranch(gitContext, "master")
+	c.Assert(err, NotNil)
+}
+
+func (s *BaseSuite) TestGit_GetBranchRevisionAt(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	head, err := s.Repository.Head()
+	c.Assert(err, IsNil)
+	s.createUpstreamBranch("staging", head.Hash(), c)
+
+	first := s.commitAndPush("foo/first.yaml", "first", c)
+	err = g.CreateBranch(gitContext, "dev", "master")
+	c.Assert(err, IsNil)
+
+	// commit times only have a precision of seconds
+	time.Sleep(1 * time.Second)
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/dev.yaml", "dev", c, w)
+	c.Assert(err, IsNil)
+	devCommit := commit("foo/dev.yaml", c, w)
+
+	revision, err := g.GetBranchRevisionAt(gitContext, "master", "")
+	c.Assert(err, IsNil)
+	c.Assert(revision.CommitID, Equals, first.String())
+
+	revision, err = g.GetBranchRevisionAt(gitContext, "dev", "")
+	c.Assert(err, IsNil)
+	c.Assert(revision.CommitID, Equals, devCommit.String())
+
+	// the revision of the dev branch did not exist in the master branch, which was at the first commit at that time
+	revision, err = g.GetBranchRevisionAt(gitContext, "master", devCommit.String())
+	c.Assert(err, IsNil)
+	c.Assert(revision.CommitID, Equals, first.String())
+
+	revision, err = g.GetBranchRevisionAt(gitContext, "dev", first.String())
+	c.Assert(err, IsNil)
+	c.Assert(revision.CommitID, Equals, first.String())
+
+	revision, err = g.GetBranchRevisionAt(gitContext, "staging", first.String())
+	c.Assert(err, IsNil)
+	c.Assert(revision.CommitID, Equals, head.Hash().String())
+
+	_, err = g.GetBranchRevisionAt(gitContext, "unknown", "")
+	c.Assert(errors.Is(err, kerrors.ErrReferenceNotFound), Equals, true)
+
+	_, err = g.GetBranchRevisionAt(gitContext, "master", "ciaoWrongId")
+	c.Assert(errors.Is(err, kerrors.ErrResolveRevision), Equals, true)
+}
+
+func (s *BaseSuite) TestGit_StageAndCommitToBranch(c *C) {
+	g := NewGit(s.NewTestGit())
+	gitContext := s.NewGitContext()
+
+	currentBranch, err := g.GetCurrentBranch(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(currentBranch, Equals, "master")
+	previousRevision, err := g.GetCurrentRevision(gitContext)
+	c.Assert(err, IsNil)
+
+	w, err := s.Repository.Worktree()
+	c.Assert(err, IsNil)
+	err = write("foo/change-request.yaml", "changed", c, w)
+	c.Assert(err, IsNil)
+
+	id, err := g.StageAndCommitToBranch(gitContext, "my change", "keptn/master-1")
+	c.Assert(err, IsNil)
+
+	// the current branch must not contain the change
+	currentRevision, err := g.GetCurrentRevision(gitContext)
+	c.Assert(err, IsNil)
+	c.Assert(currentRevision, Equals, previousRevision)
+	_, err = s.Repository.Reference(plumbing.NewBranchReferenceName("keptn/master-1"), false)
+	c.Assert(err, Equals, plumbing.ErrReferenceNotFound)
+
+	// the change must have been pushed to the feature branch of the remote
+	remote, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("keptn/master-1"), false)
+	c.Assert(err, IsNil)
+	c.Assert(ref.Hash().String(), Equals, id)
+	masterRef, err := remote.Reference(plumbing.NewBranchReferenceName("master"), false)
+	c.Assert(err, IsNil)
+	c.Assert(masterRef.Hash().String(), Equals, previousRevision)
+}
+
 func (s *BaseSuite) TestGit_MoveToNewUpstream(c *C) {
 	g := NewGit(GogitReal{})
 
@@ -1247,6 +1549,16 @@ func (s *BaseSuite) commitAndPush(file string, content string, c *C) plumbing.Ha
 	return id
 }
 
+// createUpstreamBranch creates a branch in the upstream repository and fetches it, without checking it out
+func (s *BaseSuite) createUpstreamBranch(branch string, hash plumbing.Hash, c *C) {
+	remote, err := git.PlainOpen(s.url)
+	c.Assert(err, IsNil)
+	err = remote.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), hash))
+	c.Assert(err, IsNil)
+	err = s.Repository.Fetch(&git.FetchOptions{})
+	c.Assert(err, IsNil)
+}
+
 func commit(file string, c *C, w *git.Worktree) plumbing.Hash {
 	_, err := w.Add(file)
 	c.Assert(err, IsNil)
@@ -0,0 +1,163 @@
+package common
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const gitWebhookSecretPrefix = "git-webhook-"
+const gitWebhookSecretKey = "secret"
+
+const (
+	gitHubEventHeader     = "X-GitHub-Event"
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+	gitHubPushEvent       = "push"
+	gitLabEventHeader     = "X-Gitlab-Event"
+	gitLabTokenHeader     = "X-Gitlab-Token"
+	gitLabPushEvent       = "Push Hook"
+	branchRefPrefix       = "refs/heads/"
+)
+
+// EventPublisher sends Keptn events to the message broker
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/event_publisher_mock.go . EventPublisher
+type EventPublisher interface {
+	Publish(event apimodels.KeptnContextExtendedCE) error
+}
+
+// GitWebhookSecretReader provides the shared secret used to validate the webhooks received for a project
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/git_webhook_secret_reader_mock.go . GitWebhookSecretReader
+type GitWebhookSecretReader interface {
+	GetWebhookSecret(project string) (string, error)
+}
+
+// K8sGitWebhookSecretReader reads the shared secret of a project from the 'git-webhook-<project>' secret.
+// Webhooks are rejected for projects without such a secret
+type K8sGitWebhookSecretReader struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewK8sGitWebhookSecretReader(k8sClient kubernetes.Interface) *K8sGitWebhookSecretReader {
+	return &K8sGitWebhookSecretReader{k8sClient: k8sClient}
+}
+
+func (r K8sGitWebhookSecretReader) GetWebhookSecret(project string) (string, error) {
+	secretName := GetGitWebhookSecretName(project)
+	secret, err := r.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		return "", kerrors.ErrGitWebhookNotEnabled
+	}
+	if err != nil {
+		logger.Debugf("Could not retrieve git webhook secret named: %s, error: %s", secretName, err.Error())
+		return "", err
+	}
+	webhookSecret := string(secret.Data[gitWebhookSecretKey])
+	if webhookSecret == "" {
+		return "", kerrors.ErrGitWebhookNotEnabled
+	}
+	return webhookSecret, nil
+}
+
+func GetGitWebhookSecretName(projectName string) string {
+	return fmt.Sprintf("%s%s", gitWebhookSecretPrefix, projectName)
+}
+
+// ParseGitPushEvent validates a webhook request of GitHub or GitLab using the given secret and extracts the contained push event.
+// Other events, e.g. the ping event sent by GitHub when a webhook has been created, as well as pushes of tags are
+// acknowledged without returning a push event
+func ParseGitPushEvent(header http.Header, payload []byte, secret string) (*common_models.GitPushEvent, error) {
+	var eventType, expectedEventType string
+	switch {
+	case header.Get(gitHubEventHeader) != "":
+		if !isValidGitHubSignature(header.Get(gitHubSignatureHeader), payload, secret) {
+			return nil, kerrors.ErrInvalidGitWebhookSignature
+		}
+		eventType, expectedEventType = header.Get(gitHubEventHeader), gitHubPushEvent
+	case header.Get(gitLabEventHeader) != "":
+		if subtle.ConstantTimeCompare([]byte(header.Get(gitLabTokenHeader)), []byte(secret)) != 1 {
+			return nil, kerrors.ErrInvalidGitWebhookSignature
+		}
+		eventType, expectedEventType = header.Get(gitLabEventHeader), gitLabPushEvent
+	default:
+		return nil, kerrors.ErrUnsupportedGitWebhookEvent
+	}
+
+	if eventType != expectedEventType {
+		logger.Debugf("Ignoring git webhook event of type %s", eventType)
+		return nil, nil
+	}
+	return parseGitPushPayload(payload)
+}
+
+// gitPushPayload contains the properties of a push event that are identical for GitHub and GitLab
+type gitPushPayload struct {
+	Ref     string `json:"ref"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Modified []string `json:"modified"`
+		Removed  []string `json:"removed"`
+	} `json:"commits"`
+}
+
+func parseGitPushPayload(payload []byte) (*common_models.GitPushEvent, error) {
+	push := &gitPushPayload{}
+	if err := json.Unmarshal(payload, push); err != nil {
+		return nil, kerrors.ErrMalformedGitWebhookPayload
+	}
+	if !strings.HasPrefix(push.Ref, branchRefPrefix) {
+		logger.Debugf("Ignoring push to %s", push.Ref)
+		return nil, nil
+	}
+
+	paths := map[string]bool{}
+	for _, commit := range push.Commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				paths[file] = true
+			}
+		}
+	}
+	event := &common_models.GitPushEvent{
+		Branch: strings.TrimPrefix(push.Ref, branchRefPrefix),
+		Before: push.Before,
+		After:  push.After,
+		Paths:  []string{},
+	}
+	for path := range paths {
+		event.Paths = append(event.Paths, path)
+	}
+	sort.Strings(event.Paths)
+	return event, nil
+}
+
+func isValidGitHubSignature(signature string, payload []byte, secret string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
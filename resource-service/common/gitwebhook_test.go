@@ -0,0 +1,140 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const gitPushTestPayload = `{
+	"ref": "refs/heads/dev",
+	"before": "abc",
+	"after": "def",
+	"commits": [
+		{"added": ["carts/helm/values.yaml"], "modified": ["slo.yaml"], "removed": []},
+		{"added": [], "modified": ["carts/helm/values.yaml"], "removed": ["carts/jmeter/load.jmx"]}
+	]
+}`
+
+func TestParseGitPushEvent(t *testing.T) {
+	expected := &common_models.GitPushEvent{
+		Branch: "dev",
+		Before: "abc",
+		After:  "def",
+		Paths:  []string{"carts/helm/values.yaml", "carts/jmeter/load.jmx", "slo.yaml"},
+	}
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		payload string
+		want    *common_models.GitPushEvent
+		wantErr error
+	}{
+		{
+			name:    "github push",
+			header:  getGitHubHeader("push", gitPushTestPayload, "my-secret"),
+			payload: gitPushTestPayload,
+			want:    expected,
+		},
+		{
+			name:    "github push with invalid signature",
+			header:  getGitHubHeader("push", gitPushTestPayload, "other-secret"),
+			payload: gitPushTestPayload,
+			wantErr: kerrors.ErrInvalidGitWebhookSignature,
+		},
+		{
+			name:    "github push without signature",
+			header:  http.Header{"X-Github-Event": []string{"push"}},
+			payload: gitPushTestPayload,
+			wantErr: kerrors.ErrInvalidGitWebhookSignature,
+		},
+		{
+			name:    "github ping",
+			header:  getGitHubHeader("ping", `{"zen":"Keep it simple"}`, "my-secret"),
+			payload: `{"zen":"Keep it simple"}`,
+		},
+		{
+			name:    "gitlab push",
+			header:  http.Header{"X-Gitlab-Event": []string{"Push Hook"}, "X-Gitlab-Token": []string{"my-secret"}},
+			payload: gitPushTestPayload,
+			want:    expected,
+		},
+		{
+			name:    "gitlab push with invalid token",
+			header:  http.Header{"X-Gitlab-Event": []string{"Push Hook"}, "X-Gitlab-Token": []string{"other-secret"}},
+			payload: gitPushTestPayload,
+			wantErr: kerrors.ErrInvalidGitWebhookSignature,
+		},
+		{
+			name:    "gitlab tag push",
+			header:  http.Header{"X-Gitlab-Event": []string{"Push Hook"}, "X-Gitlab-Token": []string{"my-secret"}},
+			payload: `{"ref":"refs/tags/v1.0.0","after":"def"}`,
+		},
+		{
+			name:    "malformed payload",
+			header:  http.Header{"X-Gitlab-Event": []string{"Push Hook"}, "X-Gitlab-Token": []string{"my-secret"}},
+			payload: `invalid`,
+			wantErr: kerrors.ErrMalformedGitWebhookPayload,
+		},
+		{
+			name:    "unknown sender",
+			header:  http.Header{},
+			payload: gitPushTestPayload,
+			wantErr: kerrors.ErrUnsupportedGitWebhookEvent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGitPushEvent(tt.header, []byte(tt.payload), "my-secret")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestK8sGitWebhookSecretReader_GetWebhookSecret(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	reader := NewK8sGitWebhookSecretReader(fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GetGitWebhookSecretName("my-project"), Namespace: "keptn"},
+			Data:       map[string][]byte{"secret": []byte("my-secret")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GetGitWebhookSecretName("empty-project"), Namespace: "keptn"},
+			Data:       map[string][]byte{},
+		},
+	))
+
+	secret, err := reader.GetWebhookSecret("my-project")
+	require.Nil(t, err)
+	require.Equal(t, "my-secret", secret)
+
+	_, err = reader.GetWebhookSecret("empty-project")
+	require.ErrorIs(t, err, kerrors.ErrGitWebhookNotEnabled)
+
+	_, err = reader.GetWebhookSecret("other-project")
+	require.ErrorIs(t, err, kerrors.ErrGitWebhookNotEnabled)
+}
+
+func getGitHubHeader(event string, payload string, secret string) http.Header {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	header := http.Header{}
+	header.Set("X-GitHub-Event", event)
+	header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	return header
+}
@@ -0,0 +1,260 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const projectLeasePrefix = "resource-service-lock-"
+
+// K8sLeaseProjectLocker locks projects across all replicas of the resource-service using Kubernetes Lease objects.
+// Within a replica, the project is locked locally before the lease is acquired. As long as the lock is held, the lease
+// is renewed periodically. If a replica terminates without releasing the lock, the lease can be taken over by other
+// replicas once its duration has expired. A replica that could not renew its lease in time considers the lock as lost, and
+// must not push any changes of the project anymore
+type K8sLeaseProjectLocker struct {
+	k8sClient      kubernetes.Interface
+	namespace      string
+	identity       string
+	leaseDuration  time.Duration
+	acquireTimeout time.Duration
+	retryInterval  time.Duration
+	local          LocalProjectLocker
+	heldLeases     map[string]*heldLease
+	mtx            sync.Mutex
+}
+
+type heldLease struct {
+	stop chan struct{}
+	done chan struct{}
+	// renewedAt is the time of the last successful renewal in unix nanoseconds
+	renewedAt int64
+	lost      int32
+}
+
+// NewK8sLeaseProjectLocker creates a locker which identifies the current replica with the given identity, e.g. the name of the pod.
+// Acquiring the lock of a project fails if the lease could not be acquired within the given timeout
+func NewK8sLeaseProjectLocker(k8sClient kubernetes.Interface, namespace string, identity string, leaseDuration time.Duration, acquireTimeout time.Duration) *K8sLeaseProjectLocker {
+	return &K8sLeaseProjectLocker{
+		k8sClient:      k8sClient,
+		namespace:      namespace,
+		identity:       identity,
+		leaseDuration:  leaseDuration,
+		acquireTimeout: acquireTimeout,
+		retryInterval:  200 * time.Millisecond,
+		heldLeases:     map[string]*heldLease{},
+	}
+}
+
+func (l *K8sLeaseProjectLocker) LockProject(project string) error {
+	_ = l.local.LockProject(project)
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.acquireTimeout)
+	defer cancel()
+	for {
+		acquired, err := l.tryAcquire(ctx, project)
+		if err != nil {
+			logger.Warnf("Could not acquire lease for project %s: %v", project, err)
+		}
+		if acquired {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			l.local.UnlockProject(project)
+			return fmt.Errorf("%w: %s", kerrors.ErrProjectLockTimeout, project)
+		case <-time.After(l.retryInterval):
+		}
+	}
+
+	lease := &heldLease{stop: make(chan struct{}), done: make(chan struct{}), renewedAt: time.Now().UnixNano()}
+	l.mtx.Lock()
+	l.heldLeases[project] = lease
+	l.mtx.Unlock()
+	go l.renew(project, lease)
+	return nil
+}
+
+// CheckProjectLock returns an error if the lease of the project could not be renewed before its expiry, or has been taken
+// over by another replica
+func (l *K8sLeaseProjectLocker) CheckProjectLock(project string) error {
+	l.mtx.Lock()
+	lease := l.heldLeases[project]
+	l.mtx.Unlock()
+	if lease == nil {
+		return nil
+	}
+	renewedAt := time.Unix(0, atomic.LoadInt64(&lease.renewedAt))
+	if atomic.LoadInt32(&lease.lost) == 1 || time.Since(renewedAt) >= l.leaseDuration {
+		return fmt.Errorf("%w: %s", kerrors.ErrProjectLockLost, project)
+	}
+	return nil
+}
+
+func (l *K8sLeaseProjectLocker) UnlockProject(project string) {
+	defer l.local.UnlockProject(project)
+
+	l.mtx.Lock()
+	lease := l.heldLeases[project]
+	delete(l.heldLeases, project)
+	l.mtx.Unlock()
+	if lease == nil {
+		return
+	}
+	close(lease.stop)
+	<-lease.done
+
+	if err := l.release(project); err != nil {
+		logger.Warnf("Could not release lease for project %s: %v", project, err)
+	}
+}
+
+// tryAcquire creates the lease of the project, or takes it over if it is not held by another replica
+func (l *K8sLeaseProjectLocker) tryAcquire(ctx context.Context, project string) (bool, error) {
+	leases := l.k8sClient.CoordinationV1().Leases(l.namespace)
+	now := metav1.NewMicroTime(time.Now())
+	leaseDurationSeconds := int32(l.leaseDuration.Seconds())
+
+	lease, err := leases.Get(ctx, GetProjectLeaseName(project), metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		_, err := leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GetProjectLeaseName(project),
+				Namespace: l.namespace,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &l.identity,
+				LeaseDurationSeconds: &leaseDurationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil && k8serrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if isLeaseHeldByOther(lease, l.identity, now.Time) {
+		return false, nil
+	}
+	lease.Spec.HolderIdentity = &l.identity
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSeconds
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	if err != nil && k8serrors.IsConflict(err) {
+		// another replica has updated the lease in the meantime
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (l *K8sLeaseProjectLocker) renew(project string, lease *heldLease) {
+	defer close(lease.done)
+	ticker := time.NewTicker(l.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lease.stop:
+			return
+		case <-ticker.C:
+			renewedAt := time.Now()
+			err := l.updateRenewTime(project)
+			if err == nil {
+				atomic.StoreInt64(&lease.renewedAt, renewedAt.UnixNano())
+				continue
+			}
+			logger.Warnf("Could not renew lease for project %s: %v", project, err)
+			if errors.Is(err, kerrors.ErrProjectLockLost) {
+				atomic.StoreInt32(&lease.lost, 1)
+			}
+		}
+	}
+}
+
+func (l *K8sLeaseProjectLocker) updateRenewTime(project string) error {
+	// a renewal that takes longer than a third of the lease duration would be too late anyway
+	ctx, cancel := context.WithTimeout(context.Background(), l.leaseDuration/3)
+	defer cancel()
+
+	leases := l.k8sClient.CoordinationV1().Leases(l.namespace)
+	lease, err := leases.Get(ctx, GetProjectLeaseName(project), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+		return fmt.Errorf("%w: lease is held by %s", kerrors.ErrProjectLockLost, getLeaseHolder(lease))
+	}
+	now := metav1.NewMicroTime(time.Now())
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func (l *K8sLeaseProjectLocker) release(project string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.leaseDuration)
+	defer cancel()
+
+	leases := l.k8sClient.CoordinationV1().Leases(l.namespace)
+	lease, err := leases.Get(ctx, GetProjectLeaseName(project), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != l.identity {
+		// the lease has expired and has been taken over by another replica
+		return nil
+	}
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+	_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+func isLeaseHeldByOther(lease *coordinationv1.Lease, identity string, now time.Time) bool {
+	holder := getLeaseHolder(lease)
+	if holder == "" || holder == identity {
+		return false
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiry)
+}
+
+func getLeaseHolder(lease *coordinationv1.Lease) string {
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+// GetProjectLeaseName returns the name of the lease used for locking the given project. Since the name needs to be a valid
+// DNS subdomain, project names that cannot be used as part of such a name are replaced by their hash
+func GetProjectLeaseName(project string) string {
+	name := projectLeasePrefix + project
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	hash := sha256.Sum256([]byte(project))
+	return projectLeasePrefix + hex.EncodeToString(hash[:])[:32]
+}
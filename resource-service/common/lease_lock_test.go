@@ -0,0 +1,141 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sLeaseProjectLocker_LockProject(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	replica1 := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-1", 15*time.Second, time.Minute)
+	replica1.retryInterval = 10 * time.Millisecond
+	replica2 := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-2", 15*time.Second, time.Minute)
+	replica2.retryInterval = 10 * time.Millisecond
+
+	require.Nil(t, replica1.LockProject("my-project"))
+
+	lease, err := k8sClient.CoordinationV1().Leases("keptn").Get(context.TODO(), "resource-service-lock-my-project", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "replica-1", *lease.Spec.HolderIdentity)
+
+	var acquired int32
+	go func() {
+		if err := replica2.LockProject("my-project"); err == nil {
+			atomic.StoreInt32(&acquired, 1)
+		}
+	}()
+
+	// the second replica must wait until the lock has been released
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(0), atomic.LoadInt32(&acquired))
+
+	replica1.UnlockProject("my-project")
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&acquired) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	lease, err = k8sClient.CoordinationV1().Leases("keptn").Get(context.TODO(), "resource-service-lock-my-project", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "replica-2", *lease.Spec.HolderIdentity)
+
+	replica2.UnlockProject("my-project")
+	lease, err = k8sClient.CoordinationV1().Leases("keptn").Get(context.TODO(), "resource-service-lock-my-project", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Nil(t, lease.Spec.HolderIdentity)
+}
+
+func TestK8sLeaseProjectLocker_TakeOverExpiredLease(t *testing.T) {
+	leaseDurationSeconds := int32(15)
+	holder := "crashed-replica"
+	renewTime := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	k8sClient := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "resource-service-lock-my-project",
+			Namespace: "keptn",
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &leaseDurationSeconds,
+			RenewTime:            &renewTime,
+		},
+	})
+	locker := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-1", 15*time.Second, time.Minute)
+
+	acquired, err := locker.tryAcquire(context.TODO(), "my-project")
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	// a lease that is still valid must not be taken over
+	otherLocker := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-2", 15*time.Second, time.Minute)
+	acquired, err = otherLocker.tryAcquire(context.TODO(), "my-project")
+	require.Nil(t, err)
+	require.False(t, acquired)
+}
+
+func TestK8sLeaseProjectLocker_LockProjectTimeout(t *testing.T) {
+	leaseDurationSeconds := int32(15)
+	holder := "other-replica"
+	renewTime := metav1.NewMicroTime(time.Now())
+	k8sClient := fake.NewSimpleClientset(&coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "resource-service-lock-my-project",
+			Namespace: "keptn",
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &holder,
+			LeaseDurationSeconds: &leaseDurationSeconds,
+			RenewTime:            &renewTime,
+		},
+	})
+	locker := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-1", 15*time.Second, 50*time.Millisecond)
+	locker.retryInterval = 10 * time.Millisecond
+
+	require.ErrorIs(t, locker.LockProject("my-project"), kerrors.ErrProjectLockTimeout)
+	// the local lock must have been released again
+	require.ErrorIs(t, locker.LockProject("my-project"), kerrors.ErrProjectLockTimeout)
+}
+
+func TestK8sLeaseProjectLocker_CheckProjectLock(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset()
+	locker := NewK8sLeaseProjectLocker(k8sClient, "keptn", "replica-1", 3*time.Second, time.Minute)
+
+	require.Nil(t, locker.LockProject("my-project"))
+	require.Nil(t, locker.CheckProjectLock("my-project"))
+
+	// another replica has taken over the lease, e.g. because the renewal was not possible in time
+	lease, err := k8sClient.CoordinationV1().Leases("keptn").Get(context.TODO(), "resource-service-lock-my-project", metav1.GetOptions{})
+	require.Nil(t, err)
+	otherHolder := "replica-2"
+	lease.Spec.HolderIdentity = &otherHolder
+	_, err = k8sClient.CoordinationV1().Leases("keptn").Update(context.TODO(), lease, metav1.UpdateOptions{})
+	require.Nil(t, err)
+
+	require.Eventually(t, func() bool {
+		return errors.Is(locker.CheckProjectLock("my-project"), kerrors.ErrProjectLockLost)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	locker.UnlockProject("my-project")
+	// the lease of the other replica must not be released
+	lease, err = k8sClient.CoordinationV1().Leases("keptn").Get(context.TODO(), "resource-service-lock-my-project", metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Equal(t, "replica-2", *lease.Spec.HolderIdentity)
+}
+
+func TestGetProjectLeaseName(t *testing.T) {
+	require.Equal(t, "resource-service-lock-my-project", GetProjectLeaseName("my-project"))
+
+	name := GetProjectLeaseName("My_Project")
+	require.True(t, strings.HasPrefix(name, "resource-service-lock-"))
+	require.Len(t, name, len("resource-service-lock-")+32)
+	require.NotEqual(t, name, GetProjectLeaseName("my_project"))
+}
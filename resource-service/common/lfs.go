@@ -0,0 +1,410 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+)
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+// ParseLFSPointer returns the pointer contained in the given file content, or nil if the content is not an LFS pointer
+func ParseLFSPointer(content []byte) *common_models.LFSPointer {
+	if len(content) > common_models.LFSPointerMaxSize || !bytes.HasPrefix(content, []byte("version "+common_models.LFSPointerVersion+"\n")) {
+		return nil
+	}
+	pointer := &common_models.LFSPointer{Size: -1}
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "oid":
+			pointer.OID = strings.TrimPrefix(value, "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil
+			}
+			pointer.Size = size
+		}
+	}
+	if !isValidLFSObjectID(pointer.OID) || pointer.Size < 0 {
+		return nil
+	}
+	return pointer
+}
+
+// ILFS stores files that are tracked by git LFS outside of the git repository of a project, and transfers them to and
+// from the LFS server of the upstream repository
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/lfs_mock.go . ILFS
+type ILFS interface {
+	IsTracked(gitContext common_models.GitContext, path string) bool
+	Store(gitContext common_models.GitContext, content io.Reader) (*common_models.LFSPointer, error)
+	Open(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error)
+	Upload(gitContext common_models.GitContext, pointers []common_models.LFSPointer) error
+}
+
+// LFS implements the basic transfer adapter of the git LFS batch API. Objects are kept in .git/lfs/objects of the project,
+// i.e. the same location that is used by the git LFS client
+type LFS struct{}
+
+func NewLFS() *LFS {
+	return &LFS{}
+}
+
+// IsTracked checks if the given path, relative to the root of the repository, is tracked by git LFS according to the
+// .gitattributes files of the currently checked out branch
+func (l LFS) IsTracked(gitContext common_models.GitContext, path string) bool {
+	patterns, err := gitattributes.ReadPatterns(osfs.New(GetProjectConfigPath(gitContext.Project)), nil)
+	if err != nil {
+		logger.Warnf("Could not read .gitattributes of project %s: %v", gitContext.Project, err)
+		return false
+	}
+	if len(patterns) == 0 {
+		return false
+	}
+	attributes, matched := gitattributes.NewMatcher(patterns).Match(strings.Split(path, "/"), []string{"filter"})
+	if !matched {
+		return false
+	}
+	filter, ok := attributes["filter"]
+	return ok && filter.IsValueSet() && filter.Value() == "lfs"
+}
+
+// Store writes the content to the local LFS storage of the project and returns the pointer to the object
+func (l LFS) Store(gitContext common_models.GitContext, content io.Reader) (*common_models.LFSPointer, error) {
+	tmpDir := filepath.Join(getLFSDir(gitContext.Project), "tmp")
+	if err := os.MkdirAll(tmpDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	tmpFile, err := ioutil.TempFile(tmpDir, "*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmpFile, hash), content)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, err
+	}
+
+	pointer := &common_models.LFSPointer{OID: hex.EncodeToString(hash.Sum(nil)), Size: size}
+	objectPath, err := getLFSObjectPath(gitContext.Project, pointer.OID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(objectPath), os.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpFile.Name(), objectPath); err != nil {
+		return nil, err
+	}
+	return pointer, nil
+}
+
+// Open returns the content of the referenced object. Objects that are not available locally are downloaded from the upstream
+func (l LFS) Open(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+	objectPath, err := getLFSObjectPath(gitContext.Project, pointer.OID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(objectPath); os.IsNotExist(err) {
+		if err := l.download(gitContext, pointer); err != nil {
+			return nil, fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, "download", pointer.OID, gitContext.Project, err)
+		}
+	}
+	return os.Open(objectPath)
+}
+
+// Upload transfers the referenced objects from the local LFS storage to the upstream. Objects that are already known
+// by the upstream are skipped. Only the requested objects are uploaded, regardless of the objects returned by the server
+func (l LFS) Upload(gitContext common_models.GitContext, pointers []common_models.LFSPointer) error {
+	if len(pointers) == 0 {
+		return nil
+	}
+	response, err := l.batch(gitContext, "upload", pointers)
+	if err != nil {
+		return fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, "upload", pointers[0].OID, gitContext.Project, err)
+	}
+	requested := map[string]int64{}
+	for _, pointer := range pointers {
+		requested[pointer.OID] = pointer.Size
+	}
+	for i, object := range response.Objects {
+		size, ok := requested[object.OID]
+		if !ok || !isValidLFSObjectID(object.OID) {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, "upload", object.OID, gitContext.Project, kerrors.ErrUnexpectedLFSObject)
+		}
+		// the size of the local object is sent, not the one claimed by the server
+		response.Objects[i].Size = size
+	}
+	for _, object := range response.Objects {
+		if err := l.uploadObject(gitContext, object); err != nil {
+			return fmt.Errorf(kerrors.ErrMsgCouldNotTransferLFSObject, "upload", object.OID, gitContext.Project, err)
+		}
+	}
+	return nil
+}
+
+func (l LFS) uploadObject(gitContext common_models.GitContext, object lfsBatchObject) error {
+	if object.Error != nil {
+		return fmt.Errorf("%d %s", object.Error.Code, object.Error.Message)
+	}
+	upload, ok := object.Actions["upload"]
+	if !ok {
+		// the object is already available in the upstream
+		return nil
+	}
+
+	objectPath, err := getLFSObjectPath(gitContext.Project, object.OID)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	req, err := upload.newRequest(http.MethodPut, file)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = object.Size
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if err := l.doRequest(gitContext, req, nil); err != nil {
+		return err
+	}
+
+	verify, ok := object.Actions["verify"]
+	if !ok {
+		return nil
+	}
+	payload, err := json.Marshal(lfsBatchObject{OID: object.OID, Size: object.Size})
+	if err != nil {
+		return err
+	}
+	req, err = verify.newRequest(http.MethodPost, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", lfsMediaType)
+	req.Header.Set("Accept", lfsMediaType)
+	return l.doRequest(gitContext, req, nil)
+}
+
+func (l LFS) download(gitContext common_models.GitContext, pointer common_models.LFSPointer) error {
+	response, err := l.batch(gitContext, "download", []common_models.LFSPointer{pointer})
+	if err != nil {
+		return err
+	}
+	if len(response.Objects) != 1 || response.Objects[0].OID != pointer.OID {
+		return kerrors.ErrLFSObjectNotFound
+	}
+	object := response.Objects[0]
+	if object.Error != nil {
+		if object.Error.Code == http.StatusNotFound {
+			return kerrors.ErrLFSObjectNotFound
+		}
+		return fmt.Errorf("%d %s", object.Error.Code, object.Error.Message)
+	}
+	download, ok := object.Actions["download"]
+	if !ok {
+		return kerrors.ErrLFSObjectNotFound
+	}
+
+	req, err := download.newRequest(http.MethodGet, nil)
+	if err != nil {
+		return err
+	}
+	var stored *common_models.LFSPointer
+	err = l.doRequest(gitContext, req, func(body io.Reader) error {
+		stored, err = l.Store(gitContext, body)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if *stored != pointer {
+		if storedPath, err := getLFSObjectPath(gitContext.Project, stored.OID); err == nil {
+			_ = os.Remove(storedPath)
+		}
+		return kerrors.ErrInvalidLFSObject
+	}
+	return nil
+}
+
+type lfsBatchRequest struct {
+	Operation string           `json:"operation"`
+	Transfers []string         `json:"transfers"`
+	Objects   []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	OID     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsObjectError      `json:"error,omitempty"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+func (a lfsAction) newRequest(method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, a.Href, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range a.Header {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (l LFS) batch(gitContext common_models.GitContext, operation string, pointers []common_models.LFSPointer) (*lfsBatchResponse, error) {
+	endpoint, err := getLFSEndpoint(gitContext.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	request := lfsBatchRequest{Operation: operation, Transfers: []string{"basic"}}
+	for _, pointer := range pointers {
+		request.Objects = append(request.Objects, lfsBatchObject{OID: pointer.OID, Size: pointer.Size})
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint+"/objects/batch", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", lfsMediaType)
+	req.Header.Set("Accept", lfsMediaType)
+	req.SetBasicAuth(getLFSUser(gitContext.Credentials), gitContext.Credentials.HttpsAuth.Token)
+
+	response := &lfsBatchResponse{}
+	err = l.doRequest(gitContext, req, func(body io.Reader) error {
+		return json.NewDecoder(body).Decode(response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (l LFS) doRequest(gitContext common_models.GitContext, req *http.Request, handleBody func(body io.Reader) error) error {
+	resp, err := getLFSHTTPClient(gitContext.Credentials).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return kerrors.ErrAuthenticationRequired
+	case resp.StatusCode == http.StatusForbidden:
+		return kerrors.ErrAuthorizationFailed
+	case resp.StatusCode == http.StatusNotFound:
+		return kerrors.ErrLFSObjectNotFound
+	case resp.StatusCode >= 300:
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	}
+	if handleBody == nil {
+		return nil
+	}
+	return handleBody(resp.Body)
+}
+
+// getLFSEndpoint derives the URL of the LFS server from the remote URL in the same way as the git LFS client
+func getLFSEndpoint(credentials *common_models.GitCredentials) (string, error) {
+	if credentials == nil || credentials.HttpsAuth == nil || strings.HasPrefix(credentials.RemoteURL, "ssh://") {
+		return "", kerrors.ErrLFSRequiresHTTPCredentials
+	}
+	endpoint := strings.TrimSuffix(credentials.RemoteURL, "/")
+	if strings.HasSuffix(endpoint, ".git") {
+		return endpoint + "/info/lfs", nil
+	}
+	return endpoint + ".git/info/lfs", nil
+}
+
+func getLFSUser(credentials *common_models.GitCredentials) string {
+	if credentials.User == "" {
+		return "keptnuser"
+	}
+	return credentials.User
+}
+
+func getLFSHTTPClient(credentials *common_models.GitCredentials) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if credentials != nil && credentials.HttpsAuth != nil {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: credentials.HttpsAuth.InsecureSkipTLS}
+		if proxy := credentials.HttpsAuth.Proxy; proxy != nil {
+			transport.Proxy = http.ProxyURL(&url.URL{
+				Scheme: proxy.Scheme,
+				User:   url.UserPassword(proxy.User, proxy.Password),
+				Host:   proxy.URL,
+			})
+		}
+	}
+	// objects can be large, therefore only the time until the response headers have been received is limited
+	transport.ResponseHeaderTimeout = 30 * time.Second
+	return &http.Client{Transport: transport}
+}
+
+func getLFSDir(project string) string {
+	return filepath.Join(GetProjectConfigPath(project), ".git", "lfs")
+}
+
+// getLFSObjectPath returns the path of the object in the local LFS storage. The object ID is validated, since it is part of the path
+func getLFSObjectPath(project string, oid string) (string, error) {
+	if !isValidLFSObjectID(oid) {
+		return "", kerrors.ErrInvalidLFSObject
+	}
+	return filepath.Join(getLFSDir(project), "objects", oid[0:2], oid[2:4], oid), nil
+}
+
+func isValidLFSObjectID(oid string) bool {
+	if len(oid) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(oid)
+	return err == nil
+}
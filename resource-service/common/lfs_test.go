@@ -0,0 +1,249 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const lfsTestContent = "my large file"
+
+// fakeLFSServer implements the parts of the git LFS batch API that are used by LFS
+type fakeLFSServer struct {
+	*httptest.Server
+	mutex   sync.Mutex
+	objects map[string][]byte
+	// unexpectedObjects are returned in addition to the requested objects
+	unexpectedObjects []lfsBatchObject
+}
+
+func newFakeLFSServer(t *testing.T) *fakeLFSServer {
+	server := &fakeLFSServer{objects: map[string][]byte{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/my-repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "user" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		request := lfsBatchRequest{}
+		require.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		response := lfsBatchResponse{}
+		for _, object := range request.Objects {
+			href := lfsAction{Href: server.URL + "/objects/" + object.OID}
+			_, exists := server.objects[object.OID]
+			switch {
+			case request.Operation == "upload" && !exists:
+				object.Actions = map[string]lfsAction{"upload": href}
+			case request.Operation == "download" && exists:
+				object.Actions = map[string]lfsAction{"download": href}
+			case request.Operation == "download":
+				object.Error = &lfsObjectError{Code: http.StatusNotFound, Message: "object does not exist"}
+			}
+			response.Objects = append(response.Objects, object)
+		}
+		response.Objects = append(response.Objects, server.unexpectedObjects...)
+		w.Header().Set("Content-Type", lfsMediaType)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+	mux.HandleFunc("/objects/", func(w http.ResponseWriter, r *http.Request) {
+		oid := strings.TrimPrefix(r.URL.Path, "/objects/")
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		if r.Method == http.MethodPut {
+			content, _ := ioutil.ReadAll(r.Body)
+			server.objects[oid] = content
+			return
+		}
+		_, _ = w.Write(server.objects[oid])
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getLFSTestContext(t *testing.T, remoteURL string) common_models.GitContext {
+	t.Setenv("CONFIG_DIR", t.TempDir())
+	return common_models.GitContext{
+		Project: "my-project",
+		Credentials: &common_models.GitCredentials{
+			User:      "user",
+			HttpsAuth: &apimodels.HttpsGitAuth{Token: "token"},
+			RemoteURL: remoteURL,
+		},
+	}
+}
+
+func getLFSTestOID(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestParseLFSPointer(t *testing.T) {
+	oid := getLFSTestOID(lfsTestContent)
+	tests := []struct {
+		name    string
+		content string
+		want    *common_models.LFSPointer
+	}{
+		{
+			name:    "valid pointer",
+			content: common_models.LFSPointer{OID: oid, Size: 13}.String(),
+			want:    &common_models.LFSPointer{OID: oid, Size: 13},
+		},
+		{
+			name:    "regular file",
+			content: lfsTestContent,
+		},
+		{
+			name:    "invalid oid",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 13\n",
+		},
+		{
+			name:    "missing size",
+			content: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ParseLFSPointer([]byte(tt.content)))
+		})
+	}
+}
+
+func TestLFS_IsTracked(t *testing.T) {
+	gitContext := getLFSTestContext(t, "")
+	projectPath := GetProjectConfigPath(gitContext.Project)
+	require.Nil(t, os.MkdirAll(filepath.Join(projectPath, "carts"), os.ModePerm))
+
+	lfs := NewLFS()
+	require.False(t, lfs.IsTracked(gitContext, "carts/model.bin"))
+
+	require.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, ".gitattributes"), []byte("*.bin filter=lfs diff=lfs merge=lfs -text\n"), 0600))
+	require.True(t, lfs.IsTracked(gitContext, "carts/model.bin"))
+	require.False(t, lfs.IsTracked(gitContext, "carts/values.yaml"))
+}
+
+func TestLFS_StoreAndOpen(t *testing.T) {
+	gitContext := getLFSTestContext(t, "")
+	lfs := NewLFS()
+
+	pointer, err := lfs.Store(gitContext, strings.NewReader(lfsTestContent))
+	require.Nil(t, err)
+	require.Equal(t, &common_models.LFSPointer{OID: getLFSTestOID(lfsTestContent), Size: int64(len(lfsTestContent))}, pointer)
+
+	content, err := lfs.Open(gitContext, *pointer)
+	require.Nil(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, lfsTestContent, string(data))
+}
+
+func TestLFS_UploadAndDownload(t *testing.T) {
+	server := newFakeLFSServer(t)
+	gitContext := getLFSTestContext(t, server.URL+"/my-repo")
+	lfs := NewLFS()
+
+	pointer, err := lfs.Store(gitContext, strings.NewReader(lfsTestContent))
+	require.Nil(t, err)
+	require.Nil(t, lfs.Upload(gitContext, []common_models.LFSPointer{*pointer}))
+	require.Equal(t, lfsTestContent, string(server.objects[pointer.OID]))
+
+	// objects that are already known by the upstream are not uploaded again
+	require.Nil(t, lfs.Upload(gitContext, []common_models.LFSPointer{*pointer}))
+
+	// a fresh clone of the project does not contain the object yet
+	require.Nil(t, os.RemoveAll(getLFSDir(gitContext.Project)))
+	content, err := lfs.Open(gitContext, *pointer)
+	require.Nil(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.Nil(t, err)
+	require.Equal(t, lfsTestContent, string(data))
+}
+
+func TestLFS_Upload_UnexpectedObject(t *testing.T) {
+	tests := []struct {
+		name string
+		oid  string
+	}{
+		{
+			name: "invalid object id",
+			oid:  "../../../../etc/passwd",
+		},
+		{
+			name: "object that has not been requested",
+			oid:  getLFSTestOID("other content"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeLFSServer(t)
+			server.unexpectedObjects = []lfsBatchObject{{
+				OID:     tt.oid,
+				Size:    13,
+				Actions: map[string]lfsAction{"upload": {Href: server.URL + "/objects/unexpected"}},
+			}}
+			gitContext := getLFSTestContext(t, server.URL+"/my-repo")
+			lfs := NewLFS()
+
+			pointer, err := lfs.Store(gitContext, strings.NewReader(lfsTestContent))
+			require.Nil(t, err)
+			require.ErrorIs(t, lfs.Upload(gitContext, []common_models.LFSPointer{*pointer}), kerrors.ErrUnexpectedLFSObject)
+			// nothing is uploaded if the response contains unexpected objects
+			require.Empty(t, server.objects)
+		})
+	}
+}
+
+func TestLFS_Open_InvalidObjectID(t *testing.T) {
+	gitContext := getLFSTestContext(t, "https://my-host/my-repo.git")
+
+	_, err := NewLFS().Open(gitContext, common_models.LFSPointer{OID: "../../config", Size: 13})
+	require.ErrorIs(t, err, kerrors.ErrInvalidLFSObject)
+}
+
+func TestLFS_Download_ObjectNotFound(t *testing.T) {
+	server := newFakeLFSServer(t)
+	gitContext := getLFSTestContext(t, server.URL+"/my-repo.git")
+
+	_, err := NewLFS().Open(gitContext, common_models.LFSPointer{OID: getLFSTestOID(lfsTestContent), Size: 13})
+	require.ErrorIs(t, err, kerrors.ErrLFSObjectNotFound)
+}
+
+func TestLFS_Upload_InvalidCredentials(t *testing.T) {
+	server := newFakeLFSServer(t)
+	gitContext := getLFSTestContext(t, server.URL+"/my-repo")
+	gitContext.Credentials.HttpsAuth.Token = "invalid"
+	lfs := NewLFS()
+
+	pointer, err := lfs.Store(gitContext, strings.NewReader(lfsTestContent))
+	require.Nil(t, err)
+	require.ErrorIs(t, lfs.Upload(gitContext, []common_models.LFSPointer{*pointer}), kerrors.ErrAuthenticationRequired)
+}
+
+func TestLFS_Upload_SSHCredentials(t *testing.T) {
+	gitContext := getLFSTestContext(t, "ssh://git@my-host/my-repo.git")
+	gitContext.Credentials.HttpsAuth = nil
+	gitContext.Credentials.SshAuth = &apimodels.SshGitAuth{PrivateKey: "key"}
+
+	err := NewLFS().Upload(gitContext, []common_models.LFSPointer{{OID: getLFSTestOID(lfsTestContent), Size: 13}})
+	require.ErrorIs(t, err, kerrors.ErrLFSRequiresHTTPCredentials)
+}
@@ -6,6 +6,22 @@ var mutex = &sync.Mutex{}
 
 var projectLocks = map[string]*sync.Mutex{}
 
+// ProjectLocker serializes the operations on the git repository of a project
+type ProjectLocker interface {
+	LockProject(project string) error
+	UnlockProject(project string)
+	// CheckProjectLock returns an error if the lock of the project, which is held by the caller, has been lost in the meantime
+	CheckProjectLock(project string) error
+}
+
+var projectLocker ProjectLocker = LocalProjectLocker{}
+
+// SetProjectLocker replaces the locker used by LockProject and UnlockProject. This is required if multiple
+// replicas of the resource-service are running
+func SetProjectLocker(locker ProjectLocker) {
+	projectLocker = locker
+}
+
 // Lock locks the mutex
 func Lock() {
 	mutex.Lock()
@@ -17,20 +33,41 @@ func Unlock() {
 }
 
 // LockProject locks the given project
-func LockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
-	}
-	projectLocks[project].Lock()
+func LockProject(project string) error {
+	return projectLocker.LockProject(project)
 }
 
 func UnlockProject(project string) {
+	projectLocker.UnlockProject(project)
+}
+
+// CheckProjectLock must be called before changes of the project are pushed to the upstream, to not overwrite the changes
+// of another replica that has taken over the lock
+func CheckProjectLock(project string) error {
+	return projectLocker.CheckProjectLock(project)
+}
+
+// LocalProjectLocker locks projects within the current process only
+type LocalProjectLocker struct{}
+
+func (LocalProjectLocker) LockProject(project string) error {
+	getLocalProjectLock(project).Lock()
+	return nil
+}
+
+func (LocalProjectLocker) UnlockProject(project string) {
+	getLocalProjectLock(project).Unlock()
+}
+
+func (LocalProjectLocker) CheckProjectLock(project string) error {
+	return nil
+}
+
+func getLocalProjectLock(project string) *sync.Mutex {
+	Lock()
+	defer Unlock()
 	if projectLocks[project] == nil {
-		Lock()
 		projectLocks[project] = &sync.Mutex{}
-		Unlock()
 	}
-	projectLocks[project].Unlock()
+	return projectLocks[project]
 }
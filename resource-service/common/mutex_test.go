@@ -6,7 +6,7 @@ import (
 )
 
 func TestLockProject(t *testing.T) {
-	LockProject("my-project")
+	require.Nil(t, LockProject("my-project"))
 	require.NotNil(t, projectLocks["my-project"])
 	UnlockProject("my-project")
 }
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// VariablesFileName is the name of the files containing the variables of a project, stage or service
+const VariablesFileName = "variables.yaml"
+
+// RenderContextKey is the name of the variable containing the project, stage and service of a rendered resource
+const RenderContextKey = "keptn"
+
+// templates are rendered with different delimiters than Helm charts and webhook configurations, which may contain
+// placeholders of their own
+const templateLeftDelimiter = "${{"
+const templateRightDelimiter = "}}"
+
+// MergeVariables parses the given variable files and merges them, with later files overriding the values of earlier ones.
+// Nested maps are merged recursively, while all other values are replaced
+func MergeVariables(files ...[]byte) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for _, file := range files {
+		variables := map[string]interface{}{}
+		if err := yaml.Unmarshal(file, &variables); err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidVariables, err)
+		}
+		mergeVariables(result, variables)
+	}
+	return result, nil
+}
+
+func mergeVariables(target map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		sourceMap, sourceIsMap := value.(map[string]interface{})
+		targetMap, targetIsMap := target[key].(map[string]interface{})
+		if sourceIsMap && targetIsMap {
+			mergeVariables(targetMap, sourceMap)
+			continue
+		}
+		target[key] = value
+	}
+}
+
+// RenderResource executes the given template with the variables. Referencing a variable that has not been defined is an error
+func RenderResource(name string, content []byte, variables map[string]interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Delims(templateLeftDelimiter, templateRightDelimiter).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrRenderResource, err)
+	}
+	result := &bytes.Buffer{}
+	if err := tmpl.Execute(result, variables); err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrRenderResource, err)
+	}
+	return result.Bytes(), nil
+}
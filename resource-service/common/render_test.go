@@ -0,0 +1,69 @@
+package common
+
+import (
+	"testing"
+
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeVariables(t *testing.T) {
+	project := []byte("replicas: 1\nimage:\n  name: carts\n  tag: 0.1.0\nhosts:\n  - carts.local\n")
+	stage := []byte("replicas: 3\nimage:\n  tag: 0.2.0\nhosts:\n  - carts.prod\n")
+	service := []byte("image:\n  pullPolicy: Always\n")
+
+	variables, err := MergeVariables(project, stage, service)
+	require.Nil(t, err)
+	require.Equal(t, map[string]interface{}{
+		"replicas": 3,
+		"image": map[string]interface{}{
+			"name":       "carts",
+			"tag":        "0.2.0",
+			"pullPolicy": "Always",
+		},
+		"hosts": []interface{}{"carts.prod"},
+	}, variables)
+}
+
+func TestMergeVariables_Empty(t *testing.T) {
+	variables, err := MergeVariables([]byte(""))
+	require.Nil(t, err)
+	require.Empty(t, variables)
+}
+
+func TestMergeVariables_Invalid(t *testing.T) {
+	_, err := MergeVariables([]byte("replicas: 1"), []byte("- not a map"))
+	require.ErrorIs(t, err, kerrors.ErrInvalidVariables)
+}
+
+func TestRenderResource(t *testing.T) {
+	content := []byte(`replicas: ${{ .replicas }}
+image: ${{ .image.name }}:${{ .image.tag }}
+stage: ${{ .keptn.stage }}
+url: "https://example.com/{{.data.project}}/{{.data.stage}}"
+`)
+	variables := map[string]interface{}{
+		"replicas": 3,
+		"image":    map[string]interface{}{"name": "carts", "tag": "0.2.0"},
+		"keptn":    map[string]interface{}{"project": "sockshop", "stage": "production", "service": "carts"},
+	}
+
+	rendered, err := RenderResource("values.yaml", content, variables)
+	require.Nil(t, err)
+	// placeholders of webhooks use other delimiters, and are therefore kept
+	require.Equal(t, `replicas: 3
+image: carts:0.2.0
+stage: production
+url: "https://example.com/{{.data.project}}/{{.data.stage}}"
+`, string(rendered))
+}
+
+func TestRenderResource_MissingVariable(t *testing.T) {
+	_, err := RenderResource("values.yaml", []byte("replicas: ${{ .replicas }}"), map[string]interface{}{})
+	require.ErrorIs(t, err, kerrors.ErrRenderResource)
+}
+
+func TestRenderResource_InvalidTemplate(t *testing.T) {
+	_, err := RenderResource("values.yaml", []byte("replicas: ${{ .replicas "), map[string]interface{}{"replicas": 1})
+	require.ErrorIs(t, err, kerrors.ErrRenderResource)
+}
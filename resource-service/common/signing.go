@@ -0,0 +1,235 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	logger "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const gitSigningKeySecretPrefix = "git-signing-key-"
+
+const (
+	gitSigningKeyFormatKey     = "format"
+	gitSigningKeyPrivateKeyKey = "privateKey"
+	gitSigningKeyPassphraseKey = "passphrase"
+)
+
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureVersion   = 1
+	sshSignatureNamespace = "git"
+	sshSignatureHash      = "sha512"
+	sshSignatureLineWidth = 70
+)
+
+// GitSigningKeyReader provides the key used to sign the commits of a project
+//
+//go:generate moq -pkg common_mock -skip-ensure -out ./fake/git_signing_key_reader_mock.go . GitSigningKeyReader
+type GitSigningKeyReader interface {
+	GetSigningKey(project string) (*common_models.GitSigningKey, error)
+}
+
+// K8sGitSigningKeyReader reads the signing key of a project from the 'git-signing-key-<project>' secret.
+// Commits of projects without such a secret are not signed
+type K8sGitSigningKeyReader struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewK8sGitSigningKeyReader(k8sClient kubernetes.Interface) *K8sGitSigningKeyReader {
+	return &K8sGitSigningKeyReader{k8sClient: k8sClient}
+}
+
+func (r K8sGitSigningKeyReader) GetSigningKey(project string) (*common_models.GitSigningKey, error) {
+	secretName := GetGitSigningKeySecretName(project)
+	secret, err := r.k8sClient.CoreV1().Secrets(GetKeptnNamespace()).Get(context.TODO(), secretName, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		// signing commits is opt-in
+		return nil, nil
+	}
+	if err != nil {
+		logger.Debugf("Could not retrieve signing key named: %s, error: %s", secretName, err.Error())
+		return nil, err
+	}
+
+	key := &common_models.GitSigningKey{
+		Format:     string(secret.Data[gitSigningKeyFormatKey]),
+		PrivateKey: string(secret.Data[gitSigningKeyPrivateKeyKey]),
+		Passphrase: string(secret.Data[gitSigningKeyPassphraseKey]),
+	}
+	if key.Format == "" {
+		key.Format = common_models.GitSigningFormatGPG
+	}
+	if key.PrivateKey == "" {
+		return nil, kerrors.ErrInvalidGitSigningKey
+	}
+	return key, nil
+}
+
+func GetGitSigningKeySecretName(projectName string) string {
+	return fmt.Sprintf("%s%s", gitSigningKeySecretPrefix, projectName)
+}
+
+// commitSigner creates the armored signature that is stored in the header of a signed commit
+type commitSigner func(message io.Reader) (string, error)
+
+// newCommitSigner parses the given key and returns a signer for the format of the key
+func newCommitSigner(key common_models.GitSigningKey) (commitSigner, error) {
+	switch key.Format {
+	case common_models.GitSigningFormatGPG:
+		return newGPGCommitSigner(key)
+	case common_models.GitSigningFormatSSH:
+		return newSSHCommitSigner(key)
+	default:
+		return nil, fmt.Errorf("%w: %s", kerrors.ErrUnknownGitSigningFormat, key.Format)
+	}
+}
+
+func newGPGCommitSigner(key common_models.GitSigningKey) (commitSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.PrivateKey))
+	if err != nil || len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, kerrors.ErrInvalidGitSigningKey
+	}
+	entity := entities[0]
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt([]byte(key.Passphrase)); err != nil {
+			return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidGitSigningKey, err)
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(key.Passphrase)); err != nil {
+				return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidGitSigningKey, err)
+			}
+		}
+	}
+
+	return func(message io.Reader) (string, error) {
+		signature := &bytes.Buffer{}
+		if err := openpgp.ArmoredDetachSign(signature, entity, message, nil); err != nil {
+			return "", err
+		}
+		return signature.String(), nil
+	}, nil
+}
+
+func newSSHCommitSigner(key common_models.GitSigningKey) (commitSigner, error) {
+	var signer ssh.Signer
+	var err error
+	if key.Passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key.PrivateKey), []byte(key.Passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(key.PrivateKey))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", kerrors.ErrInvalidGitSigningKey, err)
+	}
+
+	return func(message io.Reader) (string, error) {
+		return signSSH(signer, message)
+	}, nil
+}
+
+// signSSH creates a signature in the format of 'ssh-keygen -Y sign -n git', which is verified by git for commits signed
+// with gpg.format=ssh
+func signSSH(signer ssh.Signer, message io.Reader) (string, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, message); err != nil {
+		return "", err
+	}
+
+	signedData := append([]byte(sshSignatureMagic), ssh.Marshal(struct {
+		Namespace string
+		Reserved  string
+		Hash      string
+		Message   []byte
+	}{sshSignatureNamespace, "", sshSignatureHash, hash.Sum(nil)})...)
+
+	var signature *ssh.Signature
+	var err error
+	if algorithmSigner, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		// SHA-1 based RSA signatures are rejected by ssh-keygen
+		signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, signedData, ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.Sign(rand.Reader, signedData)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	blob := append([]byte(sshSignatureMagic), ssh.Marshal(struct {
+		Version   uint32
+		PublicKey []byte
+		Namespace string
+		Reserved  string
+		Hash      string
+		Signature []byte
+	}{sshSignatureVersion, signer.PublicKey().Marshal(), sshSignatureNamespace, "", sshSignatureHash, ssh.Marshal(signature)})...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	armored := &strings.Builder{}
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > sshSignatureLineWidth {
+		armored.WriteString(encoded[:sshSignatureLineWidth] + "\n")
+		encoded = encoded[sshSignatureLineWidth:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString("-----END SSH SIGNATURE-----\n")
+	return armored.String(), nil
+}
+
+// signCommit replaces the commit with the given hash, which must be the current HEAD, by a signed copy of it
+func signCommit(repo *git.Repository, hash plumbing.Hash, sign commitSigner) (plumbing.Hash, error) {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	unsigned := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(unsigned); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	reader, err := unsigned.Reader()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	defer reader.Close()
+
+	commit.PGPSignature, err = sign(reader)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signed := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(signed); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	signedHash, err := repo.Storer.SetEncodedObject(signed)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), signedHash)); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return signedHash, nil
+}
@@ -0,0 +1,154 @@
+package common
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/keptn/keptn/resource-service/common_models"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const signingTestMessage = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nmy commit\n"
+
+// newTestGPGKey returns the armored private and public key of a new GPG key pair
+func newTestGPGKey(t require.TestingT) (string, string) {
+	entity, err := openpgp.NewEntity("keptn", "", "keptn@keptn.sh", nil)
+	require.Nil(t, err)
+
+	privateKey := &bytes.Buffer{}
+	w, err := armor.Encode(privateKey, openpgp.PrivateKeyType, nil)
+	require.Nil(t, err)
+	require.Nil(t, entity.SerializePrivate(w, nil))
+	require.Nil(t, w.Close())
+
+	publicKey := &bytes.Buffer{}
+	w, err = armor.Encode(publicKey, openpgp.PublicKeyType, nil)
+	require.Nil(t, err)
+	require.Nil(t, entity.Serialize(w))
+	require.Nil(t, w.Close())
+
+	return privateKey.String(), publicKey.String()
+}
+
+func newTestSSHKey(t *testing.T) (string, ssh.PublicKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err)
+	block, err := ssh.MarshalPrivateKey(privateKey, "keptn")
+	require.Nil(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(block)), sshPublicKey
+}
+
+func TestK8sGitSigningKeyReader_GetSigningKey(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	reader := NewK8sGitSigningKeyReader(fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GetGitSigningKeySecretName("my-project"), Namespace: "keptn"},
+			Data:       map[string][]byte{"format": []byte("ssh"), "privateKey": []byte("my-key"), "passphrase": []byte("my-passphrase")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GetGitSigningKeySecretName("gpg-project"), Namespace: "keptn"},
+			Data:       map[string][]byte{"privateKey": []byte("my-key")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: GetGitSigningKeySecretName("empty-project"), Namespace: "keptn"},
+			Data:       map[string][]byte{},
+		},
+	))
+
+	key, err := reader.GetSigningKey("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.GitSigningKey{Format: "ssh", PrivateKey: "my-key", Passphrase: "my-passphrase"}, key)
+
+	key, err = reader.GetSigningKey("gpg-project")
+	require.Nil(t, err)
+	require.Equal(t, &common_models.GitSigningKey{Format: "gpg", PrivateKey: "my-key"}, key)
+
+	_, err = reader.GetSigningKey("empty-project")
+	require.ErrorIs(t, err, kerrors.ErrInvalidGitSigningKey)
+
+	key, err = reader.GetSigningKey("other-project")
+	require.Nil(t, err)
+	require.Nil(t, key)
+}
+
+func TestNewCommitSigner_GPG(t *testing.T) {
+	privateKey, publicKey := newTestGPGKey(t)
+
+	sign, err := newCommitSigner(common_models.GitSigningKey{Format: "gpg", PrivateKey: privateKey})
+	require.Nil(t, err)
+	signature, err := sign(strings.NewReader(signingTestMessage))
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(signature, "-----BEGIN PGP SIGNATURE-----"))
+
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	require.Nil(t, err)
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing, strings.NewReader(signingTestMessage), strings.NewReader(signature), nil)
+	require.Nil(t, err)
+}
+
+func TestNewCommitSigner_SSH(t *testing.T) {
+	privateKey, publicKey := newTestSSHKey(t)
+
+	sign, err := newCommitSigner(common_models.GitSigningKey{Format: "ssh", PrivateKey: privateKey})
+	require.Nil(t, err)
+	armored, err := sign(strings.NewReader(signingTestMessage))
+	require.Nil(t, err)
+	require.True(t, strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----\n"))
+	require.True(t, strings.HasSuffix(armored, "-----END SSH SIGNATURE-----\n"))
+
+	encoded := strings.TrimPrefix(strings.TrimSuffix(armored, "-----END SSH SIGNATURE-----\n"), "-----BEGIN SSH SIGNATURE-----\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	require.Nil(t, err)
+	require.Equal(t, "SSHSIG", string(blob[:6]))
+
+	sshSig := struct {
+		Version   uint32
+		PublicKey []byte
+		Namespace string
+		Reserved  string
+		Hash      string
+		Signature []byte
+	}{}
+	require.Nil(t, ssh.Unmarshal(blob[6:], &sshSig))
+	require.Equal(t, uint32(1), sshSig.Version)
+	require.Equal(t, publicKey.Marshal(), sshSig.PublicKey)
+	require.Equal(t, "git", sshSig.Namespace)
+	require.Equal(t, "sha512", sshSig.Hash)
+
+	signature := &ssh.Signature{}
+	require.Nil(t, ssh.Unmarshal(sshSig.Signature, signature))
+	hash := sha512.Sum512([]byte(signingTestMessage))
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace string
+		Reserved  string
+		Hash      string
+		Message   []byte
+	}{"git", "", "sha512", hash[:]})...)
+	require.Nil(t, publicKey.Verify(signedData, signature))
+}
+
+func TestNewCommitSigner_InvalidKey(t *testing.T) {
+	_, err := newCommitSigner(common_models.GitSigningKey{Format: "x509", PrivateKey: "my-key"})
+	require.ErrorIs(t, err, kerrors.ErrUnknownGitSigningFormat)
+
+	_, err = newCommitSigner(common_models.GitSigningKey{Format: "gpg", PrivateKey: "my-key"})
+	require.ErrorIs(t, err, kerrors.ErrInvalidGitSigningKey)
+
+	_, err = newCommitSigner(common_models.GitSigningKey{Format: "ssh", PrivateKey: "my-key"})
+	require.ErrorIs(t, err, kerrors.ErrInvalidGitSigningKey)
+}
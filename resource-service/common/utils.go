@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"os"
+	"path"
+	"strings"
 )
 
 const StageDirectoryName = ".keptn-stages"
@@ -30,3 +32,38 @@ func ensureDirectoryExists(path string) error {
 	}
 	return nil
 }
+
+// MatchesGlob checks whether the given slash-separated path, or one of its parent directories, matches the glob pattern.
+// In addition to the syntax of path.Match, a path segment consisting of "**" matches any number of directories
+func MatchesGlob(pattern string, name string) bool {
+	nameSegments := strings.Split(name, "/")
+	patternSegments := strings.Split(pattern, "/")
+	for i := 1; i <= len(nameSegments); i++ {
+		if matchesGlobSegments(patternSegments, nameSegments[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesGlobSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchesGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if matches, err := path.Match(pattern[0], name[0]); err != nil || !matches {
+			return false
+		}
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+	return len(name) == 0
+}
@@ -81,3 +81,31 @@ func TestGetServiceConfigPath(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*.yaml", name: "slo.yaml", want: true},
+		{pattern: "*.yaml", name: "helm/values.yaml", want: false},
+		{pattern: "helm", name: "helm/carts/values.yaml", want: true},
+		{pattern: "helm/*", name: "helm/carts/values.yaml", want: true},
+		{pattern: "helm/*.tgz", name: "helm/carts.tgz", want: true},
+		{pattern: "helm/*.tgz", name: "helm/carts/values.yaml", want: false},
+		{pattern: "**/*.yaml", name: "helm/carts/values.yaml", want: true},
+		{pattern: "**/*.yaml", name: "slo.yaml", want: true},
+		{pattern: "helm/**/values.yaml", name: "helm/values.yaml", want: true},
+		{pattern: "helm/**/values.yaml", name: "helm/carts/templates/values.yaml", want: true},
+		{pattern: "helm/**/values.yaml", name: "jmeter/values.yaml", want: false},
+		{pattern: "[", name: "slo.yaml", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			if got := MatchesGlob(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("MatchesGlob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package common_models
 
 import (
+	"fmt"
 	git2go "github.com/libgit2/git2go/v34"
 	"net/url"
 	"strings"
+	"time"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
 
@@ -31,6 +33,70 @@ type GitContext struct {
 	Project     string
 	Credentials *GitCredentials
 	AuthMethod  AuthMethod
+	// ChangeRequest is set if changes must not be pushed to the upstream branch directly, but via a change request
+	ChangeRequest *ChangeRequestSettings
+	// Author is recorded as author of the commits, while Keptn is recorded as committer. Commits are authored by Keptn if not set
+	Author *GitAuthor
+}
+
+// GitAuthor identifies the principal a commit is attributed to
+type GitAuthor struct {
+	Name  string
+	Email string
+}
+
+const (
+	GitSigningFormatGPG = "gpg"
+	GitSigningFormatSSH = "ssh"
+)
+
+// GitSigningKey is the private key used to sign the commits of a project
+type GitSigningKey struct {
+	// Format is either gpg or ssh
+	Format     string
+	PrivateKey string
+	Passphrase string
+}
+
+// ChangeRequestSettings enables the pull-request based change flow for the upstream repository of a project
+type ChangeRequestSettings struct {
+	// Provider is the name of the provider used to open change requests, e.g. github, gitlab or gitea
+	Provider string `json:"provider"`
+	// APIURL is the base URL of the API of the git hosting service. Defaults to the public instance of the provider
+	APIURL string `json:"apiURL,omitempty"`
+	// Repository identifies the repository within the git hosting service, e.g. <owner>/<name>
+	Repository string `json:"repository"`
+	// Token used to access the API. Defaults to the token of the upstream credentials
+	Token string `json:"token,omitempty"`
+	// BranchPrefix is prepended to the names of the feature branches. Defaults to "keptn/"
+	BranchPrefix string `json:"branchPrefix,omitempty"`
+	// WaitForMerge is the maximum duration requests that change resources wait for their change request to be merged, e.g. "30m".
+	// Requests return as soon as the change request has been opened if not set
+	WaitForMerge string `json:"waitForMerge,omitempty"`
+}
+
+func (s ChangeRequestSettings) Validate() error {
+	if s.Provider == "" {
+		return kerrors.ErrUnknownChangeRequestProvider
+	}
+	if s.Repository == "" {
+		return kerrors.ErrChangeRequestRepositoryMustNotBeEmpty
+	}
+	if s.WaitForMerge != "" {
+		if waitForMerge, err := time.ParseDuration(s.WaitForMerge); err != nil || waitForMerge <= 0 {
+			return kerrors.ErrInvalidChangeRequestWaitForMerge
+		}
+	}
+	return nil
+}
+
+// GetWaitForMerge returns the maximum duration to wait for change requests to be merged, or 0 if changes should not wait for the merge
+func (s ChangeRequestSettings) GetWaitForMerge() time.Duration {
+	waitForMerge, err := time.ParseDuration(s.WaitForMerge)
+	if err != nil {
+		return 0
+	}
+	return waitForMerge
 }
 
 func (g GitCredentials) Validate() error {
@@ -73,3 +139,42 @@ func (g GitCredentials) validateRemoteURLAndToken() error {
 	}
 	return nil
 }
+
+// GitCommit contains the metadata of a commit in the git repository of a project
+type GitCommit struct {
+	CommitID       string
+	AuthorName     string
+	AuthorEmail    string
+	CommitterName  string
+	CommitterEmail string
+	Message        string
+	Timestamp      time.Time
+}
+
+// GitPushEvent contains the information of a push to the upstream repository of a project that has been received via a webhook
+type GitPushEvent struct {
+	// Branch that has been pushed to
+	Branch string
+	// Before is the revision of the branch before the push
+	Before string
+	// After is the revision of the branch after the push
+	After string
+	// Paths contains the paths of all files that have been added, modified or removed by the pushed commits
+	Paths []string
+}
+
+// LFSPointerVersion is the version of the git LFS specification that is written to pointer files
+const LFSPointerVersion = "https://git-lfs.github.com/spec/v1"
+
+// LFSPointerMaxSize is the maximum size of a pointer file according to the specification of git LFS
+const LFSPointerMaxSize = 1024
+
+// LFSPointer references a file whose content is stored in the LFS storage of the upstream instead of the git repository
+type LFSPointer struct {
+	OID  string
+	Size int64
+}
+
+func (p LFSPointer) String() string {
+	return fmt.Sprintf("version %s\noid sha256:%s\nsize %d\n", LFSPointerVersion, p.OID, p.Size)
+}
@@ -7,10 +7,38 @@ import (
 
 var Global EnvConfig
 
+const (
+	// LockBackendLocal locks projects within the process, which is only sufficient for a single replica
+	LockBackendLocal = "local"
+	// LockBackendKubernetes locks projects across all replicas using Kubernetes Lease objects
+	LockBackendKubernetes = "kubernetes"
+)
+
 type EnvConfig struct {
 	LogLevel                         string `envconfig:"LOG_LEVEL" default:"info"`
 	DirectoryStageStructure          bool   `envconfig:"DIRECTORY_STAGE_STRUCTURE" default:"false"`
 	DefaultRemoteGitRepositoryBranch string `envconfig:"DEFAULT_REMOTE_GIT_BRANCH" default:"master"`
+	LockBackend                      string `envconfig:"LOCK_BACKEND" default:"local"`
+	LockLeaseDurationSeconds         int    `envconfig:"LOCK_LEASE_DURATION_SECONDS" default:"15"`
+	// LockTimeoutSeconds limits how long an operation waits for the lease of a project before it fails
+	LockTimeoutSeconds int `envconfig:"LOCK_TIMEOUT_SECONDS" default:"60"`
+	// CloneProjectsOnDemand clones projects that are not available locally, but have been created by another replica
+	CloneProjectsOnDemand bool   `envconfig:"CLONE_PROJECTS_ON_DEMAND" default:"false"`
+	PodName               string `envconfig:"POD_NAME" default:""`
+	// MaxResourceSizeMB limits the size of resources uploaded via the streaming endpoints
+	MaxResourceSizeMB int64 `envconfig:"MAX_RESOURCE_SIZE_MB" default:"100"`
+	// MaxArchiveSizeMB limits the size of project archives, both of the uploaded archive and of its extracted files
+	MaxArchiveSizeMB int64 `envconfig:"MAX_ARCHIVE_SIZE_MB" default:"500"`
+}
+
+// MaxResourceSizeBytes returns the maximum size of resources uploaded via the streaming endpoints in bytes
+func (e EnvConfig) MaxResourceSizeBytes() int64 {
+	return e.MaxResourceSizeMB * 1024 * 1024
+}
+
+// MaxArchiveSizeBytes returns the maximum size of project archives in bytes
+func (e EnvConfig) MaxArchiveSizeBytes() int64 {
+	return e.MaxArchiveSizeMB * 1024 * 1024
 }
 
 func (e EnvConfig) RetrieveDefaultBranchFromEnv() string {
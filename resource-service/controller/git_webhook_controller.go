@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/handler"
+)
+
+type GitWebhookController struct {
+	GitWebhookHandler handler.IGitWebhookHandler
+}
+
+func NewGitWebhookController(gitWebhookHandler handler.IGitWebhookHandler) Controller {
+	return &GitWebhookController{GitWebhookHandler: gitWebhookHandler}
+}
+
+func (controller GitWebhookController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.POST("/project/:projectName/gitwebhook", controller.GitWebhookHandler.HandleGitWebhook)
+}
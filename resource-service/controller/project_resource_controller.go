@@ -19,5 +19,14 @@ func (controller ProjectResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.PUT("/project/:projectName/resource", controller.ProjectResourceHandler.UpdateProjectResources)
 	apiGroup.GET("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.GetProjectResource)
 	apiGroup.PUT("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.UpdateProjectResource)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/raw", controller.ProjectResourceHandler.GetProjectResourceContent)
+	apiGroup.PUT("/project/:projectName/resource/:resourceURI/raw", controller.ProjectResourceHandler.UpdateProjectResourceContent)
 	apiGroup.DELETE("/project/:projectName/resource/:resourceURI", controller.ProjectResourceHandler.DeleteProjectResource)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/history", controller.ProjectResourceHandler.GetProjectResourceHistory)
+	apiGroup.GET("/project/:projectName/resource/:resourceURI/diff", controller.ProjectResourceHandler.GetProjectResourceDiff)
+	apiGroup.POST("/project/:projectName/resource/:resourceURI/revert", controller.ProjectResourceHandler.RevertProjectResource)
+	apiGroup.GET("/project/:projectName/diff", controller.ProjectResourceHandler.GetProjectResourcesDiff)
+	apiGroup.GET("/project/:projectName/archive", controller.ProjectResourceHandler.GetProjectArchive)
+	apiGroup.PUT("/project/:projectName/archive", controller.ProjectResourceHandler.ImportProjectArchive)
+	apiGroup.GET("/project/:projectName/changerequest/:changeRequestID", controller.ProjectResourceHandler.GetProjectChangeRequest)
 }
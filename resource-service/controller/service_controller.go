@@ -16,4 +16,5 @@ func NewServiceController(serviceHandler handler.IServiceHandler) Controller {
 func (controller ServiceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.POST("/project/:projectName/stage/:stageName/service", controller.ServiceHandler.CreateService)
 	apiGroup.DELETE("/project/:projectName/stage/:stageName/service/:serviceName", controller.ServiceHandler.DeleteService)
+	apiGroup.POST("/project/:projectName/service/:serviceName/rename", controller.ServiceHandler.RenameService)
 }
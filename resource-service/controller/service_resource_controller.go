@@ -19,5 +19,12 @@ func (controller ServiceResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource", controller.ServiceResourceHandler.UpdateServiceResources)
 	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.GetServiceResource)
 	apiGroup.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.UpdateServiceResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/raw", controller.ServiceResourceHandler.GetServiceResourceContent)
+	apiGroup.PUT("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/raw", controller.ServiceResourceHandler.UpdateServiceResourceContent)
 	apiGroup.DELETE("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI", controller.ServiceResourceHandler.DeleteServiceResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/history", controller.ServiceResourceHandler.GetServiceResourceHistory)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", controller.ServiceResourceHandler.GetServiceResourceDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/revert", controller.ServiceResourceHandler.RevertServiceResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/service/:serviceName/diff", controller.ServiceResourceHandler.GetServiceResourcesDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/service/:serviceName/promote", controller.ServiceResourceHandler.PromoteServiceResources)
 }
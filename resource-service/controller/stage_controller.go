@@ -15,7 +15,5 @@ func NewStageController(stageHandler handler.IStageHandler) Controller {
 
 func (controller StageController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.POST("/project/:projectName/stage", controller.StageHandler.CreateStage)
-
-	// currently not implemented in configuration-service as well, but should be added later
-	//apiGroup.DELETE("/project/:projectName/stage/:stageName", controller.StageHandler.DeleteStage)
+	apiGroup.DELETE("/project/:projectName/stage/:stageName", controller.StageHandler.DeleteStage)
 }
@@ -19,5 +19,12 @@ func (controller StageResourceController) Inject(apiGroup *gin.RouterGroup) {
 	apiGroup.PUT("/project/:projectName/stage/:stageName/resource", controller.StageResourceHandler.UpdateStageResources)
 	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.GetStageResource)
 	apiGroup.PUT("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.UpdateStageResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/raw", controller.StageResourceHandler.GetStageResourceContent)
+	apiGroup.PUT("/project/:projectName/stage/:stageName/resource/:resourceURI/raw", controller.StageResourceHandler.UpdateStageResourceContent)
 	apiGroup.DELETE("/project/:projectName/stage/:stageName/resource/:resourceURI", controller.StageResourceHandler.DeleteStageResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/history", controller.StageResourceHandler.GetStageResourceHistory)
+	apiGroup.GET("/project/:projectName/stage/:stageName/resource/:resourceURI/diff", controller.StageResourceHandler.GetStageResourceDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/resource/:resourceURI/revert", controller.StageResourceHandler.RevertStageResource)
+	apiGroup.GET("/project/:projectName/stage/:stageName/diff", controller.StageResourceHandler.GetStageResourcesDiff)
+	apiGroup.POST("/project/:projectName/stage/:stageName/promote", controller.StageResourceHandler.PromoteStageResources)
 }
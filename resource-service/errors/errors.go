@@ -29,6 +29,7 @@ var ErrProjectRepositoryNotEmpty = New("project repository is not empty")
 
 var ErrStageNotFound = New("stage not found")
 var ErrStageAlreadyExists = New("stage already exists")
+var ErrStageIsDefaultBranch = New("the default branch of a project is not a stage and can not be deleted")
 
 // Service Specific errors
 
@@ -41,6 +42,13 @@ var ErrResourceNotFound = New("resource not found")
 var ErrResourceAlreadyExists = New("resource already exists")
 var ErrResourceNotBase64Encoded = New("resource content is not base64 encoded")
 var ErrResourceInvalidResourceURI = New("invalid resource uri")
+var ErrResourceRevisionMustNotBeEmpty = New("revision must not be empty")
+var ErrResourceInvalidHistoryLimit = New("limit must not be negative")
+var ErrResourceInvalidGlobPattern = New("invalid glob pattern")
+var ErrResourceTooLarge = New("resource exceeds the maximum size")
+var ErrResourceContentMissing = New("resource content is missing")
+var ErrPromotionStageMustBeSet = New("source stage must be set")
+var ErrPromotionSameStage = New("target stage must be different from the source stage")
 
 // Git specific errors
 
@@ -68,6 +76,7 @@ var ErrSubmoduleNotFound = New("submodule not found")
 var ErrUnstagedChanges = New("worktree contains unstaged changes")
 var ErrGitModulesSymlink = New(".gitmodules is a symlink")
 var ErrNonFastForwardUpdate = New("non-fast-forward update")
+var ErrNoCommitCreated = New("no commit has been created")
 
 // Git repo
 var ErrInvalidReference = New("invalid reference, should be a tag or a branch")
@@ -97,6 +106,54 @@ var ErrInvalidRemoteURL = New("remoteURL scheme must be http, https or ssh")
 var ErrProxyInvalidURL = New("proxy URL must contain IP address and port (<ip-address>:<port>)")
 var ErrInvalidCredentials = New("credentials need to have ssh or http auth method")
 
+// Project lock specific errors
+
+var ErrProjectLockTimeout = New("could not acquire the lock of the project in time")
+var ErrProjectLockLost = New("the lock of the project has been lost")
+
+// Change request specific errors
+
+var ErrChangeRequestsNotEnabled = New("change requests are not enabled for project")
+var ErrChangeRequestNotFound = New("change request not found")
+var ErrChangeRequestIDMustNotBeEmpty = New("change request id must not be empty")
+var ErrMalformedChangeRequestSettings = New("could not decode change request settings")
+var ErrUnknownChangeRequestProvider = New("unknown change request provider")
+var ErrChangeRequestRepositoryMustNotBeEmpty = New("change request repository must not be empty")
+var ErrInvalidChangeRequestWaitForMerge = New("change request waitForMerge must be a positive duration")
+var ErrChangeRequestClosed = New("change request has been closed without being merged")
+var ErrChangeRequestNotMerged = New("change request has not been merged in time")
+
+// Git webhook specific errors
+
+var ErrGitWebhookNotEnabled = New("git webhook is not enabled for project")
+var ErrInvalidGitWebhookSignature = New("invalid git webhook signature")
+var ErrUnsupportedGitWebhookEvent = New("unsupported git webhook event")
+var ErrMalformedGitWebhookPayload = New("could not decode git webhook payload")
+
+// Git LFS specific errors
+
+var ErrLFSRequiresHTTPCredentials = New("git LFS is only supported for upstream repositories with http(s) credentials")
+var ErrLFSObjectNotFound = New("LFS object not found")
+var ErrInvalidLFSObject = New("LFS object does not match its pointer")
+var ErrUnexpectedLFSObject = New("LFS server returned an object that has not been requested")
+
+// Project archive specific errors
+
+var ErrUnknownArchiveFormat = New("unknown archive format")
+var ErrInvalidArchive = New("invalid archive")
+var ErrArchiveTooLarge = New("archive too large")
+
+// Resource rendering specific errors
+
+var ErrInvalidVariables = New("invalid variables file")
+var ErrRenderResource = New("could not render resource")
+var ErrResourceNotRenderable = New("helm charts can not be rendered")
+
+// Commit signing specific errors
+
+var ErrUnknownGitSigningFormat = New("unknown commit signing format")
+var ErrInvalidGitSigningKey = New("invalid commit signing key")
+
 // Error messages
 
 const ErrMsgCouldNotRetrieveCredentials = "could not read credentials for project %s: %w"
@@ -110,3 +167,7 @@ const ErrMsgCouldNotGetRevision = "could not get current revision for project %s
 const ErrMsgCouldNotGetDefBranch = "could not get default branch for project %s: %w"
 const ErrMsgCouldNotCheckout = "could not checkout branch %s: %w"
 const ErrMsgCouldNotCreate = "could not create branch %s for project %s: %w"
+const ErrMsgCouldNotCreateChangeRequest = "could not create change request for branch %s of project %s: %w"
+const ErrMsgCouldNotSignCommit = "could not sign commit in project %s: %w"
+const ErrMsgCouldNotTransferLFSObject = "could not %s LFS object %s of project %s: %w"
+const ErrMsgCouldNotPublishEvent = "could not publish %s event for project %s: %w"
@@ -3,15 +3,20 @@ package handler
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	git2go "github.com/libgit2/git2go/v34"
 
 	"net/http"
 	"net/url"
 
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 	logger "github.com/sirupsen/logrus"
@@ -22,10 +27,19 @@ import (
 	ssh2 "golang.org/x/crypto/ssh"
 )
 
+// eventSource is the source of the events sent by the resource-service
+const eventSource = "resource-service"
+
 const pathParamProjectName = "projectName"
 const pathParamStageName = "stageName"
 const pathParamServiceName = "serviceName"
 const pathParamResourceURI = "resourceURI"
+const pathParamChangeRequestID = "changeRequestID"
+
+// headerPrincipal and headerPrincipalEmail identify the authenticated user or API token of a request. They are set by the
+// API gateway, after the request has been authenticated
+const headerPrincipal = "X-Keptn-Principal"
+const headerPrincipalEmail = "X-Keptn-Principal-Email"
 
 func OnAPIError(c *gin.Context, err error) {
 	logger.Infof("Could not complete request %s %s: %v", c.Request.Method, c.Request.RequestURI, err)
@@ -42,6 +56,36 @@ func OnAPIError(c *gin.Context, err error) {
 		SetFailedDependencyErrorResponse(c, "Could not decode credentials for upstream repository")
 	} else if errors.Is(err, errors2.ErrCredentialsInvalidRemoteURL) || errors.Is(err, errors2.ErrCredentialsTokenMustNotBeEmpty) {
 		SetBadRequestErrorResponse(c, "Upstream repository not found")
+	} else if errors.Is(err, errors2.ErrChangeRequestsNotEnabled) {
+		SetBadRequestErrorResponse(c, "Change requests are not enabled for this project")
+	} else if errors.Is(err, errors2.ErrMalformedChangeRequestSettings) || errors.Is(err, errors2.ErrUnknownChangeRequestProvider) || errors.Is(err, errors2.ErrChangeRequestRepositoryMustNotBeEmpty) || errors.Is(err, errors2.ErrInvalidChangeRequestWaitForMerge) {
+		SetFailedDependencyErrorResponse(c, "Invalid change request settings for upstream repository")
+	} else if errors.Is(err, errors2.ErrProjectLockTimeout) || errors.Is(err, errors2.ErrProjectLockLost) {
+		SetConflictErrorResponse(c, "Project is locked by another operation")
+	} else if errors.Is(err, errors2.ErrChangeRequestClosed) || errors.Is(err, errors2.ErrChangeRequestNotMerged) {
+		SetConflictErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrGitWebhookNotEnabled) {
+		SetNotFoundErrorResponse(c, "Git webhooks are not enabled for this project")
+	} else if errors.Is(err, errors2.ErrInvalidGitWebhookSignature) {
+		SetUnauthorizedErrorResponse(c, "Invalid git webhook signature")
+	} else if errors.Is(err, errors2.ErrUnsupportedGitWebhookEvent) || errors.Is(err, errors2.ErrMalformedGitWebhookPayload) {
+		SetBadRequestErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrUnknownArchiveFormat) || errors.Is(err, errors2.ErrInvalidArchive) {
+		SetBadRequestErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrArchiveTooLarge) {
+		SetPayloadTooLargeErrorResponse(c, fmt.Sprintf("Archive exceeds the maximum size of %d MB", config.Global.MaxArchiveSizeMB))
+	} else if errors.Is(err, errors2.ErrInvalidVariables) || errors.Is(err, errors2.ErrRenderResource) {
+		SetUnprocessableEntityErrorResponse(c, err.Error())
+	} else if errors.Is(err, errors2.ErrResourceNotRenderable) || errors.Is(err, errors2.ErrStageIsDefaultBranch) {
+		SetBadRequestErrorResponse(c, err.Error())
+	} else if resourceTooLarge(err) {
+		SetPayloadTooLargeErrorResponse(c, fmt.Sprintf("Resource exceeds the maximum size of %d MB", config.Global.MaxResourceSizeMB))
+	} else if errors.Is(err, errors2.ErrLFSRequiresHTTPCredentials) {
+		SetFailedDependencyErrorResponse(c, "Git LFS requires HTTPS credentials for the upstream repository")
+	} else if errors.Is(err, errors2.ErrLFSObjectNotFound) || errors.Is(err, errors2.ErrInvalidLFSObject) || errors.Is(err, errors2.ErrUnexpectedLFSObject) {
+		SetFailedDependencyErrorResponse(c, "Could not retrieve object from the git LFS server of the upstream repository")
+	} else if errors.Is(err, errors2.ErrUnknownGitSigningFormat) || errors.Is(err, errors2.ErrInvalidGitSigningKey) {
+		SetFailedDependencyErrorResponse(c, "Invalid commit signing key for upstream repository")
 	} else if errors.Is(err, errors2.ErrRepositoryNotFound) {
 		SetNotFoundErrorResponse(c, "Upstream repository not found")
 	} else if check, resourceType := resourceNotFound(err); check {
@@ -63,6 +107,12 @@ func alreadyExists(err error) (bool, string) {
 	return false, ""
 }
 
+// resourceTooLarge checks if a resource has exceeded the maximum size, either while the request body has been read, or afterwards
+func resourceTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.Is(err, errors2.ErrResourceTooLarge) || errors.As(err, &maxBytesErr)
+}
+
 func resourceNotFound(err error) (bool, string) {
 	if errors.Is(err, errors2.ErrProjectNotFound) {
 		return true, "Project"
@@ -72,10 +122,87 @@ func resourceNotFound(err error) (bool, string) {
 		return true, "Service"
 	} else if errors.Is(err, errors2.ErrResourceNotFound) {
 		return true, "Resource"
+	} else if errors.Is(err, errors2.ErrResolveRevision) {
+		return true, "Revision"
+	} else if errors.Is(err, errors2.ErrChangeRequestNotFound) {
+		return true, "Change request"
 	}
 	return false, ""
 }
 
+// getAuthor returns the principal the request has been sent by, or nil if the request does not contain a principal
+func getAuthor(c *gin.Context) *models.Author {
+	name := c.GetHeader(headerPrincipal)
+	if name == "" {
+		return nil
+	}
+	return &models.Author{Name: name, Email: c.GetHeader(headerPrincipalEmail)}
+}
+
+// getCredentials reads the upstream credentials of the project. If projects are cloned on demand and the credentials have been
+// removed, the project has been deleted by another replica, so the local clone of the project is discarded
+func getCredentials(credentialReader common.CredentialReader, fileSystem common.IFileSystem, project string) (*common_models.GitCredentials, error) {
+	credentials, err := credentialReader.GetCredentials(project)
+	if err != nil {
+		if errors.Is(err, errors2.ErrCredentialsNotFound) && config.Global.CloneProjectsOnDemand {
+			discardProjectClone(fileSystem, project)
+		}
+		return nil, fmt.Errorf(errors2.ErrMsgCouldNotRetrieveCredentials, project, err)
+	}
+	return credentials, nil
+}
+
+// projectExists checks if the git repository of the project is available locally. If projects are cloned on demand, e.g. because
+// multiple replicas with separate volumes are running, a project that has been created by another replica is cloned from its upstream.
+// A local clone whose remote does not match the upstream of the project anymore, e.g. because another replica has moved the project
+// to a new upstream, is cloned again
+func projectExists(git common.IGit, fileSystem common.IFileSystem, gitContext common_models.GitContext) bool {
+	if git.ProjectExists(gitContext) {
+		if !config.Global.CloneProjectsOnDemand || !isStaleProjectClone(git, gitContext) {
+			return true
+		}
+		logger.Infof("The local clone of project %s does not match its upstream anymore", gitContext.Project)
+		if !discardProjectClone(fileSystem, gitContext.Project) {
+			return false
+		}
+	}
+	if !config.Global.CloneProjectsOnDemand {
+		return false
+	}
+	logger.Infof("Project %s is not available locally, cloning it from the upstream", gitContext.Project)
+	if _, err := git.CloneRepo(gitContext); err != nil {
+		logger.Warnf("Could not clone project %s: %v", gitContext.Project, err)
+		return false
+	}
+	return git.ProjectExists(gitContext)
+}
+
+func isStaleProjectClone(git common.IGit, gitContext common_models.GitContext) bool {
+	if gitContext.Credentials == nil {
+		return false
+	}
+	remoteURL, err := git.GetRemoteURL(gitContext)
+	if err != nil {
+		logger.Warnf("Could not get remote URL of project %s: %v", gitContext.Project, err)
+		return false
+	}
+	return remoteURL != gitContext.Credentials.RemoteURL
+}
+
+// discardProjectClone deletes the local clone of the project, and returns whether the project is not available locally anymore
+func discardProjectClone(fileSystem common.IFileSystem, project string) bool {
+	projectDirectory := common.GetProjectConfigPath(project)
+	if !fileSystem.FileExists(projectDirectory) {
+		return true
+	}
+	logger.Infof("Discarding the local clone of project %s", project)
+	if err := fileSystem.DeleteFile(projectDirectory); err != nil {
+		logger.Warnf("Could not discard the local clone of project %s: %v", project, err)
+		return false
+	}
+	return true
+}
+
 func getAuthMethod(credentials *common_models.GitCredentials) (*common_models.AuthMethod, error) {
 	if credentials.SshAuth != nil {
 		return getSshGitAuth(credentials)
@@ -175,6 +302,13 @@ func SetFailedDependencyErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetUnauthorizedErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnauthorized, models.Error{
+		Code:    http.StatusUnauthorized,
+		Message: msg,
+	})
+}
+
 func SetNotFoundErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusNotFound, models.Error{
 		Code:    http.StatusNotFound,
@@ -196,9 +330,45 @@ func SetBadRequestErrorResponse(c *gin.Context, msg string) {
 	})
 }
 
+func SetPayloadTooLargeErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusRequestEntityTooLarge, models.Error{
+		Code:    http.StatusRequestEntityTooLarge,
+		Message: msg,
+	})
+}
+
+func SetUnprocessableEntityErrorResponse(c *gin.Context, msg string) {
+	c.JSON(http.StatusUnprocessableEntity, models.Error{
+		Code:    http.StatusUnprocessableEntity,
+		Message: msg,
+	})
+}
+
 func SetConflictErrorResponse(c *gin.Context, msg string) {
 	c.JSON(http.StatusConflict, models.Error{
 		Code:    http.StatusConflict,
 		Message: msg,
 	})
 }
+
+func newEvent(eventType string, keptnContext string, data interface{}) apimodels.KeptnContextExtendedCE {
+	source := eventSource
+	return apimodels.KeptnContextExtendedCE{
+		Contenttype:    "application/json",
+		Data:           data,
+		ID:             uuid.New().String(),
+		Shkeptncontext: keptnContext,
+		Source:         &source,
+		Specversion:    "1.0",
+		Time:           time.Now().UTC(),
+		Type:           &eventType,
+	}
+}
+
+// publishEvent sends an event informing other services, e.g. the shipyard-controller, about a change of the structure
+// of a project. Since the change has already been pushed to the upstream repository at this point, errors are only logged
+func publishEvent(eventPublisher common.EventPublisher, project string, eventType string, data interface{}) {
+	if err := eventPublisher.Publish(newEvent(eventType, uuid.New().String(), data)); err != nil {
+		logger.Errorf("Could not publish %s event for project %s: %v", eventType, project, err)
+	}
+}
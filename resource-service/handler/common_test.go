@@ -13,7 +13,9 @@ import (
 	gittransport "github.com/go-git/go-git/v5/plumbing/transport"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 )
 
 func Test_getAuthMethod(t *testing.T) {
@@ -250,3 +252,101 @@ func TestOnAPIError(t *testing.T) {
 		})
 	}
 }
+
+func Test_projectExists(t *testing.T) {
+	defer func() { config.Global.CloneProjectsOnDemand = false }()
+
+	newGit := func(exists bool) *common_mock.IGitMock {
+		return &common_mock.IGitMock{
+			ProjectExistsFunc: func(gitContext common_models.GitContext) bool { return exists },
+			CloneRepoFunc:     func(gitContext common_models.GitContext) (bool, error) { return true, nil },
+		}
+	}
+
+	fileSystem := &common_mock.IFileSystemMock{}
+
+	git := newGit(true)
+	require.True(t, projectExists(git, fileSystem, common_models.GitContext{Project: "my-project"}))
+	require.Empty(t, git.CloneRepoCalls())
+
+	git = newGit(false)
+	require.False(t, projectExists(git, fileSystem, common_models.GitContext{Project: "my-project"}))
+	require.Empty(t, git.CloneRepoCalls())
+
+	config.Global.CloneProjectsOnDemand = true
+	cloned := false
+	git = newGit(false)
+	git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool { return cloned }
+	git.CloneRepoFunc = func(gitContext common_models.GitContext) (bool, error) {
+		cloned = true
+		return true, nil
+	}
+	require.True(t, projectExists(git, fileSystem, common_models.GitContext{Project: "my-project"}))
+	require.Len(t, git.CloneRepoCalls(), 1)
+
+	git = newGit(false)
+	git.CloneRepoFunc = func(gitContext common_models.GitContext) (bool, error) {
+		return false, errors.ErrRepositoryNotFound
+	}
+	require.False(t, projectExists(git, fileSystem, common_models.GitContext{Project: "my-project"}))
+}
+
+func Test_projectExists_StaleClone(t *testing.T) {
+	config.Global.CloneProjectsOnDemand = true
+	defer func() { config.Global.CloneProjectsOnDemand = false }()
+
+	cloned := true
+	git := &common_mock.IGitMock{
+		ProjectExistsFunc: func(gitContext common_models.GitContext) bool { return cloned },
+		GetRemoteURLFunc: func(gitContext common_models.GitContext) (string, error) {
+			return "https://my-old-upstream", nil
+		},
+		CloneRepoFunc: func(gitContext common_models.GitContext) (bool, error) {
+			cloned = true
+			return true, nil
+		},
+	}
+	fileSystem := &common_mock.IFileSystemMock{
+		FileExistsFunc: func(path string) bool { return true },
+		DeleteFileFunc: func(path string) error {
+			cloned = false
+			return nil
+		},
+	}
+
+	// the clone matches the upstream of the project
+	gitContext := common_models.GitContext{Project: "my-project", Credentials: &common_models.GitCredentials{RemoteURL: "https://my-old-upstream"}}
+	require.True(t, projectExists(git, fileSystem, gitContext))
+	require.Empty(t, fileSystem.DeleteFileCalls())
+
+	// another replica has moved the project to a new upstream
+	gitContext.Credentials.RemoteURL = "https://my-new-upstream"
+	require.True(t, projectExists(git, fileSystem, gitContext))
+	require.Len(t, fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, "/data/config/my-project", fileSystem.DeleteFileCalls()[0].Path)
+	require.Len(t, git.CloneRepoCalls(), 1)
+}
+
+func Test_getCredentials(t *testing.T) {
+	defer func() { config.Global.CloneProjectsOnDemand = false }()
+
+	credentialReader := &common_mock.CredentialReaderMock{
+		GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+			return nil, errors.ErrCredentialsNotFound
+		},
+	}
+	fileSystem := &common_mock.IFileSystemMock{
+		FileExistsFunc: func(path string) bool { return true },
+		DeleteFileFunc: func(path string) error { return nil },
+	}
+
+	_, err := getCredentials(credentialReader, fileSystem, "my-project")
+	require.ErrorIs(t, err, errors.ErrCredentialsNotFound)
+	require.Empty(t, fileSystem.DeleteFileCalls())
+
+	// the project has been deleted by another replica
+	config.Global.CloneProjectsOnDemand = true
+	_, err = getCredentials(credentialReader, fileSystem, "my-project")
+	require.ErrorIs(t, err, errors.ErrCredentialsNotFound)
+	require.Len(t, fileSystem.DeleteFileCalls(), 1)
+}
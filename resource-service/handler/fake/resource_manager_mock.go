@@ -20,15 +20,42 @@ import (
 // 			DeleteResourceFunc: func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the DeleteResource method")
 // 			},
+// 			GetChangeRequestFunc: func(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+// 				panic("mock out the GetChangeRequest method")
+// 			},
+// 			GetProjectArchiveFunc: func(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+// 				panic("mock out the GetProjectArchive method")
+// 			},
 // 			GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
 // 				panic("mock out the GetResource method")
 // 			},
+// 			GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+// 				panic("mock out the GetResourceDiff method")
+// 			},
+// 			GetResourceHistoryFunc: func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+// 				panic("mock out the GetResourceHistory method")
+// 			},
+// 			GetResourceStreamFunc: func(params models.GetResourceParams) (*models.ResourceStream, error) {
+// 				panic("mock out the GetResourceStream method")
+// 			},
 // 			GetResourcesFunc: func(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
 // 				panic("mock out the GetResources method")
 // 			},
+// 			ImportProjectArchiveFunc: func(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error) {
+// 				panic("mock out the ImportProjectArchive method")
+// 			},
+// 			PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+// 				panic("mock out the PromoteResources method")
+// 			},
+// 			RevertResourceFunc: func(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+// 				panic("mock out the RevertResource method")
+// 			},
 // 			UpdateResourceFunc: func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the UpdateResource method")
 // 			},
+// 			UpdateResourceStreamFunc: func(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error) {
+// 				panic("mock out the UpdateResourceStream method")
+// 			},
 // 			UpdateResourcesFunc: func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
 // 				panic("mock out the UpdateResources method")
 // 			},
@@ -45,15 +72,42 @@ type IResourceManagerMock struct {
 	// DeleteResourceFunc mocks the DeleteResource method.
 	DeleteResourceFunc func(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
 
+	// GetChangeRequestFunc mocks the GetChangeRequest method.
+	GetChangeRequestFunc func(params models.GetChangeRequestParams) (*models.ChangeRequest, error)
+
+	// GetProjectArchiveFunc mocks the GetProjectArchive method.
+	GetProjectArchiveFunc func(params models.GetProjectArchiveParams) (*models.ResourceStream, error)
+
 	// GetResourceFunc mocks the GetResource method.
 	GetResourceFunc func(params models.GetResourceParams) (*models.GetResourceResponse, error)
 
+	// GetResourceDiffFunc mocks the GetResourceDiff method.
+	GetResourceDiffFunc func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+
+	// GetResourceHistoryFunc mocks the GetResourceHistory method.
+	GetResourceHistoryFunc func(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+
+	// GetResourceStreamFunc mocks the GetResourceStream method.
+	GetResourceStreamFunc func(params models.GetResourceParams) (*models.ResourceStream, error)
+
 	// GetResourcesFunc mocks the GetResources method.
 	GetResourcesFunc func(params models.GetResourcesParams) (*models.GetResourcesResponse, error)
 
+	// ImportProjectArchiveFunc mocks the ImportProjectArchive method.
+	ImportProjectArchiveFunc func(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error)
+
+	// PromoteResourcesFunc mocks the PromoteResources method.
+	PromoteResourcesFunc func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error)
+
+	// RevertResourceFunc mocks the RevertResource method.
+	RevertResourceFunc func(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+
 	// UpdateResourceFunc mocks the UpdateResource method.
 	UpdateResourceFunc func(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
 
+	// UpdateResourceStreamFunc mocks the UpdateResourceStream method.
+	UpdateResourceStreamFunc func(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error)
+
 	// UpdateResourcesFunc mocks the UpdateResources method.
 	UpdateResourcesFunc func(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error)
 
@@ -69,33 +123,87 @@ type IResourceManagerMock struct {
 			// Params is the params argument value.
 			Params models.DeleteResourceParams
 		}
+		// GetChangeRequest holds details about calls to the GetChangeRequest method.
+		GetChangeRequest []struct {
+			// Params is the params argument value.
+			Params models.GetChangeRequestParams
+		}
+		// GetProjectArchive holds details about calls to the GetProjectArchive method.
+		GetProjectArchive []struct {
+			// Params is the params argument value.
+			Params models.GetProjectArchiveParams
+		}
 		// GetResource holds details about calls to the GetResource method.
 		GetResource []struct {
 			// Params is the params argument value.
 			Params models.GetResourceParams
 		}
+		// GetResourceDiff holds details about calls to the GetResourceDiff method.
+		GetResourceDiff []struct {
+			// Params is the params argument value.
+			Params models.GetResourceDiffParams
+		}
+		// GetResourceHistory holds details about calls to the GetResourceHistory method.
+		GetResourceHistory []struct {
+			// Params is the params argument value.
+			Params models.GetResourceHistoryParams
+		}
+		// GetResourceStream holds details about calls to the GetResourceStream method.
+		GetResourceStream []struct {
+			// Params is the params argument value.
+			Params models.GetResourceParams
+		}
 		// GetResources holds details about calls to the GetResources method.
 		GetResources []struct {
 			// Params is the params argument value.
 			Params models.GetResourcesParams
 		}
+		// ImportProjectArchive holds details about calls to the ImportProjectArchive method.
+		ImportProjectArchive []struct {
+			// Params is the params argument value.
+			Params models.ImportProjectArchiveParams
+		}
+		// PromoteResources holds details about calls to the PromoteResources method.
+		PromoteResources []struct {
+			// Params is the params argument value.
+			Params models.PromoteResourcesParams
+		}
+		// RevertResource holds details about calls to the RevertResource method.
+		RevertResource []struct {
+			// Params is the params argument value.
+			Params models.RevertResourceParams
+		}
 		// UpdateResource holds details about calls to the UpdateResource method.
 		UpdateResource []struct {
 			// Params is the params argument value.
 			Params models.UpdateResourceParams
 		}
+		// UpdateResourceStream holds details about calls to the UpdateResourceStream method.
+		UpdateResourceStream []struct {
+			// Params is the params argument value.
+			Params models.UpdateResourceStreamParams
+		}
 		// UpdateResources holds details about calls to the UpdateResources method.
 		UpdateResources []struct {
 			// Params is the params argument value.
 			Params models.UpdateResourcesParams
 		}
 	}
-	lockCreateResources sync.RWMutex
-	lockDeleteResource  sync.RWMutex
-	lockGetResource     sync.RWMutex
-	lockGetResources    sync.RWMutex
-	lockUpdateResource  sync.RWMutex
-	lockUpdateResources sync.RWMutex
+	lockCreateResources      sync.RWMutex
+	lockDeleteResource       sync.RWMutex
+	lockGetChangeRequest     sync.RWMutex
+	lockGetProjectArchive    sync.RWMutex
+	lockGetResource          sync.RWMutex
+	lockGetResourceDiff      sync.RWMutex
+	lockGetResourceHistory   sync.RWMutex
+	lockGetResourceStream    sync.RWMutex
+	lockGetResources         sync.RWMutex
+	lockImportProjectArchive sync.RWMutex
+	lockPromoteResources     sync.RWMutex
+	lockRevertResource       sync.RWMutex
+	lockUpdateResource       sync.RWMutex
+	lockUpdateResourceStream sync.RWMutex
+	lockUpdateResources      sync.RWMutex
 }
 
 // CreateResources calls CreateResourcesFunc.
@@ -160,6 +268,68 @@ func (mock *IResourceManagerMock) DeleteResourceCalls() []struct {
 	return calls
 }
 
+// GetChangeRequest calls GetChangeRequestFunc.
+func (mock *IResourceManagerMock) GetChangeRequest(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+	if mock.GetChangeRequestFunc == nil {
+		panic("IResourceManagerMock.GetChangeRequestFunc: method is nil but IResourceManager.GetChangeRequest was just called")
+	}
+	callInfo := struct {
+		Params models.GetChangeRequestParams
+	}{
+		Params: params,
+	}
+	mock.lockGetChangeRequest.Lock()
+	mock.calls.GetChangeRequest = append(mock.calls.GetChangeRequest, callInfo)
+	mock.lockGetChangeRequest.Unlock()
+	return mock.GetChangeRequestFunc(params)
+}
+
+// GetChangeRequestCalls gets all the calls that were made to GetChangeRequest.
+// Check the length with:
+//     len(mockedIResourceManager.GetChangeRequestCalls())
+func (mock *IResourceManagerMock) GetChangeRequestCalls() []struct {
+	Params models.GetChangeRequestParams
+} {
+	var calls []struct {
+		Params models.GetChangeRequestParams
+	}
+	mock.lockGetChangeRequest.RLock()
+	calls = mock.calls.GetChangeRequest
+	mock.lockGetChangeRequest.RUnlock()
+	return calls
+}
+
+// GetProjectArchive calls GetProjectArchiveFunc.
+func (mock *IResourceManagerMock) GetProjectArchive(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+	if mock.GetProjectArchiveFunc == nil {
+		panic("IResourceManagerMock.GetProjectArchiveFunc: method is nil but IResourceManager.GetProjectArchive was just called")
+	}
+	callInfo := struct {
+		Params models.GetProjectArchiveParams
+	}{
+		Params: params,
+	}
+	mock.lockGetProjectArchive.Lock()
+	mock.calls.GetProjectArchive = append(mock.calls.GetProjectArchive, callInfo)
+	mock.lockGetProjectArchive.Unlock()
+	return mock.GetProjectArchiveFunc(params)
+}
+
+// GetProjectArchiveCalls gets all the calls that were made to GetProjectArchive.
+// Check the length with:
+//     len(mockedIResourceManager.GetProjectArchiveCalls())
+func (mock *IResourceManagerMock) GetProjectArchiveCalls() []struct {
+	Params models.GetProjectArchiveParams
+} {
+	var calls []struct {
+		Params models.GetProjectArchiveParams
+	}
+	mock.lockGetProjectArchive.RLock()
+	calls = mock.calls.GetProjectArchive
+	mock.lockGetProjectArchive.RUnlock()
+	return calls
+}
+
 // GetResource calls GetResourceFunc.
 func (mock *IResourceManagerMock) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
 	if mock.GetResourceFunc == nil {
@@ -191,6 +361,99 @@ func (mock *IResourceManagerMock) GetResourceCalls() []struct {
 	return calls
 }
 
+// GetResourceDiff calls GetResourceDiffFunc.
+func (mock *IResourceManagerMock) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if mock.GetResourceDiffFunc == nil {
+		panic("IResourceManagerMock.GetResourceDiffFunc: method is nil but IResourceManager.GetResourceDiff was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceDiffParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceDiff.Lock()
+	mock.calls.GetResourceDiff = append(mock.calls.GetResourceDiff, callInfo)
+	mock.lockGetResourceDiff.Unlock()
+	return mock.GetResourceDiffFunc(params)
+}
+
+// GetResourceDiffCalls gets all the calls that were made to GetResourceDiff.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceDiffCalls())
+func (mock *IResourceManagerMock) GetResourceDiffCalls() []struct {
+	Params models.GetResourceDiffParams
+} {
+	var calls []struct {
+		Params models.GetResourceDiffParams
+	}
+	mock.lockGetResourceDiff.RLock()
+	calls = mock.calls.GetResourceDiff
+	mock.lockGetResourceDiff.RUnlock()
+	return calls
+}
+
+// GetResourceHistory calls GetResourceHistoryFunc.
+func (mock *IResourceManagerMock) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if mock.GetResourceHistoryFunc == nil {
+		panic("IResourceManagerMock.GetResourceHistoryFunc: method is nil but IResourceManager.GetResourceHistory was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceHistoryParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceHistory.Lock()
+	mock.calls.GetResourceHistory = append(mock.calls.GetResourceHistory, callInfo)
+	mock.lockGetResourceHistory.Unlock()
+	return mock.GetResourceHistoryFunc(params)
+}
+
+// GetResourceHistoryCalls gets all the calls that were made to GetResourceHistory.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceHistoryCalls())
+func (mock *IResourceManagerMock) GetResourceHistoryCalls() []struct {
+	Params models.GetResourceHistoryParams
+} {
+	var calls []struct {
+		Params models.GetResourceHistoryParams
+	}
+	mock.lockGetResourceHistory.RLock()
+	calls = mock.calls.GetResourceHistory
+	mock.lockGetResourceHistory.RUnlock()
+	return calls
+}
+
+// GetResourceStream calls GetResourceStreamFunc.
+func (mock *IResourceManagerMock) GetResourceStream(params models.GetResourceParams) (*models.ResourceStream, error) {
+	if mock.GetResourceStreamFunc == nil {
+		panic("IResourceManagerMock.GetResourceStreamFunc: method is nil but IResourceManager.GetResourceStream was just called")
+	}
+	callInfo := struct {
+		Params models.GetResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockGetResourceStream.Lock()
+	mock.calls.GetResourceStream = append(mock.calls.GetResourceStream, callInfo)
+	mock.lockGetResourceStream.Unlock()
+	return mock.GetResourceStreamFunc(params)
+}
+
+// GetResourceStreamCalls gets all the calls that were made to GetResourceStream.
+// Check the length with:
+//     len(mockedIResourceManager.GetResourceStreamCalls())
+func (mock *IResourceManagerMock) GetResourceStreamCalls() []struct {
+	Params models.GetResourceParams
+} {
+	var calls []struct {
+		Params models.GetResourceParams
+	}
+	mock.lockGetResourceStream.RLock()
+	calls = mock.calls.GetResourceStream
+	mock.lockGetResourceStream.RUnlock()
+	return calls
+}
+
 // GetResources calls GetResourcesFunc.
 func (mock *IResourceManagerMock) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
 	if mock.GetResourcesFunc == nil {
@@ -222,6 +485,99 @@ func (mock *IResourceManagerMock) GetResourcesCalls() []struct {
 	return calls
 }
 
+// ImportProjectArchive calls ImportProjectArchiveFunc.
+func (mock *IResourceManagerMock) ImportProjectArchive(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error) {
+	if mock.ImportProjectArchiveFunc == nil {
+		panic("IResourceManagerMock.ImportProjectArchiveFunc: method is nil but IResourceManager.ImportProjectArchive was just called")
+	}
+	callInfo := struct {
+		Params models.ImportProjectArchiveParams
+	}{
+		Params: params,
+	}
+	mock.lockImportProjectArchive.Lock()
+	mock.calls.ImportProjectArchive = append(mock.calls.ImportProjectArchive, callInfo)
+	mock.lockImportProjectArchive.Unlock()
+	return mock.ImportProjectArchiveFunc(params)
+}
+
+// ImportProjectArchiveCalls gets all the calls that were made to ImportProjectArchive.
+// Check the length with:
+//     len(mockedIResourceManager.ImportProjectArchiveCalls())
+func (mock *IResourceManagerMock) ImportProjectArchiveCalls() []struct {
+	Params models.ImportProjectArchiveParams
+} {
+	var calls []struct {
+		Params models.ImportProjectArchiveParams
+	}
+	mock.lockImportProjectArchive.RLock()
+	calls = mock.calls.ImportProjectArchive
+	mock.lockImportProjectArchive.RUnlock()
+	return calls
+}
+
+// PromoteResources calls PromoteResourcesFunc.
+func (mock *IResourceManagerMock) PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	if mock.PromoteResourcesFunc == nil {
+		panic("IResourceManagerMock.PromoteResourcesFunc: method is nil but IResourceManager.PromoteResources was just called")
+	}
+	callInfo := struct {
+		Params models.PromoteResourcesParams
+	}{
+		Params: params,
+	}
+	mock.lockPromoteResources.Lock()
+	mock.calls.PromoteResources = append(mock.calls.PromoteResources, callInfo)
+	mock.lockPromoteResources.Unlock()
+	return mock.PromoteResourcesFunc(params)
+}
+
+// PromoteResourcesCalls gets all the calls that were made to PromoteResources.
+// Check the length with:
+//     len(mockedIResourceManager.PromoteResourcesCalls())
+func (mock *IResourceManagerMock) PromoteResourcesCalls() []struct {
+	Params models.PromoteResourcesParams
+} {
+	var calls []struct {
+		Params models.PromoteResourcesParams
+	}
+	mock.lockPromoteResources.RLock()
+	calls = mock.calls.PromoteResources
+	mock.lockPromoteResources.RUnlock()
+	return calls
+}
+
+// RevertResource calls RevertResourceFunc.
+func (mock *IResourceManagerMock) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if mock.RevertResourceFunc == nil {
+		panic("IResourceManagerMock.RevertResourceFunc: method is nil but IResourceManager.RevertResource was just called")
+	}
+	callInfo := struct {
+		Params models.RevertResourceParams
+	}{
+		Params: params,
+	}
+	mock.lockRevertResource.Lock()
+	mock.calls.RevertResource = append(mock.calls.RevertResource, callInfo)
+	mock.lockRevertResource.Unlock()
+	return mock.RevertResourceFunc(params)
+}
+
+// RevertResourceCalls gets all the calls that were made to RevertResource.
+// Check the length with:
+//     len(mockedIResourceManager.RevertResourceCalls())
+func (mock *IResourceManagerMock) RevertResourceCalls() []struct {
+	Params models.RevertResourceParams
+} {
+	var calls []struct {
+		Params models.RevertResourceParams
+	}
+	mock.lockRevertResource.RLock()
+	calls = mock.calls.RevertResource
+	mock.lockRevertResource.RUnlock()
+	return calls
+}
+
 // UpdateResource calls UpdateResourceFunc.
 func (mock *IResourceManagerMock) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
 	if mock.UpdateResourceFunc == nil {
@@ -253,6 +609,37 @@ func (mock *IResourceManagerMock) UpdateResourceCalls() []struct {
 	return calls
 }
 
+// UpdateResourceStream calls UpdateResourceStreamFunc.
+func (mock *IResourceManagerMock) UpdateResourceStream(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error) {
+	if mock.UpdateResourceStreamFunc == nil {
+		panic("IResourceManagerMock.UpdateResourceStreamFunc: method is nil but IResourceManager.UpdateResourceStream was just called")
+	}
+	callInfo := struct {
+		Params models.UpdateResourceStreamParams
+	}{
+		Params: params,
+	}
+	mock.lockUpdateResourceStream.Lock()
+	mock.calls.UpdateResourceStream = append(mock.calls.UpdateResourceStream, callInfo)
+	mock.lockUpdateResourceStream.Unlock()
+	return mock.UpdateResourceStreamFunc(params)
+}
+
+// UpdateResourceStreamCalls gets all the calls that were made to UpdateResourceStream.
+// Check the length with:
+//     len(mockedIResourceManager.UpdateResourceStreamCalls())
+func (mock *IResourceManagerMock) UpdateResourceStreamCalls() []struct {
+	Params models.UpdateResourceStreamParams
+} {
+	var calls []struct {
+		Params models.UpdateResourceStreamParams
+	}
+	mock.lockUpdateResourceStream.RLock()
+	calls = mock.calls.UpdateResourceStream
+	mock.lockUpdateResourceStream.RUnlock()
+	return calls
+}
+
 // UpdateResources calls UpdateResourcesFunc.
 func (mock *IResourceManagerMock) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
 	if mock.UpdateResourcesFunc == nil {
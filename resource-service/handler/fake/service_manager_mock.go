@@ -15,11 +15,14 @@ import (
 // 		// make and configure a mocked handler.IServiceManager
 // 		mockedIServiceManager := &IServiceManagerMock{
 // 			CreateServiceFunc: func(params models.CreateServiceParams) error {
-// 				panic("mock out the CreateServicePayload method")
+// 				panic("mock out the CreateService method")
 // 			},
 // 			DeleteServiceFunc: func(params models.DeleteServiceParams) error {
 // 				panic("mock out the DeleteService method")
 // 			},
+// 			RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+// 				panic("mock out the RenameService method")
+// 			},
 // 		}
 //
 // 		// use mockedIServiceManager in code that requires handler.IServiceManager
@@ -27,15 +30,18 @@ import (
 //
 // 	}
 type IServiceManagerMock struct {
-	// CreateServiceFunc mocks the CreateServicePayload method.
+	// CreateServiceFunc mocks the CreateService method.
 	CreateServiceFunc func(params models.CreateServiceParams) error
 
 	// DeleteServiceFunc mocks the DeleteService method.
 	DeleteServiceFunc func(params models.DeleteServiceParams) error
 
+	// RenameServiceFunc mocks the RenameService method.
+	RenameServiceFunc func(params models.RenameServiceParams) (*models.RenameServiceResponse, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
-		// CreateServicePayload holds details about calls to the CreateServicePayload method.
+		// CreateService holds details about calls to the CreateService method.
 		CreateService []struct {
 			// Params is the params argument value.
 			Params models.CreateServiceParams
@@ -45,15 +51,21 @@ type IServiceManagerMock struct {
 			// Params is the params argument value.
 			Params models.DeleteServiceParams
 		}
+		// RenameService holds details about calls to the RenameService method.
+		RenameService []struct {
+			// Params is the params argument value.
+			Params models.RenameServiceParams
+		}
 	}
 	lockCreateService sync.RWMutex
 	lockDeleteService sync.RWMutex
+	lockRenameService sync.RWMutex
 }
 
-// CreateServicePayload calls CreateServiceFunc.
+// CreateService calls CreateServiceFunc.
 func (mock *IServiceManagerMock) CreateService(params models.CreateServiceParams) error {
 	if mock.CreateServiceFunc == nil {
-		panic("IServiceManagerMock.CreateServiceFunc: method is nil but IServiceManager.CreateServicePayload was just called")
+		panic("IServiceManagerMock.CreateServiceFunc: method is nil but IServiceManager.CreateService was just called")
 	}
 	callInfo := struct {
 		Params models.CreateServiceParams
@@ -66,7 +78,7 @@ func (mock *IServiceManagerMock) CreateService(params models.CreateServiceParams
 	return mock.CreateServiceFunc(params)
 }
 
-// CreateServiceCalls gets all the calls that were made to CreateServicePayload.
+// CreateServiceCalls gets all the calls that were made to CreateService.
 // Check the length with:
 //     len(mockedIServiceManager.CreateServiceCalls())
 func (mock *IServiceManagerMock) CreateServiceCalls() []struct {
@@ -111,3 +123,34 @@ func (mock *IServiceManagerMock) DeleteServiceCalls() []struct {
 	mock.lockDeleteService.RUnlock()
 	return calls
 }
+
+// RenameService calls RenameServiceFunc.
+func (mock *IServiceManagerMock) RenameService(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+	if mock.RenameServiceFunc == nil {
+		panic("IServiceManagerMock.RenameServiceFunc: method is nil but IServiceManager.RenameService was just called")
+	}
+	callInfo := struct {
+		Params models.RenameServiceParams
+	}{
+		Params: params,
+	}
+	mock.lockRenameService.Lock()
+	mock.calls.RenameService = append(mock.calls.RenameService, callInfo)
+	mock.lockRenameService.Unlock()
+	return mock.RenameServiceFunc(params)
+}
+
+// RenameServiceCalls gets all the calls that were made to RenameService.
+// Check the length with:
+//     len(mockedIServiceManager.RenameServiceCalls())
+func (mock *IServiceManagerMock) RenameServiceCalls() []struct {
+	Params models.RenameServiceParams
+} {
+	var calls []struct {
+		Params models.RenameServiceParams
+	}
+	mock.lockRenameService.RLock()
+	calls = mock.calls.RenameService
+	mock.lockRenameService.RUnlock()
+	return calls
+}
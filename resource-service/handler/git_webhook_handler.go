@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+type IGitWebhookHandler interface {
+	HandleGitWebhook(context *gin.Context)
+}
+
+type GitWebhookHandler struct {
+	GitWebhookManager IGitWebhookManager
+}
+
+func NewGitWebhookHandler(gitWebhookManager IGitWebhookManager) *GitWebhookHandler {
+	return &GitWebhookHandler{
+		GitWebhookManager: gitWebhookManager,
+	}
+}
+
+// HandleGitWebhook godoc
+// @Summary      Receive a push event from the upstream repository
+// @Description  Receive a push webhook of GitHub or GitLab. The webhook is validated using the shared secret stored in the secret git-webhook-{projectName}.
+// @Description  The pushed branch is pulled, and a sh.keptn.event.resource.changed event is sent for each project, stage and service whose resources have been changed
+// @Tags         Git Webhook
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string  true  "The name of the project"
+// @Success      200          {object}  models.HandleGitWebhookResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      401          {object}  models.Error  "Invalid signature"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/gitwebhook [post]
+func (gh *GitWebhookHandler) HandleGitWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params := &models.HandleGitWebhookParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Header:  c.Request.Header,
+		Payload: payload,
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := gh.GitWebhookManager.HandleGitWebhook(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+const gitPushTestPayload = `{"ref":"refs/heads/dev","before":"abc","after":"def"}`
+
+func TestGitWebhookHandler_HandleGitWebhook(t *testing.T) {
+	type fields struct {
+		GitWebhookManager *handler_mock.IGitWebhookManagerMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *http.Request
+		wantProject string
+		wantStatus  int
+	}{
+		{
+			name: "push handled",
+			fields: fields{
+				GitWebhookManager: &handler_mock.IGitWebhookManagerMock{HandleGitWebhookFunc: func(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error) {
+					return &models.HandleGitWebhookResponse{KeptnContext: "my-context"}, nil
+				}},
+			},
+			request:     newGitWebhookRequest("/project/my-project/gitwebhook"),
+			wantProject: "my-project",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name: "project name empty",
+			fields: fields{
+				GitWebhookManager: &handler_mock.IGitWebhookManagerMock{},
+			},
+			request:    newGitWebhookRequest("/project/%20/gitwebhook"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid signature",
+			fields: fields{
+				GitWebhookManager: &handler_mock.IGitWebhookManagerMock{HandleGitWebhookFunc: func(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error) {
+					return nil, errors2.ErrInvalidGitWebhookSignature
+				}},
+			},
+			request:     newGitWebhookRequest("/project/my-project/gitwebhook"),
+			wantProject: "my-project",
+			wantStatus:  http.StatusUnauthorized,
+		},
+		{
+			name: "webhook not enabled",
+			fields: fields{
+				GitWebhookManager: &handler_mock.IGitWebhookManagerMock{HandleGitWebhookFunc: func(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error) {
+					return nil, errors2.ErrGitWebhookNotEnabled
+				}},
+			},
+			request:     newGitWebhookRequest("/project/my-project/gitwebhook"),
+			wantProject: "my-project",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name: "unsupported event",
+			fields: fields{
+				GitWebhookManager: &handler_mock.IGitWebhookManagerMock{HandleGitWebhookFunc: func(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error) {
+					return nil, errors2.ErrUnsupportedGitWebhookEvent
+				}},
+			},
+			request:     newGitWebhookRequest("/project/my-project/gitwebhook"),
+			wantProject: "my-project",
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh := NewGitWebhookHandler(tt.fields.GitWebhookManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/gitwebhook", gh.HandleGitWebhook)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantProject != "" {
+				require.Len(t, tt.fields.GitWebhookManager.HandleGitWebhookCalls(), 1)
+				params := tt.fields.GitWebhookManager.HandleGitWebhookCalls()[0].Params
+				require.Equal(t, tt.wantProject, params.ProjectName)
+				require.Equal(t, gitPushTestPayload, string(params.Payload))
+				require.Equal(t, "push", params.Header.Get("X-GitHub-Event"))
+			} else {
+				require.Empty(t, tt.fields.GitWebhookManager.HandleGitWebhookCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func newGitWebhookRequest(url string) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, url, bytes.NewBufferString(gitPushTestPayload))
+	request.Header.Set("X-GitHub-Event", "push")
+	return request
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+// IGitWebhookManager processes the webhooks sent by the git hosting service of a project
+//
+//go:generate moq -pkg handler_mock -skip-ensure -out ./fake/git_webhook_manager_mock.go . IGitWebhookManager
+type IGitWebhookManager interface {
+	HandleGitWebhook(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error)
+}
+
+type GitWebhookManager struct {
+	git              common.IGit
+	credentialReader common.CredentialReader
+	secretReader     common.GitWebhookSecretReader
+	fileSystem       common.IFileSystem
+	eventPublisher   common.EventPublisher
+}
+
+func NewGitWebhookManager(git common.IGit, credentialReader common.CredentialReader, secretReader common.GitWebhookSecretReader, fileSystem common.IFileSystem, eventPublisher common.EventPublisher) *GitWebhookManager {
+	return &GitWebhookManager{
+		git:              git,
+		credentialReader: credentialReader,
+		secretReader:     secretReader,
+		fileSystem:       fileSystem,
+		eventPublisher:   eventPublisher,
+	}
+}
+
+// HandleGitWebhook pulls the branch that has been pushed to, and sends a sh.keptn.event.resource.changed event for each
+// project, stage and service whose resources have been changed by the pushed commits. Pushes of the resource-service itself
+// are ignored, since the changes have been made via Keptn already. The events of a push always have the same IDs, so that
+// consumers can detect duplicates if the webhook is delivered again after some of the events could not be sent
+func (m GitWebhookManager) HandleGitWebhook(params models.HandleGitWebhookParams) (*models.HandleGitWebhookResponse, error) {
+	secret, err := m.secretReader.GetWebhookSecret(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+	push, err := common.ParseGitPushEvent(params.Header, params.Payload, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.HandleGitWebhookResponse{Changes: []models.ResourceChangedEventData{}}
+	if push == nil {
+		return response, nil
+	}
+
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := m.establishContext(params.ProjectName)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := m.git.GetDefaultBranch(*gitContext)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGetDefBranch, params.ProjectName, err)
+	}
+	if config.Global.DirectoryStageStructure && push.Branch != defaultBranch {
+		logger.Infof("Ignoring push to branch %s of project %s: stages are stored in the branch %s", push.Branch, params.ProjectName, defaultBranch)
+		return response, nil
+	}
+
+	if err := m.git.CheckoutBranch(*gitContext, push.Branch); err != nil {
+		if errors.Is(err, kerrors.ErrReferenceNotFound) {
+			// e.g. a feature branch that does not belong to a stage
+			logger.Infof("Ignoring push to branch %s of project %s: branch is not known", push.Branch, params.ProjectName)
+			return response, nil
+		}
+		return nil, err
+	}
+	if err := m.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	if m.isPushedByKeptn(*gitContext, *push) {
+		logger.Infof("Ignoring push to branch %s of project %s: revision %s has been committed by Keptn", push.Branch, params.ProjectName, push.After)
+		return response, nil
+	}
+	revision, err := m.git.GetCurrentRevision(*gitContext)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotGetRevision, params.ProjectName, err)
+	}
+
+	response.Changes = m.getChanges(params.ProjectName, *push, defaultBranch, revision)
+	if len(response.Changes) == 0 {
+		return response, nil
+	}
+
+	pushedRevision := push.After
+	if pushedRevision == "" {
+		pushedRevision = revision
+	}
+	response.KeptnContext = getGitWebhookKeptnContext(params.ProjectName, push.Branch, pushedRevision)
+	for _, change := range response.Changes {
+		if err := m.eventPublisher.Publish(newResourceChangedEvent(response.KeptnContext, change)); err != nil {
+			return nil, fmt.Errorf(kerrors.ErrMsgCouldNotPublishEvent, models.ResourceChangedEventType, params.ProjectName, err)
+		}
+	}
+	return response, nil
+}
+
+func (m GitWebhookManager) establishContext(project string) (*common_models.GitContext, error) {
+	credentials, err := getCredentials(m.credentialReader, m.fileSystem, project)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := getAuthMethod(credentials)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     project,
+		Credentials: credentials,
+		AuthMethod:  *auth,
+	}
+
+	if !projectExists(m.git, m.fileSystem, gitContext) {
+		return nil, kerrors.ErrProjectNotFound
+	}
+	return &gitContext, nil
+}
+
+// getChanges groups the pushed paths by the project, stage and service they belong to
+func (m GitWebhookManager) getChanges(project string, push common_models.GitPushEvent, defaultBranch string, revision string) []models.ResourceChangedEventData {
+	changes := []models.ResourceChangedEventData{}
+	indexes := map[string]int{}
+	for _, path := range push.Paths {
+		stage, service, resourceURI := m.getEntityOfPath(project, push.Branch, defaultBranch, path)
+		key := stage + "/" + service
+		index, ok := indexes[key]
+		if !ok {
+			index = len(changes)
+			indexes[key] = index
+			changes = append(changes, models.ResourceChangedEventData{
+				Project:  project,
+				Stage:    stage,
+				Service:  service,
+				Branch:   push.Branch,
+				CommitID: revision,
+			})
+		}
+		changes[index].ChangedResources = append(changes[index].ChangedResources, resourceURI)
+	}
+	return changes
+}
+
+// getEntityOfPath determines the stage and service of the given path within the repository, as well as the URI of the resource
+// relative to its stage or service
+func (m GitWebhookManager) getEntityOfPath(project string, branch string, defaultBranch string, path string) (string, string, string) {
+	var stage, stagePath string
+	if config.Global.DirectoryStageStructure {
+		stageDirPrefix := common.StageDirectoryName + "/"
+		if !strings.HasPrefix(path, stageDirPrefix) {
+			return "", "", path
+		}
+		segments := strings.SplitN(strings.TrimPrefix(path, stageDirPrefix), "/", 2)
+		if len(segments) < 2 {
+			return "", "", path
+		}
+		stage, stagePath, path = segments[0], stageDirPrefix+segments[0]+"/", segments[1]
+	} else {
+		if branch == defaultBranch {
+			return "", "", path
+		}
+		stage = branch
+	}
+
+	segments := strings.SplitN(path, "/", 2)
+	if len(segments) == 2 && m.fileSystem.FileExists(common.GetProjectConfigPath(project)+"/"+stagePath+segments[0]+"/metadata.yaml") {
+		return stage, segments[0], segments[1]
+	}
+	return stage, "", path
+}
+
+// isPushedByKeptn checks whether the pushed revision has been committed by the resource-service. A push of the resource-service
+// only contains its own commits, since it pulls the changes of the upstream before committing
+func (m GitWebhookManager) isPushedByKeptn(gitContext common_models.GitContext, push common_models.GitPushEvent) bool {
+	if push.After == "" {
+		return false
+	}
+	commit, err := m.git.GetBranchRevisionAt(gitContext, push.Branch, push.After)
+	if err != nil {
+		logger.Warnf("Could not retrieve pushed revision %s of project %s: %v", push.After, gitContext.Project, err)
+		return false
+	}
+	return commit != nil && commit.CommitID == push.After && common.IsCommittedByKeptn(*commit)
+}
+
+// getGitWebhookKeptnContext derives the context of the events from the pushed revision, so that the events of a push are
+// the same each time the webhook is delivered
+func getGitWebhookKeptnContext(project string, branch string, revision string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s/%s/%s", project, branch, revision))).String()
+}
+
+func newResourceChangedEvent(keptnContext string, data models.ResourceChangedEventData) apimodels.KeptnContextExtendedCE {
+	event := newEvent(models.ResourceChangedEventType, keptnContext, data)
+	event.ID = uuid.NewSHA1(uuid.MustParse(keptnContext), []byte(data.Stage+"/"+data.Service)).String()
+	event.GitCommitID = data.CommitID
+	return event
+}
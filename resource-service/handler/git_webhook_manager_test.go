@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	common_mock "github.com/keptn/keptn/resource-service/common/fake"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+type gitWebhookManagerTestFields struct {
+	git              *common_mock.IGitMock
+	credentialReader *common_mock.CredentialReaderMock
+	secretReader     *common_mock.GitWebhookSecretReaderMock
+	fileSystem       *common_mock.IFileSystemMock
+	eventPublisher   *common_mock.EventPublisherMock
+}
+
+func TestGitWebhookManager_HandleGitWebhook(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	m := newTestGitWebhookManager(fields)
+
+	response, err := m.HandleGitWebhook(getGitLabPushParams(`{
+		"ref": "refs/heads/dev",
+		"before": "abc",
+		"after": "def",
+		"commits": [
+			{"added": ["carts/helm/values.yaml"], "modified": ["slo.yaml"]},
+			{"modified": ["carts/helm/values.yaml"], "removed": ["carts/jmeter/load.jmx"]}
+		]
+	}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.NotEmpty(t, response.KeptnContext)
+	require.Equal(t, []models.ResourceChangedEventData{
+		{
+			Project:          "my-project",
+			Stage:            "dev",
+			Service:          "carts",
+			Branch:           "dev",
+			CommitID:         "def",
+			ChangedResources: []string{"helm/values.yaml", "jmeter/load.jmx"},
+		},
+		{
+			Project:          "my-project",
+			Stage:            "dev",
+			Branch:           "dev",
+			CommitID:         "def",
+			ChangedResources: []string{"slo.yaml"},
+		},
+	}, response.Changes)
+
+	require.Len(t, fields.git.CheckoutBranchCalls(), 1)
+	require.Equal(t, "dev", fields.git.CheckoutBranchCalls()[0].Branch)
+	require.Len(t, fields.git.PullCalls(), 1)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 2)
+	for i, call := range fields.eventPublisher.PublishCalls() {
+		require.Equal(t, models.ResourceChangedEventType, *call.Event.Type)
+		require.Equal(t, response.KeptnContext, call.Event.Shkeptncontext)
+		require.Equal(t, "def", call.Event.GitCommitID)
+		require.Equal(t, response.Changes[i], call.Event.Data)
+	}
+}
+
+func TestGitWebhookManager_HandleGitWebhook_DefaultBranch(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	m := newTestGitWebhookManager(fields)
+
+	response, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/main","after":"def","commits":[{"modified":["carts/metadata.yaml"]}]}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.Equal(t, []models.ResourceChangedEventData{
+		{
+			Project:          "my-project",
+			Branch:           "main",
+			CommitID:         "def",
+			ChangedResources: []string{"carts/metadata.yaml"},
+		},
+	}, response.Changes)
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+}
+
+func TestGitWebhookManager_HandleGitWebhook_DirectoryStageStructure(t *testing.T) {
+	config.Global.DirectoryStageStructure = true
+	defer func() {
+		config.Global.DirectoryStageStructure = false
+	}()
+	fields := getTestGitWebhookManagerFields()
+	fields.fileSystem.FileExistsFunc = func(path string) bool {
+		return path == "/data/config/my-project/.keptn-stages/dev/carts/metadata.yaml"
+	}
+	m := newTestGitWebhookManager(fields)
+
+	response, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/main","after":"def","commits":[{"modified":[".keptn-stages/dev/carts/helm/values.yaml","shipyard.yaml"]}]}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.Equal(t, []models.ResourceChangedEventData{
+		{
+			Project:          "my-project",
+			Stage:            "dev",
+			Service:          "carts",
+			Branch:           "main",
+			CommitID:         "def",
+			ChangedResources: []string{"helm/values.yaml"},
+		},
+		{
+			Project:          "my-project",
+			Branch:           "main",
+			CommitID:         "def",
+			ChangedResources: []string{"shipyard.yaml"},
+		},
+	}, response.Changes)
+
+	// pushes to other branches do not affect the stages
+	response, err = m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def","commits":[{"modified":["carts/helm/values.yaml"]}]}`, "my-secret"))
+	require.Nil(t, err)
+	require.Empty(t, response.Changes)
+	require.Len(t, fields.eventPublisher.PublishCalls(), 2)
+}
+
+func TestGitWebhookManager_HandleGitWebhook_UnknownBranch(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	fields.git.CheckoutBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return errors2.ErrReferenceNotFound
+	}
+	m := newTestGitWebhookManager(fields)
+
+	response, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/keptn/dev-1","after":"def","commits":[{"modified":["slo.yaml"]}]}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.Empty(t, response.KeptnContext)
+	require.Empty(t, response.Changes)
+	require.Empty(t, fields.git.PullCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestGitWebhookManager_HandleGitWebhook_InvalidToken(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	m := newTestGitWebhookManager(fields)
+
+	_, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def"}`, "invalid"))
+
+	require.ErrorIs(t, err, errors2.ErrInvalidGitWebhookSignature)
+	require.Empty(t, fields.credentialReader.GetCredentialsCalls())
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestGitWebhookManager_HandleGitWebhook_NotEnabled(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	fields.secretReader.GetWebhookSecretFunc = func(project string) (string, error) {
+		return "", errors2.ErrGitWebhookNotEnabled
+	}
+	m := newTestGitWebhookManager(fields)
+
+	_, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def"}`, "my-secret"))
+
+	require.ErrorIs(t, err, errors2.ErrGitWebhookNotEnabled)
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestGitWebhookManager_HandleGitWebhook_ProjectDoesNotExist(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+	m := newTestGitWebhookManager(fields)
+
+	_, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def"}`, "my-secret"))
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestGitWebhookManager_HandleGitWebhook_PushedByKeptn(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	fields.git.GetBranchRevisionAtFunc = func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+		return &common_models.GitCommit{CommitID: revision, CommitterName: "keptn", CommitterEmail: "keptn@keptn.sh"}, nil
+	}
+	m := newTestGitWebhookManager(fields)
+
+	response, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def","commits":[{"modified":["slo.yaml"]}]}`, "my-secret"))
+
+	require.Nil(t, err)
+	require.Empty(t, response.Changes)
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+	require.Len(t, fields.git.GetBranchRevisionAtCalls(), 1)
+	require.Equal(t, "def", fields.git.GetBranchRevisionAtCalls()[0].Revision)
+}
+
+func TestGitWebhookManager_HandleGitWebhook_Redelivered(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	m := newTestGitWebhookManager(fields)
+	payload := `{"ref":"refs/heads/dev","after":"def","commits":[{"modified":["slo.yaml","carts/helm/values.yaml"]}]}`
+
+	first, err := m.HandleGitWebhook(getGitLabPushParams(payload, "my-secret"))
+	require.Nil(t, err)
+	second, err := m.HandleGitWebhook(getGitLabPushParams(payload, "my-secret"))
+	require.Nil(t, err)
+
+	// the events of a push that is delivered again are the same, so that consumers can ignore duplicates
+	require.Equal(t, first.KeptnContext, second.KeptnContext)
+	calls := fields.eventPublisher.PublishCalls()
+	require.Len(t, calls, 4)
+	require.Equal(t, calls[0].Event.ID, calls[2].Event.ID)
+	require.Equal(t, calls[1].Event.ID, calls[3].Event.ID)
+	require.NotEqual(t, calls[0].Event.ID, calls[1].Event.ID)
+
+	other, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"ghi","commits":[{"modified":["slo.yaml"]}]}`, "my-secret"))
+	require.Nil(t, err)
+	require.NotEqual(t, first.KeptnContext, other.KeptnContext)
+}
+
+func TestGitWebhookManager_HandleGitWebhook_CannotPublishEvent(t *testing.T) {
+	fields := getTestGitWebhookManagerFields()
+	fields.eventPublisher.PublishFunc = func(event apimodels.KeptnContextExtendedCE) error {
+		return errors.New("oops")
+	}
+	m := newTestGitWebhookManager(fields)
+
+	_, err := m.HandleGitWebhook(getGitLabPushParams(`{"ref":"refs/heads/dev","after":"def","commits":[{"modified":["slo.yaml"]}]}`, "my-secret"))
+
+	require.NotNil(t, err)
+	require.Len(t, fields.git.PullCalls(), 1)
+}
+
+func newTestGitWebhookManager(fields gitWebhookManagerTestFields) *GitWebhookManager {
+	return NewGitWebhookManager(fields.git, fields.credentialReader, fields.secretReader, fields.fileSystem, fields.eventPublisher)
+}
+
+func getGitLabPushParams(payload string, token string) models.HandleGitWebhookParams {
+	header := http.Header{}
+	header.Set("X-Gitlab-Event", "Push Hook")
+	header.Set("X-Gitlab-Token", token)
+	return models.HandleGitWebhookParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Header:  header,
+		Payload: []byte(payload),
+	}
+}
+
+func getTestGitWebhookManagerFields() gitWebhookManagerTestFields {
+	return gitWebhookManagerTestFields{
+		git: &common_mock.IGitMock{
+			ProjectExistsFunc: func(gitContext common_models.GitContext) bool {
+				return true
+			},
+			GetDefaultBranchFunc: func(gitContext common_models.GitContext) (string, error) {
+				return "main", nil
+			},
+			CheckoutBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+				return nil
+			},
+			PullFunc: func(gitContext common_models.GitContext) error {
+				return nil
+			},
+			GetCurrentRevisionFunc: func(gitContext common_models.GitContext) (string, error) {
+				return "def", nil
+			},
+			GetBranchRevisionAtFunc: func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+				return &common_models.GitCommit{CommitID: revision, CommitterName: "GitHub", CommitterEmail: "noreply@github.com"}, nil
+			},
+		},
+		credentialReader: &common_mock.CredentialReaderMock{
+			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
+				return &common_models.GitCredentials{
+					User: "my-user",
+					HttpsAuth: &apimodels.HttpsGitAuth{
+						Token: "my-token",
+					},
+					RemoteURL: "my-remote-uri",
+				}, nil
+			},
+		},
+		secretReader: &common_mock.GitWebhookSecretReaderMock{
+			GetWebhookSecretFunc: func(project string) (string, error) {
+				return "my-secret", nil
+			},
+		},
+		fileSystem: &common_mock.IFileSystemMock{
+			FileExistsFunc: func(path string) bool {
+				return path == "/data/config/my-project/carts/metadata.yaml"
+			},
+		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+				return nil
+			},
+		},
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/keptn/go-utils/pkg/common/retry"
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 	"gopkg.in/yaml.v3"
@@ -40,7 +41,9 @@ func NewProjectManager(git common.IGit, credentialReader common.CredentialReader
 }
 
 func (p ProjectManager) CreateProject(project models.CreateProjectParams) error {
-	common.LockProject(project.ProjectName)
+	if err := common.LockProject(project.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(project.ProjectName)
 	projectDirectory := common.GetProjectConfigPath(project.ProjectName)
 
@@ -60,6 +63,11 @@ func (p ProjectManager) CreateProject(project models.CreateProjectParams) error
 		AuthMethod:  *auth,
 	}
 
+	// if another replica has deleted the project and created it again with a different upstream, the local clone is outdated
+	if config.Global.CloneProjectsOnDemand && p.fileSystem.FileExists(projectDirectory) && isStaleProjectClone(p.git, gitContext) {
+		discardProjectClone(p.fileSystem, project.ProjectName)
+	}
+
 	// first, check if the local directory of the project already exists
 	// if yes, we can definitely say that this is an attempt to create the same project again
 
@@ -127,7 +135,9 @@ func (p ProjectManager) isProjectInitialized(project string) bool {
 }
 
 func (p ProjectManager) UpdateProject(project models.UpdateProjectParams) error {
-	common.LockProject(project.ProjectName)
+	if err := common.LockProject(project.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(project.ProjectName)
 
 	currentCredentials, err := p.credentialReader.GetCredentials(project.ProjectName)
@@ -228,7 +238,9 @@ func (p ProjectManager) migrateProject(project models.UpdateProjectParams, gitCo
 }
 
 func (p ProjectManager) DeleteProject(projectName string) error {
-	common.LockProject(projectName)
+	if err := common.LockProject(projectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(projectName)
 
 	if err := p.fileSystem.DeleteFile(common.GetProjectConfigPath(projectName)); err != nil {
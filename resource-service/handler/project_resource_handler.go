@@ -14,7 +14,16 @@ type IProjectResourceHandler interface {
 	UpdateProjectResources(context *gin.Context)
 	GetProjectResource(context *gin.Context)
 	UpdateProjectResource(context *gin.Context)
+	GetProjectResourceContent(context *gin.Context)
+	UpdateProjectResourceContent(context *gin.Context)
 	DeleteProjectResource(context *gin.Context)
+	GetProjectResourceHistory(context *gin.Context)
+	GetProjectResourceDiff(context *gin.Context)
+	GetProjectResourcesDiff(context *gin.Context)
+	RevertProjectResource(context *gin.Context)
+	GetProjectChangeRequest(context *gin.Context)
+	GetProjectArchive(context *gin.Context)
+	ImportProjectArchive(context *gin.Context)
 }
 
 type ProjectResourceHandler struct {
@@ -45,6 +54,7 @@ func (ph *ProjectResourceHandler) CreateProjectResources(c *gin.Context) {
 	params := &models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -132,6 +142,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 	params := &models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -168,8 +179,10 @@ func (ph *ProjectResourceHandler) UpdateProjectResources(c *gin.Context) {
 // @Param        projectName                                 path    string  true  "The name of the project"
 // @Param        resourceURI                           path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource with the variables of the project"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
 // @Failure      500          {object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/resource/{resourceURI} [get]
 func (ph *ProjectResourceHandler) GetProjectResource(c *gin.Context) {
@@ -220,6 +233,7 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 	params := &models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -245,6 +259,99 @@ func (ph *ProjectResourceHandler) UpdateProjectResource(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetProjectResourceContent godoc
+// @Summary      Get raw content of a project resource
+// @Description  Streams the raw content of a resource of the project. The git commit ID of the content is returned in the X-Keptn-Resource-Version header
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource with the variables of the project"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/raw [get]
+func (ph *ProjectResourceHandler) GetProjectResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	stream, err := ph.ProjectResourceManager.GetResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	writeResourceStream(c, stream)
+}
+
+// UpdateProjectResourceContent godoc
+// @Summary      Updates the raw content of a project resource
+// @Description  Creates or updates a resource of the project with the raw content of the request body, or of the 'file' field of a multipart form
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream,mpfd
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        file         formData  file  false  "The content of the resource, if sent as multipart form"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      413          {object}  models.Error  "Resource too large"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/raw [put]
+func (ph *ProjectResourceHandler) UpdateProjectResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	content, err := getResourceStreamContent(c)
+	if err != nil {
+		setResourceStreamContentError(c, err)
+		return
+	}
+
+	params.Content = content
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.UpdateResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // DeleteProjectResource godoc
 // @Summary      Deletes a project resource
 // @Description  Deletes a project resource
@@ -263,6 +370,7 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 	params := &models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -280,3 +388,317 @@ func (ph *ProjectResourceHandler) DeleteProjectResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetProjectResourceHistory godoc
+// @Summary      Get history of a project resource
+// @Description  Get the commits that changed a resource of the project, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        limit        query  int  false  "The maximum number of commits to return"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/history [get]
+func (ph *ProjectResourceHandler) GetProjectResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ProjectResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetProjectResourceDiff godoc
+// @Summary      Get diff of a project resource
+// @Description  Get the unified diff of a resource of the project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// GetProjectResourcesDiff godoc
+// @Summary      Get diff of all project resources
+// @Description  Get the unified diff of all resources of the project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/diff [get]
+func (ph *ProjectResourceHandler) GetProjectResourcesDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		},
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ProjectResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertProjectResource godoc
+// @Summary      Reverts a project resource
+// @Description  Reverts a resource of the project to the content of an earlier revision by creating a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        revision     body  models.RevertResourcePayload  true  "The commit ID to revert to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/resource/{resourceURI}/revert [post]
+func (ph *ProjectResourceHandler) RevertProjectResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ProjectResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetProjectChangeRequest godoc
+// @Summary      Get a change request of a project
+// @Description  Get the state of a change request that has been opened for changes of the project. Only available if the project uses the pull-request based change flow
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName      path  string  true  "The name of the project"
+// @Param        changeRequestID  path  string  true  "The ID of the change request"
+// @Success      200              {object}  models.ChangeRequest
+// @Failure      400              {object}  models.Error  "Invalid payload"
+// @Failure      404              {object}  models.Error  "Not found"
+// @Failure      500              {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/changerequest/{changeRequestID} [get]
+func (ph *ProjectResourceHandler) GetProjectChangeRequest(c *gin.Context) {
+	params := &models.GetChangeRequestParams{
+		Project:         models.Project{ProjectName: c.Param(pathParamProjectName)},
+		ChangeRequestID: c.Param(pathParamChangeRequestID),
+	}
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	changeRequest, err := ph.ProjectResourceManager.GetChangeRequest(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changeRequest)
+}
+
+// GetProjectArchive godoc
+// @Summary      Get an archive of a project
+// @Description  Streams an archive containing the resources of all stages and services of the project. Resources of stages are stored in the .keptn-stages/{stageName} directory of the archive.
+// @Description  The git commit ID of the default branch is returned in the X-Keptn-Resource-Version header
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      octet-stream
+// @Param        projectName  path   string  true   "The name of the project"
+// @Param        commitID     query  string  false  "The commit ID the archive is created for. Defaults to the latest revision"
+// @Param        format       query  string  false  "The format of the archive, either tar.gz or zip. Defaults to tar.gz"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/archive [get]
+func (ph *ProjectResourceHandler) GetProjectArchive(c *gin.Context) {
+	params := &models.GetProjectArchiveParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+	}
+	getArchive := &models.GetProjectArchiveQuery{}
+	if err := c.ShouldBindQuery(getArchive); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetProjectArchiveQuery = *getArchive
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	stream, err := ph.ProjectResourceManager.GetProjectArchive(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	writeArchiveStream(c, params.ProjectName, params.GetFormat(), stream)
+}
+
+// ImportProjectArchive godoc
+// @Summary      Import an archive into a project
+// @Description  Writes the files of an archive to the project, using the same layout as archives returned for the project. The files of each branch are committed at once.
+// @Description  The archive is uploaded either as raw request body, or as the 'file' field of a multipart form
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Project Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream,mpfd
+// @Produce      json
+// @Param        projectName  path      string  true   "The name of the project"
+// @Param        format       query     string  false  "The format of the archive, either tar.gz or zip. Defaults to tar.gz"
+// @Param        file         formData  file    false  "The archive, if sent as multipart form"
+// @Success      200          {object}  models.ImportProjectArchiveResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      413          {object}  models.Error  "Archive too large"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/archive [put]
+func (ph *ProjectResourceHandler) ImportProjectArchive(c *gin.Context) {
+	params := &models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Author:  getAuthor(c),
+		},
+	}
+	importArchive := &models.ProjectArchiveQuery{}
+	if err := c.ShouldBindQuery(importArchive); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.ProjectArchiveQuery = *importArchive
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	content, err := getArchiveStreamContent(c)
+	if err != nil {
+		setResourceStreamContentError(c, toArchiveError(err))
+		return
+	}
+
+	params.Content = content
+
+	result, err := ph.ProjectResourceManager.ImportProjectArchive(*params)
+	if err != nil {
+		OnAPIError(c, toArchiveError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
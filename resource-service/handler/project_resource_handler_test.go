@@ -4,7 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
+	"mime/multipart"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/config"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
@@ -80,6 +85,12 @@ var testGetResourcesResponse = models.GetResourcesResponse{
 	TotalCount: 2,
 }
 
+func withPrincipal(request *http.Request, name string, email string) *http.Request {
+	request.Header.Set("X-Keptn-Principal", name)
+	request.Header.Set("X-Keptn-Principal-Email", email)
+	return request
+}
+
 func TestProjectResourceHandler_CreateProjectResources(t *testing.T) {
 	type fields struct {
 		ProjectResourceManager *handler_mock.IResourceManagerMock
@@ -114,6 +125,30 @@ func TestProjectResourceHandler_CreateProjectResources(t *testing.T) {
 			},
 			wantStatus: http.StatusCreated,
 		},
+		{
+			name: "create resource on behalf of principal",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{CreateResourcesFunc: func(project models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+					return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+				}},
+			},
+			request: withPrincipal(httptest.NewRequest(http.MethodPost, "/project/my-project/resource", bytes.NewBuffer([]byte(createResourcesTestPayload))), "my-token", "my-token@keptn.sh"),
+			wantParams: &models.CreateResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Author:  &models.Author{Name: "my-token", Email: "my-token@keptn.sh"},
+				},
+				CreateResourcesPayload: models.CreateResourcesPayload{
+					Resources: []models.Resource{
+						{
+							ResourceURI:     "resource.yaml",
+							ResourceContent: "c3RyaW5n",
+						},
+					},
+				},
+			},
+			wantStatus: http.StatusCreated,
+		},
 		{
 			name: "resource content not base64 encoded",
 			fields: fields{
@@ -850,3 +885,570 @@ func TestProjectResourceHandler_DeleteProjectResource(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectResourceHandler_GetProjectChangeRequest(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetChangeRequestParams
+		wantStatus int
+	}{
+		{
+			name: "get change request",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetChangeRequestFunc: func(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+					return &models.ChangeRequest{ID: "1", State: models.ChangeRequestStateMerged}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/changerequest/1", nil),
+			wantParams: &models.GetChangeRequestParams{
+				Project:         models.Project{ProjectName: "my-project"},
+				ChangeRequestID: "1",
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "project name empty",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/%20/changerequest/1", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "change requests not enabled",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetChangeRequestFunc: func(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+					return nil, errors2.ErrChangeRequestsNotEnabled
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/changerequest/1", nil),
+			wantParams: &models.GetChangeRequestParams{
+				Project:         models.Project{ProjectName: "my-project"},
+				ChangeRequestID: "1",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "change request not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetChangeRequestFunc: func(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+					return nil, errors2.ErrChangeRequestNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/changerequest/2", nil),
+			wantParams: &models.GetChangeRequestParams{
+				Project:         models.Project{ProjectName: "my-project"},
+				ChangeRequestID: "2",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/changerequest/:changeRequestID", ph.GetProjectChangeRequest)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetChangeRequestCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetChangeRequestCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetChangeRequestCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourceContent(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		request     *http.Request
+		wantParams  *models.GetResourceParams
+		wantStatus  int
+		wantContent string
+	}{
+		{
+			name: "get resource content",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetResourceStreamFunc: func(params models.GetResourceParams) (*models.ResourceStream, error) {
+					return &models.ResourceStream{
+						Content:  io.NopCloser(strings.NewReader("string")),
+						Size:     6,
+						Metadata: models.Version{Version: "my-commit-id"},
+					}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/raw?gitCommitID=my-commit-id", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI:      "resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{GitCommitID: "my-commit-id"},
+			},
+			wantStatus:  http.StatusOK,
+			wantContent: "string",
+		},
+		{
+			name: "resource not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetResourceStreamFunc: func(params models.GetResourceParams) (*models.ResourceStream, error) {
+					return nil, errors2.ErrResourceNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/resource/resource.yaml/raw", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "resource.yaml",
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "resourceUri contains invalid string",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/resource/..resource.yaml/raw", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/resource/:resourceURI/raw", ph.GetProjectResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceStreamCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceStreamCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceStreamCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+			if tt.wantContent != "" {
+				require.Equal(t, tt.wantContent, resp.Body.String())
+				require.Equal(t, "my-commit-id", resp.Header().Get("X-Keptn-Resource-Version"))
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_UpdateProjectResourceContent(t *testing.T) {
+	config.Global.MaxResourceSizeMB = 1
+	defer func() { config.Global.MaxResourceSizeMB = 0 }()
+
+	multipartBody := &bytes.Buffer{}
+	multipartWriter := multipart.NewWriter(multipartBody)
+	_ = multipartWriter.WriteField("description", "my-description")
+	file, _ := multipartWriter.CreateFormFile("file", "resource.yaml")
+	_, _ = file.Write([]byte("string"))
+	_ = multipartWriter.Close()
+
+	emptyMultipartBody := &bytes.Buffer{}
+	emptyMultipartWriter := multipart.NewWriter(emptyMultipartBody)
+	_ = emptyMultipartWriter.Close()
+
+	newRequest := func(body io.Reader, contentType string) *http.Request {
+		request := httptest.NewRequest(http.MethodPut, "/project/my-project/resource/resource.yaml/raw", body)
+		request.Header.Set("Content-Type", contentType)
+		return request
+	}
+
+	tests := []struct {
+		name        string
+		request     *http.Request
+		managerErr  error
+		wantCalled  bool
+		wantContent string
+		wantStatus  int
+	}{
+		{
+			name:        "raw request body",
+			request:     newRequest(strings.NewReader("string"), "application/octet-stream"),
+			wantCalled:  true,
+			wantContent: "string",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "multipart form",
+			request:     newRequest(bytes.NewReader(multipartBody.Bytes()), multipartWriter.FormDataContentType()),
+			wantCalled:  true,
+			wantContent: "string",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "multipart form without file",
+			request:    newRequest(bytes.NewReader(emptyMultipartBody.Bytes()), emptyMultipartWriter.FormDataContentType()),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "content length exceeds maximum size",
+			request:    newRequest(strings.NewReader(strings.Repeat("a", 1024*1024+1)), "application/octet-stream"),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "resource too large",
+			request:     newRequest(strings.NewReader("string"), "application/octet-stream"),
+			managerErr:  errors2.ErrResourceTooLarge,
+			wantCalled:  true,
+			wantContent: "string",
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "resourceUri contains invalid string",
+			request:    httptest.NewRequest(http.MethodPut, "/project/my-project/resource/..resource.yaml/raw", strings.NewReader("string")),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var content []byte
+			manager := &handler_mock.IResourceManagerMock{UpdateResourceStreamFunc: func(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error) {
+				content, _ = io.ReadAll(params.Content)
+				if tt.managerErr != nil {
+					return nil, tt.managerErr
+				}
+				return &models.WriteResourceResponse{CommitID: "my-commit-id"}, nil
+			}}
+			ph := NewProjectResourceHandler(manager)
+
+			router := gin.Default()
+			router.PUT("/project/:projectName/resource/:resourceURI/raw", ph.UpdateProjectResourceContent)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantCalled {
+				require.Len(t, manager.UpdateResourceStreamCalls(), 1)
+				params := manager.UpdateResourceStreamCalls()[0].Params
+				require.Equal(t, models.ResourceContext{Project: models.Project{ProjectName: "my-project"}}, params.ResourceContext)
+				require.Equal(t, "resource.yaml", params.ResourceURI)
+				require.Equal(t, tt.wantContent, string(content))
+			} else {
+				require.Empty(t, manager.UpdateResourceStreamCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectArchive(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		request         *http.Request
+		wantParams      *models.GetProjectArchiveParams
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+	}{
+		{
+			name: "get archive",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetProjectArchiveFunc: func(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+					return &models.ResourceStream{
+						Content:  io.NopCloser(strings.NewReader("archive")),
+						Size:     7,
+						Metadata: models.Version{Version: "my-commit-id"},
+					}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/archive?commitID=my-commit-id", nil),
+			wantParams: &models.GetProjectArchiveParams{
+				Project:                models.Project{ProjectName: "my-project"},
+				GetProjectArchiveQuery: models.GetProjectArchiveQuery{GitCommitID: "my-commit-id"},
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/gzip",
+			wantFilename:    "my-project.tar.gz",
+		},
+		{
+			name: "get zip archive",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetProjectArchiveFunc: func(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+					return &models.ResourceStream{
+						Content:  io.NopCloser(strings.NewReader("archive")),
+						Size:     7,
+						Metadata: models.Version{Version: "my-commit-id"},
+					}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/archive?format=zip", nil),
+			wantParams: &models.GetProjectArchiveParams{
+				Project: models.Project{ProjectName: "my-project"},
+				GetProjectArchiveQuery: models.GetProjectArchiveQuery{
+					ProjectArchiveQuery: models.ProjectArchiveQuery{Format: models.ArchiveFormatZip},
+				},
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/zip",
+			wantFilename:    "my-project.zip",
+		},
+		{
+			name: "project not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{GetProjectArchiveFunc: func(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+					return nil, errors2.ErrProjectNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/archive", nil),
+			wantParams: &models.GetProjectArchiveParams{
+				Project: models.Project{ProjectName: "my-project"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "unknown format",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/archive?format=rar", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/archive", ph.GetProjectArchive)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetProjectArchiveCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetProjectArchiveCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetProjectArchiveCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+			if tt.wantStatus == http.StatusOK {
+				require.Equal(t, "archive", resp.Body.String())
+				require.Equal(t, tt.wantContentType, resp.Header().Get("Content-Type"))
+				require.Equal(t, "attachment; filename="+tt.wantFilename, resp.Header().Get("Content-Disposition"))
+				require.Equal(t, "my-commit-id", resp.Header().Get("X-Keptn-Resource-Version"))
+			}
+		})
+	}
+}
+
+func TestProjectResourceHandler_ImportProjectArchive(t *testing.T) {
+	config.Global.MaxArchiveSizeMB = 1
+	defer func() { config.Global.MaxArchiveSizeMB = 0 }()
+
+	newRequest := func(url string, body io.Reader) *http.Request {
+		request := httptest.NewRequest(http.MethodPut, url, body)
+		request.Header.Set("Content-Type", "application/octet-stream")
+		return request
+	}
+	// the size of chunked uploads is only known once the body has been read
+	chunkedRequest := newRequest("/project/my-project/archive", strings.NewReader(strings.Repeat("a", 1024*1024+1)))
+	chunkedRequest.ContentLength = -1
+
+	tests := []struct {
+		name        string
+		request     *http.Request
+		managerErr  error
+		wantCalled  bool
+		wantFormat  string
+		wantContent string
+		wantStatus  int
+	}{
+		{
+			name:        "raw request body",
+			request:     newRequest("/project/my-project/archive", strings.NewReader("archive")),
+			wantCalled:  true,
+			wantContent: "archive",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:        "zip archive",
+			request:     newRequest("/project/my-project/archive?format=zip", strings.NewReader("archive")),
+			wantCalled:  true,
+			wantFormat:  models.ArchiveFormatZip,
+			wantContent: "archive",
+			wantStatus:  http.StatusOK,
+		},
+		{
+			name:       "unknown format",
+			request:    newRequest("/project/my-project/archive?format=rar", strings.NewReader("archive")),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "invalid archive",
+			request:     newRequest("/project/my-project/archive", strings.NewReader("archive")),
+			managerErr:  errors2.ErrInvalidArchive,
+			wantCalled:  true,
+			wantContent: "archive",
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "stage not found",
+			request:     newRequest("/project/my-project/archive", strings.NewReader("archive")),
+			managerErr:  errors2.ErrStageNotFound,
+			wantCalled:  true,
+			wantContent: "archive",
+			wantStatus:  http.StatusNotFound,
+		},
+		{
+			name:       "content length exceeds maximum size",
+			request:    newRequest("/project/my-project/archive", strings.NewReader(strings.Repeat("a", 1024*1024+1))),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "body exceeds maximum size",
+			request:     chunkedRequest,
+			wantCalled:  true,
+			wantContent: strings.Repeat("a", 1024*1024),
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var content []byte
+			manager := &handler_mock.IResourceManagerMock{ImportProjectArchiveFunc: func(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error) {
+				var err error
+				content, err = io.ReadAll(params.Content)
+				if err != nil {
+					return nil, err
+				}
+				if tt.managerErr != nil {
+					return nil, tt.managerErr
+				}
+				return &models.ImportProjectArchiveResponse{Commits: []models.WriteResourceResponse{{CommitID: "my-commit-id"}}}, nil
+			}}
+			ph := NewProjectResourceHandler(manager)
+
+			router := gin.Default()
+			router.PUT("/project/:projectName/archive", ph.ImportProjectArchive)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantCalled {
+				require.Len(t, manager.ImportProjectArchiveCalls(), 1)
+				params := manager.ImportProjectArchiveCalls()[0].Params
+				require.Equal(t, models.ResourceContext{Project: models.Project{ProjectName: "my-project"}}, params.ResourceContext)
+				require.Equal(t, tt.wantFormat, params.Format)
+				require.Equal(t, tt.wantContent, string(content))
+			} else {
+				require.Empty(t, manager.ImportProjectArchiveCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestProjectResourceHandler_GetProjectResourcesDiff(t *testing.T) {
+	type fields struct {
+		ProjectResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantResult *models.GetResourceDiffResponse
+		wantStatus int
+	}{
+		{
+			name: "get diff of project",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &models.GetResourceDiffResponse{From: "from-id", To: "to-id", Diff: "my-diff"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/diff?from=from-id&to=to-id", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				GetResourceDiffQuery: models.GetResourceDiffQuery{From: "from-id", To: "to-id"},
+			},
+			wantResult: &models.GetResourceDiffResponse{From: "from-id", To: "to-id", Diff: "my-diff"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "from revision missing",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/diff", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "revision not found",
+			fields: fields{
+				ProjectResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return nil, errors2.ErrResolveRevision
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/diff?from=unknown", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				GetResourceDiffQuery: models.GetResourceDiffQuery{From: "unknown"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewProjectResourceHandler(tt.fields.ProjectResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/diff", ph.GetProjectResourcesDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ProjectResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ProjectResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantResult != nil {
+				result := &models.GetResourceDiffResponse{}
+				err := json.Unmarshal(resp.Body.Bytes(), result)
+				require.Nil(t, err)
+				require.Equal(t, tt.wantResult, result)
+			}
+		})
+	}
+}
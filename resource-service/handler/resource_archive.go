@@ -0,0 +1,384 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/retry"
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+const importArchiveCommitMessage = "Imported project archive"
+
+// archiveRevision is the revision of a branch that is written into a project archive. The files of stage branches are
+// stored in the stage directory of the archive, to get the same layout regardless of the stage structure of the project
+type archiveRevision struct {
+	branch string
+	stage  string
+	commit *common_models.GitCommit
+}
+
+// archiveEntry is a file of an imported archive that has been extracted to a temporary file
+type archiveEntry struct {
+	resourceURI string
+	tmpFile     string
+}
+
+// GetProjectArchive returns an archive containing the resources of all stages and services of a project at the given revision
+func (p ResourceManager) GetProjectArchive(params models.GetProjectArchiveParams) (*models.ResourceStream, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, _, err := p.establishContext(models.ResourceContext{Project: params.Project})
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := p.getArchiveRevisions(gitContext, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// the archive is written to a temporary file, so that errors can still be returned before the response is sent
+	reader, writer := io.Pipe()
+	go func() {
+		_ = writer.CloseWithError(p.writeArchive(gitContext, writer, params.GetFormat(), revisions))
+	}()
+	tmpFile, size, err := p.fileSystem.WriteTempFile(reader)
+	_ = reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	content, _, err := p.fileSystem.OpenFile(tmpFile)
+	if err != nil {
+		p.deleteTempFile(tmpFile)
+		return nil, err
+	}
+
+	stream := newResourceStream(gitContext, "", &tempFileReader{ReadCloser: content, path: tmpFile, fileSystem: p.fileSystem}, size)
+	stream.Metadata.Branch = revisions[0].branch
+	if revisions[0].commit != nil {
+		stream.Metadata.Version = revisions[0].commit.CommitID
+	}
+	return stream, nil
+}
+
+// getArchiveRevisions returns the revisions of the default branch and, if stages are stored in branches, of all stage branches.
+// The revision of the default branch is always the first element
+func (p ResourceManager) getArchiveRevisions(gitContext *common_models.GitContext, params models.GetProjectArchiveParams) ([]archiveRevision, error) {
+	defaultBranch, err := p.git.GetDefaultBranch(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	defaultBranch = strings.TrimPrefix(defaultBranch, "refs/heads/")
+
+	revisions := []archiveRevision{{branch: defaultBranch}}
+	if !config.Global.DirectoryStageStructure {
+		branches, err := p.git.ListBranches(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range branches {
+			// branches containing a slash, e.g. the ones created for change requests, can not be stages
+			if branch != defaultBranch && !strings.Contains(branch, "/") {
+				revisions = append(revisions, archiveRevision{branch: branch, stage: branch})
+			}
+		}
+	}
+
+	for i := range revisions {
+		if params.GitCommitID == "" {
+			resourceContext := models.ResourceContext{Project: params.Project}
+			if revisions[i].stage != "" {
+				resourceContext.Stage = &models.Stage{StageName: revisions[i].stage}
+			}
+			if _, _, err := p.establishContext(resourceContext); err != nil {
+				return nil, err
+			}
+			if err := p.git.Pull(*gitContext); err != nil {
+				return nil, err
+			}
+		}
+		revisions[i].commit, err = p.git.GetBranchRevisionAt(*gitContext, revisions[i].branch, params.GitCommitID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+func (p ResourceManager) writeArchive(gitContext *common_models.GitContext, w io.Writer, format string, revisions []archiveRevision) error {
+	archive, err := common.NewArchiveWriter(w, format)
+	if err != nil {
+		return err
+	}
+	for _, revision := range revisions {
+		// branches that did not exist at the time of the requested revision are not part of the archive
+		if revision.commit == nil {
+			continue
+		}
+		prefix := ""
+		if revision.stage != "" {
+			prefix = common.StageDirectoryName + "/" + revision.stage + "/"
+		}
+		files, err := p.git.ListFiles(*gitContext, revision.commit.CommitID, "")
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := p.writeArchiveEntry(gitContext, archive, revision.commit, file, prefix+file); err != nil {
+				return err
+			}
+		}
+	}
+	return archive.Close()
+}
+
+func (p ResourceManager) writeArchiveEntry(gitContext *common_models.GitContext, archive *common.ArchiveWriter, commit *common_models.GitCommit, repositoryPath string, name string) error {
+	content, size, err := p.git.GetFileRevisionReader(*gitContext, commit.CommitID, repositoryPath)
+	if err != nil {
+		return err
+	}
+	content, size, err = p.resolveLFSStream(gitContext, repositoryPath, content, size)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	return archive.Add(name, content, size, commit.Timestamp)
+}
+
+// ImportProjectArchive writes the files of an archive to the project. The changes of each branch are committed at once
+func (p ResourceManager) ImportProjectArchive(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error) {
+	result, err := p.importProjectArchive(params)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Commits {
+		if _, err := p.awaitWriteResult(params.ProjectName, &result.Commits[i], nil); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p ResourceManager) importProjectArchive(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error) {
+	// like for streamed resources, the archive is received before the project is locked
+	archiveFile, size, err := p.fileSystem.WriteTempFile(params.Content)
+	if err != nil {
+		return nil, err
+	}
+	defer p.deleteTempFile(archiveFile)
+	if size > config.Global.MaxArchiveSizeBytes() {
+		return nil, kerrors.ErrArchiveTooLarge
+	}
+
+	entries, err := p.extractArchive(archiveFile, params.GetFormat())
+	defer func() {
+		for _, stageEntries := range entries {
+			for _, entry := range stageEntries {
+				p.deleteTempFile(entry.tmpFile)
+			}
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]string, 0, len(entries))
+	for stage := range entries {
+		stages = append(stages, stage)
+	}
+	sort.Strings(stages)
+
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	// all stages are checked before anything is written, to not import an archive partially
+	var gitContext *common_models.GitContext
+	configPaths := map[string]string{}
+	for _, stage := range stages {
+		resourceContext := params.ResourceContext
+		if stage != "" {
+			resourceContext.Stage = &models.Stage{StageName: stage}
+		}
+		gitContext, configPaths[stage], err = p.establishContext(resourceContext)
+		if errors.Is(err, kerrors.ErrReferenceNotFound) {
+			return nil, kerrors.ErrStageNotFound
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.ImportProjectArchiveResponse{Commits: []models.WriteResourceResponse{}}
+	if len(stages) == 0 {
+		return result, nil
+	}
+	if config.Global.DirectoryStageStructure {
+		// all stages are stored in the default branch, which is still checked out
+		commit, err := p.importArchiveEntries(gitContext, configPaths, entries)
+		if err != nil {
+			return nil, err
+		}
+		result.Commits = append(result.Commits, *commit)
+		return result, nil
+	}
+
+	for _, stage := range stages {
+		resourceContext := params.ResourceContext
+		if stage != "" {
+			resourceContext.Stage = &models.Stage{StageName: stage}
+		}
+		gitContext, configPath, err := p.establishContext(resourceContext)
+		if err != nil {
+			return nil, err
+		}
+		commit, err := p.importArchiveEntries(gitContext, map[string]string{stage: configPath}, map[string][]archiveEntry{stage: entries[stage]})
+		if err != nil {
+			return nil, err
+		}
+		result.Commits = append(result.Commits, *commit)
+	}
+	return result, nil
+}
+
+// extractArchive extracts the files of the archive to temporary files, grouped by the stage they belong to. Files of the
+// project are stored with an empty stage name
+func (p ResourceManager) extractArchive(archiveFile string, format string) (map[string][]archiveEntry, error) {
+	entries := map[string][]archiveEntry{}
+	var extractedSize int64
+	err := common.WalkArchive(archiveFile, format, func(name string, content io.Reader) error {
+		if isGitArchiveEntry(name) {
+			return nil
+		}
+		// like for promotions, the metadata of the project and its stages is managed by Keptn
+		stage, resourceURI := getArchiveEntryStage(name)
+		if resourceURI == "metadata.yaml" {
+			return nil
+		}
+
+		tmpFile, size, err := p.fileSystem.WriteTempFile(io.LimitReader(content, config.Global.MaxResourceSizeBytes()+1))
+		if err != nil {
+			return err
+		}
+		entries[stage] = append(entries[stage], archiveEntry{resourceURI: resourceURI, tmpFile: tmpFile})
+		if size > config.Global.MaxResourceSizeBytes() {
+			return kerrors.ErrResourceTooLarge
+		}
+		extractedSize += size
+		if extractedSize > config.Global.MaxArchiveSizeBytes() {
+			return kerrors.ErrArchiveTooLarge
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// isGitArchiveEntry returns whether an archive entry is located in a .git directory, at the top level or nested in any of
+// the directories of the archive. These must never be written to the working tree of the repository
+func isGitArchiveEntry(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		if strings.EqualFold(segment, ".git") {
+			return true
+		}
+	}
+	return false
+}
+
+// getArchiveEntryStage returns the stage of an archive entry, as well as its path relative to the stage
+func getArchiveEntryStage(name string) (string, string) {
+	stageDirPrefix := common.StageDirectoryName + "/"
+	if !strings.HasPrefix(name, stageDirPrefix) {
+		return "", name
+	}
+	segments := strings.SplitN(strings.TrimPrefix(name, stageDirPrefix), "/", 2)
+	if len(segments) < 2 {
+		return "", name
+	}
+	return segments[0], segments[1]
+}
+
+func (p ResourceManager) importArchiveEntries(gitContext *common_models.GitContext, configPaths map[string]string, entries map[string][]archiveEntry) (*models.WriteResourceResponse, error) {
+	var resultErr error
+	var resultCommit *models.WriteResourceResponse
+	err := retry.Retry(func() error {
+		if err := p.git.Pull(*gitContext); err != nil {
+			resultErr = err
+			return nil
+		}
+		pointers := []*common_models.LFSPointer{}
+		for stage, stageEntries := range entries {
+			for _, entry := range stageEntries {
+				pointer, err := p.storeResourceFile(gitContext, configPaths[stage]+"/"+entry.resourceURI, entry.tmpFile)
+				if err != nil {
+					resultErr = err
+					return nil
+				}
+				pointers = append(pointers, pointer)
+			}
+		}
+
+		commit, err := p.uploadAndCommit(gitContext, importArchiveCommitMessage, pointers...)
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		resultCommit = commit
+		resultErr = nil
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	if err != nil {
+		// all attempts have been rejected by the upstream
+		return nil, err
+	}
+	if resultErr != nil {
+		return nil, resultErr
+	}
+	if resultCommit == nil {
+		return nil, kerrors.ErrNoCommitCreated
+	}
+
+	if resultCommit.Metadata.Branch == "" {
+		branch, err := p.git.GetCurrentBranch(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		resultCommit.Metadata.Branch = branch
+	}
+	return resultCommit, nil
+}
+
+func (p ResourceManager) deleteTempFile(path string) {
+	if err := p.fileSystem.DeleteFile(path); err != nil {
+		logger.Errorf("Could not delete temporary file %s: %v", path, err)
+	}
+}
+
+// tempFileReader removes the temporary file it reads from once it is closed
+type tempFileReader struct {
+	io.ReadCloser
+	path       string
+	fileSystem common.IFileSystem
+}
+
+func (r *tempFileReader) Close() error {
+	err := r.ReadCloser.Close()
+	if deleteErr := r.fileSystem.DeleteFile(r.path); deleteErr != nil {
+		logger.Errorf("Could not delete temporary file %s: %v", r.path, deleteErr)
+	}
+	return err
+}
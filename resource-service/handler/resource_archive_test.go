@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+// getTestArchiveFields returns fields that store temporary files in a temporary directory, since archives are spooled to disk
+func getTestArchiveFields(t *testing.T) testResourceManagerFields {
+	config.Global.MaxResourceSizeMB = 1
+	config.Global.MaxArchiveSizeMB = 2
+	t.Cleanup(func() {
+		config.Global.MaxResourceSizeMB = 0
+		config.Global.MaxArchiveSizeMB = 0
+	})
+
+	fileSystem := common.NewFileSystem(t.TempDir())
+	fields := getTestResourceManagerFields()
+	fields.fileSystem.WriteTempFileFunc = fileSystem.WriteTempFile
+	fields.fileSystem.OpenFileFunc = fileSystem.OpenFile
+	fields.fileSystem.DeleteFileFunc = fileSystem.DeleteFile
+	fields.fileSystem.CopyFileFunc = func(source string, target string) error {
+		return nil
+	}
+	fields.git.ListBranchesFunc = func(gitContext common_models.GitContext) ([]string, error) {
+		return []string{"dev", "keptn/main-1", "main", "production"}, nil
+	}
+	fields.git.GetBranchRevisionAtFunc = func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+		// the production stage has been created after the requested revision
+		if branch == "production" && revision != "" {
+			return nil, nil
+		}
+		return &common_models.GitCommit{CommitID: branch + "-revision", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}, nil
+	}
+	fields.git.ListFilesFunc = func(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+		if revision == "main-revision" {
+			return []string{"metadata.yaml", "shipyard.yaml"}, nil
+		}
+		return []string{"metadata.yaml", "carts/values.yaml"}, nil
+	}
+	fields.git.GetFileRevisionReaderFunc = func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+		content := revision + ":" + file
+		return ioutil.NopCloser(strings.NewReader(content)), int64(len(content)), nil
+	}
+	fields.git.GetCurrentBranchFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "main", nil
+	}
+	return fields
+}
+
+func readTestProjectArchive(t *testing.T, content io.Reader, format string) map[string]string {
+	archiveFile := t.TempDir() + "/archive"
+	data, err := ioutil.ReadAll(content)
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(archiveFile, data, 0600))
+
+	files := map[string]string{}
+	err = common.WalkArchive(archiveFile, format, func(name string, content io.Reader) error {
+		data, err := ioutil.ReadAll(content)
+		files[name] = string(data)
+		return err
+	})
+	require.Nil(t, err)
+	return files
+}
+
+func newTestProjectArchive(t *testing.T, format string, files map[string]string) io.Reader {
+	buf := &bytes.Buffer{}
+	archive, err := common.NewArchiveWriter(buf, format)
+	require.Nil(t, err)
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		require.Nil(t, archive.Add(name, strings.NewReader(files[name]), int64(len(files[name])), time.Now()))
+	}
+	require.Nil(t, archive.Close())
+	return buf
+}
+
+func TestResourceManager_GetProjectArchive(t *testing.T) {
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	stream, err := rm.GetProjectArchive(models.GetProjectArchiveParams{
+		Project: models.Project{ProjectName: "my-project"},
+	})
+	require.Nil(t, err)
+	require.Equal(t, models.Version{Branch: "main", UpstreamURL: "remote-url", Version: "main-revision"}, stream.Metadata)
+
+	files := readTestProjectArchive(t, stream.Content, models.ArchiveFormatTarGz)
+	require.Equal(t, map[string]string{
+		"metadata.yaml":                              "main-revision:metadata.yaml",
+		"shipyard.yaml":                              "main-revision:shipyard.yaml",
+		".keptn-stages/dev/metadata.yaml":            "dev-revision:metadata.yaml",
+		".keptn-stages/dev/carts/values.yaml":        "dev-revision:carts/values.yaml",
+		".keptn-stages/production/metadata.yaml":     "production-revision:metadata.yaml",
+		".keptn-stages/production/carts/values.yaml": "production-revision:carts/values.yaml",
+	}, files)
+
+	// the latest changes of each branch have been pulled, while the branches of change requests are not part of the archive
+	require.Len(t, fields.git.PullCalls(), 3)
+	require.Len(t, fields.git.GetBranchRevisionAtCalls(), 3)
+
+	// the temporary file is removed once the archive has been sent
+	require.Empty(t, fields.fileSystem.DeleteFileCalls())
+	require.Nil(t, stream.Content.Close())
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+}
+
+func TestResourceManager_GetProjectArchive_ProvideGitCommitID(t *testing.T) {
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	stream, err := rm.GetProjectArchive(models.GetProjectArchiveParams{
+		Project: models.Project{ProjectName: "my-project"},
+		GetProjectArchiveQuery: models.GetProjectArchiveQuery{
+			ProjectArchiveQuery: models.ProjectArchiveQuery{Format: models.ArchiveFormatZip},
+			GitCommitID:         "my-commit",
+		},
+	})
+	require.Nil(t, err)
+	defer stream.Content.Close()
+
+	files := readTestProjectArchive(t, stream.Content, models.ArchiveFormatZip)
+	require.Len(t, files, 4)
+	require.NotContains(t, files, ".keptn-stages/production/carts/values.yaml")
+
+	require.Empty(t, fields.git.PullCalls())
+	require.Equal(t, "my-commit", fields.git.GetBranchRevisionAtCalls()[0].Revision)
+}
+
+func TestResourceManager_GetProjectArchive_StageDirectory(t *testing.T) {
+	config.Global.DirectoryStageStructure = true
+	defer func() { config.Global.DirectoryStageStructure = false }()
+
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	stream, err := rm.GetProjectArchive(models.GetProjectArchiveParams{
+		Project: models.Project{ProjectName: "my-project"},
+	})
+	require.Nil(t, err)
+	defer stream.Content.Close()
+
+	// stages are part of the default branch, which is archived as is
+	files := readTestProjectArchive(t, stream.Content, models.ArchiveFormatTarGz)
+	require.Len(t, files, 2)
+	require.Empty(t, fields.git.ListBranchesCalls())
+}
+
+func TestResourceManager_GetProjectArchive_ReadFails(t *testing.T) {
+	fields := getTestArchiveFields(t)
+	fields.git.GetFileRevisionReaderFunc = func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	stream, err := rm.GetProjectArchive(models.GetProjectArchiveParams{
+		Project: models.Project{ProjectName: "my-project"},
+	})
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, stream)
+}
+
+func TestResourceManager_ImportProjectArchive(t *testing.T) {
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content: newTestProjectArchive(t, models.ArchiveFormatTarGz, map[string]string{
+			"metadata.yaml":                       "my-metadata",
+			"shipyard.yaml":                       "my-shipyard",
+			".git/config":                         "my-config",
+			"carts/.git/config":                   "my-config",
+			".keptn-stages/dev/metadata.yaml":     "my-metadata",
+			".keptn-stages/dev/carts/values.yaml": "my-values",
+			".keptn-stages/dev/carts/.GIT/HEAD":   "my-head",
+		}),
+	})
+	require.Nil(t, err)
+
+	// each branch is committed separately
+	expectedCommit := models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{Branch: "main", UpstreamURL: "remote-url", Version: "my-revision"}}
+	require.Equal(t, &models.ImportProjectArchiveResponse{Commits: []models.WriteResourceResponse{expectedCommit, expectedCommit}}, result)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 2)
+
+	require.Len(t, fields.fileSystem.CopyFileCalls(), 2)
+	require.Equal(t, testConfigDir+"/shipyard.yaml", fields.fileSystem.CopyFileCalls()[0].Target)
+	require.Equal(t, testConfigDir+"/carts/values.yaml", fields.fileSystem.CopyFileCalls()[1].Target)
+
+	// all stages are checked before the files are written
+	establishCalls := fields.stageContext.EstablishCalls()
+	require.Len(t, establishCalls, 4)
+	require.Nil(t, establishCalls[0].Params.Stage)
+	require.Equal(t, "dev", establishCalls[1].Params.Stage.StageName)
+
+	// the archive and all extracted files are removed
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 3)
+}
+
+func TestResourceManager_ImportProjectArchive_CommitRejected(t *testing.T) {
+	fields := getTestArchiveFields(t)
+	fields.git.StageAndCommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
+		return "", errors2.ErrNonFastForwardUpdate
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content: newTestProjectArchive(t, models.ArchiveFormatTarGz, map[string]string{
+			"shipyard.yaml": "my-shipyard",
+		}),
+	})
+	// rejected commits are reported as error instead of an incomplete result
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 5)
+}
+
+func TestResourceManager_ImportProjectArchive_StageDirectory(t *testing.T) {
+	config.Global.DirectoryStageStructure = true
+	defer func() { config.Global.DirectoryStageStructure = false }()
+
+	fields := getTestArchiveFields(t)
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return testConfigDir + "/.keptn-stages/" + params.Stage.StageName, nil
+		}
+		return testConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext:     models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		ProjectArchiveQuery: models.ProjectArchiveQuery{Format: models.ArchiveFormatZip},
+		Content: newTestProjectArchive(t, models.ArchiveFormatZip, map[string]string{
+			"shipyard.yaml":                        "my-shipyard",
+			".keptn-stages/dev/carts/values.yaml":  "my-values",
+			".keptn-stages/prod/carts/values.yaml": "my-values",
+		}),
+	})
+	require.Nil(t, err)
+
+	// all stages are committed at once, without checking out the default branch again
+	require.Len(t, result.Commits, 1)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Len(t, fields.stageContext.EstablishCalls(), 3)
+
+	targets := []string{}
+	for _, call := range fields.fileSystem.CopyFileCalls() {
+		targets = append(targets, call.Target)
+	}
+	require.ElementsMatch(t, []string{
+		testConfigDir + "/shipyard.yaml",
+		testConfigDir + "/.keptn-stages/dev/carts/values.yaml",
+		testConfigDir + "/.keptn-stages/prod/carts/values.yaml",
+	}, targets)
+}
+
+func TestResourceManager_ImportProjectArchive_StageNotFound(t *testing.T) {
+	fields := getTestArchiveFields(t)
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage != nil {
+			return "", errors2.ErrReferenceNotFound
+		}
+		return testConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content: newTestProjectArchive(t, models.ArchiveFormatTarGz, map[string]string{
+			"shipyard.yaml":                       "my-shipyard",
+			".keptn-stages/dev/carts/values.yaml": "my-values",
+		}),
+	})
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+	require.Nil(t, result)
+
+	// nothing has been imported
+	require.Empty(t, fields.fileSystem.CopyFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_ImportProjectArchive_TooLarge(t *testing.T) {
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	// the size of a single file is limited by the maximum resource size
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content: newTestProjectArchive(t, models.ArchiveFormatTarGz, map[string]string{
+			"shipyard.yaml": "my-shipyard",
+			"model.bin":     string(make([]byte, 2*1024*1024)),
+		}),
+	})
+	require.ErrorIs(t, err, errors2.ErrResourceTooLarge)
+	require.Nil(t, result)
+	require.Empty(t, fields.stageContext.EstablishCalls())
+
+	// the extracted files are limited by the maximum archive size, even though the archive itself is smaller
+	result, err = rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content: newTestProjectArchive(t, models.ArchiveFormatTarGz, map[string]string{
+			"model1.bin": string(make([]byte, 1024*1024)),
+			"model2.bin": string(make([]byte, 1024*1024)),
+			"model3.bin": string(make([]byte, 1024*1024)),
+		}),
+	})
+	require.ErrorIs(t, err, errors2.ErrArchiveTooLarge)
+	require.Nil(t, result)
+	require.Empty(t, fields.stageContext.EstablishCalls())
+}
+
+func TestResourceManager_ImportProjectArchive_InvalidArchive(t *testing.T) {
+	fields := getTestArchiveFields(t)
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.ImportProjectArchive(models.ImportProjectArchiveParams{
+		ResourceContext: models.ResourceContext{Project: models.Project{ProjectName: "my-project"}},
+		Content:         strings.NewReader("not an archive"),
+	})
+	require.ErrorIs(t, err, errors2.ErrInvalidArchive)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/url"
 	"strings"
 	"time"
@@ -11,10 +15,15 @@ import (
 	"github.com/keptn/go-utils/pkg/common/retry"
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
 )
 
+const defaultChangeRequestBranchPrefix = "keptn/"
+const defaultChangeRequestPollInterval = 10 * time.Second
+
 // IResourceManager provides an interface for resource CRUD operations
 //
 //go:generate moq -pkg handler_mock -skip-ensure -out ./fake/resource_manager_mock.go . IResourceManager
@@ -23,8 +32,17 @@ type IResourceManager interface {
 	GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error)
 	UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error)
 	GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error)
+	GetResourceStream(params models.GetResourceParams) (*models.ResourceStream, error)
 	UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error)
+	UpdateResourceStream(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error)
 	DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error)
+	GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error)
+	GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error)
+	RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error)
+	PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error)
+	GetChangeRequest(params models.GetChangeRequestParams) (*models.ChangeRequest, error)
+	GetProjectArchive(params models.GetProjectArchiveParams) (*models.ResourceStream, error)
+	ImportProjectArchive(params models.ImportProjectArchiveParams) (*models.ImportProjectArchiveResponse, error)
 }
 
 type ResourceManager struct {
@@ -32,23 +50,38 @@ type ResourceManager struct {
 	credentialReader     common.CredentialReader
 	fileSystem           common.IFileSystem
 	configurationContext IConfigurationContext
+	changeRequests       common.IChangeRequestManager
+	lfs                  common.ILFS
+	// changeRequestPollInterval is the interval in which the state of change requests is checked while waiting for their merge
+	changeRequestPollInterval time.Duration
 }
 
-func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ResourceManager {
+func NewResourceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, changeRequests common.IChangeRequestManager, lfs common.ILFS) *ResourceManager {
 	projectResourceManager := &ResourceManager{
 		git:                  git,
 		credentialReader:     credentialReader,
 		fileSystem:           fileWriter,
 		configurationContext: stageContext,
+		changeRequests:       changeRequests,
+		lfs:                  lfs,
+
+		changeRequestPollInterval: defaultChangeRequestPollInterval,
 	}
 	return projectResourceManager
 }
 
 func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	result, err := p.createResources(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) createResources(params models.CreateResourcesParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -57,10 +90,12 @@ func (p ResourceManager) CreateResources(params models.CreateResourcesParams) (*
 }
 
 func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models.GetResourcesResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -87,10 +122,17 @@ func (p ResourceManager) GetResources(params models.GetResourcesParams) (*models
 }
 
 func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	result, err := p.updateResources(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) updateResources(params models.UpdateResourcesParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -99,10 +141,12 @@ func (p ResourceManager) UpdateResources(params models.UpdateResourcesParams) (*
 }
 
 func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.GetResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -112,14 +156,29 @@ func (p ResourceManager) GetResource(params models.GetResourceParams) (*models.G
 		return nil, kerrors.ErrResourceInvalidResourceURI
 	}
 
+	if params.Render {
+		content, revision, err := p.renderResource(gitContext, params, unescapedResourceName)
+		if err != nil {
+			return nil, err
+		}
+		return newGetResourceResponse(gitContext, params.ResourceURI, content, revision), nil
+	}
+
 	return p.readResource(gitContext, params, configPath, unescapedResourceName)
 }
 
 func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	result, err := p.updateResource(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) updateResource(params models.UpdateResourceParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -134,11 +193,154 @@ func (p ResourceManager) UpdateResource(params models.UpdateResourceParams) (*mo
 	return p.writeAndCommitResource(gitContext, resourcePath, string(params.ResourceContent))
 }
 
+// GetResourceStream returns the raw content of a resource without loading it into memory at once
+func (p ResourceManager) GetResourceStream(params models.GetResourceParams) (*models.ResourceStream, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// rendered resources are loaded into memory, since templates are expected to be small
+	if params.Render {
+		content, revision, err := p.renderResource(gitContext, params, unescapedResourceName)
+		if err != nil {
+			return nil, err
+		}
+		return newResourceStream(gitContext, revision, ioutil.NopCloser(bytes.NewReader(content)), int64(len(content))), nil
+	}
+
+	revision := params.GitCommitID
+	if revision == "" || revision == "\"\"" {
+		if err := p.git.Pull(*gitContext); err != nil {
+			return nil, err
+		}
+		revision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		// Helm charts are stored unpacked, therefore they are only available in the working tree
+		if common.IsHelmChartPath(unescapedResourceName) {
+			content, size, err := p.fileSystem.OpenFile(configPath + "/" + unescapedResourceName)
+			if err != nil {
+				return nil, err
+			}
+			return newResourceStream(gitContext, revision, content, size), nil
+		}
+	}
+
+	// the content is read from the git objects of the revision, since they are not changed by concurrent writes once the lock has been released
+	repositoryPath := getRepositoryPath(params.ProjectName, configPath, unescapedResourceName)
+	content, size, err := p.git.GetFileRevisionReader(*gitContext, revision, repositoryPath)
+	if err != nil {
+		return nil, err
+	}
+	content, size, err = p.resolveLFSStream(gitContext, repositoryPath, content, size)
+	if err != nil {
+		return nil, err
+	}
+	return newResourceStream(gitContext, revision, content, size), nil
+}
+
+// UpdateResourceStream writes the raw content of a resource without loading it into memory at once
+func (p ResourceManager) UpdateResourceStream(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error) {
+	result, err := p.updateResourceStream(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) updateResourceStream(params models.UpdateResourceStreamParams) (*models.WriteResourceResponse, error) {
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// the content is received before the project is locked, to not block other requests while a large file is uploaded
+	tmpFile, size, err := p.fileSystem.WriteTempFile(params.Content)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := p.fileSystem.DeleteFile(tmpFile); err != nil {
+			logger.Errorf("Could not delete temporary file %s: %v", tmpFile, err)
+		}
+	}()
+	if size > config.Global.MaxResourceSizeBytes() {
+		return nil, kerrors.ErrResourceTooLarge
+	}
+
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := configPath + "/" + unescapedResourceName
+
+	var resultErr error
+	var resultCommit *models.WriteResourceResponse
+	_ = retry.Retry(func() error {
+		err := p.git.Pull(*gitContext)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		pointer, err := p.storeResourceFile(gitContext, resourcePath, tmpFile)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+
+		commit, err := p.uploadAndCommit(gitContext, "Updated resource", pointer)
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		resultCommit = commit
+		resultErr = nil
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	return resultCommit, resultErr
+}
+
+func newResourceStream(gitContext *common_models.GitContext, revision string, content io.ReadCloser, size int64) *models.ResourceStream {
+	return &models.ResourceStream{
+		Content: content,
+		Size:    size,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}
+}
+
 func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
-	common.LockProject(params.ProjectName)
+	result, err := p.removeResource(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) removeResource(params models.DeleteResourceParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	gitContext, configPath, err := p.establishContext(params.Project, params.Stage, params.Service)
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
 	if err != nil {
 		return nil, err
 	}
@@ -173,10 +375,367 @@ func (p ResourceManager) DeleteResource(params models.DeleteResourceParams) (*mo
 	return resultCommit, resultErr
 }
 
-func (p ResourceManager) establishContext(project models.Project, stage *models.Stage, service *models.Service) (*common_models.GitContext, string, error) {
-	credentials, err := p.credentialReader.GetCredentials(project.ProjectName)
+func (p ResourceManager) GetResourceHistory(params models.GetResourceHistoryParams) (*models.GetResourceHistoryResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	revision, err := p.git.GetCurrentRevision(*gitContext)
 	if err != nil {
-		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+		return nil, err
+	}
+
+	history, err := p.git.GetFileHistory(*gitContext, getRepositoryPath(params.ProjectName, configPath, unescapedResourceName), params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.GetResourceHistoryResponse{
+		Commits: make([]models.ResourceCommit, 0, len(history)),
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}
+	for _, commit := range history {
+		result.Commits = append(result.Commits, models.ResourceCommit{
+			CommitID:    commit.CommitID,
+			Author:      commit.AuthorName,
+			AuthorEmail: commit.AuthorEmail,
+			Message:     strings.TrimSpace(commit.Message),
+			Timestamp:   commit.Timestamp,
+		})
+	}
+	return result, nil
+}
+
+func (p ResourceManager) GetResourceDiff(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	revision, err := p.git.GetCurrentRevision(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision := params.To
+	if toRevision == "" {
+		toRevision = revision
+	}
+
+	diff, err := p.git.GetDiff(*gitContext, params.From, toRevision, getRepositoryPath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.GetResourceDiffResponse{
+		From: params.From,
+		To:   toRevision,
+		Diff: diff,
+		Metadata: models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		},
+	}, nil
+}
+
+func (p ResourceManager) RevertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	result, err := p.revertResource(params)
+	return p.awaitWriteResult(params.ProjectName, result, err)
+}
+
+func (p ResourceManager) revertResource(params models.RevertResourceParams) (*models.WriteResourceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, configPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	unescapedResourceName, err := url.QueryUnescape(params.ResourceURI)
+	if err != nil {
+		return nil, kerrors.ErrResourceInvalidResourceURI
+	}
+
+	// the revision might have been created by another replica or directly in the upstream
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	fileContent, err := p.git.GetFileRevision(*gitContext, params.GitCommitID, getRepositoryPath(params.ProjectName, configPath, unescapedResourceName))
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := configPath + "/" + unescapedResourceName
+	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
+	message := fmt.Sprintf("Reverted resource %s to revision %s", unescapedResourceName, params.GitCommitID)
+
+	var resultErr error
+	var resultCommit *models.WriteResourceResponse
+	err = retry.Retry(func() error {
+		err := p.git.Pull(*gitContext)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		pointer, err := p.storeResource(gitContext, resourcePath, resourceContent)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+
+		commit, err := p.uploadAndCommit(gitContext, message, pointer)
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		resultCommit = commit
+		resultErr = nil
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	if err != nil {
+		// all attempts have been rejected by the upstream
+		return nil, err
+	}
+	return resultCommit, resultErr
+}
+
+func (p ResourceManager) PromoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	result, err := p.promoteResources(params)
+	if err != nil || result.ChangeRequest == nil {
+		return result, err
+	}
+	result.ChangeRequest, err = p.waitForMerge(params.ProjectName, result.ChangeRequest)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p ResourceManager) promoteResources(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, sourceConfigPath, err := p.establishContext(params.ResourceContext)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+	sourceRevision := params.GitCommitID
+	if sourceRevision == "" {
+		sourceRevision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resources, err := p.getResourcesToPromote(gitContext, params, sourceConfigPath, sourceRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	// establishing the context of the target stage checks out the branch of the target stage, if required
+	_, targetConfigPath, err := p.establishContext(models.ResourceContext{
+		Project: params.Project,
+		Stage:   &models.Stage{StageName: params.TargetStage},
+		Service: params.Service,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.PromoteResourcesResponse{
+		SourceCommitID: sourceRevision,
+		Resources:      make([]string, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		result.Resources = append(result.Resources, resource.Path)
+	}
+
+	var resultErr error
+	err = retry.Retry(func() error {
+		if err := p.git.Pull(*gitContext); err != nil {
+			resultErr = err
+			return nil
+		}
+		changes, err := p.getPromotionChanges(params.ProjectName, targetConfigPath, resources)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		diff, err := common.GetUnifiedDiff(changes)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		result.Diff = diff
+
+		revision, err := p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			resultErr = err
+			return nil
+		}
+		result.Metadata = models.Version{
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     revision,
+		}
+		if params.Preview || diff == "" {
+			resultErr = nil
+			return nil
+		}
+
+		for _, resource := range resources {
+			if err := p.fileSystem.WriteFile(targetConfigPath+"/"+resource.Path, resource.To); err != nil {
+				resultErr = err
+				return nil
+			}
+		}
+		commit, err := p.stageAndCommit(gitContext, getPromotionCommitMessage(params, sourceRevision))
+		if err != nil {
+			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
+				return err
+			}
+			resultErr = err
+			return nil
+		}
+		result.CommitID = commit.CommitID
+		result.Metadata = commit.Metadata
+		result.ChangeRequest = commit.ChangeRequest
+		resultErr = nil
+		return nil
+	}, retry.NumberOfRetries(5), retry.DelayBetweenRetries(1*time.Second))
+	if err != nil {
+		// all attempts have been rejected by the upstream
+		return nil, err
+	}
+	if resultErr != nil {
+		return nil, resultErr
+	}
+	return result, nil
+}
+
+// getResourcesToPromote returns the content of all resources of the source context at the given revision that match the
+// include and exclude patterns of the promotion. The paths of the returned resources are relative to the source context
+func (p ResourceManager) getResourcesToPromote(gitContext *common_models.GitContext, params models.PromoteResourcesParams, sourceConfigPath string, sourceRevision string) ([]common.FileChange, error) {
+	sourcePath := getRepositoryPath(params.ProjectName, sourceConfigPath, "")
+	files, err := p.git.ListFiles(*gitContext, sourceRevision, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []common.FileChange{}
+	for _, file := range files {
+		resourceURI := strings.TrimPrefix(strings.TrimPrefix(file, sourcePath), "/")
+		if !shouldPromoteResource(resourceURI, params.Include, params.Exclude) {
+			continue
+		}
+		content, err := p.git.GetFileRevision(*gitContext, sourceRevision, file)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, common.FileChange{Path: resourceURI, To: content})
+	}
+	if len(resources) == 0 {
+		return nil, kerrors.ErrResourceNotFound
+	}
+	return resources, nil
+}
+
+// getPromotionChanges adds the current content of the resources in the target context to the given resources
+func (p ResourceManager) getPromotionChanges(projectName string, targetConfigPath string, resources []common.FileChange) ([]common.FileChange, error) {
+	changes := make([]common.FileChange, 0, len(resources))
+	for _, resource := range resources {
+		change := common.FileChange{
+			Path: getRepositoryPath(projectName, targetConfigPath, resource.Path),
+			To:   resource.To,
+		}
+		targetPath := targetConfigPath + "/" + resource.Path
+		if p.fileSystem.FileExists(targetPath) {
+			content, err := p.fileSystem.ReadFile(targetPath)
+			if err != nil {
+				return nil, err
+			}
+			change.From = content
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+func shouldPromoteResource(resourceURI string, include []string, exclude []string) bool {
+	// internal files are never promoted
+	if resourceURI == "metadata.yaml" || strings.Contains(resourceURI, common.StageDirectoryName) {
+		return false
+	}
+	for _, pattern := range exclude {
+		if common.MatchesGlob(pattern, resourceURI) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if common.MatchesGlob(pattern, resourceURI) {
+			return true
+		}
+	}
+	return false
+}
+
+func getPromotionCommitMessage(params models.PromoteResourcesParams, sourceRevision string) string {
+	message := fmt.Sprintf("Promoted resources of stage %s to stage %s", params.Stage.StageName, params.TargetStage)
+	if params.Service != nil {
+		message = fmt.Sprintf("Promoted resources of service %s from stage %s to stage %s", params.Service.ServiceName, params.Stage.StageName, params.TargetStage)
+	}
+	return fmt.Sprintf("%s\n\nSource commit: %s", message, sourceRevision)
+}
+
+func (p ResourceManager) establishContext(resourceContext models.ResourceContext) (*common_models.GitContext, string, error) {
+	project := resourceContext.Project
+	credentials, err := getCredentials(p.credentialReader, p.fileSystem, project.ProjectName)
+	if err != nil {
+		return nil, "", err
 	}
 
 	auth, err := getAuthMethod(credentials)
@@ -184,20 +743,27 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project.ProjectName, err)
 	}
 
+	changeRequestSettings, err := p.getChangeRequestSettings(project.ProjectName, credentials)
+	if err != nil {
+		return nil, "", err
+	}
+
 	gitContext := common_models.GitContext{
-		Project:     project.ProjectName,
-		Credentials: credentials,
-		AuthMethod:  *auth,
+		Project:       project.ProjectName,
+		Credentials:   credentials,
+		AuthMethod:    *auth,
+		ChangeRequest: changeRequestSettings,
+		Author:        getGitAuthor(resourceContext.Author),
 	}
 
-	if !p.git.ProjectExists(gitContext) {
+	if !projectExists(p.git, p.fileSystem, gitContext) {
 		return nil, "", kerrors.ErrProjectNotFound
 	}
 
 	configPath, err := p.configurationContext.Establish(common_models.ConfigurationContextParams{
 		Project:                 project,
-		Stage:                   stage,
-		Service:                 service,
+		Stage:                   resourceContext.Stage,
+		Service:                 resourceContext.Service,
 		GitContext:              gitContext,
 		CheckConfigDirAvailable: true,
 	})
@@ -207,17 +773,21 @@ func (p ResourceManager) establishContext(project models.Project, stage *models.
 	return &gitContext, configPath, nil
 }
 
+func getGitAuthor(author *models.Author) *common_models.GitAuthor {
+	if author == nil {
+		return nil
+	}
+	return &common_models.GitAuthor{Name: author.Name, Email: author.Email}
+}
+
 func (p ResourceManager) readResource(gitContext *common_models.GitContext, params models.GetResourceParams, configPath string, resourceName string) (*models.GetResourceResponse, error) {
 	var fileContent []byte
 	var revision string
 	var err error
 
+	repositoryPath := getRepositoryPath(params.ProjectName, configPath, resourceName)
 	if params.GitCommitID != "" && params.GitCommitID != "\"\"" {
-		// if commit ID is set, path needs to be relative to the project directory
-		configPath = strings.TrimPrefix(configPath, common.GetProjectConfigPath(params.ProjectName))
-		// resource path must not start with "/", otherwise git is not able to resolve the revision
-		resourcePath := strings.TrimPrefix(configPath+"/"+resourceName, "/")
-		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, resourcePath)
+		fileContent, err = p.git.GetFileRevision(*gitContext, params.GitCommitID, repositoryPath)
 		revision = params.GitCommitID
 	} else {
 		resourcePath := configPath + "/" + resourceName
@@ -233,19 +803,27 @@ func (p ResourceManager) readResource(gitContext *common_models.GitContext, para
 	if err != nil {
 		return nil, err
 	}
+	fileContent, err = p.resolveLFSContent(gitContext, repositoryPath, fileContent)
+	if err != nil {
+		return nil, err
+	}
 
-	resourceContent := base64.StdEncoding.EncodeToString(fileContent)
+	return newGetResourceResponse(gitContext, params.ResourceURI, fileContent, revision), nil
+}
+
+func newGetResourceResponse(gitContext *common_models.GitContext, resourceURI string, content []byte, revision string) *models.GetResourceResponse {
+	resourceContent := base64.StdEncoding.EncodeToString(content)
 
 	return &models.GetResourceResponse{
 		Resource: models.Resource{
-			ResourceURI:     params.ResourceURI,
+			ResourceURI:     resourceURI,
 			ResourceContent: models.ResourceContent(resourceContent),
 		},
 		Metadata: models.Version{
 			UpstreamURL: gitContext.Credentials.RemoteURL,
 			Version:     revision,
 		},
-	}, nil
+	}
 }
 
 func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*models.WriteResourceResponse, error) {
@@ -258,12 +836,13 @@ func (p ResourceManager) writeAndCommitResource(gitContext *common_models.GitCon
 			resultErr = err
 			return nil
 		}
-		if err := p.storeResource(resourcePath, resourceContent); err != nil {
+		pointer, err := p.storeResource(gitContext, resourcePath, resourceContent)
+		if err != nil {
 			resultErr = err
 			return nil
 		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.uploadAndCommit(gitContext, "Updated resource", pointer)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -287,15 +866,18 @@ func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitCo
 			resultErr = err
 			return nil
 		}
+		pointers := []*common_models.LFSPointer{}
 		for _, res := range resources {
 			filePath := directory + "/" + res.ResourceURI
-			if err := p.storeResource(filePath, string(res.ResourceContent)); err != nil {
+			pointer, err := p.storeResource(gitContext, filePath, string(res.ResourceContent))
+			if err != nil {
 				resultErr = err
 				return nil
 			}
+			pointers = append(pointers, pointer)
 		}
 
-		commit, err := p.stageAndCommit(gitContext, "Updated resource")
+		commit, err := p.uploadAndCommit(gitContext, "Updated resource", pointers...)
 		if err != nil {
 			if errors.Is(err, kerrors.ErrNonFastForwardUpdate) || errors.Is(err, kerrors.ErrForceNeeded) {
 				return err
@@ -309,19 +891,130 @@ func (p ResourceManager) writeAndCommitResources(gitContext *common_models.GitCo
 	return resultCommit, resultErr
 }
 
-func (p ResourceManager) storeResource(resourcePath, resourceContent string) error {
+// storeResource writes the base64 encoded content to the given path. If the path is tracked by git LFS, the content is
+// moved to the LFS storage, and the returned pointer is written instead
+func (p ResourceManager) storeResource(gitContext *common_models.GitContext, resourcePath, resourceContent string) (*common_models.LFSPointer, error) {
+	if p.isTrackedByLFS(gitContext, resourcePath) {
+		content, err := base64.StdEncoding.DecodeString(resourceContent)
+		if err != nil {
+			return nil, kerrors.ErrResourceNotBase64Encoded
+		}
+		return p.storeLFSObject(gitContext, resourcePath, bytes.NewReader(content))
+	}
 	if err := p.fileSystem.WriteBase64EncodedFile(resourcePath, resourceContent); err != nil {
-		return err
+		return nil, err
 	}
 	if common.IsHelmChartPath(resourcePath) {
 		if err := p.fileSystem.WriteHelmChart(resourcePath); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// storeResourceFile is the equivalent of storeResource for content that has been written to a temporary file
+func (p ResourceManager) storeResourceFile(gitContext *common_models.GitContext, resourcePath, sourceFile string) (*common_models.LFSPointer, error) {
+	if p.isTrackedByLFS(gitContext, resourcePath) {
+		content, _, err := p.fileSystem.OpenFile(sourceFile)
+		if err != nil {
+			return nil, err
+		}
+		defer content.Close()
+		return p.storeLFSObject(gitContext, resourcePath, content)
+	}
+	if err := p.fileSystem.CopyFile(sourceFile, resourcePath); err != nil {
+		return nil, err
+	}
+	if common.IsHelmChartPath(resourcePath) {
+		if err := p.fileSystem.WriteHelmChart(resourcePath); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (p ResourceManager) isTrackedByLFS(gitContext *common_models.GitContext, resourcePath string) bool {
+	// Helm charts are stored unpacked, therefore the archive itself is never tracked
+	if common.IsHelmChartPath(resourcePath) {
+		return false
+	}
+	return p.lfs.IsTracked(*gitContext, getRepositoryPath(gitContext.Project, resourcePath, ""))
+}
+
+func (p ResourceManager) storeLFSObject(gitContext *common_models.GitContext, resourcePath string, content io.Reader) (*common_models.LFSPointer, error) {
+	// like the clean filter of git LFS, valid pointers are committed unchanged, e.g. when a resource is reverted
+	reader := bufio.NewReaderSize(content, common_models.LFSPointerMaxSize+1)
+	if peek, _ := reader.Peek(common_models.LFSPointerMaxSize + 1); len(peek) <= common_models.LFSPointerMaxSize {
+		if pointer := common.ParseLFSPointer(peek); pointer != nil {
+			return nil, p.fileSystem.WriteFile(resourcePath, []byte(pointer.String()))
+		}
+	}
+	pointer, err := p.lfs.Store(*gitContext, reader)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.fileSystem.WriteFile(resourcePath, []byte(pointer.String())); err != nil {
+		return nil, err
+	}
+	return pointer, nil
+}
+
+// resolveLFSContent returns the content of the LFS object if the given content is a pointer to a file tracked by git LFS
+func (p ResourceManager) resolveLFSContent(gitContext *common_models.GitContext, repositoryPath string, content []byte) ([]byte, error) {
+	pointer := common.ParseLFSPointer(content)
+	if pointer == nil || !p.lfs.IsTracked(*gitContext, repositoryPath) {
+		return content, nil
+	}
+	object, err := p.lfs.Open(*gitContext, *pointer)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+	return ioutil.ReadAll(object)
+}
+
+// resolveLFSStream is the equivalent of resolveLFSContent for streamed content
+func (p ResourceManager) resolveLFSStream(gitContext *common_models.GitContext, repositoryPath string, content io.ReadCloser, size int64) (io.ReadCloser, int64, error) {
+	if size > common_models.LFSPointerMaxSize {
+		return content, size, nil
+	}
+	defer content.Close()
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, 0, err
+	}
+	pointer := common.ParseLFSPointer(data)
+	if pointer == nil || !p.lfs.IsTracked(*gitContext, repositoryPath) {
+		return ioutil.NopCloser(bytes.NewReader(data)), size, nil
+	}
+	object, err := p.lfs.Open(*gitContext, *pointer)
+	if err != nil {
+		return nil, 0, err
+	}
+	return object, pointer.Size, nil
+}
+
+// uploadAndCommit transfers the objects of the resources tracked by git LFS to the upstream before the pointers are committed,
+// since the upstream may reject pushes that reference unknown objects
+func (p ResourceManager) uploadAndCommit(gitContext *common_models.GitContext, message string, pointers ...*common_models.LFSPointer) (*models.WriteResourceResponse, error) {
+	objects := []common_models.LFSPointer{}
+	for _, pointer := range pointers {
+		if pointer != nil {
+			objects = append(objects, *pointer)
+		}
+	}
+	if len(objects) > 0 {
+		if err := p.lfs.Upload(*gitContext, objects); err != nil {
+			return nil, err
+		}
+	}
+	return p.stageAndCommit(gitContext, message)
 }
 
 func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	if gitContext.ChangeRequest != nil {
+		return p.stageAndCommitChangeRequest(gitContext, message)
+	}
 	commitID, err := p.git.StageAndCommitAll(*gitContext, message)
 	if err != nil {
 		return nil, err
@@ -337,6 +1030,132 @@ func (p ResourceManager) stageAndCommit(gitContext *common_models.GitContext, me
 	return result, nil
 }
 
+// stageAndCommitChangeRequest pushes the changes to a new feature branch and opens a change request for merging it into
+// the current branch
+func (p ResourceManager) stageAndCommitChangeRequest(gitContext *common_models.GitContext, message string) (*models.WriteResourceResponse, error) {
+	targetBranch, err := p.git.GetCurrentBranch(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	changeBranch := getChangeRequestBranchName(*gitContext.ChangeRequest, targetBranch)
+
+	commitID, err := p.git.StageAndCommitToBranch(*gitContext, message, changeBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	title, description := splitCommitMessage(message)
+	changeRequest, err := p.changeRequests.CreateChangeRequest(*gitContext.ChangeRequest, models.ChangeRequest{
+		SourceBranch: changeBranch,
+		TargetBranch: targetBranch,
+		Title:        title,
+		Description:  description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotCreateChangeRequest, changeBranch, gitContext.Project, err)
+	}
+
+	return &models.WriteResourceResponse{
+		CommitID: commitID,
+		Metadata: models.Version{
+			Branch:      changeBranch,
+			UpstreamURL: gitContext.Credentials.RemoteURL,
+			Version:     commitID,
+		},
+		ChangeRequest: changeRequest,
+	}, nil
+}
+
+// awaitWriteResult waits for the change request of a write operation to be merged, if the project is configured to do so
+func (p ResourceManager) awaitWriteResult(projectName string, result *models.WriteResourceResponse, err error) (*models.WriteResourceResponse, error) {
+	if err != nil || result.ChangeRequest == nil {
+		return result, err
+	}
+	result.ChangeRequest, err = p.waitForMerge(projectName, result.ChangeRequest)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// waitForMerge polls the given change request until it has been merged, as long as the 'waitForMerge' duration of the change
+// request settings permits. It must not be called while the project is locked, since other requests for the project, e.g.
+// of the sequences the change request is waiting for, would be blocked otherwise
+func (p ResourceManager) waitForMerge(projectName string, changeRequest *models.ChangeRequest) (*models.ChangeRequest, error) {
+	credentials, err := p.credentialReader.GetCredentials(projectName)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, projectName, err)
+	}
+	settings, err := p.getChangeRequestSettings(projectName, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || settings.GetWaitForMerge() == 0 {
+		return changeRequest, nil
+	}
+
+	deadline := time.Now().Add(settings.GetWaitForMerge())
+	for changeRequest.State != models.ChangeRequestStateMerged {
+		if changeRequest.State == models.ChangeRequestStateClosed {
+			return nil, fmt.Errorf("%w: %s", kerrors.ErrChangeRequestClosed, changeRequest.ID)
+		}
+		if !time.Now().Before(deadline) {
+			return nil, fmt.Errorf("%w: %s", kerrors.ErrChangeRequestNotMerged, changeRequest.ID)
+		}
+		time.Sleep(p.changeRequestPollInterval)
+		changeRequest, err = p.changeRequests.GetChangeRequest(*settings, changeRequest.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changeRequest, nil
+}
+
+func (p ResourceManager) GetChangeRequest(params models.GetChangeRequestParams) (*models.ChangeRequest, error) {
+	credentials, err := p.credentialReader.GetCredentials(params.ProjectName)
+	if err != nil {
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+	}
+	settings, err := p.getChangeRequestSettings(params.ProjectName, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, kerrors.ErrChangeRequestsNotEnabled
+	}
+	return p.changeRequests.GetChangeRequest(*settings, params.ChangeRequestID)
+}
+
+// getChangeRequestSettings returns the change request settings of the project, or nil if the changes of the project are pushed
+// to the upstream directly. If no separate token is configured, the token of the upstream credentials is used
+func (p ResourceManager) getChangeRequestSettings(projectName string, credentials *common_models.GitCredentials) (*common_models.ChangeRequestSettings, error) {
+	settings, err := p.changeRequests.GetSettings(projectName)
+	if err != nil || settings == nil {
+		return nil, err
+	}
+	if settings.Token == "" && credentials.HttpsAuth != nil {
+		settings.Token = credentials.HttpsAuth.Token
+	}
+	return settings, nil
+}
+
+func getChangeRequestBranchName(settings common_models.ChangeRequestSettings, targetBranch string) string {
+	prefix := settings.BranchPrefix
+	if prefix == "" {
+		prefix = defaultChangeRequestBranchPrefix
+	}
+	return fmt.Sprintf("%s%s-%d", prefix, targetBranch, time.Now().UTC().UnixNano())
+}
+
+// splitCommitMessage returns the first line of the commit message as title, and the remaining lines as description
+func splitCommitMessage(message string) (string, string) {
+	parts := strings.SplitN(message, "\n", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], strings.TrimSpace(parts[1])
+}
+
 func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, resourcePath string) (*models.WriteResourceResponse, error) {
 	if !p.fileSystem.FileExists(resourcePath) {
 		return nil, kerrors.ErrResourceNotFound
@@ -347,3 +1166,15 @@ func (p ResourceManager) deleteResource(gitContext *common_models.GitContext, re
 
 	return p.stageAndCommit(gitContext, "Deleted resources")
 }
+
+// getRepositoryPath returns the path of the given resource relative to the root of the project repository, as required for
+// resolving files of a git revision. If resourceName is empty, the path of the configuration directory is returned
+func getRepositoryPath(projectName, configPath, resourceName string) string {
+	// path needs to be relative to the project directory
+	repositoryPath := strings.TrimPrefix(configPath, common.GetProjectConfigPath(projectName))
+	if resourceName != "" {
+		repositoryPath = repositoryPath + "/" + resourceName
+	}
+	// path must not start with "/", otherwise git is not able to resolve the revision
+	return strings.TrimPrefix(repositoryPath, "/")
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/keptn/keptn/resource-service/common"
 	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
@@ -27,12 +29,14 @@ type testResourceManagerFields struct {
 	credentialReader *common_mock.CredentialReaderMock
 	fileSystem       *common_mock.IFileSystemMock
 	stageContext     *handler_mock.IConfigurationContextMock
+	changeRequests   *common_mock.IChangeRequestManagerMock
+	lfs              *common_mock.ILFSMock
 }
 
 func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -68,10 +72,35 @@ func TestResourceManager_CreateResources_ProjectResource(t *testing.T) {
 	require.Equal(t, fields.fileSystem.WriteBase64EncodedFileCalls()[1].Path, common.GetProjectConfigPath("my-project")+"/file2")
 }
 
+func TestResourceManager_CreateResources_ProjectResource_Author(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	_, err := rm.CreateResources(models.CreateResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Author:  &models.Author{Name: "my-token", Email: "my-token@keptn.sh"},
+		},
+		CreateResourcesPayload: models.CreateResourcesPayload{
+			Resources: []models.Resource{
+				{
+					ResourceContent: "c3RyaW5n",
+					ResourceURI:     "file1",
+				},
+			},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, &common_models.GitAuthor{Name: "my-token", Email: "my-token@keptn.sh"}, fields.git.StageAndCommitAllCalls()[0].GitContext.Author)
+}
+
 func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -111,7 +140,7 @@ func TestResourceManager_CreateResources_StageResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -152,7 +181,7 @@ func TestResourceManager_CreateResources_ServiceResource(t *testing.T) {
 func TestResourceManager_CreateResources_ServiceResource_HelmChart(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -190,7 +219,7 @@ func TestResourceManager_CreateResources_ServiceResource_HelmChartWriteFails(t *
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -227,7 +256,7 @@ func TestResourceManager_CreateResources_ProjectResource_ProjectNotFound(t *test
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -264,7 +293,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotReadCredentials(t
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrMalformedCredentials
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -301,7 +330,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.CreateResources(models.CreateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -333,7 +362,7 @@ func TestResourceManager_CreateResources_ProjectResource_CannotEstablishContext(
 func TestResourceManager_UpdateResources_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -371,7 +400,7 @@ func TestResourceManager_UpdateResources_ProjectResource_ProjectNotFound(t *test
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -409,7 +438,7 @@ func TestResourceManager_UpdateResources_ProjectResource_WritingFileFails(t *tes
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -446,7 +475,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFails(t *testing.
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -484,7 +513,7 @@ func TestResourceManager_UpdateResources_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -526,7 +555,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResources(models.UpdateResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -560,7 +589,7 @@ func TestResourceManager_UpdateResources_ProjectResource_CommitFailsOnFirstTry(t
 func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -585,7 +614,7 @@ func TestResourceManager_UpdateResource_ProjectResource(t *testing.T) {
 func TestResourceManager_UpdateResource_ProjectResourceWebhook(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -614,7 +643,7 @@ func TestResourceManager_UpdateResource_ProjectResource_ProjectNotFound(t *testi
 		return false
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -642,7 +671,7 @@ func TestResourceManager_UpdateResource_ProjectResource_WritingFileFails(t *test
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -671,7 +700,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFails(t *testing.T
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -700,7 +729,7 @@ func TestResourceManager_UpdateResource_ProjectResource_PullFails(t *testing.T)
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -734,7 +763,7 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.UpdateResource(models.UpdateResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -756,10 +785,178 @@ func TestResourceManager_UpdateResource_ProjectResource_CommitFailsOnFirstTry(t
 	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
 }
 
+func TestResourceManager_UpdateResource_ChangeRequest(t *testing.T) {
+	fields := getTestChangeRequestFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	revision, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.Nil(t, err)
+
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+	changeBranch := fields.git.StageAndCommitToBranchCalls()[0].Branch
+	require.True(t, strings.HasPrefix(changeBranch, "keptn/main-"))
+	// the token of the upstream credentials is used if the settings do not contain a token
+	require.Equal(t, "token", fields.git.StageAndCommitToBranchCalls()[0].GitContext.ChangeRequest.Token)
+
+	require.Len(t, fields.changeRequests.CreateChangeRequestCalls(), 1)
+	require.Equal(t, models.ChangeRequest{
+		SourceBranch: changeBranch,
+		TargetBranch: "main",
+		Title:        "Updated resource",
+	}, fields.changeRequests.CreateChangeRequestCalls()[0].Request)
+
+	require.Equal(t, &models.WriteResourceResponse{
+		CommitID: "my-change-revision",
+		Metadata: models.Version{Branch: changeBranch, UpstreamURL: "remote-url", Version: "my-change-revision"},
+		ChangeRequest: &models.ChangeRequest{
+			ID:           "1",
+			State:        models.ChangeRequestStateOpen,
+			SourceBranch: changeBranch,
+			TargetBranch: "main",
+			Title:        "Updated resource",
+		},
+	}, revision)
+}
+
+func TestResourceManager_UpdateResource_ChangeRequestCannotBeCreated(t *testing.T) {
+	fields := getTestChangeRequestFields()
+	fields.changeRequests.CreateChangeRequestFunc = func(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+		return nil, errors2.ErrAuthorizationFailed
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	revision, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrAuthorizationFailed)
+	require.Nil(t, revision)
+	require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+}
+
+func TestResourceManager_UpdateResource_ChangeRequestWaitForMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		states     []models.ChangeRequestState
+		wantState  models.ChangeRequestState
+		wantErr    error
+		wantPolled int
+	}{
+		{
+			name:       "merged",
+			states:     []models.ChangeRequestState{models.ChangeRequestStateOpen, models.ChangeRequestStateMerged},
+			wantState:  models.ChangeRequestStateMerged,
+			wantPolled: 2,
+		},
+		{
+			name:       "closed",
+			states:     []models.ChangeRequestState{models.ChangeRequestStateClosed},
+			wantErr:    errors2.ErrChangeRequestClosed,
+			wantPolled: 1,
+		},
+		{
+			name:    "not merged in time",
+			states:  []models.ChangeRequestState{models.ChangeRequestStateOpen},
+			wantErr: errors2.ErrChangeRequestNotMerged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := getTestChangeRequestFields()
+			fields.changeRequests.GetSettingsFunc = func(project string) (*common_models.ChangeRequestSettings, error) {
+				return &common_models.ChangeRequestSettings{Provider: "github", Repository: "my-org/my-repo", WaitForMerge: "50ms"}, nil
+			}
+			fields.changeRequests.GetChangeRequestFunc = func(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+				polled := len(fields.changeRequests.GetChangeRequestCalls())
+				if polled > len(tt.states) {
+					polled = len(tt.states)
+				}
+				return &models.ChangeRequest{ID: id, State: tt.states[polled-1]}, nil
+			}
+
+			rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+			rm.changeRequestPollInterval = time.Millisecond
+
+			revision, err := rm.UpdateResource(models.UpdateResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+				},
+				ResourceURI: "file1",
+				UpdateResourcePayload: models.UpdateResourcePayload{
+					ResourceContent: "c3RyaW5n",
+				},
+			})
+
+			require.Len(t, fields.git.StageAndCommitToBranchCalls(), 1)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Nil(t, revision)
+			} else {
+				require.Nil(t, err)
+				require.Equal(t, tt.wantState, revision.ChangeRequest.State)
+			}
+			if tt.wantPolled > 0 {
+				require.Len(t, fields.changeRequests.GetChangeRequestCalls(), tt.wantPolled)
+			}
+		})
+	}
+}
+
+func TestResourceManager_GetChangeRequest(t *testing.T) {
+	fields := getTestChangeRequestFields()
+	fields.changeRequests.GetChangeRequestFunc = func(settings common_models.ChangeRequestSettings, id string) (*models.ChangeRequest, error) {
+		return &models.ChangeRequest{ID: id, State: models.ChangeRequestStateMerged}, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	changeRequest, err := rm.GetChangeRequest(models.GetChangeRequestParams{
+		Project:         models.Project{ProjectName: "my-project"},
+		ChangeRequestID: "1",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.ChangeRequest{ID: "1", State: models.ChangeRequestStateMerged}, changeRequest)
+	require.Len(t, fields.changeRequests.GetChangeRequestCalls(), 1)
+	require.Equal(t, "github", fields.changeRequests.GetChangeRequestCalls()[0].Settings.Provider)
+}
+
+func TestResourceManager_GetChangeRequest_NotEnabled(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	changeRequest, err := rm.GetChangeRequest(models.GetChangeRequestParams{
+		Project:         models.Project{ProjectName: "my-project"},
+		ChangeRequestID: "1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrChangeRequestsNotEnabled)
+	require.Nil(t, changeRequest)
+}
+
 func TestResourceManager_DeleteResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -784,7 +981,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ProjectNotFound(t *testi
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -811,7 +1008,7 @@ func TestResourceManager_DeleteResource_ProjectResource_ResourceNotFound(t *test
 		}
 		return true
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -835,7 +1032,7 @@ func TestResourceManager_DeleteResource_ProjectResource_DeleteFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -870,7 +1067,7 @@ func TestResourceManager_DeleteResource_Git_ThrowsNonFastForward_Or_ThrowsForceN
 		return "my-revision", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -897,7 +1094,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	revision, err := rm.DeleteResource(models.DeleteResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -921,7 +1118,7 @@ func TestResourceManager_DeleteResource_ProjectResource_CommitFails(t *testing.T
 func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -954,7 +1151,7 @@ func TestResourceManager_GetResource_ProjectResource(t *testing.T) {
 func TestResourceManager_GetResource_ProjectResource_ProvideGitCommitID(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -993,7 +1190,7 @@ func TestResourceManager_GetResource_ServiceResource_ProvideGitCommitID(t *testi
 		return testConfigDir + "/my-service", nil
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1033,7 +1230,7 @@ func TestResourceManager_GetResource_ProjectResource_PullFails(t *testing.T) {
 	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
 		return errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1060,7 +1257,7 @@ func TestResourceManager_GetResource_ProjectResource_ProjectNotFound(t *testing.
 	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
 		return false
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1085,7 +1282,7 @@ func TestResourceManager_GetResource_ServiceResource_ServiceNotFound(t *testing.
 	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors2.ErrServiceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1116,7 +1313,7 @@ func TestResourceManager_GetResource_ProjectResource_ResourceNotFound(t *testing
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors2.ErrResourceNotFound
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1142,7 +1339,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
 		return nil, errors.New("oops")
 	}
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1165,7 +1362,7 @@ func TestResourceManager_GetResource_ProjectResource_CannotReadFIle(t *testing.T
 func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResource(models.GetResourceParams{
 		ResourceContext: models.ResourceContext{
@@ -1186,10 +1383,258 @@ func TestResourceManager_GetResource_ProjectResource_InvalidResourceName(t *test
 	require.Empty(t, fields.git.GetFileRevisionCalls())
 }
 
+func TestResourceManager_GetResource_ProjectResource_LFS(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	pointer := common_models.LFSPointer{OID: "4fd8b7bd1a5b4a5e2a0b5f7a0bf0a3f3e5fbe0c9f0a1fa0d4b6c2a2d3e0b1c9a", Size: 12}
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		return []byte(pointer.String()), nil
+	}
+	fields.lfs.IsTrackedFunc = func(gitContext common_models.GitContext, path string) bool {
+		return path == "model.bin"
+	}
+	fields.lfs.OpenFunc = func(gitContext common_models.GitContext, pointer common_models.LFSPointer) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("file-content")), nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResource(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "model.bin",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, models.ResourceContent("ZmlsZS1jb250ZW50"), result.ResourceContent)
+
+	require.Len(t, fields.lfs.OpenCalls(), 1)
+	require.Equal(t, pointer, fields.lfs.OpenCalls()[0].Pointer)
+}
+
+func TestResourceManager_GetResourceStream_ProjectResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.git.GetFileRevisionReaderFunc = func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+		return io.NopCloser(strings.NewReader("file-content")), 12, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceStream(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, int64(12), result.Size)
+	require.Equal(t, models.Version{UpstreamURL: "remote-url", Version: "my-revision"}, result.Metadata)
+	content, err := io.ReadAll(result.Content)
+	require.Nil(t, err)
+	require.Equal(t, "file-content", string(content))
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileRevisionReaderCalls(), 1)
+	require.Equal(t, "my-revision", fields.git.GetFileRevisionReaderCalls()[0].Revision)
+	require.Equal(t, "file1", fields.git.GetFileRevisionReaderCalls()[0].File)
+}
+
+func TestResourceManager_GetResourceStream_ServiceResource_HelmChart(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+	fields.fileSystem.OpenFileFunc = func(filename string) (io.ReadCloser, int64, error) {
+		return io.NopCloser(strings.NewReader("chart")), 5, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceStream(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI: "helm%2Fmy-service.tgz",
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, int64(5), result.Size)
+
+	require.Len(t, fields.fileSystem.OpenFileCalls(), 1)
+	require.Equal(t, testServiceConfigDir+"/helm/my-service.tgz", fields.fileSystem.OpenFileCalls()[0].Filename)
+	require.Empty(t, fields.git.GetFileRevisionReaderCalls())
+}
+
+func TestResourceManager_GetResourceStream_ProvideGitCommitID(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.git.GetFileRevisionReaderFunc = func(gitContext common_models.GitContext, revision string, file string) (io.ReadCloser, int64, error) {
+		return nil, 0, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceStream(models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		GetResourceQuery: models.GetResourceQuery{
+			GitCommitID: "my-commit-id",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.git.PullCalls())
+	require.Equal(t, "my-commit-id", fields.git.GetFileRevisionReaderCalls()[0].Revision)
+}
+
+func TestResourceManager_UpdateResourceStream_ProjectResource(t *testing.T) {
+	config.Global.MaxResourceSizeMB = 1
+	defer func() { config.Global.MaxResourceSizeMB = 0 }()
+
+	fields := getTestResourceManagerFields()
+	fields.fileSystem.WriteTempFileFunc = func(content io.Reader) (string, int64, error) {
+		return "/data/config/.upload-1", 6, nil
+	}
+	fields.fileSystem.CopyFileFunc = func(source string, target string) error {
+		return nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.UpdateResourceStream(models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		Content:     strings.NewReader("string"),
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	require.Len(t, fields.fileSystem.CopyFileCalls(), 1)
+	require.Equal(t, "/data/config/.upload-1", fields.fileSystem.CopyFileCalls()[0].Source)
+	require.Equal(t, testConfigDir+"/file1", fields.fileSystem.CopyFileCalls()[0].Target)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Empty(t, fields.lfs.UploadCalls())
+
+	// the temporary file is removed after the resource has been committed
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+	require.Equal(t, "/data/config/.upload-1", fields.fileSystem.DeleteFileCalls()[0].Path)
+}
+
+func TestResourceManager_UpdateResourceStream_TooLarge(t *testing.T) {
+	config.Global.MaxResourceSizeMB = 1
+	defer func() { config.Global.MaxResourceSizeMB = 0 }()
+
+	fields := getTestResourceManagerFields()
+	fields.fileSystem.WriteTempFileFunc = func(content io.Reader) (string, int64, error) {
+		return "/data/config/.upload-1", 2 * 1024 * 1024, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.UpdateResourceStream(models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+		Content:     strings.NewReader("string"),
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceTooLarge)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.stageContext.EstablishCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Len(t, fields.fileSystem.DeleteFileCalls(), 1)
+}
+
+func TestResourceManager_UpdateResourceStream_LFS(t *testing.T) {
+	config.Global.MaxResourceSizeMB = 1
+	defer func() { config.Global.MaxResourceSizeMB = 0 }()
+
+	pointer := &common_models.LFSPointer{OID: "4fd8b7bd1a5b4a5e2a0b5f7a0bf0a3f3e5fbe0c9f0a1fa0d4b6c2a2d3e0b1c9a", Size: 6}
+	fields := getTestResourceManagerFields()
+	fields.fileSystem.WriteTempFileFunc = func(content io.Reader) (string, int64, error) {
+		return "/data/config/.upload-1", 6, nil
+	}
+	fields.fileSystem.OpenFileFunc = func(filename string) (io.ReadCloser, int64, error) {
+		return io.NopCloser(strings.NewReader("string")), 6, nil
+	}
+	fields.lfs.IsTrackedFunc = func(gitContext common_models.GitContext, path string) bool {
+		return path == "model.bin"
+	}
+	fields.lfs.StoreFunc = func(gitContext common_models.GitContext, content io.Reader) (*common_models.LFSPointer, error) {
+		return pointer, nil
+	}
+	fields.lfs.UploadFunc = func(gitContext common_models.GitContext, pointers []common_models.LFSPointer) error {
+		return nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	_, err := rm.UpdateResourceStream(models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "model.bin",
+		Content:     strings.NewReader("string"),
+	})
+
+	require.Nil(t, err)
+
+	// the pointer is committed instead of the content, after the object has been uploaded
+	require.Len(t, fields.lfs.StoreCalls(), 1)
+	require.Len(t, fields.fileSystem.WriteFileCalls(), 1)
+	require.Equal(t, testConfigDir+"/model.bin", fields.fileSystem.WriteFileCalls()[0].Path)
+	require.Equal(t, pointer.String(), string(fields.fileSystem.WriteFileCalls()[0].Content))
+	require.Len(t, fields.lfs.UploadCalls(), 1)
+	require.Equal(t, []common_models.LFSPointer{*pointer}, fields.lfs.UploadCalls()[0].Pointers)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+}
+
+func TestResourceManager_UpdateResource_LFSUploadFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+	fields.lfs.IsTrackedFunc = func(gitContext common_models.GitContext, path string) bool {
+		return true
+	}
+	fields.lfs.StoreFunc = func(gitContext common_models.GitContext, content io.Reader) (*common_models.LFSPointer, error) {
+		return &common_models.LFSPointer{OID: "4fd8b7bd1a5b4a5e2a0b5f7a0bf0a3f3e5fbe0c9f0a1fa0d4b6c2a2d3e0b1c9a", Size: 6}, nil
+	}
+	fields.lfs.UploadFunc = func(gitContext common_models.GitContext, pointers []common_models.LFSPointer) error {
+		return errors2.ErrLFSRequiresHTTPCredentials
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.UpdateResource(models.UpdateResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "model.bin",
+		UpdateResourcePayload: models.UpdateResourcePayload{
+			ResourceContent: "c3RyaW5n",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrLFSRequiresHTTPCredentials)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
 func TestResourceManager_GetResources(t *testing.T) {
 	fields := getTestResourceManagerFields()
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1257,7 +1702,7 @@ func TestResourceManager_GetResourcesPullFailed(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1288,7 +1733,7 @@ func TestResourceManager_GetCurrentRevisionFailed(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext)
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
 
 	result, err := rm.GetResources(models.GetResourcesParams{
 		ResourceContext: models.ResourceContext{
@@ -1312,6 +1757,388 @@ func TestResourceManager_GetCurrentRevisionFailed(t *testing.T) {
 	require.Empty(t, fields.fileSystem.WalkPathCalls())
 }
 
+func TestResourceManager_GetResourceHistory_ServiceResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/my-service", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI:             "file1",
+		GetResourceHistoryQuery: models.GetResourceHistoryQuery{Limit: 5},
+	})
+
+	require.Nil(t, err)
+
+	require.Equal(t, &models.GetResourceHistoryResponse{
+		Commits: []models.ResourceCommit{
+			{
+				CommitID:    "my-revision",
+				Author:      "keptn",
+				AuthorEmail: "keptn@keptn.sh",
+				Message:     "Updated resource",
+				Timestamp:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.git.PullCalls(), 1)
+	require.Len(t, fields.git.GetFileHistoryCalls(), 1)
+	require.Equal(t, "my-service/file1", fields.git.GetFileHistoryCalls()[0].Path)
+	require.Equal(t, 5, fields.git.GetFileHistoryCalls()[0].Limit)
+}
+
+func TestResourceManager_GetResourceHistory_ProjectResource_ResourceNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileHistoryFunc = func(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceHistory(models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI: "file1",
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_GetResourceDiff_StageDirectory(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/.keptn/stages/my-stage", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+		},
+		GetResourceDiffQuery: models.GetResourceDiffQuery{From: "old-revision"},
+	})
+
+	require.Nil(t, err)
+
+	require.Equal(t, &models.GetResourceDiffResponse{
+		From: "old-revision",
+		To:   "my-revision",
+		Diff: "my-diff",
+		Metadata: models.Version{
+			UpstreamURL: "remote-url",
+			Version:     "my-revision",
+		},
+	}, result)
+
+	require.Len(t, fields.git.GetDiffCalls(), 1)
+	require.Equal(t, "old-revision", fields.git.GetDiffCalls()[0].FromRevision)
+	require.Equal(t, "my-revision", fields.git.GetDiffCalls()[0].ToRevision)
+	require.Equal(t, ".keptn/stages/my-stage", fields.git.GetDiffCalls()[0].Path)
+}
+
+func TestResourceManager_GetResourceDiff_ProjectResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResourceDiff(models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:          "shipyard.yaml",
+		GetResourceDiffQuery: models.GetResourceDiffQuery{From: "old-revision", To: "new-revision"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "new-revision", result.To)
+
+	require.Len(t, fields.git.GetDiffCalls(), 1)
+	require.Equal(t, "new-revision", fields.git.GetDiffCalls()[0].ToRevision)
+	require.Equal(t, "shipyard.yaml", fields.git.GetDiffCalls()[0].Path)
+}
+
+func TestResourceManager_RevertResource_ServiceResource(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testServiceConfigDir, nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "my-stage"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		ResourceURI:           "file1",
+		RevertResourcePayload: models.RevertResourcePayload{GitCommitID: "old-revision"},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, &models.WriteResourceResponse{CommitID: "my-revision", Metadata: models.Version{UpstreamURL: "remote-url", Version: "my-revision"}}, result)
+
+	// the clone is updated before the revision is read, and again before the commit is created
+	require.Len(t, fields.git.PullCalls(), 2)
+	require.Len(t, fields.git.GetFileRevisionCalls(), 1)
+	require.Equal(t, "old-revision", fields.git.GetFileRevisionCalls()[0].Revision)
+	require.Equal(t, "my-service/file1", fields.git.GetFileRevisionCalls()[0].File)
+
+	require.Len(t, fields.fileSystem.WriteBase64EncodedFileCalls(), 1)
+	require.Equal(t, testServiceConfigDir+"/file1", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Path)
+	require.Equal(t, "ZmlsZS1jb250ZW50", fields.fileSystem.WriteBase64EncodedFileCalls()[0].Content)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Reverted resource file1 to revision old-revision", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_RevertResource_RevisionNotFound(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return nil, errors2.ErrResourceNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:           "file1",
+		RevertResourcePayload: models.RevertResourcePayload{GitCommitID: "old-revision"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.fileSystem.WriteBase64EncodedFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_RevertResource_PullFails(t *testing.T) {
+	fields := getTestResourceManagerFields()
+
+	fields.git.PullFunc = func(gitContext common_models.GitContext) error {
+		return errors2.ErrRepositoryNotFound
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.RevertResource(models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+		},
+		ResourceURI:           "file1",
+		RevertResourcePayload: models.RevertResourcePayload{GitCommitID: "old-revision"},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrRepositoryNotFound)
+	require.Nil(t, result)
+
+	require.Empty(t, fields.git.GetFileRevisionCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func getTestPromotionFields() testResourceManagerFields {
+	fields := getTestResourceManagerFields()
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		return testConfigDir + "/.keptn-stages/" + params.Stage.StageName + "/" + params.Service.ServiceName, nil
+	}
+	fields.git.ListFilesFunc = func(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+		return []string{
+			path + "/helm/values.yaml",
+			path + "/slo.yaml",
+			path + "/jmeter/load.jmx",
+		}, nil
+	}
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		return []byte("new content of " + filepath.Base(file) + "\n"), nil
+	}
+	fields.fileSystem.FileExistsFunc = func(path string) bool {
+		return strings.HasSuffix(path, "slo.yaml")
+	}
+	fields.fileSystem.ReadFileFunc = func(filename string) ([]byte, error) {
+		return []byte("old content\n"), nil
+	}
+	return fields
+}
+
+func TestResourceManager_PromoteResources_ServiceResources(t *testing.T) {
+	fields := getTestPromotionFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "staging",
+			GitCommitID: "source-revision",
+			Exclude:     []string{"jmeter"},
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "source-revision", result.SourceCommitID)
+	require.Equal(t, "my-revision", result.CommitID)
+	require.Equal(t, []string{"helm/values.yaml", "slo.yaml"}, result.Resources)
+	require.Contains(t, result.Diff, "+++ b/.keptn-stages/staging/my-service/helm/values.yaml")
+	require.Contains(t, result.Diff, "-old content")
+	require.Contains(t, result.Diff, "+new content of slo.yaml")
+	require.NotContains(t, result.Diff, "load.jmx")
+
+	require.Len(t, fields.stageContext.EstablishCalls(), 2)
+	require.Equal(t, "staging", fields.stageContext.EstablishCalls()[1].Params.Stage.StageName)
+
+	require.Len(t, fields.git.ListFilesCalls(), 1)
+	require.Equal(t, "source-revision", fields.git.ListFilesCalls()[0].Revision)
+	require.Equal(t, ".keptn-stages/dev/my-service", fields.git.ListFilesCalls()[0].Path)
+
+	require.Len(t, fields.fileSystem.WriteFileCalls(), 2)
+	require.Equal(t, testConfigDir+"/.keptn-stages/staging/my-service/helm/values.yaml", fields.fileSystem.WriteFileCalls()[0].Path)
+	require.Equal(t, []byte("new content of values.yaml\n"), fields.fileSystem.WriteFileCalls()[0].Content)
+
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Promoted resources of service my-service from stage dev to stage staging\n\nSource commit: source-revision", fields.git.StageAndCommitAllCalls()[0].Message)
+}
+
+func TestResourceManager_PromoteResources_CommitRejected(t *testing.T) {
+	fields := getTestPromotionFields()
+	fields.git.StageAndCommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
+		return "", errors2.ErrNonFastForwardUpdate
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "staging",
+			GitCommitID: "source-revision",
+		},
+	})
+
+	// a promotion whose commits are rejected by the upstream must not succeed without a commit
+	require.NotNil(t, err)
+	require.Nil(t, result)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 5)
+}
+
+func TestResourceManager_PromoteResources_Preview(t *testing.T) {
+	fields := getTestPromotionFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "staging",
+			Include:     []string{"*.yaml"},
+			Preview:     true,
+		},
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, "my-revision", result.SourceCommitID)
+	require.Empty(t, result.CommitID)
+	require.Equal(t, []string{"slo.yaml"}, result.Resources)
+	require.Contains(t, result.Diff, "+new content of slo.yaml")
+
+	require.Empty(t, fields.fileSystem.WriteFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestResourceManager_PromoteResources_NoMatchingResources(t *testing.T) {
+	fields := getTestPromotionFields()
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "staging",
+			Include:     []string{"*.json"},
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Len(t, fields.stageContext.EstablishCalls(), 1)
+}
+
+func TestResourceManager_PromoteResources_TargetServiceNotFound(t *testing.T) {
+	fields := getTestPromotionFields()
+
+	fields.stageContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage.StageName == "staging" {
+			return "", errors2.ErrServiceNotFound
+		}
+		return testConfigDir + "/.keptn-stages/dev/my-service", nil
+	}
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.PromoteResources(models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "dev"},
+			Service: &models.Service{ServiceName: "my-service"},
+		},
+		PromoteResourcesPayload: models.PromoteResourcesPayload{
+			TargetStage: "staging",
+		},
+	})
+
+	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func Test_shouldPromoteResource(t *testing.T) {
+	require.True(t, shouldPromoteResource("helm/values.yaml", nil, nil))
+	require.False(t, shouldPromoteResource("metadata.yaml", nil, nil))
+	require.False(t, shouldPromoteResource(common.StageDirectoryName+"/dev/slo.yaml", nil, nil))
+	require.True(t, shouldPromoteResource("helm/values.yaml", []string{"helm"}, nil))
+	require.False(t, shouldPromoteResource("slo.yaml", []string{"helm"}, nil))
+	require.False(t, shouldPromoteResource("helm/values.yaml", []string{"helm"}, []string{"**/values.yaml"}))
+}
+
 type fakeFileInfo struct {
 	name  string
 	isDir bool
@@ -1357,6 +2184,14 @@ func getTestResourceManagerFields() testResourceManagerFields {
 			GetFileRevisionFunc: func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
 				return []byte("file-content"), nil
 			},
+			GetFileHistoryFunc: func(gitContext common_models.GitContext, path string, limit int) ([]common_models.GitCommit, error) {
+				return []common_models.GitCommit{
+					{CommitID: "my-revision", AuthorName: "keptn", AuthorEmail: "keptn@keptn.sh", Message: "Updated resource\n", Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil
+			},
+			GetDiffFunc: func(gitContext common_models.GitContext, fromRevision string, toRevision string, path string) (string, error) {
+				return "my-diff", nil
+			},
 			ProjectExistsFunc:     func(gitContext common_models.GitContext) bool { return true },
 			ProjectRepoExistsFunc: func(projectName string) bool { return true },
 			PullFunc:              func(gitContext common_models.GitContext) error { return nil },
@@ -1410,5 +2245,34 @@ func getTestResourceManagerFields() testResourceManagerFields {
 				return testConfigDir, nil
 			},
 		},
+		changeRequests: &common_mock.IChangeRequestManagerMock{
+			GetSettingsFunc: func(project string) (*common_models.ChangeRequestSettings, error) {
+				return nil, nil
+			},
+		},
+		lfs: &common_mock.ILFSMock{
+			IsTrackedFunc: func(gitContext common_models.GitContext, path string) bool {
+				return false
+			},
+		},
+	}
+}
+
+func getTestChangeRequestFields() testResourceManagerFields {
+	fields := getTestResourceManagerFields()
+	fields.git.GetCurrentBranchFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "main", nil
+	}
+	fields.git.StageAndCommitToBranchFunc = func(gitContext common_models.GitContext, message string, branch string) (string, error) {
+		return "my-change-revision", nil
+	}
+	fields.changeRequests.GetSettingsFunc = func(project string) (*common_models.ChangeRequestSettings, error) {
+		return &common_models.ChangeRequestSettings{Provider: "github", Repository: "my-org/my-repo"}, nil
+	}
+	fields.changeRequests.CreateChangeRequestFunc = func(settings common_models.ChangeRequestSettings, request models.ChangeRequest) (*models.ChangeRequest, error) {
+		request.ID = "1"
+		request.State = models.ChangeRequestStateOpen
+		return &request, nil
 	}
+	return fields
 }
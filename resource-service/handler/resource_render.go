@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"path"
+
+	"github.com/keptn/keptn/resource-service/common"
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	kerrors "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+)
+
+// renderLevel is a configuration directory from which templates and variables are read when a resource is rendered
+type renderLevel struct {
+	revision       string
+	repositoryPath string
+}
+
+// renderResource renders a resource with the variables of the project, as well as of the stage and service it has been
+// requested for. If the resource does not exist for the service or stage, the template of the stage or project is used instead.
+// Returns the rendered content and the revision of the requested branch
+func (p ResourceManager) renderResource(gitContext *common_models.GitContext, params models.GetResourceParams, resourceName string) ([]byte, string, error) {
+	if common.IsHelmChartPath(resourceName) {
+		return nil, "", kerrors.ErrResourceNotRenderable
+	}
+
+	revision := params.GitCommitID
+	if revision == "" || revision == "\"\"" {
+		if err := p.git.Pull(*gitContext); err != nil {
+			return nil, "", err
+		}
+		var err error
+		revision, err = p.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	levels, err := p.getRenderLevels(gitContext, params.ResourceContext, revision)
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err := p.getRenderTemplate(gitContext, levels, resourceName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// variables of more specific levels override the ones of the project
+	variableFiles := [][]byte{}
+	for i := len(levels) - 1; i >= 0; i-- {
+		file, err := p.git.GetFileRevision(*gitContext, levels[i].revision, path.Join(levels[i].repositoryPath, common.VariablesFileName))
+		if errors.Is(err, kerrors.ErrResourceNotFound) {
+			continue
+		} else if err != nil {
+			return nil, "", err
+		}
+		variableFiles = append(variableFiles, file)
+	}
+	variables, err := common.MergeVariables(variableFiles...)
+	if err != nil {
+		return nil, "", err
+	}
+	variables[common.RenderContextKey] = getRenderContext(params.ResourceContext)
+
+	rendered, err := common.RenderResource(resourceName, content, variables)
+	if err != nil {
+		return nil, "", err
+	}
+	return rendered, revision, nil
+}
+
+// getRenderLevels returns the configuration directories of the service, stage and project, starting with the most specific one
+func (p ResourceManager) getRenderLevels(gitContext *common_models.GitContext, resourceContext models.ResourceContext, revision string) ([]renderLevel, error) {
+	levels := []renderLevel{}
+	if resourceContext.Stage == nil {
+		return append(levels, renderLevel{revision: revision}), nil
+	}
+
+	stagePath := ""
+	if config.Global.DirectoryStageStructure {
+		stagePath = common.StageDirectoryName + "/" + resourceContext.Stage.StageName
+	}
+	if resourceContext.Service != nil {
+		levels = append(levels, renderLevel{revision: revision, repositoryPath: path.Join(stagePath, resourceContext.Service.ServiceName)})
+	}
+	levels = append(levels, renderLevel{revision: revision, repositoryPath: stagePath})
+
+	if config.Global.DirectoryStageStructure {
+		return append(levels, renderLevel{revision: revision}), nil
+	}
+
+	// the project is stored in the default branch, which is used as it was at the time of the revision
+	defaultBranch, err := p.git.GetDefaultBranch(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	projectRevision, err := p.git.GetBranchRevisionAt(*gitContext, defaultBranch, revision)
+	if err != nil {
+		return nil, err
+	}
+	if projectRevision != nil {
+		levels = append(levels, renderLevel{revision: projectRevision.CommitID})
+	}
+	return levels, nil
+}
+
+// getRenderTemplate returns the content of the resource at the most specific level it exists
+func (p ResourceManager) getRenderTemplate(gitContext *common_models.GitContext, levels []renderLevel, resourceName string) ([]byte, error) {
+	for _, level := range levels {
+		repositoryPath := path.Join(level.repositoryPath, resourceName)
+		content, err := p.git.GetFileRevision(*gitContext, level.revision, repositoryPath)
+		if errors.Is(err, kerrors.ErrResourceNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return p.resolveLFSContent(gitContext, repositoryPath, content)
+	}
+	return nil, kerrors.ErrResourceNotFound
+}
+
+func getRenderContext(resourceContext models.ResourceContext) map[string]interface{} {
+	renderContext := map[string]interface{}{
+		"project": resourceContext.Project.ProjectName,
+		"stage":   "",
+		"service": "",
+	}
+	if resourceContext.Stage != nil {
+		renderContext["stage"] = resourceContext.Stage.StageName
+	}
+	if resourceContext.Service != nil {
+		renderContext["service"] = resourceContext.Service.ServiceName
+	}
+	return renderContext
+}
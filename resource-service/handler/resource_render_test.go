@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"testing"
+
+	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
+	errors2 "github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	"github.com/stretchr/testify/require"
+)
+
+// getTestRenderFields returns fields with a repository containing the given files, indexed by revision and path
+func getTestRenderFields(files map[string]map[string]string) testResourceManagerFields {
+	fields := getTestResourceManagerFields()
+	fields.git.GetFileRevisionFunc = func(gitContext common_models.GitContext, revision string, file string) ([]byte, error) {
+		content, ok := files[revision][file]
+		if !ok {
+			return nil, errors2.ErrResourceNotFound
+		}
+		return []byte(content), nil
+	}
+	fields.git.GetBranchRevisionAtFunc = func(gitContext common_models.GitContext, branch string, revision string) (*common_models.GitCommit, error) {
+		return &common_models.GitCommit{CommitID: branch + "-revision"}, nil
+	}
+	return fields
+}
+
+func getTestRenderParams(resourceURI string) models.GetResourceParams {
+	return models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: "my-project"},
+			Stage:   &models.Stage{StageName: "production"},
+			Service: &models.Service{ServiceName: "carts"},
+		},
+		ResourceURI:      resourceURI,
+		GetResourceQuery: models.GetResourceQuery{Render: true},
+	}
+}
+
+func TestResourceManager_GetResource_Render(t *testing.T) {
+	fields := getTestRenderFields(map[string]map[string]string{
+		"main-revision": {
+			"values.yaml":    "replicas: ${{ .replicas }}\nimage: ${{ .image.name }}:${{ .image.tag }}\nstage: ${{ .keptn.stage }}\n",
+			"variables.yaml": "replicas: 1\nimage:\n  name: carts\n  tag: 0.1.0\n",
+		},
+		"my-revision": {
+			"variables.yaml":       "replicas: 3\n",
+			"carts/variables.yaml": "image:\n  tag: 0.2.0\n",
+		},
+	})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResource(getTestRenderParams("values.yaml"))
+	require.Nil(t, err)
+
+	// the template of the project is used, since the resource does not exist for the stage or service
+	content, err := base64.StdEncoding.DecodeString(string(result.ResourceContent))
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 3\nimage: carts:0.2.0\nstage: production\n", string(content))
+	require.Equal(t, models.Version{UpstreamURL: "remote-url", Version: "my-revision"}, result.Metadata)
+
+	// the project is read from the default branch as it was at the time of the revision of the stage
+	require.Len(t, fields.git.GetBranchRevisionAtCalls(), 1)
+	require.Equal(t, "main", fields.git.GetBranchRevisionAtCalls()[0].Branch)
+	require.Equal(t, "my-revision", fields.git.GetBranchRevisionAtCalls()[0].Revision)
+	require.Len(t, fields.git.PullCalls(), 1)
+}
+
+func TestResourceManager_GetResource_Render_StageDirectory(t *testing.T) {
+	config.Global.DirectoryStageStructure = true
+	defer func() { config.Global.DirectoryStageStructure = false }()
+
+	fields := getTestRenderFields(map[string]map[string]string{
+		"my-commit": {
+			"values.yaml": "replicas: ${{ .replicas }}\n",
+			".keptn-stages/production/carts/values.yaml": "replicas: ${{ .replicas }}\nservice: ${{ .keptn.service }}\n",
+			"variables.yaml": "replicas: 1\n",
+			".keptn-stages/production/variables.yaml":       "replicas: 3\n",
+			".keptn-stages/production/carts/variables.yaml": "replicas: 5\n",
+		},
+	})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	params := getTestRenderParams("values.yaml")
+	params.GitCommitID = "my-commit"
+	result, err := rm.GetResource(params)
+	require.Nil(t, err)
+
+	content, err := base64.StdEncoding.DecodeString(string(result.ResourceContent))
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 5\nservice: carts\n", string(content))
+	require.Equal(t, "my-commit", result.Metadata.Version)
+
+	require.Empty(t, fields.git.GetBranchRevisionAtCalls())
+	require.Empty(t, fields.git.PullCalls())
+}
+
+func TestResourceManager_GetResource_Render_MissingVariable(t *testing.T) {
+	fields := getTestRenderFields(map[string]map[string]string{
+		"my-revision": {
+			"carts/values.yaml": "replicas: ${{ .replicas }}\n",
+		},
+	})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResource(getTestRenderParams("values.yaml"))
+	require.ErrorIs(t, err, errors2.ErrRenderResource)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_GetResource_Render_ResourceNotFound(t *testing.T) {
+	fields := getTestRenderFields(map[string]map[string]string{})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResource(getTestRenderParams("values.yaml"))
+	require.ErrorIs(t, err, errors2.ErrResourceNotFound)
+	require.Nil(t, result)
+	require.Len(t, fields.git.GetFileRevisionCalls(), 3)
+}
+
+func TestResourceManager_GetResource_Render_HelmChart(t *testing.T) {
+	fields := getTestRenderFields(map[string]map[string]string{})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	result, err := rm.GetResource(getTestRenderParams("helm/carts.tgz"))
+	require.ErrorIs(t, err, errors2.ErrResourceNotRenderable)
+	require.Nil(t, result)
+}
+
+func TestResourceManager_GetResourceStream_Render(t *testing.T) {
+	fields := getTestRenderFields(map[string]map[string]string{
+		"my-revision": {
+			"carts/values.yaml":    "replicas: ${{ .replicas }}\n",
+			"carts/variables.yaml": "replicas: 3\n",
+		},
+	})
+
+	rm := NewResourceManager(fields.git, fields.credentialReader, fields.fileSystem, fields.stageContext, fields.changeRequests, fields.lfs)
+
+	stream, err := rm.GetResourceStream(getTestRenderParams("values.yaml"))
+	require.Nil(t, err)
+
+	content, err := ioutil.ReadAll(stream.Content)
+	require.Nil(t, err)
+	require.Equal(t, "replicas: 3\n", string(content))
+	require.Equal(t, int64(len(content)), stream.Size)
+	require.Equal(t, "my-revision", stream.Metadata.Version)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/resource-service/config"
+	"github.com/keptn/keptn/resource-service/errors"
+	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
+)
+
+// headerResourceVersion contains the git commit ID of a streamed resource, since the metadata can not be part of the response body
+const headerResourceVersion = "X-Keptn-Resource-Version"
+const formFieldFile = "file"
+
+// getResourceStreamContent returns the content of a resource that is uploaded either as raw request body, or as the 'file' field
+// of a multipart form. The content is limited to the maximum resource size
+func getResourceStreamContent(c *gin.Context) (io.Reader, error) {
+	return getStreamContent(c, config.Global.MaxResourceSizeBytes())
+}
+
+// getArchiveStreamContent is the equivalent of getResourceStreamContent for project archives, which are limited to the maximum
+// archive size
+func getArchiveStreamContent(c *gin.Context) (io.Reader, error) {
+	return getStreamContent(c, config.Global.MaxArchiveSizeBytes())
+}
+
+func getStreamContent(c *gin.Context, maxSize int64) (io.Reader, error) {
+	if c.Request.ContentLength > maxSize {
+		return nil, &http.MaxBytesError{Limit: maxSize}
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+
+	if !strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		return c.Request.Body, nil
+	}
+	// the parts are read one after another, instead of parsing the whole form into memory
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errors.ErrResourceContentMissing
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == formFieldFile {
+			return part, nil
+		}
+	}
+}
+
+// setResourceStreamContentError responds with the status matching the error that occurred while reading the uploaded content
+func setResourceStreamContentError(c *gin.Context, err error) {
+	if resourceTooLarge(err) || goerrors.Is(err, errors.ErrArchiveTooLarge) {
+		OnAPIError(c, err)
+		return
+	}
+	SetBadRequestErrorResponse(c, err.Error())
+}
+
+// toArchiveError returns ErrArchiveTooLarge if the request body exceeded the maximum size, since it is otherwise reported as
+// resource that is too large
+func toArchiveError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if goerrors.As(err, &maxBytesErr) {
+		return errors.ErrArchiveTooLarge
+	}
+	return err
+}
+
+func writeResourceStream(c *gin.Context, stream *models.ResourceStream) {
+	defer func() {
+		if err := stream.Content.Close(); err != nil {
+			logger.Errorf("Could not close resource stream: %v", err)
+		}
+	}()
+	c.DataFromReader(http.StatusOK, stream.Size, "application/octet-stream", stream.Content, map[string]string{
+		headerResourceVersion: stream.Metadata.Version,
+	})
+}
+
+func writeArchiveStream(c *gin.Context, projectName string, format string, stream *models.ResourceStream) {
+	defer func() {
+		if err := stream.Content.Close(); err != nil {
+			logger.Errorf("Could not close archive stream: %v", err)
+		}
+	}()
+	contentType := "application/gzip"
+	if format == models.ArchiveFormatZip {
+		contentType = "application/zip"
+	}
+	c.DataFromReader(http.StatusOK, stream.Size, contentType, stream.Content, map[string]string{
+		headerResourceVersion: stream.Metadata.Version,
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%s.%s", projectName, format),
+	})
+}
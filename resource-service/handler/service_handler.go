@@ -10,6 +10,7 @@ import (
 type IServiceHandler interface {
 	CreateService(context *gin.Context)
 	DeleteService(context *gin.Context)
+	RenameService(context *gin.Context)
 }
 
 type ServiceHandler struct {
@@ -68,3 +69,49 @@ func (sh *ServiceHandler) DeleteService(c *gin.Context) {
 	}
 	c.String(http.StatusNoContent, "")
 }
+
+// RenameService godoc
+// @Summary      Renames a service
+// @Description  Renames a service in all stages of a project it exists in. If stages are stored in branches, one commit is created for each branch.
+// @Description  A sh.keptn.event.service.renamed event is sent afterwards.
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path      string                       true  "The name of the project"
+// @Param        serviceName  path      string                       true  "The name of the service"
+// @Param        service      body      models.RenameServicePayload  true  "The new name of the service"
+// @Success      200          {object}  models.RenameServiceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project or service not found"
+// @Failure      409          {object}  models.Error  "A service with the new name already exists"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/service/{serviceName}/rename [post]
+func (sh *ServiceHandler) RenameService(c *gin.Context) {
+	params := &models.RenameServiceParams{
+		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+		Service: models.Service{ServiceName: c.Param(pathParamServiceName)},
+	}
+
+	renameService := &models.RenameServicePayload{}
+	if err := c.ShouldBindJSON(renameService); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RenameServicePayload = *renameService
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := sh.ServiceManager.RenameService(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
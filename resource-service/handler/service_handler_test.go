@@ -15,6 +15,7 @@ import (
 
 const createServiceTestPayload = `{"serviceName": "my-service"}`
 const createServiceWithoutNameTestPayload = `{"serviceName": ""}`
+const renameServiceTestPayload = `{"newServiceName": "my-renamed-service"}`
 
 func TestServiceHandler_CreateService(t *testing.T) {
 	type fields struct {
@@ -365,3 +366,103 @@ func TestServiceHandler_DeleteService(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceHandler_RenameService(t *testing.T) {
+	type fields struct {
+		ServiceManager *handler_mock.IServiceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.RenameServiceParams
+		wantStatus int
+	}{
+		{
+			name: "rename service successful",
+			fields: fields{
+				ServiceManager: &handler_mock.IServiceManagerMock{RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+					return &models.RenameServiceResponse{Stages: []models.RenamedServiceStage{{StageName: "my-stage", CommitID: "my-commit"}}}, nil
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/service/my-service/rename", bytes.NewBuffer([]byte(renameServiceTestPayload))),
+			wantParams: &models.RenameServiceParams{
+				Project:              models.Project{ProjectName: "my-project"},
+				Service:              models.Service{ServiceName: "my-service"},
+				RenameServicePayload: models.RenameServicePayload{NewServiceName: "my-renamed-service"},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "service not found",
+			fields: fields{
+				ServiceManager: &handler_mock.IServiceManagerMock{RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+					return nil, errors2.ErrServiceNotFound
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/service/my-service/rename", bytes.NewBuffer([]byte(renameServiceTestPayload))),
+			wantParams: &models.RenameServiceParams{
+				Project:              models.Project{ProjectName: "my-project"},
+				Service:              models.Service{ServiceName: "my-service"},
+				RenameServicePayload: models.RenameServicePayload{NewServiceName: "my-renamed-service"},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "service with new name already exists",
+			fields: fields{
+				ServiceManager: &handler_mock.IServiceManagerMock{RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+					return nil, errors2.ErrServiceAlreadyExists
+				}},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/service/my-service/rename", bytes.NewBuffer([]byte(renameServiceTestPayload))),
+			wantParams: &models.RenameServiceParams{
+				Project:              models.Project{ProjectName: "my-project"},
+				Service:              models.Service{ServiceName: "my-service"},
+				RenameServicePayload: models.RenameServicePayload{NewServiceName: "my-renamed-service"},
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "new name equals current name",
+			fields: fields{
+				ServiceManager: &handler_mock.IServiceManagerMock{RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/service/my-service/rename", bytes.NewBuffer([]byte(`{"newServiceName": "my-service"}`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid payload",
+			fields: fields{
+				ServiceManager: &handler_mock.IServiceManagerMock{RenameServiceFunc: func(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+					return nil, errors.New("should not have been called")
+				}},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/service/my-service/rename", bytes.NewBuffer([]byte("invalid"))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := NewServiceHandler(tt.fields.ServiceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/service/:serviceName/rename", sh.RenameService)
+
+			resp := performRequest(router, tt.request)
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceManager.RenameServiceCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceManager.RenameServiceCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceManager.RenameServiceCalls())
+			}
+		})
+	}
+}
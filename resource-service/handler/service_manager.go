@@ -3,11 +3,15 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/keptn/go-utils/pkg/common/retry"
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	kerrors "github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
 	"gopkg.in/yaml.v3"
@@ -18,6 +22,7 @@ import (
 type IServiceManager interface {
 	CreateService(params models.CreateServiceParams) error
 	DeleteService(params models.DeleteServiceParams) error
+	RenameService(params models.RenameServiceParams) (*models.RenameServiceResponse, error)
 }
 
 type ServiceManager struct {
@@ -25,20 +30,31 @@ type ServiceManager struct {
 	credentialReader common.CredentialReader
 	fileSystem       common.IFileSystem
 	stageContext     IConfigurationContext
+	eventPublisher   common.EventPublisher
 }
 
-func NewServiceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext) *ServiceManager {
+// serviceRename is the directory of a service in a stage, which is moved when the service is renamed
+type serviceRename struct {
+	stage          string
+	servicePath    string
+	newServicePath string
+}
+
+func NewServiceManager(git common.IGit, credentialReader common.CredentialReader, fileWriter common.IFileSystem, stageContext IConfigurationContext, eventPublisher common.EventPublisher) *ServiceManager {
 	serviceManager := &ServiceManager{
 		git:              git,
 		credentialReader: credentialReader,
 		fileSystem:       fileWriter,
 		stageContext:     stageContext,
+		eventPublisher:   eventPublisher,
 	}
 	return serviceManager
 }
 
 func (s ServiceManager) CreateService(params models.CreateServiceParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, servicePath, err := s.establishServiceContext(params.Project, params.Stage, params.Service)
@@ -69,7 +85,9 @@ func (s ServiceManager) CreateService(params models.CreateServiceParams) error {
 }
 
 func (s ServiceManager) DeleteService(params models.DeleteServiceParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, servicePath, err := s.establishServiceContext(params.Project, params.Stage, params.Service)
@@ -99,6 +117,170 @@ func (s ServiceManager) DeleteService(params models.DeleteServiceParams) error {
 	return resultErr
 }
 
+// RenameService renames a service in all stages of a project it exists in. If stages are stored in branches, the changes
+// of each branch are committed separately, otherwise all stages are changed with a single commit
+func (s ServiceManager) RenameService(params models.RenameServiceParams) (*models.RenameServiceResponse, error) {
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return nil, err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	gitContext, err := s.establishProjectContext(params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	stages, err := s.getStages(gitContext, params.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	// all stages are checked before anything is changed, to not rename the service only partially
+	renames := []serviceRename{}
+	for _, stage := range stages {
+		servicePath, err := s.establishStageContext(gitContext, params.Project, stage, params.Service)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.git.Pull(*gitContext); err != nil {
+			return nil, err
+		}
+		if !s.fileSystem.FileExists(servicePath) {
+			continue
+		}
+		newServicePath := path.Join(path.Dir(servicePath), params.NewServiceName)
+		if s.fileSystem.FileExists(newServicePath) {
+			return nil, kerrors.ErrServiceAlreadyExists
+		}
+		renames = append(renames, serviceRename{stage: stage, servicePath: servicePath, newServicePath: newServicePath})
+	}
+	if len(renames) == 0 {
+		return nil, kerrors.ErrServiceNotFound
+	}
+
+	message := fmt.Sprintf("Renamed service: %s to %s", params.ServiceName, params.NewServiceName)
+	response := &models.RenameServiceResponse{Stages: []models.RenamedServiceStage{}}
+	if config.Global.DirectoryStageStructure {
+		// the directories of all stages are part of the default branch, which is still checked out
+		for _, rename := range renames {
+			if err := s.moveService(rename, params.NewServiceName); err != nil {
+				return nil, err
+			}
+		}
+		commitID, err := s.git.StageAndCommitAll(*gitContext, message)
+		if err != nil {
+			return nil, err
+		}
+		for _, rename := range renames {
+			response.Stages = append(response.Stages, models.RenamedServiceStage{StageName: rename.stage, CommitID: commitID})
+		}
+	} else {
+		for _, rename := range renames {
+			if _, err := s.establishStageContext(gitContext, params.Project, rename.stage, params.Service); err != nil {
+				return nil, err
+			}
+			if err := s.moveService(rename, params.NewServiceName); err != nil {
+				return nil, err
+			}
+			commitID, err := s.git.StageAndCommitAll(*gitContext, message)
+			if err != nil {
+				return nil, fmt.Errorf("could not rename service %s in stage %s: %w", params.ServiceName, rename.stage, err)
+			}
+			response.Stages = append(response.Stages, models.RenamedServiceStage{StageName: rename.stage, CommitID: commitID})
+		}
+	}
+
+	renamedStages := make([]string, 0, len(renames))
+	for _, rename := range renames {
+		renamedStages = append(renamedStages, rename.stage)
+	}
+	publishEvent(s.eventPublisher, params.ProjectName, models.ServiceRenamedEventType, models.ServiceRenamedEventData{
+		Project:    params.ProjectName,
+		Service:    params.ServiceName,
+		NewService: params.NewServiceName,
+		Stages:     renamedStages,
+	})
+	return response, nil
+}
+
+// getStages returns the names of all stages of a project, which are either the branches besides the default branch, or
+// the directories within the stage directory of the default branch
+func (s ServiceManager) getStages(gitContext *common_models.GitContext, project models.Project) ([]string, error) {
+	if _, err := s.stageContext.Establish(common_models.ConfigurationContextParams{
+		Project:    project,
+		GitContext: *gitContext,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.git.Pull(*gitContext); err != nil {
+		return nil, err
+	}
+
+	stages := []string{}
+	if config.Global.DirectoryStageStructure {
+		revision, err := s.git.GetCurrentRevision(*gitContext)
+		if err != nil {
+			return nil, err
+		}
+		files, err := s.git.ListFiles(*gitContext, revision, common.StageDirectoryName)
+		if err != nil {
+			return nil, err
+		}
+		known := map[string]bool{}
+		for _, file := range files {
+			segments := strings.Split(strings.TrimPrefix(file, common.StageDirectoryName+"/"), "/")
+			if len(segments) > 1 && !known[segments[0]] {
+				known[segments[0]] = true
+				stages = append(stages, segments[0])
+			}
+		}
+		sort.Strings(stages)
+		return stages, nil
+	}
+
+	defaultBranch, err := s.git.GetDefaultBranch(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	defaultBranch = strings.TrimPrefix(defaultBranch, "refs/heads/")
+	branches, err := s.git.ListBranches(*gitContext)
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range branches {
+		// branches containing a slash, e.g. the ones created for change requests, can not be stages
+		if branch != defaultBranch && !strings.Contains(branch, "/") {
+			stages = append(stages, branch)
+		}
+	}
+	return stages, nil
+}
+
+// moveService moves the directory of a service, and updates the name of the service in its metadata
+func (s ServiceManager) moveService(rename serviceRename, newServiceName string) error {
+	if err := s.fileSystem.MoveFile(rename.servicePath, rename.newServicePath); err != nil {
+		return fmt.Errorf("could not move directory of service in stage %s: %w", rename.stage, err)
+	}
+
+	metadataPath := rename.newServicePath + "/metadata.yaml"
+	content, err := s.fileSystem.ReadFile(metadataPath)
+	if errors.Is(err, kerrors.ErrResourceNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	metadata := &common.ServiceMetadata{}
+	if err := yaml.Unmarshal(content, metadata); err != nil {
+		return fmt.Errorf("could not decode metadata of service in stage %s: %w", rename.stage, err)
+	}
+	metadata.ServiceName = newServiceName
+	content, err = yaml.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return s.fileSystem.WriteFile(metadataPath, content)
+}
+
 func (s ServiceManager) deleteService(gitContext *common_models.GitContext, serviceName, servicePath string) (string, error) {
 
 	if !s.fileSystem.FileExists(servicePath) {
@@ -112,14 +294,28 @@ func (s ServiceManager) deleteService(gitContext *common_models.GitContext, serv
 }
 
 func (s ServiceManager) establishServiceContext(project models.Project, stage models.Stage, service models.Service) (*common_models.GitContext, string, error) {
-	credentials, err := s.credentialReader.GetCredentials(project.ProjectName)
+	gitContext, err := s.establishProjectContext(project)
 	if err != nil {
-		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+		return nil, "", err
+	}
+
+	configPath, err := s.establishStageContext(gitContext, project, stage.StageName, service)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return gitContext, configPath, nil
+}
+
+func (s ServiceManager) establishProjectContext(project models.Project) (*common_models.GitContext, error) {
+	credentials, err := getCredentials(s.credentialReader, s.fileSystem, project.ProjectName)
+	if err != nil {
+		return nil, err
 	}
 
 	auth, err := getAuthMethod(credentials)
 	if err != nil {
-		return nil, "", fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project.ProjectName, err)
+		return nil, fmt.Errorf(kerrors.ErrMsgCouldNotEstablishAuthMethod, project.ProjectName, err)
 	}
 
 	gitContext := common_models.GitContext{
@@ -128,22 +324,25 @@ func (s ServiceManager) establishServiceContext(project models.Project, stage mo
 		AuthMethod:  *auth,
 	}
 
-	if !s.git.ProjectExists(gitContext) {
-		return nil, "", kerrors.ErrProjectNotFound
+	if !projectExists(s.git, s.fileSystem, gitContext) {
+		return nil, kerrors.ErrProjectNotFound
 	}
+	return &gitContext, nil
+}
 
+// establishStageContext checks out the given stage and returns the directory of the service within it
+func (s ServiceManager) establishStageContext(gitContext *common_models.GitContext, project models.Project, stage string, service models.Service) (string, error) {
 	configPath, err := s.stageContext.Establish(common_models.ConfigurationContextParams{
 		Project:                 project,
-		Stage:                   &stage,
+		Stage:                   &models.Stage{StageName: stage},
 		Service:                 &service,
-		GitContext:              gitContext,
+		GitContext:              *gitContext,
 		CheckConfigDirAvailable: false,
 	})
 	if err != nil {
-		return nil, "", fmt.Errorf("could not check out branch %s of project %s: %w", stage.StageName, project.ProjectName, err)
+		return "", fmt.Errorf("could not check out branch %s of project %s: %w", stage, project.ProjectName, err)
 	}
-
-	return &gitContext, configPath, nil
+	return configPath, nil
 }
 
 func (s ServiceManager) createService(gitContext *common_models.GitContext, serviceName, servicePath string) (string, error) {
@@ -10,6 +10,7 @@ import (
 	common_mock "github.com/keptn/keptn/resource-service/common/fake"
 
 	"github.com/keptn/keptn/resource-service/common_models"
+	"github.com/keptn/keptn/resource-service/config"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
 	"github.com/keptn/keptn/resource-service/models"
@@ -22,6 +23,7 @@ type serviceManagerTestFields struct {
 	credentialReader     *common_mock.CredentialReaderMock
 	fileWriter           *common_mock.IFileSystemMock
 	configurationContext *handler_mock.IConfigurationContextMock
+	eventPublisher       *common_mock.EventPublisherMock
 }
 
 func TestServiceManager_CreateService(t *testing.T) {
@@ -43,7 +45,7 @@ func TestServiceManager_CreateService(t *testing.T) {
 
 	fields := getTestServiceManagerFields()
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.Nil(t, err)
@@ -77,7 +79,7 @@ func TestServiceManager_CreateService_CannotReadCredentials(t *testing.T) {
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrCredentialsNotFound
 	}
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrCredentialsNotFound)
@@ -109,7 +111,7 @@ func TestServiceManager_CreateService_ProjectNotFound(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -144,7 +146,7 @@ func TestServiceManager_CreateService_StageNotFound(t *testing.T) {
 		return "", errors2.ErrStageNotFound
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrStageNotFound)
@@ -180,7 +182,7 @@ func TestServiceManager_CreateService_ServiceAlreadyExists(t *testing.T) {
 		return true
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.ErrorIs(t, err, errors2.ErrServiceAlreadyExists)
@@ -216,7 +218,7 @@ func TestServiceManager_CreateService_CannotCreateDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -252,7 +254,7 @@ func TestServiceManager_CreateService_CannotCreateMetadata(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -289,7 +291,7 @@ func TestServiceManager_CreateService_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.CreateService(params)
 
 	require.NotNil(t, err)
@@ -324,7 +326,7 @@ func TestServiceManager_DeleteService(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.DeleteService(params)
 
 	require.Nil(t, err)
@@ -364,7 +366,7 @@ func TestServiceManager_DeleteService_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.DeleteService(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -395,7 +397,7 @@ func TestServiceManager_DeleteService_ServiceDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.DeleteService(params)
 
 	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
@@ -433,7 +435,7 @@ func TestServiceManager_DeleteService_DeleteDirectoryFails(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.DeleteService(params)
 
 	require.NotNil(t, err)
@@ -471,7 +473,7 @@ func TestServiceManager_DeleteService_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext)
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
 	err := p.DeleteService(params)
 
 	require.NotNil(t, err)
@@ -529,5 +531,156 @@ func getTestServiceManagerFields() serviceManagerTestFields {
 				return testServiceConfigDir, nil
 			},
 		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+				return nil
+			},
+		},
+	}
+}
+
+func getTestRenameServiceFields() serviceManagerTestFields {
+	fields := getTestServiceManagerFields()
+	fields.git.ListBranchesFunc = func(gitContext common_models.GitContext) ([]string, error) {
+		return []string{"dev", "keptn/change-request", "main", "production"}, nil
+	}
+	fields.git.StageAndCommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
+		return "my-commit", nil
+	}
+	fields.configurationContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
+		if params.Stage == nil {
+			return "/data/config/my-project", nil
+		}
+		return "/data/config/my-project/.keptn-stages/" + params.Stage.StageName + "/" + params.Service.ServiceName, nil
+	}
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return strings.HasSuffix(path, "/my-service")
+	}
+	fields.fileWriter.MoveFileFunc = func(source string, target string) error {
+		return nil
+	}
+	fields.fileWriter.ReadFileFunc = func(filename string) ([]byte, error) {
+		return []byte("servicename: my-service\ncreationtimestamp: \"2022-01-01\"\n"), nil
+	}
+	return fields
+}
+
+func getTestRenameServiceParams() models.RenameServiceParams {
+	return models.RenameServiceParams{
+		Project:              models.Project{ProjectName: "my-project"},
+		Service:              models.Service{ServiceName: "my-service"},
+		RenameServicePayload: models.RenameServicePayload{NewServiceName: "my-renamed-service"},
+	}
+}
+
+func TestServiceManager_RenameService(t *testing.T) {
+	fields := getTestRenameServiceFields()
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
+	result, err := p.RenameService(getTestRenameServiceParams())
+
+	require.Nil(t, err)
+	require.Equal(t, &models.RenameServiceResponse{Stages: []models.RenamedServiceStage{
+		{StageName: "dev", CommitID: "my-commit"},
+		{StageName: "production", CommitID: "my-commit"},
+	}}, result)
+
+	// each stage branch is renamed with its own commit
+	require.Len(t, fields.fileWriter.MoveFileCalls(), 2)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/dev/my-service", fields.fileWriter.MoveFileCalls()[0].Source)
+	require.Equal(t, "/data/config/my-project/.keptn-stages/dev/my-renamed-service", fields.fileWriter.MoveFileCalls()[0].Target)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 2)
+	require.Equal(t, "Renamed service: my-service to my-renamed-service", fields.git.StageAndCommitAllCalls()[0].Message)
+
+	// the metadata of the service contains the new name
+	require.Len(t, fields.fileWriter.WriteFileCalls(), 2)
+	metadata := &common.ServiceMetadata{}
+	require.Nil(t, yaml.Unmarshal(fields.fileWriter.WriteFileCalls()[0].Content, metadata))
+	require.Equal(t, "my-renamed-service", metadata.ServiceName)
+	require.Equal(t, "2022-01-01", metadata.CreationTimestamp)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+	event := fields.eventPublisher.PublishCalls()[0].Event
+	require.Equal(t, models.ServiceRenamedEventType, *event.Type)
+	require.Equal(t, models.ServiceRenamedEventData{
+		Project:    "my-project",
+		Service:    "my-service",
+		NewService: "my-renamed-service",
+		Stages:     []string{"dev", "production"},
+	}, event.Data)
+}
+
+func TestServiceManager_RenameService_StageDirectory(t *testing.T) {
+	config.Global.DirectoryStageStructure = true
+	defer func() { config.Global.DirectoryStageStructure = false }()
+
+	fields := getTestRenameServiceFields()
+	fields.git.GetCurrentRevisionFunc = func(gitContext common_models.GitContext) (string, error) {
+		return "my-revision", nil
+	}
+	fields.git.ListFilesFunc = func(gitContext common_models.GitContext, revision string, path string) ([]string, error) {
+		return []string{
+			".keptn-stages/dev/metadata.yaml",
+			".keptn-stages/dev/my-service/metadata.yaml",
+			".keptn-stages/production/metadata.yaml",
+		}, nil
+	}
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return strings.HasSuffix(path, "/dev/my-service")
 	}
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
+	result, err := p.RenameService(getTestRenameServiceParams())
+
+	require.Nil(t, err)
+	require.Equal(t, []models.RenamedServiceStage{{StageName: "dev", CommitID: "my-commit"}}, result.Stages)
+
+	require.Len(t, fields.git.ListFilesCalls(), 1)
+	require.Equal(t, "my-revision", fields.git.ListFilesCalls()[0].Revision)
+	require.Len(t, fields.fileWriter.MoveFileCalls(), 1)
+	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+}
+
+func TestServiceManager_RenameService_ServiceNotFound(t *testing.T) {
+	fields := getTestRenameServiceFields()
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return false
+	}
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
+	result, err := p.RenameService(getTestRenameServiceParams())
+
+	require.ErrorIs(t, err, errors2.ErrServiceNotFound)
+	require.Nil(t, result)
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
+func TestServiceManager_RenameService_ServiceAlreadyExists(t *testing.T) {
+	fields := getTestRenameServiceFields()
+	fields.fileWriter.FileExistsFunc = func(path string) bool {
+		return strings.HasSuffix(path, "/my-service") || strings.HasSuffix(path, "/production/my-renamed-service")
+	}
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
+	result, err := p.RenameService(getTestRenameServiceParams())
+
+	// no stage is changed, since the conflict is detected before
+	require.ErrorIs(t, err, errors2.ErrServiceAlreadyExists)
+	require.Nil(t, result)
+	require.Empty(t, fields.fileWriter.MoveFileCalls())
+	require.Empty(t, fields.git.StageAndCommitAllCalls())
+}
+
+func TestServiceManager_RenameService_ProjectNotFound(t *testing.T) {
+	fields := getTestRenameServiceFields()
+	fields.git.ProjectExistsFunc = func(gitContext common_models.GitContext) bool {
+		return false
+	}
+
+	p := NewServiceManager(fields.git, fields.credentialReader, fields.fileWriter, fields.configurationContext, fields.eventPublisher)
+	_, err := p.RenameService(getTestRenameServiceParams())
+
+	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
+	require.Empty(t, fields.configurationContext.EstablishCalls())
 }
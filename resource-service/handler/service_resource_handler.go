@@ -15,7 +15,14 @@ type IServiceResourceHandler interface {
 	UpdateServiceResources(context *gin.Context)
 	GetServiceResource(context *gin.Context)
 	UpdateServiceResource(context *gin.Context)
+	GetServiceResourceContent(context *gin.Context)
+	UpdateServiceResourceContent(context *gin.Context)
 	DeleteServiceResource(context *gin.Context)
+	GetServiceResourceHistory(context *gin.Context)
+	GetServiceResourceDiff(context *gin.Context)
+	GetServiceResourcesDiff(context *gin.Context)
+	RevertServiceResource(context *gin.Context)
+	PromoteServiceResources(context *gin.Context)
 }
 
 type ServiceResourceHandler struct {
@@ -50,6 +57,7 @@ func (ph *ServiceResourceHandler) CreateServiceResources(c *gin.Context) {
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
 			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -145,6 +153,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
 			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -183,8 +192,10 @@ func (ph *ServiceResourceHandler) UpdateServiceResources(c *gin.Context) {
 // @Param        serviceName                                 path    string  true  "The name of the service"
 // @Param        resourceURI                           path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource with the variables of the project, stage and service"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
 // @Failure      500          {object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI} [get]
 func (ph *ServiceResourceHandler) GetServiceResource(c *gin.Context) {
@@ -240,6 +251,7 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
 			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -265,6 +277,107 @@ func (ph *ServiceResourceHandler) UpdateServiceResource(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetServiceResourceContent godoc
+// @Summary      Get raw content of a service resource
+// @Description  Streams the raw content of a resource of the service in the given stage of a project. The git commit ID of the content is returned in the X-Keptn-Resource-Version header
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource with the variables of the project, stage and service"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/raw [get]
+func (ph *ServiceResourceHandler) GetServiceResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	stream, err := ph.ServiceResourceManager.GetResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	writeResourceStream(c, stream)
+}
+
+// UpdateServiceResourceContent godoc
+// @Summary      Updates the raw content of a service resource
+// @Description  Creates or updates a resource of the service in the given stage of a project with the raw content of the request body, or of the 'file' field of a multipart form
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream,mpfd
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        file         formData  file  false  "The content of the resource, if sent as multipart form"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      413          {object}  models.Error  "Resource too large"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/raw [put]
+func (ph *ServiceResourceHandler) UpdateServiceResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	content, err := getResourceStreamContent(c)
+	if err != nil {
+		setResourceStreamContentError(c, err)
+		return
+	}
+
+	params.Content = content
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.UpdateResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // DeleteServiceResource godoc
 // @Summary      Deletes a service resource
 // @Description  Deletes a resource for the service in the given stage of a project
@@ -287,6 +400,7 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
 			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -304,3 +418,250 @@ func (ph *ServiceResourceHandler) DeleteServiceResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetServiceResourceHistory godoc
+// @Summary      Get history of a service resource
+// @Description  Get the commits that changed a resource of the service in the given stage of a project, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        limit        query  int  false  "The maximum number of commits to return"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/history [get]
+func (ph *ServiceResourceHandler) GetServiceResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.ServiceResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetServiceResourceDiff godoc
+// @Summary      Get diff of a service resource
+// @Description  Get the unified diff of a resource of the service in the given stage of a project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// GetServiceResourcesDiff godoc
+// @Summary      Get diff of all service resources
+// @Description  Get the unified diff of all resources of the service in the given stage of a project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/diff [get]
+func (ph *ServiceResourceHandler) GetServiceResourcesDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+		},
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.ServiceResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertServiceResource godoc
+// @Summary      Reverts a service resource
+// @Description  Reverts a resource of the service in the given stage of a project to the content of an earlier revision by creating a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        revision     body  models.RevertResourcePayload  true  "The commit ID to revert to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/resource/{resourceURI}/revert [post]
+func (ph *ServiceResourceHandler) RevertServiceResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PromoteServiceResources godoc
+// @Summary      Promotes service resources to another stage
+// @Description  Copies the resources of the service in the given stage of a project at the given commit to another stage, as a single commit.
+// @Description  The resources can be filtered with include and exclude glob patterns. If preview is set, only the diff of the promotion is returned.
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Service Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the source stage"
+// @Param        serviceName  path  string  true  "The name of the service"
+// @Param        promotion    body  models.PromoteResourcesPayload  true  "The promotion to be performed"
+// @Success      200          {object}  models.PromoteResourcesResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/service/{serviceName}/promote [post]
+func (ph *ServiceResourceHandler) PromoteServiceResources(c *gin.Context) {
+	params := &models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Service: &models.Service{ServiceName: c.Param(pathParamServiceName)},
+			Author:  getAuthor(c),
+		},
+	}
+
+	promoteResources := &models.PromoteResourcesPayload{}
+	if err := c.ShouldBindJSON(promoteResources); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.PromoteResourcesPayload = *promoteResources
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.ServiceResourceManager.PromoteResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
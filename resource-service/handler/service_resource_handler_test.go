@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	errors2 "github.com/keptn/keptn/resource-service/errors"
 	handler_mock "github.com/keptn/keptn/resource-service/handler/fake"
@@ -602,6 +603,30 @@ func TestServiceResourceHandler_GetServiceResource(t *testing.T) {
 			wantResult: &testGetResourceCommitResponse,
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "render resource",
+			fields: fields{
+				ResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceFunc: func(params models.GetResourceParams) (*models.GetResourceResponse, error) {
+						return nil, fmt.Errorf("%w: map has no entry for key \"replicas\"", errors2.ErrRenderResource)
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml?render=true", nil),
+			wantParams: &models.GetResourceParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI: "my-resource.yaml",
+				GetResourceQuery: models.GetResourceQuery{
+					Render: true,
+				},
+			},
+			wantResult: nil,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
 		{
 			name: "get resource in parent directory- should return error",
 			fields: fields{
@@ -922,3 +947,164 @@ func TestServiceResourceHandler_DeleteServiceResource(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceResourceHandler_GetServiceResourceDiff(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.GetResourceDiffParams
+		wantStatus int
+	}{
+		{
+			name: "get diff of resource",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					GetResourceDiffFunc: func(params models.GetResourceDiffParams) (*models.GetResourceDiffResponse, error) {
+						return &models.GetResourceDiffResponse{From: "from-id", To: "to-id", Diff: "my-diff"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/my-resource.yaml/diff?from=from-id", nil),
+			wantParams: &models.GetResourceDiffParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "my-stage"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				ResourceURI:          "my-resource.yaml",
+				GetResourceDiffQuery: models.GetResourceDiffQuery{From: "from-id"},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "invalid resourceURI",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodGet, "/project/my-project/stage/my-stage/service/my-service/resource/~my-resource.yaml/diff?from=from-id", nil),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.GET("/project/:projectName/stage/:stageName/service/:serviceName/resource/:resourceURI/diff", ph.GetServiceResourceDiff)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.GetResourceDiffCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.GetResourceDiffCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
+
+func TestServiceResourceHandler_PromoteServiceResources(t *testing.T) {
+	type fields struct {
+		ServiceResourceManager *handler_mock.IResourceManagerMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		request    *http.Request
+		wantParams *models.PromoteResourcesParams
+		wantStatus int
+	}{
+		{
+			name: "promote resources",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return &models.PromoteResourcesResponse{SourceCommitID: "source-id", CommitID: "commit-id"}, nil
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/dev/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "staging", "gitCommitID": "source-id", "include": ["helm/**"], "preview": true}`))),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "dev"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					TargetStage: "staging",
+					GitCommitID: "source-id",
+					Include:     []string{"helm/**"},
+					Preview:     true,
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "target stage equals source stage",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/dev/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "dev"}`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid glob pattern",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{},
+			},
+			request:    httptest.NewRequest(http.MethodPost, "/project/my-project/stage/dev/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "staging", "exclude": ["["]}`))),
+			wantParams: nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service not found in target stage",
+			fields: fields{
+				ServiceResourceManager: &handler_mock.IResourceManagerMock{
+					PromoteResourcesFunc: func(params models.PromoteResourcesParams) (*models.PromoteResourcesResponse, error) {
+						return nil, errors2.ErrServiceNotFound
+					},
+				},
+			},
+			request: httptest.NewRequest(http.MethodPost, "/project/my-project/stage/dev/service/my-service/promote", bytes.NewBuffer([]byte(`{"targetStage": "staging"}`))),
+			wantParams: &models.PromoteResourcesParams{
+				ResourceContext: models.ResourceContext{
+					Project: models.Project{ProjectName: "my-project"},
+					Stage:   &models.Stage{StageName: "dev"},
+					Service: &models.Service{ServiceName: "my-service"},
+				},
+				PromoteResourcesPayload: models.PromoteResourcesPayload{
+					TargetStage: "staging",
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph := NewServiceResourceHandler(tt.fields.ServiceResourceManager)
+
+			router := gin.Default()
+			router.POST("/project/:projectName/stage/:stageName/service/:serviceName/promote", ph.PromoteServiceResources)
+
+			resp := performRequest(router, tt.request)
+
+			if tt.wantParams != nil {
+				require.Len(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls(), 1)
+				require.Equal(t, *tt.wantParams, tt.fields.ServiceResourceManager.PromoteResourcesCalls()[0].Params)
+			} else {
+				require.Empty(t, tt.fields.ServiceResourceManager.PromoteResourcesCalls())
+			}
+
+			require.Equal(t, tt.wantStatus, resp.Code)
+		})
+	}
+}
@@ -50,6 +50,20 @@ func (ph *StageHandler) CreateStage(c *gin.Context) {
 	c.String(http.StatusNoContent, "")
 }
 
+// DeleteStage godoc
+// @Summary      Deletes a stage
+// @Description  Deletes a stage of a project. If stages are stored in branches, the latest revision of the branch is archived as tag keptn-archive/stages/{stageName}/{timestamp} of the upstream repository before the branch is deleted.
+// @Description  A sh.keptn.event.stage.deleted event is sent afterwards.
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:delete</span>
+// @Tags         Stage
+// @Security     ApiKeyAuth
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Success      204          {string}  string        "ok"
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Project or stage not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName} [delete]
 func (sh *StageHandler) DeleteStage(c *gin.Context) {
 	params := &models.DeleteStageParams{
 		Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
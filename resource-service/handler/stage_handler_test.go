@@ -193,6 +193,24 @@ func TestStageHandler_DeleteStage(t *testing.T) {
 			},
 			wantStatus: http.StatusNotFound,
 		},
+		{
+			name: "stage is default branch",
+			fields: fields{
+				StageManager: &handler_mock.IStageManagerMock{DeleteStageFunc: func(params models.DeleteStageParams) error {
+					return errors2.ErrStageIsDefaultBranch
+				}},
+			},
+			request: httptest.NewRequest(http.MethodDelete, "/project/my-project/stage/main", nil),
+			wantParams: &models.DeleteStageParams{
+				Project: models.Project{
+					ProjectName: "my-project",
+				},
+				Stage: models.Stage{
+					StageName: "main",
+				},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
 		{
 			name: "stage not found",
 			fields: fields{
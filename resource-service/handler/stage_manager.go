@@ -1,16 +1,22 @@
 package handler
 
 import (
+	stderrors "errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/keptn/keptn/resource-service/common"
 	"github.com/keptn/keptn/resource-service/common_models"
 	"github.com/keptn/keptn/resource-service/errors"
 	"github.com/keptn/keptn/resource-service/models"
+	logger "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
 
+// stageArchiveTagPrefix is the prefix of the tags the branches of deleted stages are archived with
+const stageArchiveTagPrefix = "keptn-archive/stages"
+
 //IStageManager provides an interface for stage CRUD operations
 //go:generate moq -pkg handler_mock -skip-ensure -out ./fake/stage_manager_mock.go . IStageManager
 type IStageManager interface {
@@ -21,23 +27,29 @@ type IStageManager interface {
 type BranchingStageManager struct {
 	git              common.IGit
 	credentialReader common.CredentialReader
+	fileSystem       common.IFileSystem
+	eventPublisher   common.EventPublisher
 }
 
-func NewStageManager(git common.IGit, credentialReader common.CredentialReader) *BranchingStageManager {
+func NewStageManager(git common.IGit, credentialReader common.CredentialReader, fileSystem common.IFileSystem, eventPublisher common.EventPublisher) *BranchingStageManager {
 	stageManager := &BranchingStageManager{
 		git:              git,
 		credentialReader: credentialReader,
+		fileSystem:       fileSystem,
+		eventPublisher:   eventPublisher,
 	}
 	return stageManager
 }
 
 func (s BranchingStageManager) CreateStage(params models.CreateStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
-	credentials, err := s.credentialReader.GetCredentials(params.ProjectName)
+	credentials, err := getCredentials(s.credentialReader, s.fileSystem, params.ProjectName)
 	if err != nil {
-		return fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, params.ProjectName, err)
+		return err
 	}
 
 	auth, err := getAuthMethod(credentials)
@@ -51,7 +63,7 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 		AuthMethod:  *auth,
 	}
 
-	if !s.git.ProjectExists(gitContext) {
+	if !projectExists(s.git, s.fileSystem, gitContext) {
 		return errors.ErrProjectNotFound
 	}
 
@@ -73,8 +85,77 @@ func (s BranchingStageManager) CreateStage(params models.CreateStageParams) erro
 	return nil
 }
 
+// DeleteStage deletes the branch of a stage. Before that, the latest revision of the branch is archived with a tag in the
+// upstream repository, so that the stage can be restored later on
 func (s BranchingStageManager) DeleteStage(params models.DeleteStageParams) error {
-	panic("implement me")
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
+	defer common.UnlockProject(params.ProjectName)
+
+	credentials, err := getCredentials(s.credentialReader, s.fileSystem, params.ProjectName)
+	if err != nil {
+		return err
+	}
+
+	auth, err := getAuthMethod(credentials)
+	if err != nil {
+		return fmt.Errorf(errors.ErrMsgCouldNotEstablishAuthMethod, params.Project.ProjectName, err)
+	}
+
+	gitContext := common_models.GitContext{
+		Project:     params.ProjectName,
+		Credentials: credentials,
+		AuthMethod:  *auth,
+	}
+
+	if !projectExists(s.git, s.fileSystem, gitContext) {
+		return errors.ErrProjectNotFound
+	}
+
+	defaultBranch, err := s.git.GetDefaultBranch(gitContext)
+	if err != nil {
+		return fmt.Errorf("could not determine default branch of project %s: %w", params.ProjectName, err)
+	}
+	defaultBranch = strings.TrimPrefix(defaultBranch, "refs/heads/")
+	if params.StageName == defaultBranch {
+		return errors.ErrStageIsDefaultBranch
+	}
+
+	// the latest changes of the stage are pulled, to not lose them when the branch is deleted
+	if err := s.git.CheckoutBranch(gitContext, params.StageName); err != nil {
+		if stderrors.Is(err, errors.ErrReferenceNotFound) {
+			return errors.ErrStageNotFound
+		}
+		return fmt.Errorf("could not check out branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+	if err := s.git.Pull(gitContext); err != nil {
+		return fmt.Errorf("could not pull branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+	if err := s.git.CheckoutBranch(gitContext, defaultBranch); err != nil {
+		return fmt.Errorf("could not check out branch %s of project %s: %w", defaultBranch, params.ProjectName, err)
+	}
+
+	archiveTag := getStageArchiveTag(params.StageName, time.Now().UTC())
+	if err := s.git.CreateTag(gitContext, archiveTag, params.StageName); err != nil {
+		return fmt.Errorf("could not archive branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+	if err := s.git.DeleteBranch(gitContext, params.StageName); err != nil {
+		return fmt.Errorf("could not delete branch %s of project %s: %w", params.StageName, params.ProjectName, err)
+	}
+	logger.Infof("Deleted stage %s of project %s, its last revision has been archived as tag %s", params.StageName, params.ProjectName, archiveTag)
+
+	publishEvent(s.eventPublisher, params.ProjectName, models.StageDeletedEventType, models.StageDeletedEventData{
+		Project:    params.ProjectName,
+		Stage:      params.StageName,
+		ArchiveTag: archiveTag,
+	})
+	return nil
+}
+
+// getStageArchiveTag returns the name of the tag the branch of a deleted stage is archived with
+func getStageArchiveTag(stage string, deletedAt time.Time) string {
+	return fmt.Sprintf("%s/%s/%s", stageArchiveTagPrefix, stage, deletedAt.Format("20060102T150405Z"))
 }
 
 type DirectoryStageManager struct {
@@ -82,14 +163,17 @@ type DirectoryStageManager struct {
 	fileSystem           common.IFileSystem
 	credentialReader     common.CredentialReader
 	git                  common.IGit
+	eventPublisher       common.EventPublisher
 }
 
-func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSystem common.IFileSystem, credentialReader common.CredentialReader, git common.IGit) *DirectoryStageManager {
-	return &DirectoryStageManager{configurationContext: configurationContext, fileSystem: fileSystem, credentialReader: credentialReader, git: git}
+func NewDirectoryStageManager(configurationContext IConfigurationContext, fileSystem common.IFileSystem, credentialReader common.CredentialReader, git common.IGit, eventPublisher common.EventPublisher) *DirectoryStageManager {
+	return &DirectoryStageManager{configurationContext: configurationContext, fileSystem: fileSystem, credentialReader: credentialReader, git: git, eventPublisher: eventPublisher}
 }
 
 func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, stagePath, err := dm.establishStageContext(params.Project, params.Stage)
@@ -122,7 +206,9 @@ func (dm DirectoryStageManager) CreateStage(params models.CreateStageParams) err
 }
 
 func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) error {
-	common.LockProject(params.ProjectName)
+	if err := common.LockProject(params.ProjectName); err != nil {
+		return err
+	}
 	defer common.UnlockProject(params.ProjectName)
 
 	gitContext, stagePath, err := dm.establishStageContext(params.Project, params.Stage)
@@ -137,17 +223,21 @@ func (dm DirectoryStageManager) DeleteStage(params models.DeleteStageParams) err
 		return fmt.Errorf("could not delete directory of stage %s: %w", params.StageName, err)
 	}
 
-	if _, err := dm.git.StageAndCommitAll(*gitContext, "Added stage: "+params.StageName); err != nil {
+	if _, err := dm.git.StageAndCommitAll(*gitContext, "Removed stage: "+params.StageName); err != nil {
 		return fmt.Errorf("could not delete stage %s: %w", params.StageName, err)
 	}
 
+	publishEvent(dm.eventPublisher, params.ProjectName, models.StageDeletedEventType, models.StageDeletedEventData{
+		Project: params.ProjectName,
+		Stage:   params.StageName,
+	})
 	return nil
 }
 
 func (dm DirectoryStageManager) establishStageContext(project models.Project, stage models.Stage) (*common_models.GitContext, string, error) {
-	credentials, err := dm.credentialReader.GetCredentials(project.ProjectName)
+	credentials, err := getCredentials(dm.credentialReader, dm.fileSystem, project.ProjectName)
 	if err != nil {
-		return nil, "", fmt.Errorf(errors.ErrMsgCouldNotRetrieveCredentials, project.ProjectName, err)
+		return nil, "", err
 	}
 
 	auth, err := getAuthMethod(credentials)
@@ -161,7 +251,7 @@ func (dm DirectoryStageManager) establishStageContext(project models.Project, st
 		AuthMethod:  *auth,
 	}
 
-	if !dm.git.ProjectExists(gitContext) {
+	if !projectExists(dm.git, dm.fileSystem, gitContext) {
 		return nil, "", errors.ErrProjectNotFound
 	}
 
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	apimodels "github.com/keptn/go-utils/pkg/api/models"
@@ -20,6 +22,7 @@ type stageManagerTestFields struct {
 	credentialReader     *common_mock.CredentialReaderMock
 	configurationContext *handler_mock.IConfigurationContextMock
 	fileSystem           *common_mock.IFileSystemMock
+	eventPublisher       *common_mock.EventPublisherMock
 }
 
 func TestStageManager_CreateStage(t *testing.T) {
@@ -39,7 +42,7 @@ func TestStageManager_CreateStage(t *testing.T) {
 	}
 
 	fields := getTestStageManagerFields()
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.Nil(t, err)
@@ -70,7 +73,7 @@ func TestStageManager_CreateStage_NoCredentialsFound(t *testing.T) {
 	fields.credentialReader.GetCredentialsFunc = func(project string) (*common_models.GitCredentials, error) {
 		return nil, errors2.ErrCredentialsNotFound
 	}
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrCredentialsNotFound)
@@ -100,7 +103,7 @@ func TestStageManager_CreateStage_ProjectDoesNotExist(t *testing.T) {
 		return false
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrProjectNotFound)
@@ -133,7 +136,7 @@ func TestStageManager_CreateStage_CannotGetDefaultBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.NotNil(t, err)
@@ -166,7 +169,7 @@ func TestStageManager_CreateStage_CannotCreateBranch(t *testing.T) {
 		return errors2.ErrStageAlreadyExists
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.ErrorIs(t, err, errors2.ErrStageAlreadyExists)
@@ -202,7 +205,7 @@ func TestStageManager_CreateStage_CannotPushBranch(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	s := NewStageManager(fields.git, fields.credentialReader)
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
 	err := s.CreateStage(params)
 
 	require.NotNil(t, err)
@@ -216,6 +219,87 @@ func TestStageManager_CreateStage_CannotPushBranch(t *testing.T) {
 	require.Equal(t, fields.git.CreateBranchCalls()[0].Branch, "my-stage")
 }
 
+func TestStageManager_DeleteStage(t *testing.T) {
+	fields := getTestStageManagerFields()
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
+
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+	require.Nil(t, err)
+
+	// the stage is pulled before it is archived, and the default branch is checked out to delete the stage branch
+	require.Len(t, fields.git.CheckoutBranchCalls(), 2)
+	require.Equal(t, "my-stage", fields.git.CheckoutBranchCalls()[0].Branch)
+	require.Equal(t, "main", fields.git.CheckoutBranchCalls()[1].Branch)
+	require.Len(t, fields.git.PullCalls(), 1)
+
+	require.Len(t, fields.git.CreateTagCalls(), 1)
+	require.Equal(t, "my-stage", fields.git.CreateTagCalls()[0].Branch)
+	require.True(t, strings.HasPrefix(fields.git.CreateTagCalls()[0].Tag, "keptn-archive/stages/my-stage/"))
+
+	require.Len(t, fields.git.DeleteBranchCalls(), 1)
+	require.Equal(t, "my-stage", fields.git.DeleteBranchCalls()[0].Branch)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+	event := fields.eventPublisher.PublishCalls()[0].Event
+	require.Equal(t, models.StageDeletedEventType, *event.Type)
+	require.Equal(t, models.StageDeletedEventData{
+		Project:    "my-project",
+		Stage:      "my-stage",
+		ArchiveTag: fields.git.CreateTagCalls()[0].Tag,
+	}, event.Data)
+}
+
+func TestStageManager_DeleteStage_DefaultBranch(t *testing.T) {
+	fields := getTestStageManagerFields()
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
+
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "main"},
+	})
+	require.ErrorIs(t, err, errors2.ErrStageIsDefaultBranch)
+
+	require.Empty(t, fields.git.DeleteBranchCalls())
+}
+
+func TestStageManager_DeleteStage_StageNotFound(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.CheckoutBranchFunc = func(gitContext common_models.GitContext, branch string) error {
+		return fmt.Errorf(errors2.ErrMsgCouldNotCheckout, branch, errors2.ErrReferenceNotFound)
+	}
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
+
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+	require.ErrorIs(t, err, errors2.ErrStageNotFound)
+
+	require.Empty(t, fields.git.CreateTagCalls())
+	require.Empty(t, fields.git.DeleteBranchCalls())
+}
+
+func TestStageManager_DeleteStage_CannotArchiveBranch(t *testing.T) {
+	fields := getTestStageManagerFields()
+	fields.git.CreateTagFunc = func(gitContext common_models.GitContext, tag string, branch string) error {
+		return errors.New("oops")
+	}
+	s := NewStageManager(fields.git, fields.credentialReader, fields.fileSystem, fields.eventPublisher)
+
+	err := s.DeleteStage(models.DeleteStageParams{
+		Project: models.Project{ProjectName: "my-project"},
+		Stage:   models.Stage{StageName: "my-stage"},
+	})
+	require.NotNil(t, err)
+
+	// the branch is only deleted once it has been archived
+	require.Empty(t, fields.git.DeleteBranchCalls())
+	require.Empty(t, fields.eventPublisher.PublishCalls())
+}
+
 func getTestStageManagerFields() stageManagerTestFields {
 	return stageManagerTestFields{
 		git: &common_mock.IGitMock{
@@ -240,6 +324,15 @@ func getTestStageManagerFields() stageManagerTestFields {
 			CreateBranchFunc: func(gitContext common_models.GitContext, branch string, sourceBranch string) error {
 				return nil
 			},
+			PullFunc: func(gitContext common_models.GitContext) error {
+				return nil
+			},
+			CreateTagFunc: func(gitContext common_models.GitContext, tag string, branch string) error {
+				return nil
+			},
+			DeleteBranchFunc: func(gitContext common_models.GitContext, branch string) error {
+				return nil
+			},
 		},
 		credentialReader: &common_mock.CredentialReaderMock{
 			GetCredentialsFunc: func(project string) (*common_models.GitCredentials, error) {
@@ -269,6 +362,11 @@ func getTestStageManagerFields() stageManagerTestFields {
 				return nil
 			},
 		},
+		eventPublisher: &common_mock.EventPublisherMock{
+			PublishFunc: func(event apimodels.KeptnContextExtendedCE) error {
+				return nil
+			},
+		},
 	}
 }
 
@@ -279,7 +377,7 @@ func TestDirectoryStageManager_CreateStage(t *testing.T) {
 		return false
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -298,7 +396,7 @@ func TestDirectoryStageManager_CreateStage_CannotEstablishContext(t *testing.T)
 		return "", errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -319,7 +417,7 @@ func TestDirectoryStageManager_CreateStage_CannotGetCredentials(t *testing.T) {
 		return nil, errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -340,7 +438,7 @@ func TestDirectoryStageManager_CreateStage_ProjectNotFound(t *testing.T) {
 		return false
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -361,7 +459,7 @@ func TestDirectoryStageManager_CreateStage_StageAlreadyExists(t *testing.T) {
 		return true
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -384,7 +482,7 @@ func TestDirectoryStageManager_CreateStage_CannotCreateDirectory(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -407,7 +505,7 @@ func TestDirectoryStageManager_CreateStage_CannotWriteMetadata(t *testing.T) {
 		return errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -430,7 +528,7 @@ func TestDirectoryStageManager_CreateStage_CannotCommit(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.CreateStage(models.CreateStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -445,7 +543,7 @@ func TestDirectoryStageManager_CreateStage_CannotCommit(t *testing.T) {
 func TestDirectoryStageManager_DeleteStage(t *testing.T) {
 	fields := getTestStageManagerFields()
 
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -455,6 +553,10 @@ func TestDirectoryStageManager_DeleteStage(t *testing.T) {
 	require.Nil(t, err)
 
 	require.Len(t, fields.git.StageAndCommitAllCalls(), 1)
+	require.Equal(t, "Removed stage: my-stage", fields.git.StageAndCommitAllCalls()[0].Message)
+
+	require.Len(t, fields.eventPublisher.PublishCalls(), 1)
+	require.Equal(t, models.StageDeletedEventData{Project: "my-project", Stage: "my-stage"}, fields.eventPublisher.PublishCalls()[0].Event.Data)
 }
 
 func TestDirectoryStageManager_DeleteStage_CannotEstablishContext(t *testing.T) {
@@ -463,7 +565,7 @@ func TestDirectoryStageManager_DeleteStage_CannotEstablishContext(t *testing.T)
 	fields.configurationContext.EstablishFunc = func(params common_models.ConfigurationContextParams) (string, error) {
 		return "", errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -481,7 +583,7 @@ func TestDirectoryStageManager_DeleteStage_StageDirectoryNotAvailable(t *testing
 	fields.fileSystem.FileExistsFunc = func(path string) bool {
 		return false
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -499,7 +601,7 @@ func TestDirectoryStageManager_DeleteStage_CannotDeleteDirectory(t *testing.T) {
 	fields.fileSystem.DeleteFileFunc = func(path string) error {
 		return errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
@@ -517,7 +619,7 @@ func TestDirectoryStageManager_DeleteStage_CannotCommitChanges(t *testing.T) {
 	fields.git.StageAndCommitAllFunc = func(gitContext common_models.GitContext, message string) (string, error) {
 		return "", errors.New("oops")
 	}
-	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git)
+	dm := NewDirectoryStageManager(fields.configurationContext, fields.fileSystem, fields.credentialReader, fields.git, fields.eventPublisher)
 
 	err := dm.DeleteStage(models.DeleteStageParams{
 		Project: models.Project{ProjectName: "my-project"},
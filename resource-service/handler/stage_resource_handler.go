@@ -15,7 +15,14 @@ type IStageResourceHandler interface {
 	UpdateStageResources(context *gin.Context)
 	GetStageResource(context *gin.Context)
 	UpdateStageResource(context *gin.Context)
+	GetStageResourceContent(context *gin.Context)
+	UpdateStageResourceContent(context *gin.Context)
 	DeleteStageResource(context *gin.Context)
+	GetStageResourceHistory(context *gin.Context)
+	GetStageResourceDiff(context *gin.Context)
+	GetStageResourcesDiff(context *gin.Context)
+	RevertStageResource(context *gin.Context)
+	PromoteStageResources(context *gin.Context)
 }
 
 type StageResourceHandler struct {
@@ -48,6 +55,7 @@ func (ph *StageResourceHandler) CreateStageResources(c *gin.Context) {
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -138,6 +146,7 @@ func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
 		},
 	}
 
@@ -175,8 +184,10 @@ func (ph *StageResourceHandler) UpdateStageResources(c *gin.Context) {
 // @Param        stageName    path    string  true  "The name of the stage"
 // @Param        resourceURI  path  string  true    "The path of the resource file"
 // @Param        gitCommitID  query     string  false  "The commit ID to be checked out"
+// @Param        render       query     bool    false  "Render the resource with the variables of the project and stage"
 // @Success      200          {object}  models.GetResourceResponse
 // @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
 // @Failure      500          {object}  models.Error  "Internal error"
 // @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI} [get]
 func (ph *StageResourceHandler) GetStageResource(c *gin.Context) {
@@ -230,6 +241,7 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -255,6 +267,103 @@ func (ph *StageResourceHandler) UpdateStageResource(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetStageResourceContent godoc
+// @Summary      Get raw content of a stage resource
+// @Description  Streams the raw content of a resource of the stage of a project. The git commit ID of the content is returned in the X-Keptn-Resource-Version header
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      octet-stream
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        gitCommitID  query  string  false  "The commit ID to be checked out"
+// @Param        render       query  bool    false  "Render the resource with the variables of the project and stage"
+// @Success      200          {file}    binary
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      422          {object}  models.Error  "Resource could not be rendered"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/raw [get]
+func (ph *StageResourceHandler) GetStageResourceContent(c *gin.Context) {
+	params := &models.GetResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResource := &models.GetResourceQuery{}
+	if err := c.ShouldBindQuery(getResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceQuery = *getResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	stream, err := ph.StageResourceManager.GetResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	writeResourceStream(c, stream)
+}
+
+// UpdateStageResourceContent godoc
+// @Summary      Updates the raw content of a stage resource
+// @Description  Creates or updates a resource of the stage of a project with the raw content of the request body, or of the 'file' field of a multipart form
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       octet-stream,mpfd
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        file         formData  file  false  "The content of the resource, if sent as multipart form"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      413          {object}  models.Error  "Resource too large"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/raw [put]
+func (ph *StageResourceHandler) UpdateStageResourceContent(c *gin.Context) {
+	params := &models.UpdateResourceStreamParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	content, err := getResourceStreamContent(c)
+	if err != nil {
+		setResourceStreamContentError(c, err)
+		return
+	}
+
+	params.Content = content
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.UpdateResourceStream(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // DeleteStageResource godoc
 // @Summary      Deletes a stage resource
 // @Description  Deletes a resource for the stage of a project
@@ -275,6 +384,7 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 		ResourceContext: models.ResourceContext{
 			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
 			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
 		},
 		ResourceURI: c.Param(pathParamResourceURI),
 	}
@@ -292,3 +402,240 @@ func (ph *StageResourceHandler) DeleteStageResource(c *gin.Context) {
 
 	c.JSON(http.StatusOK, result)
 }
+
+// GetStageResourceHistory godoc
+// @Summary      Get history of a stage resource
+// @Description  Get the commits that changed a resource of the stage of a project, starting with the most recent one
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        limit        query  int  false  "The maximum number of commits to return"
+// @Success      200          {object}  models.GetResourceHistoryResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/history [get]
+func (ph *StageResourceHandler) GetStageResourceHistory(c *gin.Context) {
+	params := &models.GetResourceHistoryParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceHistory := &models.GetResourceHistoryQuery{}
+	if err := c.ShouldBindQuery(getResourceHistory); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceHistoryQuery = *getResourceHistory
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	history, err := ph.StageResourceManager.GetResourceHistory(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetStageResourceDiff godoc
+// @Summary      Get diff of a stage resource
+// @Description  Get the unified diff of a resource of the stage of a project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/diff [get]
+func (ph *StageResourceHandler) GetStageResourceDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// GetStageResourcesDiff godoc
+// @Summary      Get diff of all stage resources
+// @Description  Get the unified diff of all resources of the stage of a project between two revisions
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:read</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        from         query  string  true  "The commit ID the diff starts from"
+// @Param        to           query  string  false  "The commit ID the diff ends with, defaults to the latest commit"
+// @Success      200          {object}  models.GetResourceDiffResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/diff [get]
+func (ph *StageResourceHandler) GetStageResourcesDiff(c *gin.Context) {
+	params := &models.GetResourceDiffParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+		},
+	}
+	getResourceDiff := &models.GetResourceDiffQuery{}
+	if err := c.ShouldBindQuery(getResourceDiff); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.GetResourceDiffQuery = *getResourceDiff
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	diff, err := ph.StageResourceManager.GetResourceDiff(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertStageResource godoc
+// @Summary      Reverts a stage resource
+// @Description  Reverts a resource of the stage of a project to the content of an earlier revision by creating a new commit
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the stage"
+// @Param        resourceURI  path  string  true  "The path of the resource file"
+// @Param        revision     body  models.RevertResourcePayload  true  "The commit ID to revert to"
+// @Success      200          {object}  models.WriteResourceResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/resource/{resourceURI}/revert [post]
+func (ph *StageResourceHandler) RevertStageResource(c *gin.Context) {
+	params := &models.RevertResourceParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
+		},
+		ResourceURI: c.Param(pathParamResourceURI),
+	}
+	revertResource := &models.RevertResourcePayload{}
+	if err := c.ShouldBindJSON(revertResource); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.RevertResourcePayload = *revertResource
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.RevertResource(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PromoteStageResources godoc
+// @Summary      Promotes stage resources to another stage
+// @Description  Copies the resources of the stage of a project at the given commit to another stage, as a single commit.
+// @Description  The resources can be filtered with include and exclude glob patterns. If preview is set, only the diff of the promotion is returned.
+// @Description  <span class="oauth-scopes">Required OAuth scopes: ${prefix}resources:write</span>
+// @Tags         Stage Resource
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        projectName  path  string  true  "The name of the project"
+// @Param        stageName    path  string  true  "The name of the source stage"
+// @Param        promotion    body  models.PromoteResourcesPayload  true  "The promotion to be performed"
+// @Success      200          {object}  models.PromoteResourcesResponse
+// @Failure      400          {object}  models.Error  "Invalid payload"
+// @Failure      404          {object}  models.Error  "Not found"
+// @Failure      500          {object}  models.Error  "Internal error"
+// @Router       /project/{projectName}/stage/{stageName}/promote [post]
+func (ph *StageResourceHandler) PromoteStageResources(c *gin.Context) {
+	params := &models.PromoteResourcesParams{
+		ResourceContext: models.ResourceContext{
+			Project: models.Project{ProjectName: c.Param(pathParamProjectName)},
+			Stage:   &models.Stage{StageName: c.Param(pathParamStageName)},
+			Author:  getAuthor(c),
+		},
+	}
+
+	promoteResources := &models.PromoteResourcesPayload{}
+	if err := c.ShouldBindJSON(promoteResources); err != nil {
+		SetBadRequestErrorResponse(c, errors.ErrMsgInvalidRequestFormat)
+		return
+	}
+
+	params.PromoteResourcesPayload = *promoteResources
+
+	if err := params.Validate(); err != nil {
+		SetBadRequestErrorResponse(c, err.Error())
+		return
+	}
+
+	result, err := ph.StageResourceManager.PromoteResources(*params)
+	if err != nil {
+		OnAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
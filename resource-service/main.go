@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/keptn/keptn/resource-service/common"
@@ -16,6 +18,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/common/osutils"
+	"github.com/keptn/go-utils/pkg/sdk/connector/nats"
 	"github.com/keptn/keptn/resource-service/config"
 	"github.com/keptn/keptn/resource-service/controller"
 	"github.com/keptn/keptn/resource-service/handler"
@@ -82,10 +85,19 @@ func main() {
 		log.Fatalf("could not create kubernetes client: %s", err.Error())
 	}
 
+	projectLocker, err := createProjectLocker(kubeAPI)
+	if err != nil {
+		log.Fatalf("could not create project locker: %s", err.Error())
+	}
+	common.SetProjectLocker(projectLocker)
+
 	credentialReader := common.NewK8sCredentialReader(kubeAPI)
+	changeRequestManager := common.NewChangeRequestManager(kubeAPI)
 	fileSystem := common.NewFileSystem(common.GetConfigDir())
 
 	git := common.NewGit(&common.GogitReal{})
+	git.SetSigningKeyReader(common.NewK8sGitSigningKeyReader(kubeAPI))
+	lfs := common.NewLFS()
 	configurationContext := createConfigurationContext(git, fileSystem)
 
 	projectManager := handler.NewProjectManager(git, credentialReader, fileSystem)
@@ -93,31 +105,39 @@ func main() {
 	projectController := controller.NewProjectController(projectHandler)
 	projectController.Inject(apiV1)
 
-	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader)
+	eventPublisher := nats.NewFromEnv()
+
+	stageManager := createStageManager(configurationContext, git, fileSystem, credentialReader, eventPublisher)
 	stageHandler := handler.NewStageHandler(stageManager)
 	stageController := controller.NewStageController(stageHandler)
 	stageController.Inject(apiV1)
 
-	serviceManager := handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext)
+	serviceManager := handler.NewServiceManager(git, credentialReader, fileSystem, configurationContext, eventPublisher)
 	serviceHandler := handler.NewServiceHandler(serviceManager)
 	serviceController := controller.NewServiceController(serviceHandler)
 	serviceController.Inject(apiV1)
 
-	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	projectResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, changeRequestManager, lfs)
 	projectResourceHandler := handler.NewProjectResourceHandler(projectResourceManager)
 	projectResourceController := controller.NewProjectResourceController(projectResourceHandler)
 	projectResourceController.Inject(apiV1)
 
-	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	stageResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, changeRequestManager, lfs)
 	stageResourceHandler := handler.NewStageResourceHandler(stageResourceManager)
 	stageResourceController := controller.NewStageResourceController(stageResourceHandler)
 	stageResourceController.Inject(apiV1)
 
-	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext)
+	serviceResourceManager := handler.NewResourceManager(git, credentialReader, fileSystem, configurationContext, changeRequestManager, lfs)
 	serviceResourceHandler := handler.NewServiceResourceHandler(serviceResourceManager)
 	serviceResourceController := controller.NewServiceResourceController(serviceResourceHandler)
 	serviceResourceController.Inject(apiV1)
 
+	gitWebhookSecretReader := common.NewK8sGitWebhookSecretReader(kubeAPI)
+	gitWebhookManager := handler.NewGitWebhookManager(git, credentialReader, gitWebhookSecretReader, fileSystem, eventPublisher)
+	gitWebhookHandler := handler.NewGitWebhookHandler(gitWebhookManager)
+	gitWebhookController := controller.NewGitWebhookController(gitWebhookHandler)
+	gitWebhookController.Inject(apiV1)
+
 	healthHandler := handler.NewHealthHandler()
 	healthController := controller.NewHealthController(healthHandler)
 	healthController.Inject(apiHealth)
@@ -150,16 +170,43 @@ func createConfigurationContext(git *common.Git, fileSystem *common.FileSystem)
 	return configContext
 }
 
-func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader) handler.IStageManager {
+func createStageManager(configurationContext handler.IConfigurationContext, git common.IGit, fileSystem common.IFileSystem, credentialReader common.CredentialReader, eventPublisher common.EventPublisher) handler.IStageManager {
 	var stageManager handler.IStageManager
 	if config.Global.DirectoryStageStructure {
-		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git)
+		stageManager = handler.NewDirectoryStageManager(configurationContext, fileSystem, credentialReader, git, eventPublisher)
 	} else {
-		stageManager = handler.NewStageManager(git, credentialReader)
+		stageManager = handler.NewStageManager(git, credentialReader, fileSystem, eventPublisher)
 	}
 	return stageManager
 }
 
+func createProjectLocker(kubeAPI kubernetes.Interface) (common.ProjectLocker, error) {
+	switch config.Global.LockBackend {
+	case config.LockBackendLocal:
+		return common.LocalProjectLocker{}, nil
+	case config.LockBackendKubernetes:
+		identity := config.Global.PodName
+		if identity == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, err
+			}
+			identity = hostname
+		}
+		leaseDuration := time.Duration(config.Global.LockLeaseDurationSeconds) * time.Second
+		if leaseDuration <= 0 {
+			return nil, fmt.Errorf("lease duration must be positive, got %d seconds", config.Global.LockLeaseDurationSeconds)
+		}
+		lockTimeout := time.Duration(config.Global.LockTimeoutSeconds) * time.Second
+		if lockTimeout <= 0 {
+			return nil, fmt.Errorf("lock timeout must be positive, got %d seconds", config.Global.LockTimeoutSeconds)
+		}
+		return common.NewK8sLeaseProjectLocker(kubeAPI, common.GetKeptnNamespace(), identity, leaseDuration, lockTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown lock backend %s", config.Global.LockBackend)
+	}
+}
+
 func gracefulShutdown(ctx context.Context, wg *sync.WaitGroup, srv *http.Server) {
 	quit := make(chan os.Signal, 1)
 
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"io"
+
+	"github.com/keptn/keptn/resource-service/errors"
+)
+
+const (
+	ArchiveFormatTarGz = "tar.gz"
+	ArchiveFormatZip   = "zip"
+)
+
+type ProjectArchiveQuery struct {
+	// Format of the archive, either tar.gz or zip. Defaults to tar.gz
+	Format string `json:"format,omitempty" form:"format"`
+}
+
+func (q ProjectArchiveQuery) Validate() error {
+	if q.Format != "" && q.Format != ArchiveFormatTarGz && q.Format != ArchiveFormatZip {
+		return errors.ErrUnknownArchiveFormat
+	}
+	return nil
+}
+
+// GetFormat returns the format of the archive, or the default format if none has been specified
+func (q ProjectArchiveQuery) GetFormat() string {
+	if q.Format == "" {
+		return ArchiveFormatTarGz
+	}
+	return q.Format
+}
+
+type GetProjectArchiveQuery struct {
+	ProjectArchiveQuery
+	// GitCommitID is the revision the archive is created for. Defaults to the latest revision
+	GitCommitID string `json:"commitID,omitempty" form:"commitID"`
+}
+
+type GetProjectArchiveParams struct {
+	Project
+	GetProjectArchiveQuery
+}
+
+func (p GetProjectArchiveParams) Validate() error {
+	if err := p.Project.Validate(); err != nil {
+		return err
+	}
+	return p.ProjectArchiveQuery.Validate()
+}
+
+type ImportProjectArchiveParams struct {
+	ResourceContext
+	ProjectArchiveQuery
+	Content io.Reader
+}
+
+func (p ImportProjectArchiveParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	return p.ProjectArchiveQuery.Validate()
+}
+
+// ImportProjectArchiveResponse contains the commits that have been created for the imported archive
+//
+// swagger:model ImportProjectArchiveResponse
+type ImportProjectArchiveResponse struct {
+
+	// One commit for each branch the files of the archive have been written to
+	Commits []WriteResourceResponse `json:"commits"`
+}
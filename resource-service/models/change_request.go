@@ -0,0 +1,53 @@
+package models
+
+import "github.com/keptn/keptn/resource-service/errors"
+
+type ChangeRequestState string
+
+const (
+	ChangeRequestStateOpen   ChangeRequestState = "open"
+	ChangeRequestStateMerged ChangeRequestState = "merged"
+	ChangeRequestStateClosed ChangeRequestState = "closed"
+)
+
+// ChangeRequest merge request (pull request) containing changes that have been pushed to a feature branch of the upstream repository
+//
+// swagger:model ChangeRequest
+type ChangeRequest struct {
+
+	// ID of the change request within the repository, e.g. the number of a pull request
+	ID string `json:"id"`
+
+	// Link to the change request
+	URL string `json:"url,omitempty"`
+
+	// State of the change request
+	State ChangeRequestState `json:"state"`
+
+	// Branch containing the changes
+	SourceBranch string `json:"sourceBranch"`
+
+	// Branch the changes are merged into
+	TargetBranch string `json:"targetBranch"`
+
+	// Title of the change request
+	Title string `json:"title,omitempty"`
+
+	// Description of the change request
+	Description string `json:"description,omitempty"`
+}
+
+type GetChangeRequestParams struct {
+	Project
+	ChangeRequestID string
+}
+
+func (p GetChangeRequestParams) Validate() error {
+	if err := p.Project.Validate(); err != nil {
+		return err
+	}
+	if p.ChangeRequestID == "" {
+		return errors.ErrChangeRequestIDMustNotBeEmpty
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package models
+
+import "net/http"
+
+// ResourceChangedEventType is the type of the event that is sent when resources have been changed in the upstream repository of a project
+const ResourceChangedEventType = "sh.keptn.event.resource.changed"
+
+// HandleGitWebhookParams contains the webhook request that has been sent by the git hosting service of a project
+type HandleGitWebhookParams struct {
+	Project
+	Header  http.Header
+	Payload []byte
+}
+
+func (p HandleGitWebhookParams) Validate() error {
+	return p.Project.Validate()
+}
+
+// ResourceChangedEventData contains the resources of a project, stage or service that have been changed by a push to the upstream repository
+//
+// swagger:model ResourceChangedEventData
+type ResourceChangedEventData struct {
+
+	// Project the resources belong to
+	Project string `json:"project"`
+
+	// Stage the resources belong to. Empty for resources of the project
+	Stage string `json:"stage,omitempty"`
+
+	// Service the resources belong to. Empty for resources of the project or the stage
+	Service string `json:"service,omitempty"`
+
+	// Branch of the upstream repository that has been pushed to
+	Branch string `json:"branch"`
+
+	// Revision of the branch after the push
+	CommitID string `json:"commitID"`
+
+	// URIs of the resources that have been added, modified or removed
+	ChangedResources []string `json:"changedResources"`
+}
+
+// HandleGitWebhookResponse contains the changes that have been detected in the pushed commits
+//
+// swagger:model HandleGitWebhookResponse
+type HandleGitWebhookResponse struct {
+
+	// Keptn context of the sent events. Empty if no events have been sent
+	KeptnContext string `json:"keptnContext,omitempty"`
+
+	// Changed resources per project, stage and service. One event is sent for each element
+	Changes []ResourceChangedEventData `json:"changes"`
+}
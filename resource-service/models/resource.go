@@ -2,7 +2,10 @@ package models
 
 import (
 	"encoding/base64"
+	"io"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/keptn/keptn/resource-service/errors"
 )
@@ -44,6 +47,14 @@ type ResourceContext struct {
 	Project
 	Stage   *Stage
 	Service *Service
+	// Author is the authenticated principal the request has been sent by. Changes are committed on behalf of the Keptn user if not set
+	Author *Author
+}
+
+// Author is the user or API token the changes to the resources of a project are attributed to
+type Author struct {
+	Name  string
+	Email string
 }
 
 func (rc ResourceContext) Validate() error {
@@ -83,6 +94,8 @@ func (p GetResourcesParams) Validate() error {
 
 type GetResourceQuery struct {
 	GitCommitID string `json:"gitCommitID,omitempty" form:"gitCommitID"`
+	// Render the resource with the variables of the project, stage and service
+	Render bool `json:"render,omitempty" form:"render"`
 }
 
 type GetResourceParams struct {
@@ -144,6 +157,26 @@ func (p UpdateResourceParams) Validate() error {
 	return nil
 }
 
+// UpdateResourceStreamParams contains the raw, i.e. not base64 encoded, content of a resource that is streamed to the service
+type UpdateResourceStreamParams struct {
+	ResourceContext
+	ResourceURI string
+	Content     io.Reader
+}
+
+func (p UpdateResourceStreamParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.Content == nil {
+		return errors.ErrResourceContentMissing
+	}
+	return nil
+}
+
 type CreateResourcesPayload struct {
 	Resources []Resource `json:"resources"`
 }
@@ -187,6 +220,116 @@ func (p UpdateResourcesParams) Validate() error {
 	return nil
 }
 
+type GetResourceHistoryQuery struct {
+	Limit int `json:"limit,omitempty" form:"limit"`
+}
+
+type GetResourceHistoryParams struct {
+	ResourceContext
+	ResourceURI string
+	GetResourceHistoryQuery
+}
+
+func (p GetResourceHistoryParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.Limit < 0 {
+		return errors.ErrResourceInvalidHistoryLimit
+	}
+	return nil
+}
+
+type GetResourceDiffQuery struct {
+	From string `json:"from" form:"from"`
+	To   string `json:"to,omitempty" form:"to"`
+}
+
+type GetResourceDiffParams struct {
+	ResourceContext
+	// ResourceURI is empty if the diff of the whole directory of the context is requested
+	ResourceURI string
+	GetResourceDiffQuery
+}
+
+func (p GetResourceDiffParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.From == "" {
+		return errors.ErrResourceRevisionMustNotBeEmpty
+	}
+	return nil
+}
+
+type RevertResourcePayload struct {
+	GitCommitID string `json:"gitCommitID"`
+}
+
+type RevertResourceParams struct {
+	ResourceContext
+	ResourceURI string
+	RevertResourcePayload
+}
+
+func (p RevertResourceParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if err := validateResourceURI(p.ResourceURI); err != nil {
+		return err
+	}
+	if p.GitCommitID == "" {
+		return errors.ErrResourceRevisionMustNotBeEmpty
+	}
+	return nil
+}
+
+type PromoteResourcesPayload struct {
+	// TargetStage is the stage the resources are copied to
+	TargetStage string `json:"targetStage"`
+	// GitCommitID is the commit of the source stage the resources are copied from. Defaults to the latest commit
+	GitCommitID string `json:"gitCommitID,omitempty"`
+	// Include contains glob patterns of the resources to be promoted. If empty, all resources are promoted
+	Include []string `json:"include,omitempty"`
+	// Exclude contains glob patterns of the resources that must not be promoted
+	Exclude []string `json:"exclude,omitempty"`
+	// Preview only returns the diff the promotion would cause, without changing the target stage
+	Preview bool `json:"preview,omitempty"`
+}
+
+type PromoteResourcesParams struct {
+	ResourceContext
+	PromoteResourcesPayload
+}
+
+func (p PromoteResourcesParams) Validate() error {
+	if err := p.ResourceContext.Validate(); err != nil {
+		return err
+	}
+	if p.Stage == nil {
+		return errors.ErrPromotionStageMustBeSet
+	}
+	if err := validateEntityName(p.TargetStage); err != nil {
+		return err
+	}
+	if p.TargetStage == p.Stage.StageName {
+		return errors.ErrPromotionSameStage
+	}
+	for _, pattern := range append(append([]string{}, p.Include...), p.Exclude...) {
+		if err := validateGlobPattern(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetResourcesResponse resources
 //
 // swagger:model GetResourcesResponse
@@ -213,9 +356,91 @@ type GetResourceResponse struct {
 	Metadata Version `json:"metadata"`
 }
 
+// ResourceCommit commit that changed a resource
+//
+// swagger:model ResourceCommit
+type ResourceCommit struct {
+
+	// ID of the commit
+	CommitID string `json:"commitID"`
+
+	// Name of the author of the commit
+	Author string `json:"author"`
+
+	// Email of the author of the commit
+	AuthorEmail string `json:"authorEmail,omitempty"`
+
+	// Commit message
+	Message string `json:"message"`
+
+	// Time of the commit
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// GetResourceHistoryResponse commit history of a resource
+//
+// swagger:model GetResourceHistoryResponse
+type GetResourceHistoryResponse struct {
+
+	// Commits that changed the resource, starting with the most recent one
+	Commits []ResourceCommit `json:"commits"`
+
+	Metadata Version `json:"metadata"`
+}
+
+// GetResourceDiffResponse diff between two revisions
+//
+// swagger:model GetResourceDiffResponse
+type GetResourceDiffResponse struct {
+
+	// Revision the diff starts from
+	From string `json:"from"`
+
+	// Revision the diff ends with
+	To string `json:"to"`
+
+	// Unified diff of the changes. Empty if nothing has changed
+	Diff string `json:"diff"`
+
+	Metadata Version `json:"metadata"`
+}
+
+// PromoteResourcesResponse result of a promotion
+//
+// swagger:model PromoteResourcesResponse
+type PromoteResourcesResponse struct {
+
+	// Commit of the source stage the resources have been copied from
+	SourceCommitID string `json:"sourceCommitID"`
+
+	// Commit containing the promoted resources in the target stage. Empty for previews, or if no resource has changed
+	CommitID string `json:"commitID,omitempty"`
+
+	// URIs of the promoted resources
+	Resources []string `json:"resources"`
+
+	// Unified diff of the changes in the target stage
+	Diff string `json:"diff"`
+
+	// Change request containing the promoted resources, if the project uses the pull-request based change flow
+	ChangeRequest *ChangeRequest `json:"changeRequest,omitempty"`
+
+	Metadata Version `json:"metadata"`
+}
+
+// ResourceStream contains the raw content of a resource. Content must be closed by the caller
+type ResourceStream struct {
+	Content  io.ReadCloser
+	Size     int64
+	Metadata Version
+}
+
 type WriteResourceResponse struct {
 	CommitID string  `json:"commitID"`
 	Metadata Version `json:"metadata"`
+	// ChangeRequest is set if the project uses the pull-request based change flow. In this case, the commit
+	// is only contained in the source branch of the change request until it is merged
+	ChangeRequest *ChangeRequest `json:"changeRequest,omitempty"`
 }
 
 func validateResourceURI(uri string) error {
@@ -224,3 +449,17 @@ func validateResourceURI(uri string) error {
 	}
 	return nil
 }
+
+// validateGlobPattern checks the syntax of a glob pattern. In addition to the syntax of path.Match, a path segment
+// consisting of "**" matches any number of directories
+func validateGlobPattern(pattern string) error {
+	if pattern == "" || strings.Contains(pattern, "..") {
+		return errors.ErrResourceInvalidGlobPattern
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.ErrResourceInvalidGlobPattern
+		}
+	}
+	return nil
+}
@@ -597,3 +597,164 @@ func TestResource_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestGetResourceHistoryParams_Validate(t *testing.T) {
+	validContext := ResourceContext{
+		Project: Project{ProjectName: "my-project"},
+		Stage:   &Stage{StageName: "my-stage"},
+	}
+	tests := []struct {
+		name    string
+		params  GetResourceHistoryParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  GetResourceHistoryParams{ResourceContext: validContext, ResourceURI: "my-resource.txt", GetResourceHistoryQuery: GetResourceHistoryQuery{Limit: 10}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid resourceURI",
+			params:  GetResourceHistoryParams{ResourceContext: validContext, ResourceURI: "../my-resource.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "negative limit",
+			params:  GetResourceHistoryParams{ResourceContext: validContext, ResourceURI: "my-resource.txt", GetResourceHistoryQuery: GetResourceHistoryQuery{Limit: -1}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetResourceDiffParams_Validate(t *testing.T) {
+	validContext := ResourceContext{
+		Project: Project{ProjectName: "my-project"},
+		Stage:   &Stage{StageName: "my-stage"},
+		Service: &Service{ServiceName: "my-service"},
+	}
+	tests := []struct {
+		name    string
+		params  GetResourceDiffParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  GetResourceDiffParams{ResourceContext: validContext, ResourceURI: "my-resource.txt", GetResourceDiffQuery: GetResourceDiffQuery{From: "abc"}},
+			wantErr: false,
+		},
+		{
+			name:    "valid - whole directory",
+			params:  GetResourceDiffParams{ResourceContext: validContext, GetResourceDiffQuery: GetResourceDiffQuery{From: "abc", To: "def"}},
+			wantErr: false,
+		},
+		{
+			name:    "from revision missing",
+			params:  GetResourceDiffParams{ResourceContext: validContext, ResourceURI: "my-resource.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid resourceURI",
+			params:  GetResourceDiffParams{ResourceContext: validContext, ResourceURI: "~/my-resource.txt", GetResourceDiffQuery: GetResourceDiffQuery{From: "abc"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRevertResourceParams_Validate(t *testing.T) {
+	validContext := ResourceContext{
+		Project: Project{ProjectName: "my-project"},
+	}
+	tests := []struct {
+		name    string
+		params  RevertResourceParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  RevertResourceParams{ResourceContext: validContext, ResourceURI: "my-resource.txt", RevertResourcePayload: RevertResourcePayload{GitCommitID: "abc"}},
+			wantErr: false,
+		},
+		{
+			name:    "commit ID missing",
+			params:  RevertResourceParams{ResourceContext: validContext, ResourceURI: "my-resource.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid project name",
+			params:  RevertResourceParams{ResourceContext: ResourceContext{Project: Project{ProjectName: "my project"}}, ResourceURI: "my-resource.txt", RevertResourcePayload: RevertResourcePayload{GitCommitID: "abc"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromoteResourcesParams_Validate(t *testing.T) {
+	validContext := ResourceContext{
+		Project: Project{ProjectName: "my-project"},
+		Stage:   &Stage{StageName: "dev"},
+		Service: &Service{ServiceName: "my-service"},
+	}
+	tests := []struct {
+		name    string
+		params  PromoteResourcesParams
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			params:  PromoteResourcesParams{ResourceContext: validContext, PromoteResourcesPayload: PromoteResourcesPayload{TargetStage: "staging", Include: []string{"helm/**/*.yaml"}, Exclude: []string{"jmeter"}}},
+			wantErr: false,
+		},
+		{
+			name:    "source stage missing",
+			params:  PromoteResourcesParams{ResourceContext: ResourceContext{Project: Project{ProjectName: "my-project"}}, PromoteResourcesPayload: PromoteResourcesPayload{TargetStage: "staging"}},
+			wantErr: true,
+		},
+		{
+			name:    "target stage missing",
+			params:  PromoteResourcesParams{ResourceContext: validContext},
+			wantErr: true,
+		},
+		{
+			name:    "same stage",
+			params:  PromoteResourcesParams{ResourceContext: validContext, PromoteResourcesPayload: PromoteResourcesPayload{TargetStage: "dev"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid include pattern",
+			params:  PromoteResourcesParams{ResourceContext: validContext, PromoteResourcesPayload: PromoteResourcesPayload{TargetStage: "staging", Include: []string{"helm/[a"}}},
+			wantErr: true,
+		},
+		{
+			name:    "exclude pattern pointing to parent directory",
+			params:  PromoteResourcesParams{ResourceContext: validContext, PromoteResourcesPayload: PromoteResourcesPayload{TargetStage: "staging", Exclude: []string{"../*"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.params.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
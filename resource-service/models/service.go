@@ -1,5 +1,7 @@
 package models
 
+import "errors"
+
 type Service struct {
 	// ServiceName the name of the service
 	ServiceName string `json:"serviceName,omitempty"`
@@ -50,3 +52,72 @@ func (s DeleteServiceParams) Validate() error {
 	}
 	return s.Service.Validate()
 }
+
+// ServiceRenamedEventType is the type of the event that is sent when a service has been renamed
+const ServiceRenamedEventType = "sh.keptn.event.service.renamed"
+
+type RenameServicePayload struct {
+	// NewServiceName the new name of the service
+	NewServiceName string `json:"newServiceName"`
+}
+
+// RenameServiceParams contains information about the service to be renamed in all stages of a project
+//
+// swagger:model RenameServiceParams
+type RenameServiceParams struct {
+	Project
+	Service
+	RenameServicePayload
+}
+
+func (s RenameServiceParams) Validate() error {
+	if err := s.Project.Validate(); err != nil {
+		return err
+	}
+	if err := s.Service.Validate(); err != nil {
+		return err
+	}
+	if err := validateEntityName(s.NewServiceName); err != nil {
+		return err
+	}
+	if s.NewServiceName == s.ServiceName {
+		return errors.New("new name must be different from the current name of the service")
+	}
+	return nil
+}
+
+// RenameServiceResponse contains the commits that have been created to rename a service
+//
+// swagger:model RenameServiceResponse
+type RenameServiceResponse struct {
+
+	// Stages the service has been renamed in
+	Stages []RenamedServiceStage `json:"stages"`
+}
+
+type RenamedServiceStage struct {
+
+	// StageName the name of the stage
+	StageName string `json:"stageName"`
+
+	// CommitID of the commit that renamed the service. Stages sharing the same branch have the same commit
+	CommitID string `json:"commitID"`
+}
+
+// ServiceRenamedEventData contains the service that has been renamed in all stages of a project
+//
+// swagger:model ServiceRenamedEventData
+type ServiceRenamedEventData struct {
+
+	// Project the service belongs to
+	Project string `json:"project"`
+
+	// Service the previous name of the service
+	Service string `json:"service"`
+
+	// NewService the new name of the service
+	NewService string `json:"newService"`
+
+	// Stages the service has been renamed in
+	Stages []string `json:"stages"`
+}
@@ -141,3 +141,49 @@ func TestCreateServiceParams_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestRenameServiceParams_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceName    string
+		newServiceName string
+		wantErr        bool
+	}{
+		{
+			name:           "valid",
+			serviceName:    "my-service",
+			newServiceName: "my-renamed-service",
+			wantErr:        false,
+		},
+		{
+			name:           "invalid new name",
+			serviceName:    "my-service",
+			newServiceName: "my/service",
+			wantErr:        true,
+		},
+		{
+			name:           "empty new name",
+			serviceName:    "my-service",
+			newServiceName: "",
+			wantErr:        true,
+		},
+		{
+			name:           "same name",
+			serviceName:    "my-service",
+			newServiceName: "my-service",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := RenameServiceParams{
+				Project:              Project{ProjectName: "my-project"},
+				Service:              Service{ServiceName: tt.serviceName},
+				RenameServicePayload: RenameServicePayload{NewServiceName: tt.newServiceName},
+			}
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -42,3 +42,21 @@ func (s DeleteStageParams) Validate() error {
 	}
 	return s.Stage.Validate()
 }
+
+// StageDeletedEventType is the type of the event that is sent when a stage has been deleted
+const StageDeletedEventType = "sh.keptn.event.stage.deleted"
+
+// StageDeletedEventData contains the stage that has been deleted from a project
+//
+// swagger:model StageDeletedEventData
+type StageDeletedEventData struct {
+
+	// Project the stage belonged to
+	Project string `json:"project"`
+
+	// Stage that has been deleted
+	Stage string `json:"stage"`
+
+	// Tag of the upstream repository pointing to the last revision of the stage branch. Empty if stages are stored in directories
+	ArchiveTag string `json:"archiveTag,omitempty"`
+}
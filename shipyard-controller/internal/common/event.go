@@ -26,6 +26,13 @@ const (
 	RootEvent EventStatus = "root"
 )
 
+const (
+	// StageDeletedEventType is sent by the resource-service after a stage has been deleted
+	StageDeletedEventType = "sh.keptn.event.stage.deleted"
+	// ServiceRenamedEventType is sent by the resource-service after a service has been renamed in all stages of a project
+	ServiceRenamedEventType = "sh.keptn.event.service.renamed"
+)
+
 // EventFilter allows to pass filters
 type EventFilter struct {
 	Type         string
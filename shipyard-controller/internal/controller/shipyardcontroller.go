@@ -189,11 +189,40 @@ func (sc *ShipyardController) HandleIncomingEvent(event apimodels.KeptnContextEx
 			cb(err)
 		}()
 	default:
-		return nil
+		if *event.Type != common.StageDeletedEventType && *event.Type != common.ServiceRenamedEventType {
+			return nil
+		}
+		go func() {
+			err := sc.handleProjectStructureChanged(event)
+			if err != nil {
+				log.Errorf("Unable to handle '%s' event: %v", *event.Type, err)
+			}
+			cb(err)
+		}()
 	}
 	return completeEventHandler(waitForCompletion, done)
 }
 
+// handleProjectStructureChanged updates the materialized view of a project after the resource-service has deleted one
+// of its stages, or renamed one of its services
+func (sc *ShipyardController) handleProjectStructureChanged(event apimodels.KeptnContextExtendedCE) error {
+	switch *event.Type {
+	case common.StageDeletedEventType:
+		eventData := &keptnv2.EventData{}
+		if err := keptnv2.Decode(event.Data, eventData); err != nil {
+			return fmt.Errorf("unable to decode event data: %w", err)
+		}
+		return sc.projectMvRepo.DeleteStage(eventData.Project, eventData.Stage)
+	case common.ServiceRenamedEventType:
+		eventData := &models.ServiceRenamedEventData{}
+		if err := keptnv2.Decode(event.Data, eventData); err != nil {
+			return fmt.Errorf("unable to decode event data: %w", err)
+		}
+		return sc.projectMvRepo.RenameService(eventData.Project, eventData.Service, eventData.NewService)
+	}
+	return nil
+}
+
 func completeEventHandler(waitForCompletion bool, done chan error) error {
 	if waitForCompletion {
 		return <-done
@@ -204,3 +204,36 @@ func TestHandleTaskEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleProjectStructureChanged(t *testing.T) {
+	projectMvRepo := &db_mock.ProjectMVRepoMock{
+		DeleteStageFunc: func(project string, stage string) error {
+			return nil
+		},
+		RenameServiceFunc: func(project string, service string, newService string) error {
+			return nil
+		},
+	}
+	sc := &ShipyardController{projectMvRepo: projectMvRepo}
+
+	stageDeletedType := common.StageDeletedEventType
+	err := sc.handleProjectStructureChanged(apimodels.KeptnContextExtendedCE{
+		Type: &stageDeletedType,
+		Data: map[string]interface{}{"project": "my-project", "stage": "dev"},
+	})
+	require.Nil(t, err)
+	require.Len(t, projectMvRepo.DeleteStageCalls(), 1)
+	require.Equal(t, "my-project", projectMvRepo.DeleteStageCalls()[0].Project)
+	require.Equal(t, "dev", projectMvRepo.DeleteStageCalls()[0].Stage)
+
+	serviceRenamedType := common.ServiceRenamedEventType
+	err = sc.handleProjectStructureChanged(apimodels.KeptnContextExtendedCE{
+		Type: &serviceRenamedType,
+		Data: models.ServiceRenamedEventData{Project: "my-project", Service: "carts", NewService: "carts-v2", Stages: []string{"dev"}},
+	})
+	require.Nil(t, err)
+	require.Len(t, projectMvRepo.RenameServiceCalls(), 1)
+	require.Equal(t, "my-project", projectMvRepo.RenameServiceCalls()[0].Project)
+	require.Equal(t, "carts", projectMvRepo.RenameServiceCalls()[0].Service)
+	require.Equal(t, "carts-v2", projectMvRepo.RenameServiceCalls()[0].NewService)
+}
@@ -53,6 +53,9 @@ import (
 // 			OnSequenceTaskEventFunc: func(event apimodels.KeptnContextExtendedCE)  {
 // 				panic("mock out the OnSequenceTaskEvent method")
 // 			},
+// 			RenameServiceFunc: func(project string, service string, newService string) error {
+// 				panic("mock out the RenameService method")
+// 			},
 // 			UpdateEventOfServiceFunc: func(e apimodels.KeptnContextExtendedCE) error {
 // 				panic("mock out the UpdateEventOfService method")
 // 			},
@@ -114,6 +117,9 @@ type ProjectMVRepoMock struct {
 	// OnSequenceTaskEventFunc mocks the OnSequenceTaskEvent method.
 	OnSequenceTaskEventFunc func(event apimodels.KeptnContextExtendedCE)
 
+	// RenameServiceFunc mocks the RenameService method.
+	RenameServiceFunc func(project string, service string, newService string) error
+
 	// UpdateEventOfServiceFunc mocks the UpdateEventOfService method.
 	UpdateEventOfServiceFunc func(e apimodels.KeptnContextExtendedCE) error
 
@@ -222,6 +228,15 @@ type ProjectMVRepoMock struct {
 			// Event is the event argument value.
 			Event apimodels.KeptnContextExtendedCE
 		}
+		// RenameService holds details about calls to the RenameService method.
+		RenameService []struct {
+			// Project is the project argument value.
+			Project string
+			// Service is the service argument value.
+			Service string
+			// NewService is the newService argument value.
+			NewService string
+		}
 		// UpdateEventOfService holds details about calls to the UpdateEventOfService method.
 		UpdateEventOfService []struct {
 			// E is the e argument value.
@@ -269,6 +284,7 @@ type ProjectMVRepoMock struct {
 	lockGetProjects           sync.RWMutex
 	lockGetService            sync.RWMutex
 	lockOnSequenceTaskEvent   sync.RWMutex
+	lockRenameService         sync.RWMutex
 	lockUpdateEventOfService  sync.RWMutex
 	lockUpdateProject         sync.RWMutex
 	lockUpdateShipyard        sync.RWMutex
@@ -730,6 +746,45 @@ func (mock *ProjectMVRepoMock) OnSequenceTaskEventCalls() []struct {
 	return calls
 }
 
+// RenameService calls RenameServiceFunc.
+func (mock *ProjectMVRepoMock) RenameService(project string, service string, newService string) error {
+	if mock.RenameServiceFunc == nil {
+		panic("ProjectMVRepoMock.RenameServiceFunc: method is nil but ProjectMVRepo.RenameService was just called")
+	}
+	callInfo := struct {
+		Project    string
+		Service    string
+		NewService string
+	}{
+		Project:    project,
+		Service:    service,
+		NewService: newService,
+	}
+	mock.lockRenameService.Lock()
+	mock.calls.RenameService = append(mock.calls.RenameService, callInfo)
+	mock.lockRenameService.Unlock()
+	return mock.RenameServiceFunc(project, service, newService)
+}
+
+// RenameServiceCalls gets all the calls that were made to RenameService.
+// Check the length with:
+//     len(mockedProjectMVRepo.RenameServiceCalls())
+func (mock *ProjectMVRepoMock) RenameServiceCalls() []struct {
+	Project    string
+	Service    string
+	NewService string
+} {
+	var calls []struct {
+		Project    string
+		Service    string
+		NewService string
+	}
+	mock.lockRenameService.RLock()
+	calls = mock.calls.RenameService
+	mock.lockRenameService.RUnlock()
+	return calls
+}
+
 // UpdateEventOfService calls UpdateEventOfServiceFunc.
 func (mock *ProjectMVRepoMock) UpdateEventOfService(e apimodels.KeptnContextExtendedCE) error {
 	if mock.UpdateEventOfServiceFunc == nil {
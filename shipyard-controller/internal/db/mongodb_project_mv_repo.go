@@ -41,6 +41,7 @@ type ProjectMVRepo interface {
 	CreateService(project string, stage string, service string) error
 	GetService(projectName, stageName, serviceName string) (*apimodels.ExpandedService, error)
 	DeleteService(project string, stage string, service string) error
+	RenameService(project string, service string, newService string) error
 	UpdateEventOfService(e apimodels.KeptnContextExtendedCE) error
 	CreateRemediation(project, stage, service string, remediation *apimodels.Remediation) error
 	CloseOpenRemediations(project, stage, service, keptnContext string) error
@@ -341,8 +342,7 @@ func (mv *MongoDBProjectMVRepo) DeleteStage(project string, stage string) error
 	prj.Stages[len(prj.Stages)-1] = nil
 	prj.Stages = prj.Stages[:len(prj.Stages)-1]
 
-	err = mv.projectRepo.UpdateProject(prj)
-	return nil
+	return mv.projectRepo.UpdateProject(prj)
 }
 
 // CreateService creates a service
@@ -436,6 +436,53 @@ func (mv *MongoDBProjectMVRepo) DeleteService(project string, stage string, serv
 	return nil
 }
 
+// RenameService renames a service in all stages of a project. The deployment information and last events of the
+// service are kept
+func (mv *MongoDBProjectMVRepo) RenameService(project string, service string, newService string) error {
+	existingProject, err := mv.GetProject(project)
+	if err != nil {
+		log.Errorf("Could not rename service %s in project %s. Could not load project: %s", service, project, err.Error())
+		return err
+	} else if existingProject == nil {
+		return common.ErrProjectNotFound
+	}
+
+	renamed := false
+	for _, stg := range existingProject.Stages {
+		if serviceExists(stg, newService) {
+			log.Infof("Service %s already exists in stage %s in project %s", newService, stg.StageName, project)
+			continue
+		}
+		for _, svc := range stg.Services {
+			if svc.ServiceName == service {
+				svc.ServiceName = newService
+				renamed = true
+			}
+		}
+	}
+	if !renamed {
+		log.Infof("Could not rename service %s in project %s. Service not found in database", service, project)
+		return nil
+	}
+
+	err = mv.projectRepo.UpdateProject(existingProject)
+	if err != nil {
+		log.Errorf("Could not rename service %s in project %s: %s", service, project, err.Error())
+		return err
+	}
+	log.Infof("Renamed service %s to %s in project %s", service, newService, project)
+	return nil
+}
+
+func serviceExists(stage *apimodels.ExpandedStage, service string) bool {
+	for _, svc := range stage.Services {
+		if svc.ServiceName == service {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateEventOfService updates a service event
 func (mv *MongoDBProjectMVRepo) UpdateEventOfService(e apimodels.KeptnContextExtendedCE) error {
 	if e.Type == nil {
@@ -810,6 +810,155 @@ func Test_projectsMaterializedView_DeleteService(t *testing.T) {
 	}
 }
 
+func Test_projectsMaterializedView_RenameService(t *testing.T) {
+	type fields struct {
+		ProjectRepo ProjectRepo
+	}
+	type args struct {
+		project    string
+		service    string
+		newService string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Rename service that does not exist",
+			fields: fields{
+				ProjectRepo: &db_mock.ProjectRepoMock{
+					GetProjectFunc: func(projectName string) (project *apimodels.ExpandedProject, err error) {
+						return &apimodels.ExpandedProject{
+							ProjectName: "test-project",
+							Stages: []*apimodels.ExpandedStage{
+								{
+									Services:  nil,
+									StageName: "dev",
+								},
+							},
+						}, nil
+					},
+					UpdateProjectFunc: func(project *apimodels.ExpandedProject) error {
+						return errors.New("should not be called in this case")
+					},
+				},
+			},
+			args: args{
+				project:    "test-project",
+				service:    "test-service",
+				newService: "new-service",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename service in all stages",
+			fields: fields{
+				ProjectRepo: &db_mock.ProjectRepoMock{
+					GetProjectFunc: func(projectName string) (project *apimodels.ExpandedProject, err error) {
+						return &apimodels.ExpandedProject{
+							ProjectName: "test-project",
+							Stages: []*apimodels.ExpandedStage{
+								{
+									Services: []*apimodels.ExpandedService{
+										{
+											ServiceName: "test-service",
+										},
+									},
+									StageName: "dev",
+								},
+								{
+									Services: []*apimodels.ExpandedService{
+										{
+											ServiceName: "test-service",
+										},
+									},
+									StageName: "prod",
+								},
+							},
+						}, nil
+					},
+					UpdateProjectFunc: func(project *apimodels.ExpandedProject) error {
+						for _, stage := range project.Stages {
+							if stage.Services[0].ServiceName != "new-service" {
+								return errors.New("service was not renamed properly before update")
+							}
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				project:    "test-project",
+				service:    "test-service",
+				newService: "new-service",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename service that has already been renamed in a stage",
+			fields: fields{
+				ProjectRepo: &db_mock.ProjectRepoMock{
+					GetProjectFunc: func(projectName string) (project *apimodels.ExpandedProject, err error) {
+						return &apimodels.ExpandedProject{
+							ProjectName: "test-project",
+							Stages: []*apimodels.ExpandedStage{
+								{
+									Services: []*apimodels.ExpandedService{
+										{
+											ServiceName: "test-service",
+										},
+										{
+											ServiceName: "new-service",
+										},
+									},
+									StageName: "dev",
+								},
+							},
+						}, nil
+					},
+					UpdateProjectFunc: func(project *apimodels.ExpandedProject) error {
+						return errors.New("should not be called in this case")
+					},
+				},
+			},
+			args: args{
+				project:    "test-project",
+				service:    "test-service",
+				newService: "new-service",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rename service in non-existing project",
+			fields: fields{
+				ProjectRepo: &db_mock.ProjectRepoMock{
+					GetProjectFunc: func(projectName string) (project *apimodels.ExpandedProject, err error) {
+						return nil, errors.New("")
+					},
+				},
+			},
+			args: args{
+				project:    "test-project",
+				service:    "test-service",
+				newService: "new-service",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mv := &MongoDBProjectMVRepo{
+				projectRepo: tt.fields.ProjectRepo,
+			}
+			if err := mv.RenameService(tt.args.project, tt.args.service, tt.args.newService); (err != nil) != tt.wantErr {
+				t.Errorf("RenameService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func Test_updateServiceInStage(t *testing.T) {
 	type args struct {
 		project *apimodels.ExpandedProject
@@ -20,3 +20,18 @@ type GetServiceParams struct {
 	//The number of items to return
 	PageSize *int64 `form:"pageSize"`
 }
+
+// ServiceRenamedEventData is the data of the event sent by the resource-service after a service has been renamed
+type ServiceRenamedEventData struct {
+	// Project the service belongs to
+	Project string `json:"project"`
+
+	// Service the previous name of the service
+	Service string `json:"service"`
+
+	// NewService the new name of the service
+	NewService string `json:"newService"`
+
+	// Stages the service has been renamed in
+	Stages []string `json:"stages"`
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// maxAllowListBodySize limits the size of the payload of a request updating an allow-list
+const maxAllowListBodySize = 1024 * 1024
+
+// adminTokenHeader contains the admin token that is required to change allow-lists, in addition to the Keptn API
+// token checked by the API gateway
+const adminTokenHeader = "X-Keptn-Admin-Token"
+
+// principalHeader and principalEmailHeader contain the principal authenticated by the API gateway
+const principalHeader = "X-Keptn-Principal"
+const principalEmailHeader = "X-Keptn-Principal-Email"
+
+// allowListPathPattern matches the path of the allow-list of a project, e.g. /v1/project/my-project/allow-list
+var allowListPathPattern = regexp.MustCompile(`^/v1/project/([a-z][a-z0-9-]*)/allow-list$`)
+
+// AllowListHandler manages the allow-lists of the targets of webhook requests of projects. Allow-lists can only be
+// changed with the admin token, and changes are recorded in the audit log
+type AllowListHandler struct {
+	allowListProvider lib.AllowListProvider
+	adminToken        string
+}
+
+// NewAllowListHandler creates an AllowListHandler. If adminToken is empty, allow-lists can not be changed
+func NewAllowListHandler(allowListProvider lib.AllowListProvider, adminToken string) *AllowListHandler {
+	return &AllowListHandler{
+		allowListProvider: allowListProvider,
+		adminToken:        adminToken,
+	}
+}
+
+func (ah *AllowListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	matches := allowListPathPattern.FindStringSubmatch(r.URL.Path)
+	if matches == nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	project := matches[1]
+
+	switch r.Method {
+	case http.MethodGet:
+		ah.getAllowList(w, project)
+	case http.MethodPut:
+		if ah.isAdmin(w, r) {
+			ah.setAllowList(w, r, project)
+		}
+	case http.MethodDelete:
+		if ah.isAdmin(w, r) {
+			ah.deleteAllowList(w, r, project)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// isAdmin checks whether the request contains the admin token, and rejects it otherwise
+func (ah *AllowListHandler) isAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if ah.adminToken == "" {
+		http.Error(w, "allow-lists can not be changed, since no admin token is configured", http.StatusForbidden)
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(adminTokenHeader)), []byte(ah.adminToken)) != 1 {
+		http.Error(w, "allow-lists can only be changed with the admin token", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (ah *AllowListHandler) getAllowList(w http.ResponseWriter, project string) {
+	allowList, err := ah.allowListProvider.Get(project)
+	if err != nil {
+		logger.Errorf("Could not read allow-list of project %s: %v", project, err)
+		http.Error(w, "could not read allow-list", http.StatusInternalServerError)
+		return
+	}
+	if allowList == nil {
+		http.Error(w, fmt.Sprintf("project '%s' does not have an allow-list", project), http.StatusNotFound)
+		return
+	}
+	writeAllowList(w, *allowList)
+}
+
+func (ah *AllowListHandler) setAllowList(w http.ResponseWriter, r *http.Request, project string) {
+	allowList := lib.AllowList{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAllowListBodySize)).Decode(&allowList); err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if allowList.Entries == nil {
+		allowList.Entries = []string{}
+	}
+	if err := allowList.Validate(); err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := ah.allowListProvider.Set(project, allowList); err != nil {
+		logger.Errorf("Could not update allow-list of project %s: %v", project, err)
+		http.Error(w, "could not update allow-list", http.StatusInternalServerError)
+		return
+	}
+	lib.AuditLogChange(project, lib.AuditActionAllowListUpdated, getPrincipal(r), fmt.Sprintf("allow-list set to [%s] from %s", strings.Join(allowList.Entries, " "), getClientAddress(r)))
+	writeAllowList(w, allowList)
+}
+
+func (ah *AllowListHandler) deleteAllowList(w http.ResponseWriter, r *http.Request, project string) {
+	if err := ah.allowListProvider.Delete(project); err != nil {
+		logger.Errorf("Could not delete allow-list of project %s: %v", project, err)
+		http.Error(w, "could not delete allow-list", http.StatusInternalServerError)
+		return
+	}
+	lib.AuditLogChange(project, lib.AuditActionAllowListDeleted, getPrincipal(r), fmt.Sprintf("allow-list deleted from %s", getClientAddress(r)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeAllowList(w http.ResponseWriter, allowList lib.AllowList) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(allowList)
+}
+
+// getPrincipal returns the principal that has been authenticated by the API gateway
+func getPrincipal(r *http.Request) lib.AuditPrincipal {
+	return lib.AuditPrincipal{
+		Subject: r.Header.Get(principalHeader),
+		Email:   r.Header.Get(principalEmailHeader),
+	}
+}
+
+// getClientAddress returns the address of the client, which is forwarded by the API gateway
+func getClientAddress(r *http.Request) string {
+	if address := r.Header.Get("X-Real-IP"); address != "" {
+		return address
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,122 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListHandler_ServeHTTP(t *testing.T) {
+	allowLists := map[string]lib.AllowList{}
+	allowListHandler := handler.NewAllowListHandler(fake.AllowListProviderMock{
+		GetFunc: func(project string) (*lib.AllowList, error) {
+			allowList, ok := allowLists[project]
+			if !ok {
+				return nil, nil
+			}
+			return &allowList, nil
+		},
+		SetFunc: func(project string, allowList lib.AllowList) error {
+			allowLists[project] = allowList
+			return nil
+		},
+		DeleteFunc: func(project string) error {
+			delete(allowLists, project)
+			return nil
+		},
+	}, "my-admin-token")
+	serve := func(method string, target string, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(method, target, strings.NewReader(body))
+		request.Header.Set("X-Keptn-Admin-Token", "my-admin-token")
+		allowListHandler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/project/my-project/allow-list", "").Code)
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/project/My_Project/allow-list", "").Code)
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/project/my-project/other", "").Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, "/v1/project/my-project/allow-list", "").Code)
+
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPut, "/v1/project/my-project/allow-list", "not a JSON").Code)
+	response := serve(http.MethodPut, "/v1/project/my-project/allow-list", `{"entries": ["api.example.com", "https://api.example.com"]}`)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "invalid allow-list entry 'https://api.example.com'")
+	require.Empty(t, allowLists)
+
+	response = serve(http.MethodPut, "/v1/project/my-project/allow-list", `{"entries": ["api.example.com", "10.0.0.0/8"]}`)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, lib.AllowList{Entries: []string{"api.example.com", "10.0.0.0/8"}}, allowLists["my-project"])
+
+	response = serve(http.MethodGet, "/v1/project/my-project/allow-list", "")
+	require.Equal(t, http.StatusOK, response.Code)
+	allowList := lib.AllowList{}
+	require.Nil(t, json.Unmarshal(response.Body.Bytes(), &allowList))
+	require.Equal(t, lib.AllowList{Entries: []string{"api.example.com", "10.0.0.0/8"}}, allowList)
+
+	// an allow-list without entries denies all requests
+	require.Equal(t, http.StatusOK, serve(http.MethodPut, "/v1/project/other-project/allow-list", `{}`).Code)
+	require.Equal(t, lib.AllowList{Entries: []string{}}, allowLists["other-project"])
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/v1/project/my-project/allow-list", "").Code)
+	require.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/v1/project/my-project/allow-list", "").Code)
+}
+
+func TestAllowListHandler_ServeHTTP_AdminToken(t *testing.T) {
+	allowListProvider := fake.AllowListProviderMock{
+		GetFunc: func(project string) (*lib.AllowList, error) {
+			return &lib.AllowList{Entries: []string{"api.example.com"}}, nil
+		},
+		SetFunc: func(project string, allowList lib.AllowList) error {
+			return nil
+		},
+		DeleteFunc: func(project string) error {
+			return nil
+		},
+	}
+	serve := func(allowListHandler *handler.AllowListHandler, method string, adminToken string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(method, "/v1/project/my-project/allow-list", strings.NewReader(`{"entries": ["api.example.com"]}`))
+		if adminToken != "" {
+			request.Header.Set("X-Keptn-Admin-Token", adminToken)
+		}
+		request.Header.Set("X-Keptn-Principal", "jane")
+		request.Header.Set("X-Keptn-Principal-Email", "jane@example.com")
+		allowListHandler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	allowListHandler := handler.NewAllowListHandler(allowListProvider, "my-admin-token")
+	require.Equal(t, http.StatusOK, serve(allowListHandler, http.MethodGet, "").Code)
+	require.Equal(t, http.StatusForbidden, serve(allowListHandler, http.MethodPut, "").Code)
+	require.Equal(t, http.StatusForbidden, serve(allowListHandler, http.MethodPut, "other-token").Code)
+	require.Equal(t, http.StatusForbidden, serve(allowListHandler, http.MethodDelete, "").Code)
+
+	// the principal authenticated by the API gateway is recorded in the audit log
+	hook := test.NewGlobal()
+	defer hook.Reset()
+	require.Equal(t, http.StatusOK, serve(allowListHandler, http.MethodPut, "my-admin-token").Code)
+	require.NotNil(t, hook.LastEntry())
+	require.Equal(t, logrus.Fields{
+		"audit":          true,
+		"project":        "my-project",
+		"action":         lib.AuditActionAllowListUpdated,
+		"principal":      "jane",
+		"principalEmail": "jane@example.com",
+	}, hook.LastEntry().Data)
+
+	// without an admin token, allow-lists can only be read
+	allowListHandler = handler.NewAllowListHandler(allowListProvider, "")
+	require.Equal(t, http.StatusOK, serve(allowListHandler, http.MethodGet, "").Code)
+	require.Equal(t, http.StatusForbidden, serve(allowListHandler, http.MethodPut, "").Code)
+	require.Equal(t, http.StatusForbidden, serve(allowListHandler, http.MethodDelete, "").Code)
+}
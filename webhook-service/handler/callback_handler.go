@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// maxCallbackBodySize limits the size of the payload of a request to a callback URL
+const maxCallbackBodySize = 1024 * 1024
+
+// CallbackHandler completes asynchronous webhooks when their callback URL is called by the remote system
+type CallbackHandler struct {
+	callbackRegistry *lib.CallbackRegistry
+}
+
+func NewCallbackHandler(callbackRegistry *lib.CallbackRegistry) *CallbackHandler {
+	return &CallbackHandler{
+		callbackRegistry: callbackRegistry,
+	}
+}
+
+func (ch *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id, ok := lib.CallbackID(r.URL.Path)
+	if !ok {
+		http.Error(w, "callback not found", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBodySize))
+	if err != nil {
+		http.Error(w, "could not read payload", http.StatusBadRequest)
+		return
+	}
+	result := lib.CallbackResult{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &result); err != nil {
+			http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if err := result.Validate(); err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = ch.callbackRegistry.Complete(id, r.URL.Query().Get("signature"), result)
+	switch {
+	case errors.Is(err, lib.ErrInvalidCallbackSignature):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, lib.ErrCallbackNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case err != nil:
+		logger.Errorf("Could not complete callback %s: %v", id, err)
+		http.Error(w, "could not complete callback", http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
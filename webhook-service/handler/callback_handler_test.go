@@ -0,0 +1,47 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallbackHandler_ServeHTTP(t *testing.T) {
+	callbackRegistry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+
+	var completedResult *lib.CallbackResult
+	_, callbackURL, err := callbackRegistry.Register(time.Minute, func(result *lib.CallbackResult, err error) {
+		completedResult = result
+	})
+	require.Nil(t, err)
+	parsedURL, err := url.Parse(callbackURL)
+	require.Nil(t, err)
+
+	callbackHandler := handler.NewCallbackHandler(callbackRegistry)
+	serve := func(method string, target string, body string) int {
+		recorder := httptest.NewRecorder()
+		callbackHandler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, parsedURL.RequestURI(), ""))
+	require.Equal(t, http.StatusNotFound, serve(http.MethodPost, "/v1/callback/", ""))
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPost, parsedURL.RequestURI(), "not a JSON"))
+	require.Equal(t, http.StatusBadRequest, serve(http.MethodPost, parsedURL.RequestURI(), `{"result": "unknown"}`))
+	require.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, parsedURL.Path+"?signature=invalid", ""))
+	require.Nil(t, completedResult)
+
+	// an empty payload completes the task with result pass
+	require.Equal(t, http.StatusNoContent, serve(http.MethodPost, parsedURL.RequestURI(), ""))
+	require.Equal(t, &lib.CallbackResult{}, completedResult)
+
+	require.Equal(t, http.StatusNotFound, serve(http.MethodPost, parsedURL.RequestURI(), ""))
+}
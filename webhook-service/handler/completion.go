@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// ErrWebhookServiceStopped is the error of asynchronous webhooks that have not been completed, and of requests waiting
+// to be retried, when the webhook service is shut down
+var ErrWebhookServiceStopped = errors.New("the webhook service has been stopped before the task has been completed")
+
+// awaitedCallback is a callback URL that has been registered for an asynchronous webhook, whose outcome is received
+// from done
+type awaitedCallback struct {
+	id   string
+	url  string
+	done chan callbackOutcome
+}
+
+type callbackOutcome struct {
+	result *lib.CallbackResult
+	err    error
+}
+
+func (th *TaskHandler) registerCallback(completion lib.Completion) (*awaitedCallback, error) {
+	if th.callbackRegistry == nil {
+		return nil, errors.New("completion mode 'callback' is not enabled")
+	}
+	// the callback might be completed before all requests have been executed, therefore the outcome is buffered
+	done := make(chan callbackOutcome, 1)
+	id, callbackURL, err := th.callbackRegistry.Register(completion.GetTimeout(), func(result *lib.CallbackResult, err error) {
+		done <- callbackOutcome{result: result, err: err}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not register callback: %w", err)
+	}
+	return &awaitedCallback{id: id, url: callbackURL, done: done}, nil
+}
+
+// completeAsync waits until the task of an asynchronous webhook has been completed, and sends the .finished event.
+// The task is finished with an error if the webhook service is shut down before, since pending callbacks and polling
+// requests are not resumed after a restart
+func (th *TaskHandler) completeAsync(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, completion lib.Completion, callback *awaitedCallback, taskName string, requestsResult *requestsResult, secrets map[string]string) {
+	defer th.asyncCompletions.Done()
+
+	var err error
+	switch completion.Mode {
+	case lib.CompletionModeCallback:
+		err = th.awaitCallback(callback, requestsResult)
+	case lib.CompletionModePolling:
+		err = th.poll(*completion.Polling, completion.GetTimeout(), eventAdapter, requestsResult)
+	}
+
+	result := getFinishedEventData(eventAdapter, taskName, requestsResult, secrets)
+	if err != nil {
+		logger.Errorf("Error during completion of asynchronous webhook: %v", err)
+		result["result"] = keptnv2.ResultFailed
+		result["status"] = keptnv2.StatusErrored
+		result["message"] = removeSecretsFromMessage(err.Error(), secrets)
+	}
+	th.sendFinishedEvent(keptnHandler, event, result)
+}
+
+func (th *TaskHandler) awaitCallback(callback *awaitedCallback, requestsResult *requestsResult) error {
+	var outcome callbackOutcome
+	select {
+	case outcome = <-callback.done:
+	case <-th.ctx.Done():
+		th.callbackRegistry.Cancel(callback.id)
+		return ErrWebhookServiceStopped
+	}
+	if outcome.err != nil {
+		return outcome.err
+	}
+	for key, value := range outcome.result.Data {
+		requestsResult.outputs[key] = value
+	}
+	requestsResult.setResult(outcome.result.GetResult(), outcome.result.Message)
+	return nil
+}
+
+// poll executes the polling request until one of its conditions is met, or the timeout is reached. The last response
+// is added to the responses of the task, and its outputs are extracted
+func (th *TaskHandler) poll(polling lib.Polling, timeout time.Duration, eventAdapter *lib.EventDataAdapter, requestsResult *requestsResult) error {
+	if _, err := th.CreateRequest(polling.Request); err != nil {
+		return fmt.Errorf("creating polling request failed: %s", err.Error())
+	}
+	request, err := th.parseRequest(polling.Request, eventAdapter)
+	if err != nil {
+		return fmt.Errorf("could not parse polling request '%s %s' : %s", polling.Request.Method, polling.Request.URL, err.Error())
+	}
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(polling.GetInterval())
+	defer ticker.Stop()
+
+	var requestErr error
+	for {
+		// responses with an error status code are returned together with an error, and can be expected by the conditions
+		response, err := th.httpExecutor.Execute(*request)
+		switch {
+		case response != nil && polling.FailWhen != nil && len(polling.FailWhen.Check(*response)) == 0:
+			requestsResult.addPollingResponse(*request, *response)
+			requestsResult.setResult(keptnv2.ResultFailed, fmt.Sprintf("polling request '%s %s' met the failure condition", polling.Request.Method, polling.Request.URL))
+			return nil
+		case response != nil && len(polling.Until.Check(*response)) == 0:
+			requestsResult.addPollingResponse(*request, *response)
+			return nil
+		case err != nil && lib.IsDeniedURLError(err):
+			return fmt.Errorf("could not execute polling request '%s %s': %s", polling.Request.Method, polling.Request.URL, err.Error())
+		}
+		requestErr = err
+
+		select {
+		case <-deadline:
+			if requestErr != nil {
+				return fmt.Errorf("polling request '%s %s' did not meet its condition within %s, last error: %s", polling.Request.Method, polling.Request.URL, timeout, requestErr.Error())
+			}
+			return fmt.Errorf("polling request '%s %s' did not meet its condition within %s", polling.Request.Method, polling.Request.URL, timeout)
+		case <-th.ctx.Done():
+			return ErrWebhookServiceStopped
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *requestsResult) addPollingResponse(request lib.Request, response lib.HTTPResponse) {
+	r.responses = append(r.responses, UnmarshalResponse(response.Body))
+	r.attempts = append(r.attempts, 1)
+	r.addResponse(request, response)
+}
+
+// Shutdown finishes the tasks of all asynchronous webhooks that have not been completed yet with an error, and waits
+// until their .finished events have been sent. Webhooks that are executed afterwards are finished immediately, and
+// requests are not retried anymore
+func (th *TaskHandler) Shutdown() {
+	th.stop()
+	th.asyncCompletions.Wait()
+}
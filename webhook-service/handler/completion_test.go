@@ -0,0 +1,273 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const webHookContentWithCallback_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+      - url: http://local:8080/builds
+        method: POST
+        payload: '{"callback": "{{.callbackURL}}"}'
+      completion:
+        mode: callback
+        timeout: ${timeout}`
+
+const webHookContentWithPolling_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+      - url: http://local:8080/builds
+        method: POST
+      completion:
+        mode: polling
+        timeout: 1s
+        polling:
+          request:
+            url: http://local:8080/builds/{{.responses.0.body.id}}
+            method: GET
+            outputs:
+              - name: status
+                jsonPath: .status
+          interval: 10ms
+          until:
+            body:
+              - jsonPath: .status
+                equals: finished
+          failWhen:
+            body:
+              - jsonPath: .status
+                equals: failed`
+
+func newAsyncTestTaskHandler(t *testing.T, webhookContent string, httpExecutorMock *fake.IHTTPExecutorMock) (*sdk.FakeKeptn, *handler.TaskHandler, *lib.CallbackRegistry) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	callbackRegistry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{}, handler.WithCallbackRegistry(callbackRegistry))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webhookContent})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+	return fakeKeptn, taskHandler, callbackRegistry
+}
+
+func awaitFinishedEvent(t *testing.T, fakeKeptn *sdk.FakeKeptn) keptnv2.EventData {
+	require.Eventually(t, func() bool {
+		return len(fakeKeptn.SentEvents) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+	eventData := keptnv2.EventData{}
+	require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+	return eventData
+}
+
+func TestTaskHandler_Execute_CallbackCompletion(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusAccepted, Body: `{"id": "build-1"}`}, nil
+	}}
+	fakeKeptn, _, callbackRegistry := newAsyncTestTaskHandler(t, strings.ReplaceAll(webHookContentWithCallback_BETA, "${timeout}", "1h"), httpExecutorMock)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	// the .finished event is only sent once the callback URL has been called
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+	fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	payload := httpExecutorMock.ExecuteCalls()[0].Request.Payload
+	callbackURL, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(payload, `{"callback": "`), `"}`))
+	require.Nil(t, err)
+	require.Equal(t, "webhook-service:8081", callbackURL.Host)
+
+	recorder := httptest.NewRecorder()
+	handler.NewCallbackHandler(callbackRegistry).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, callbackURL.RequestURI(), strings.NewReader(`{"result": "warning", "message": "2 tests skipped", "data": {"testsSkipped": 2}}`)))
+	require.Equal(t, http.StatusNoContent, recorder.Code)
+
+	eventData := awaitFinishedEvent(t, fakeKeptn)
+	require.Equal(t, keptnv2.ResultWarning, eventData.Result)
+	require.Equal(t, keptnv2.StatusSucceeded, eventData.Status)
+	require.Equal(t, "2 tests skipped", eventData.Message)
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{map[string]interface{}{"id": "build-1"}},
+		"outputs":   map[string]interface{}{"testsSkipped": float64(2)},
+	}, fakeKeptn.SentEvents[1].Data.(map[string]interface{})["webhook"])
+}
+
+func TestTaskHandler_Execute_CallbackCompletionTimeout(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusAccepted, Body: `{"id": "build-1"}`}, nil
+	}}
+	fakeKeptn, _, _ := newAsyncTestTaskHandler(t, strings.ReplaceAll(webHookContentWithCallback_BETA, "${timeout}", "10ms"), httpExecutorMock)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	eventData := awaitFinishedEvent(t, fakeKeptn)
+	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, lib.ErrCallbackTimeout.Error(), eventData.Message)
+}
+
+func TestTaskHandler_Execute_CallbackCompletionShutdown(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusAccepted, Body: `{"id": "build-1"}`}, nil
+	}}
+	fakeKeptn, taskHandler, callbackRegistry := newAsyncTestTaskHandler(t, strings.ReplaceAll(webHookContentWithCallback_BETA, "${timeout}", "1h"), httpExecutorMock)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	fakeKeptn.AssertNumberOfEventSent(t, 1)
+
+	// the .finished event has been sent once the shutdown returns
+	taskHandler.Shutdown()
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	eventData := keptnv2.EventData{}
+	require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, handler.ErrWebhookServiceStopped.Error(), eventData.Message)
+
+	// the callback can no longer be used
+	payload := httpExecutorMock.ExecuteCalls()[0].Request.Payload
+	callbackURL, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(payload, `{"callback": "`), `"}`))
+	require.Nil(t, err)
+	recorder := httptest.NewRecorder()
+	handler.NewCallbackHandler(callbackRegistry).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, callbackURL.RequestURI(), nil))
+	require.NotEqual(t, http.StatusNoContent, recorder.Code)
+}
+
+func TestTaskHandler_Execute_CallbackCompletionNotEnabled(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(&fake.ITemplateEngineMock{}, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, &fake.ISecretReaderMock{})
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.ReplaceAll(webHookContentWithCallback_BETA, "${timeout}", "1h")})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+}
+
+func TestTaskHandler_Execute_PollingCompletion(t *testing.T) {
+	tests := []struct {
+		name           string
+		statuses       []string
+		wantResult     keptnv2.ResultType
+		wantStatus     keptnv2.StatusType
+		wantPolls      int
+		wantOutputs    map[string]interface{}
+		wantMessageHas string
+	}{
+		{
+			name:        "until condition is met",
+			statuses:    []string{"running", "running", "finished"},
+			wantResult:  keptnv2.ResultPass,
+			wantStatus:  keptnv2.StatusSucceeded,
+			wantPolls:   3,
+			wantOutputs: map[string]interface{}{"status": "finished"},
+		},
+		{
+			name:           "failure condition is met",
+			statuses:       []string{"running", "failed"},
+			wantResult:     keptnv2.ResultFailed,
+			wantStatus:     keptnv2.StatusSucceeded,
+			wantPolls:      2,
+			wantOutputs:    map[string]interface{}{"status": "failed"},
+			wantMessageHas: "polling request 'GET http://local:8080/builds/{{.responses.0.body.id}}' met the failure condition",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+				if request.Method == http.MethodPost {
+					return &lib.HTTPResponse{StatusCode: http.StatusAccepted, Body: `{"id": "build-1"}`}, nil
+				}
+				require.Equal(t, "http://local:8080/builds/build-1", request.URL)
+				status := tt.statuses[polls]
+				polls++
+				return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "` + status + `"}`}, nil
+			}}
+			fakeKeptn, _, _ := newAsyncTestTaskHandler(t, webHookContentWithPolling_BETA, httpExecutorMock)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+			eventData := awaitFinishedEvent(t, fakeKeptn)
+			require.Equal(t, tt.wantResult, eventData.Result)
+			require.Equal(t, tt.wantStatus, eventData.Status)
+			require.Contains(t, eventData.Message, tt.wantMessageHas)
+			require.Len(t, httpExecutorMock.ExecuteCalls(), tt.wantPolls+1)
+			require.Equal(t, tt.wantOutputs, fakeKeptn.SentEvents[1].Data.(map[string]interface{})["webhook"].(map[string]interface{})["outputs"])
+		})
+	}
+}
+
+func TestTaskHandler_Execute_PollingCompletionShutdown(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"id": "build-1", "status": "running"}`}, nil
+	}}
+	fakeKeptn, taskHandler, _ := newAsyncTestTaskHandler(t, strings.ReplaceAll(webHookContentWithPolling_BETA, "timeout: 1s", "timeout: 1h"), httpExecutorMock)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	taskHandler.Shutdown()
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	eventData := keptnv2.EventData{}
+	require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, handler.ErrWebhookServiceStopped.Error(), eventData.Message)
+}
+
+func TestTaskHandler_Execute_PollingCompletionTimeout(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		if request.Method == http.MethodPost {
+			return &lib.HTTPResponse{StatusCode: http.StatusAccepted, Body: `{"id": "build-1"}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "running"}`}, nil
+	}}
+	fakeKeptn, _, _ := newAsyncTestTaskHandler(t, strings.ReplaceAll(webHookContentWithPolling_BETA, "timeout: 1s", "timeout: 50ms"), httpExecutorMock)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	eventData := awaitFinishedEvent(t, fakeKeptn)
+	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Equal(t, "polling request 'GET http://local:8080/builds/{{.responses.0.body.id}}' did not meet its condition within 50ms", eventData.Message)
+}
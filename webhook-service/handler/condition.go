@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// checkCondition returns why the webhook is not executed for the event, or an empty string if it has no 'when'
+// condition or the event meets it. An error is returned if the expression can not be evaluated, e.g. because it
+// references a missing property, which is not treated as an unmet condition. The condition must be checked before
+// secrets are added to the data of the event
+func (th *TaskHandler) checkCondition(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter) (string, error) {
+	if webhook.When == nil {
+		return "", nil
+	}
+	if failures := webhook.When.Check(eventAdapter.Get()); len(failures) > 0 {
+		return "condition not met: " + strings.Join(failures, ", "), nil
+	}
+	if webhook.When.Expression == "" {
+		return "", nil
+	}
+	value, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), webhook.When.Expression)
+	if err != nil {
+		return "", fmt.Errorf("expression '%s' of the condition can not be evaluated: %w", webhook.When.Expression, err)
+	}
+	if strings.TrimSpace(value) != "true" {
+		return fmt.Sprintf("expression '%s' evaluated to '%s'", webhook.When.Expression, strings.TrimSpace(value)), nil
+	}
+	return "", nil
+}
+
+// onSkippedWebhook handles an event the webhook is not executed for. Unless onSkip is set to 'pass', the event is
+// ignored. Otherwise, a .started and a .finished event with result 'pass' are sent for <task>.triggered events
+func (th *TaskHandler) onSkippedWebhook(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, webhook lib.Webhook, reason string) (interface{}, *sdk.Error) {
+	logger.Infof("Skipping webhook with subscription %s for event %s: %s", webhook.SubscriptionID, *event.Type, reason)
+	if !keptnv2.IsTaskEventType(*event.Type) || !keptnv2.IsTriggeredEventType(*event.Type) {
+		return nil, nil
+	}
+	if webhook.When.GetOnSkip() != lib.WhenOnSkipPass {
+		// the task is only continued if another service responds to it, otherwise the sequence times out
+		logger.Warnf("Ignoring event %s of subscription %s without responding to the task", *event.Type, webhook.SubscriptionID)
+		return nil, nil
+	}
+	if err := keptnHandler.SendStartedEvent(event); err != nil {
+		return nil, sdkError(fmt.Sprintf("could not send .started event: %s", err.Error()), err)
+	}
+	result := map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+		"result":  keptnv2.ResultPass,
+		"status":  keptnv2.StatusSucceeded,
+		"message": "webhook skipped, " + reason,
+	}
+	if err := keptnHandler.SendFinishedEvent(event, result); err != nil {
+		return nil, sdkError(fmt.Sprintf("could not send finished event: %s", err.Error()), err)
+	}
+	return result, nil
+}
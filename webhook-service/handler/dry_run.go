@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+)
+
+// dryRunCallbackURL is used instead of a callback URL when asynchronous webhooks are tested, since they are not completed
+const dryRunCallbackURL = "https://callback-url-of-webhook-service"
+
+// WebhookTestResult contains the rendered requests of a webhook that has been tested against an event, or, if the
+// event does not meet the 'when' condition of the webhook, the reason why the webhook is skipped
+type WebhookTestResult struct {
+	Type           string              `json:"type"`
+	SubscriptionID string              `json:"subscriptionID"`
+	Requests       []RequestTestResult `json:"requests,omitempty"`
+	SkipReason     string              `json:"skipReason,omitempty"`
+	Error          string              `json:"error,omitempty"`
+}
+
+// RequestTestResult contains the rendered request, i.e. the curl command of v1alpha1 webhooks, in which secrets are
+// replaced by placeholders. Requests that have been executed only contain the status code of their response, since
+// the rendered request and the response might contain secrets, e.g. transformed by a template function
+type RequestTestResult struct {
+	Request  interface{} `json:"request,omitempty"`
+	Response interface{} `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+}
+
+// renderedRequest contains the properties of a rendered v1beta1 request. The TLS options are omitted,
+// as they usually contain secrets
+type renderedRequest struct {
+	Method  string       `json:"method"`
+	URL     string       `json:"url"`
+	Headers []lib.Header `json:"headers,omitempty"`
+	Payload string       `json:"payload,omitempty"`
+	Proxy   string       `json:"proxy,omitempty"`
+}
+
+type renderedResponse struct {
+	StatusCode int `json:"statusCode"`
+}
+
+// TestWebhook renders the requests of the webhook against the event, without sending any events. If execute is
+// set, the requests are also executed, which is required for requests referencing the responses of previous requests.
+// Secrets are only read if the requests are executed
+func (th *TaskHandler) TestWebhook(webhook lib.Webhook, event sdk.KeptnEvent, execute bool) WebhookTestResult {
+	result := WebhookTestResult{
+		Type:           webhook.Type,
+		SubscriptionID: webhook.SubscriptionID,
+		Requests:       []RequestTestResult{},
+	}
+
+	eventAdapter, err := lib.NewEventDataAdapter(event)
+	if err != nil {
+		result.Error = fmt.Sprintf("could not parse event: %s", err.Error())
+		return result
+	}
+	if result.SkipReason, err = th.checkCondition(webhook, eventAdapter); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if result.SkipReason != "" {
+		return result
+	}
+	secretEnvVars := getSecretPlaceholders(webhook)
+	if execute {
+		if secretEnvVars, err = th.gatherSecretEnvVars(webhook); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+	}
+	eventAdapter.Add("env", secretEnvVars)
+	if webhook.IsAsync() && webhook.Completion.Mode == lib.CompletionModeCallback {
+		eventAdapter.Add("callbackURL", dryRunCallbackURL)
+	}
+
+	mask := func(value string) string {
+		if !execute {
+			return value
+		}
+		return removeSecretsFromMessage(value, secretEnvVars)
+	}
+
+	templateResponses := newResponseTemplateData(eventAdapter)
+	for _, req := range webhook.Requests {
+		requestResult, ok := th.testRequest(req, eventAdapter, templateResponses, execute, mask)
+		result.Requests = append(result.Requests, requestResult)
+		// subsequent requests might depend on the response of a failed request
+		if !ok {
+			break
+		}
+	}
+	return result
+}
+
+func (th *TaskHandler) testRequest(req interface{}, eventAdapter *lib.EventDataAdapter, templateResponses *responseTemplateData, execute bool, mask func(string) string) (RequestTestResult, bool) {
+	requestResult := RequestTestResult{}
+	request, err := th.CreateRequest(req)
+	if err != nil {
+		requestResult.Error = mask(fmt.Sprintf("creating request failed: %s", err.Error()))
+		return requestResult, false
+	}
+
+	switch r := request.(type) {
+	case string:
+		curlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), r)
+		if err != nil {
+			requestResult.Error = mask(fmt.Sprintf("could not parse request '%s': %s", r, err.Error()))
+			return requestResult, false
+		}
+		if !execute {
+			requestResult.Request = curlCommand
+			return requestResult, true
+		}
+		// the project of a tested event is claimed by the caller, therefore requests are only executed if the allow-list
+		// of the project allows them
+		if err := th.validateCurlRequest(eventAdapter, true); err != nil {
+			requestResult.Error = getTestExecutionError(nil, err)
+			return requestResult, false
+		}
+		response, err := th.curlExecutor.Curl(curlCommand)
+		if err != nil {
+			requestResult.Error = getTestExecutionError(nil, err)
+			return requestResult, false
+		}
+		templateResponses.add(r, UnmarshalResponse(response), nil)
+	case lib.Request:
+		parsedRequest, err := th.parseRequest(r, eventAdapter)
+		if err != nil {
+			requestResult.Error = mask(fmt.Sprintf("could not parse request '%s %s': %s", r.Method, r.URL, err.Error()))
+			return requestResult, false
+		}
+		if !execute {
+			requestResult.Request = renderedRequest{
+				Method:  parsedRequest.Method,
+				URL:     parsedRequest.URL,
+				Headers: parsedRequest.Headers,
+				Payload: parsedRequest.Payload,
+				Proxy:   parsedRequest.Proxy,
+			}
+			return requestResult, true
+		}
+		parsedRequest.RequireAllowList = true
+		response, err := th.httpExecutor.Execute(*parsedRequest)
+		if response != nil {
+			requestResult.Response = renderedResponse{StatusCode: response.StatusCode}
+		}
+		if err != nil {
+			requestResult.Error = getTestExecutionError(response, err)
+			return requestResult, false
+		}
+		templateResponses.add(r, UnmarshalResponse(response.Body), response)
+	}
+	return requestResult, true
+}
+
+// getSecretPlaceholders returns the placeholders of the secrets of a webhook whose requests are not executed, which
+// show where the secrets are used, e.g. "<secret:my-secret.token>"
+func getSecretPlaceholders(webhook lib.Webhook) map[string]string {
+	placeholders := map[string]string{}
+	for _, secretRef := range webhook.EnvFrom {
+		placeholders[secretRef.Name] = fmt.Sprintf("<secret:%s.%s>", secretRef.SecretRef.Name, secretRef.SecretRef.Key)
+	}
+	return placeholders
+}
+
+// getTestExecutionError describes why an executed request has failed. The error itself is not returned, since it might
+// contain the rendered request or the response, e.g. the body of a response with an error status code
+func getTestExecutionError(response *lib.HTTPResponse, err error) string {
+	switch {
+	case lib.IsDeniedURLError(err):
+		return "could not execute request: the URL of the request is denied"
+	case lib.IsInvalidCommandError(err):
+		return "could not execute request: the request is invalid"
+	case response != nil:
+		return fmt.Sprintf("could not execute request: request failed with status code %d", response.StatusCode)
+	case lib.IsNotSentError(err):
+		return "could not execute request: the connection could not be established"
+	}
+	return "could not execute request: no response has been received"
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	keptn "github.com/keptn/go-utils/pkg/api/utils"
 
@@ -23,19 +26,70 @@ type SecretEnv struct {
 }
 
 type TaskHandler struct {
-	templateEngine   lib.ITemplateEngine
-	curlExecutor     lib.ICurlExecutor
-	requestValidator lib.RequestValidator
-	secretReader     lib.ISecretReader
+	templateEngine     lib.ITemplateEngine
+	curlExecutor       lib.ICurlExecutor
+	httpExecutor       lib.IHTTPExecutor
+	requestValidator   lib.RequestValidator
+	secretReaders      map[string]lib.ISecretReader
+	callbackRegistry   *lib.CallbackRegistry
+	allowListValidator lib.AllowListValidator
+	// asyncCompletions tracks the asynchronous webhooks whose .finished event has not been sent yet, and ctx is
+	// cancelled once the service is shut down, which stops waiting for completions and retries
+	asyncCompletions sync.WaitGroup
+	ctx              context.Context
+	stop             context.CancelFunc
 }
 
-func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader) *TaskHandler {
-	return &TaskHandler{
+type TaskHandlerOption func(th *TaskHandler)
+
+// WithCallbackRegistry enables asynchronous webhooks that are completed by a request to their callback URL
+func WithCallbackRegistry(callbackRegistry *lib.CallbackRegistry) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.callbackRegistry = callbackRegistry
+	}
+}
+
+// WithSecretReader enables reading the secrets of the given source, e.g. lib.SecretSourceFile
+func WithSecretReader(source string, secretReader lib.ISecretReader) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.secretReaders[source] = secretReader
+	}
+}
+
+// WithContext stops the TaskHandler once ctx is done, e.g. when the service receives SIGTERM, in addition to Shutdown
+func WithContext(ctx context.Context) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.ctx = ctx
+	}
+}
+
+// WithAllowListValidator rejects requests of v1alpha1 webhooks in projects with an allow-list. The targets of
+// requests of v1beta1 webhooks are checked by the lib.IHTTPExecutor
+func WithAllowListValidator(allowListValidator lib.AllowListValidator) TaskHandlerOption {
+	return func(th *TaskHandler) {
+		th.allowListValidator = allowListValidator
+	}
+}
+
+// NewTaskHandler creates a TaskHandler that reads the secrets of the source lib.SecretSourceK8s with secretReader,
+// which can be nil if that source is disabled
+func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, httpExecutor lib.IHTTPExecutor, requestValidator lib.RequestValidator, secretReader lib.ISecretReader, opts ...TaskHandlerOption) *TaskHandler {
+	th := &TaskHandler{
 		templateEngine:   templateEngine,
 		curlExecutor:     curlExecutor,
+		httpExecutor:     httpExecutor,
 		requestValidator: requestValidator,
-		secretReader:     secretReader,
+		secretReaders:    map[string]lib.ISecretReader{},
+		ctx:              context.Background(),
+	}
+	if secretReader != nil {
+		th.secretReaders[lib.SecretSourceK8s] = secretReader
+	}
+	for _, o := range opts {
+		o(th)
 	}
+	th.ctx, th.stop = context.WithCancel(th.ctx)
+	return th
 }
 
 func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
@@ -56,6 +110,15 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return nil, sdkError(err.Error(), err)
 	}
 
+	reason, err := th.checkCondition(*webhook, eventAdapter)
+	if err != nil {
+		th.onPreExecutionError(keptnHandler, event, eventAdapter, err)
+		return nil, sdkError(err.Error(), err)
+	}
+	if reason != "" {
+		return th.onSkippedWebhook(keptnHandler, event, eventAdapter, *webhook, reason)
+	}
+
 	if sdkErr := th.onStartedWebhookExecution(keptnHandler, event, webhook); sdkErr != nil {
 		return nil, sdkErr
 	}
@@ -69,29 +132,46 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 	}
 	eventAdapter.Add("env", secretEnvVars)
 
-	responses := []interface{}{}
-	responses, err = th.performWebhookRequests(*webhook, eventAdapter, responses)
+	isTriggeredTaskEvent := keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type)
+
+	// the callback URL of an asynchronous webhook is registered before the requests are executed,
+	// since it needs to be passed to the remote system by one of them, e.g. {{.callbackURL}}
+	var callback *awaitedCallback
+	if isTriggeredTaskEvent && webhook.IsAsync() && webhook.Completion.Mode == lib.CompletionModeCallback {
+		callback, err = th.registerCallback(*webhook.Completion)
+		if err != nil {
+			err = lib.NewWebhookExecutionError(true, err)
+			onError(err, secretEnvVars)
+			return nil, sdkError(err.Error(), err)
+		}
+		eventAdapter.Add("callbackURL", callback.url)
+	}
+
+	requestsResult, err := th.performWebhookRequests(*webhook, eventAdapter)
 	if err != nil {
+		if callback != nil {
+			th.callbackRegistry.Cancel(callback.id)
+		}
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 
 	// check if the incoming event was a task.triggered event, and if the 'sendFinished'  property of the webhook was set to true
 	// only in this case, the result should be sent back to Keptn in the form of a .finished event
-	if keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type) && webhook.ShouldSendFinishedEvent() {
+	if isTriggeredTaskEvent && webhook.ShouldSendFinishedEvent() {
 		taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
 		if err != nil {
 			return nil, sdkError(fmt.Sprintf("could not derive task name from event type %s", *event.Type), err)
 		}
-		result := map[string]interface{}{
-			"project": eventAdapter.Project(),
-			"stage":   eventAdapter.Stage(),
-			"service": eventAdapter.Service(),
-			"labels":  eventAdapter.Labels(),
-			taskName: map[string]interface{}{
-				"responses": responses,
-			},
+
+		// the .finished event of an asynchronous webhook is sent once the task has been completed by the remote system
+		if webhook.IsAsync() {
+			th.asyncCompletions.Add(1)
+			go th.completeAsync(keptnHandler, event, eventAdapter, *webhook.Completion, callback, taskName, requestsResult, secretEnvVars)
+			return nil, nil
 		}
+
+		result := getFinishedEventData(eventAdapter, taskName, requestsResult, secretEnvVars)
 		err = keptnHandler.SendFinishedEvent(event, result)
 		if err != nil {
 			return nil, sdkError(fmt.Sprintf("could not send finished event: %s", err.Error()), err)
@@ -102,6 +182,39 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 	return nil, nil
 }
 
+// getFinishedEventData returns the data of the .finished event, containing the responses and outputs of the requests
+func getFinishedEventData(eventAdapter *lib.EventDataAdapter, taskName string, requestsResult *requestsResult, secrets map[string]string) map[string]interface{} {
+	taskResult := map[string]interface{}{
+		"responses": requestsResult.responses,
+	}
+	if len(requestsResult.outputs) > 0 {
+		taskResult["outputs"] = requestsResult.outputs
+	}
+	// the number of attempts of each request is only added if a request has been retried
+	for _, attempts := range requestsResult.attempts {
+		if attempts > 1 {
+			taskResult["attempts"] = requestsResult.attempts
+			break
+		}
+	}
+	result := map[string]interface{}{
+		"project": eventAdapter.Project(),
+		"stage":   eventAdapter.Stage(),
+		"service": eventAdapter.Service(),
+		"labels":  eventAdapter.Labels(),
+		taskName:  taskResult,
+	}
+	// failed assertions of the responses determine the result of the task
+	if requestsResult.result != keptnv2.ResultPass {
+		result["result"] = requestsResult.result
+		result["status"] = keptnv2.StatusSucceeded
+	}
+	if len(requestsResult.messages) > 0 {
+		result["message"] = removeSecretsFromMessage(strings.Join(requestsResult.messages, "\n"), secrets)
+	}
+	return result
+}
+
 func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, err error) {
 	// only send .started events for <task>.triggered events
 	if !keptnv2.IsTaskEventType(*event.Type) || !keptnv2.IsTriggeredEventType(*event.Type) {
@@ -201,32 +314,230 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	return nil
 }
 
-func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []interface{}) ([]interface{}, error) {
+// requestsResult contains the aggregated responses and outputs of the requests of a webhook, as well as the result
+// derived from the assertions of the requests
+type requestsResult struct {
+	responses []interface{}
+	attempts  []int
+	outputs   map[string]interface{}
+	result    keptnv2.ResultType
+	messages  []string
+}
+
+func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter) (*requestsResult, error) {
+	result := &requestsResult{
+		responses: []interface{}{},
+		outputs:   map[string]interface{}{},
+		result:    keptnv2.ResultPass,
+	}
+
+	templateResponses := newResponseTemplateData(eventAdapter)
 
 	executedRequests := 0
 	logger.Debugf("Executing webhooks for subscriptionID %s", webhook.SubscriptionID)
 	for _, req := range webhook.Requests {
 		request, err := th.CreateRequest(req)
 		if err != nil {
-			logger.Warnf("creating CURL request failed: %v", err)
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating CURL request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			logger.Warnf("creating request failed: %v", err)
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("creating request failed: %s", err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
 		}
-		// parse the data from the event, together with the secret env vars
-		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
-		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+
+		var response string
+		var httpResponse *lib.HTTPResponse
+		attempts := 1
+		switch r := request.(type) {
+		// v1alpha1 requests are curl commands
+		case string:
+			response, err = th.performCurlRequest(r, eventAdapter)
+		// v1beta1 requests are executed with the HTTP client
+		case lib.Request:
+			httpResponse, attempts, err = th.performHTTPRequest(r, eventAdapter)
+			if err == nil {
+				response = httpResponse.Body
+				result.addResponse(r, *httpResponse)
+			}
 		}
-		// perform the request
-		response, err := th.curlExecutor.Curl(parsedCurlCommand)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s': %s", request, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return nil, lib.NewWebhookExecutionError(true, err, lib.WithNrOfExecutedRequests(executedRequests))
 		}
 		executedRequests = executedRequests + 1
 
 		data := UnmarshalResponse(response)
-		responses = append(responses, data)
+		result.responses = append(result.responses, data)
+		result.attempts = append(result.attempts, attempts)
+
+		templateResponses.add(request, data, httpResponse)
+	}
+	return result, nil
+}
+
+// responseTemplateData makes the responses of previous requests available to the templates of subsequent requests,
+// e.g. {{.responses.0.body.id}}, and the responses of named requests also by their name, e.g. {{.steps.createTicket.body.id}}
+type responseTemplateData struct {
+	eventAdapter *lib.EventDataAdapter
+	responses    []interface{}
+	steps        map[string]interface{}
+}
+
+func newResponseTemplateData(eventAdapter *lib.EventDataAdapter) *responseTemplateData {
+	d := &responseTemplateData{
+		eventAdapter: eventAdapter,
+		responses:    []interface{}{},
+		steps:        map[string]interface{}{},
+	}
+	eventAdapter.Add("responses", d.responses)
+	eventAdapter.Add("steps", d.steps)
+	return d
+}
+
+// add adds the response of a request. The status code and headers are only available for v1beta1 requests
+func (d *responseTemplateData) add(request interface{}, body interface{}, httpResponse *lib.HTTPResponse) {
+	responseData := map[string]interface{}{"body": body}
+	if httpResponse != nil {
+		responseData["statusCode"] = httpResponse.StatusCode
+		responseData["headers"] = httpResponse.Headers
+		if r, ok := request.(lib.Request); ok && r.Name != "" {
+			d.steps[r.Name] = responseData
+		}
+	}
+	d.responses = append(d.responses, responseData)
+	d.eventAdapter.Add("responses", d.responses)
+}
+
+// setResult adds the message and sets the result of the task, unless it is already worse
+func (r *requestsResult) setResult(result keptnv2.ResultType, message string) {
+	if message != "" {
+		r.messages = append(r.messages, message)
+	}
+	// a failed request can not be turned into a warning by another request
+	if r.result == keptnv2.ResultFailed || result == keptnv2.ResultPass {
+		return
+	}
+	r.result = result
+}
+
+// addResponse checks the assertions of the request, and extracts its outputs from the response
+func (r *requestsResult) addResponse(request lib.Request, response lib.HTTPResponse) {
+	if request.Assertions != nil {
+		failures := request.Assertions.Check(response)
+		if len(failures) > 0 {
+			r.setResult(keptnv2.ResultType(request.Assertions.GetResult()), fmt.Sprintf("assertions of request '%s %s' failed: %s", request.Method, request.URL, strings.Join(failures, ", ")))
+		}
+	}
+
+	for _, output := range request.Outputs {
+		value, err := output.Extract(response)
+		if err != nil {
+			logger.Warnf("Could not extract output '%s' of request '%s %s': %v", output.Name, request.Method, request.URL, err)
+			continue
+		}
+		r.outputs[output.Name] = value
+	}
+}
+
+func (th *TaskHandler) performCurlRequest(request string, eventAdapter *lib.EventDataAdapter) (string, error) {
+	// parse the data from the event, together with the secret env vars
+	parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), request)
+	if err != nil {
+		return "", fmt.Errorf("could not parse request '%s' : %s", request, err.Error())
+	}
+	if err := th.validateCurlRequest(eventAdapter, false); err != nil {
+		return "", fmt.Errorf("could not execute request '%s': %s", request, err.Error())
+	}
+	// perform the request
+	response, err := th.curlExecutor.Curl(parsedCurlCommand)
+	if err != nil {
+		return "", fmt.Errorf("could not execute request '%s': %s", request, err.Error())
+	}
+	return response, nil
+}
+
+func (th *TaskHandler) performHTTPRequest(request lib.Request, eventAdapter *lib.EventDataAdapter) (*lib.HTTPResponse, int, error) {
+	// parse the data from the event, together with the secret env vars
+	parsedRequest, err := th.parseRequest(request, eventAdapter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not parse request '%s %s' : %s", request.Method, request.URL, err.Error())
+	}
+	// perform the request, and retry it according to its retry policy
+	attempts := 0
+	for {
+		attempts++
+		response, err := th.httpExecutor.Execute(*parsedRequest)
+		if err == nil {
+			return response, attempts, nil
+		}
+		if request.Retry != nil && attempts < request.Retry.MaxAttempts && request.Retry.ShouldRetry(response, err) && request.Retry.GetBackoff(attempts) > 0 {
+			backoff := request.Retry.GetBackoff(attempts)
+			logger.Warnf("Retrying request '%s %s' in %s after attempt %d of %d failed: %v", request.Method, request.URL, backoff, attempts, request.Retry.MaxAttempts, err)
+			if err := th.waitForRetry(backoff); err != nil {
+				return nil, attempts, fmt.Errorf("could not execute request '%s %s' after %d attempts: %s", request.Method, request.URL, attempts, err.Error())
+			}
+			continue
+		}
+		// if status codes are asserted, responses with an error status code are checked by the assertions instead
+		if response != nil && request.Assertions != nil && request.Assertions.ChecksStatusCode() {
+			return response, attempts, nil
+		}
+		if attempts > 1 {
+			return nil, attempts, fmt.Errorf("could not execute request '%s %s' after %d attempts: %s", request.Method, request.URL, attempts, err.Error())
+		}
+		return nil, attempts, fmt.Errorf("could not execute request '%s %s': %s", request.Method, request.URL, err.Error())
+	}
+}
+
+// waitForRetry waits for the backoff before a request is retried, unless the webhook service is stopped before
+func (th *TaskHandler) waitForRetry(backoff time.Duration) error {
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-th.ctx.Done():
+		return ErrWebhookServiceStopped
+	}
+}
+
+// parseRequest resolves the placeholders of all properties of a v1beta1 request, and sets its project
+func (th *TaskHandler) parseRequest(request lib.Request, eventAdapter *lib.EventDataAdapter) (*lib.Request, error) {
+	data := eventAdapter.Get()
+	var err error
+	parse := func(value string) string {
+		if err != nil || value == "" {
+			return value
+		}
+		var parsed string
+		parsed, err = th.templateEngine.ParseTemplate(data, value)
+		return parsed
+	}
+
+	parsedRequest := lib.Request{
+		Name:       request.Name,
+		URL:        parse(request.URL),
+		Method:     request.Method,
+		Payload:    parse(request.Payload),
+		Proxy:      parse(request.Proxy),
+		Assertions: request.Assertions,
+		Outputs:    request.Outputs,
+		Retry:      request.Retry,
+		Timeout:    request.Timeout,
+		RateLimit:  request.RateLimit,
+		Project:    eventAdapter.Project(),
+	}
+	for _, header := range request.Headers {
+		parsedRequest.Headers = append(parsedRequest.Headers, lib.Header{Key: parse(header.Key), Value: parse(header.Value)})
+	}
+	if request.TLS != nil {
+		parsedRequest.TLS = &lib.TLSOptions{
+			InsecureSkipVerify: request.TLS.InsecureSkipVerify,
+			CACert:             parse(request.TLS.CACert),
+			ClientCert:         parse(request.TLS.ClientCert),
+			ClientKey:          parse(request.TLS.ClientKey),
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
-	return responses, nil
+	return &parsedRequest, nil
 }
 
 // UnmarshalResponse attempts to create a json object out of the response as requested in https://github.com/keptn/keptn/issues/8256
@@ -248,7 +559,11 @@ func UnmarshalResponse(response string) interface{} {
 func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
 	secretEnvVars := map[string]string{}
 	for _, secretRef := range webhook.EnvFrom {
-		secretValue, err := th.secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key)
+		secretReader, ok := th.secretReaders[secretRef.SecretRef.GetSource()]
+		if !ok {
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s: secret source '%s' is not enabled", secretRef.SecretRef.Name, secretRef.SecretRef.Key, secretRef.SecretRef.GetSource()))
+		}
+		secretValue, err := secretReader.ReadSecret(secretRef.SecretRef.Name, secretRef.SecretRef.Key)
 		if err != nil {
 			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s", secretRef.SecretRef.Name, secretRef.SecretRef.Key))
 		}
@@ -257,29 +572,43 @@ func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]stri
 	return secretEnvVars, nil
 }
 
-func (th *TaskHandler) CreateRequest(request interface{}) (string, error) {
+// CreateRequest validates a request of a webhook. Requests of v1alpha1 webhooks are returned as curl command,
+// while requests of v1beta1 webhooks are returned as lib.Request
+func (th *TaskHandler) CreateRequest(request interface{}) (interface{}, error) {
 	switch req := request.(type) {
 	// v1alpha1 version
 	case string:
 		logger.Debug("creating CURL request from type string")
 		if err := th.validateAlphaCurlRequest(req); err != nil {
-			return "", err
+			return nil, err
 		}
 		return req, nil
 	// v1beta1 version
-	default:
-		logger.Debug("creating CURL request from type Request")
+	case lib.Request, map[string]interface{}:
+		logger.Debug("creating HTTP request from type Request")
 		convertedRequest := lib.ConvertToRequest(request)
 		if err := th.requestValidator.Validate(convertedRequest); err != nil {
-			return "", err
+			return nil, err
 		}
-		betaRequest := buildBetaCurlRequest(convertedRequest)
-		if betaRequest != "" {
-			return betaRequest, nil
+		if convertedRequest.Options != "" {
+			logger.Warnf("Ignoring curl options '%s' of request to %s, since they are not supported for webhooks of version v1beta1", convertedRequest.Options, convertedRequest.URL)
 		}
+		return convertedRequest, nil
 	}
 
-	return "", fmt.Errorf("could not create request: invalid request type")
+	return nil, fmt.Errorf("could not create request: invalid request type")
+}
+
+// validateCurlRequest checks whether the curl command can be executed in the project of the event. If requireAllowList
+// is set, e.g. because the project has not been verified, projects without an allow-list can not execute it either
+func (th *TaskHandler) validateCurlRequest(eventAdapter *lib.EventDataAdapter, requireAllowList bool) error {
+	if th.allowListValidator == nil {
+		return nil
+	}
+	if requireAllowList {
+		return th.allowListValidator.Required().ValidateCurlRequest(eventAdapter.Project())
+	}
+	return th.allowListValidator.ValidateCurlRequest(eventAdapter.Project())
 }
 
 func (th *TaskHandler) validateAlphaCurlRequest(curlCmd string) error {
@@ -293,28 +622,6 @@ func (th *TaskHandler) validateAlphaCurlRequest(curlCmd string) error {
 	return nil
 }
 
-func buildBetaCurlRequest(req lib.Request) string {
-	tmpReq := ""
-	if req.Method != "" {
-		tmpReq = fmt.Sprintf("curl --request %s", req.Method)
-	}
-	if len(req.Headers) > 0 {
-		for _, header := range req.Headers {
-			tmpReq = fmt.Sprintf(tmpReq+" --header '%s: %s'", header.Key, header.Value)
-		}
-	}
-	if req.Payload != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" --data '%s'", req.Payload)
-	}
-	if req.Options != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" %s", req.Options)
-	}
-	if req.URL != "" {
-		tmpReq = fmt.Sprintf(tmpReq+" %s", req.URL)
-	}
-	return tmpReq
-}
-
 func sdkError(msg string, err error) *sdk.Error {
 	return &sdk.Error{
 		StatusType: keptnv2.StatusErrored,
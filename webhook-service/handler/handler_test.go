@@ -1,11 +1,13 @@
 package handler_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
@@ -300,6 +302,136 @@ spec:
       - url: http://local:8080 {{.unavailable}} {{.env.mysecret}}
         method: GET`
 
+const webHookContentWithRequestProperties_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - secretRef:
+          name: mysecret
+      requests:
+      - url: https://local:8080/{{.data.project}}
+        method: POST
+        headers:
+          - key: x-token
+            value: "{{.env.mysecret}}"
+        payload: '{"stage": "{{.data.stage}}"}'
+        proxy: http://proxy:3128
+        tls:
+          clientCert: "{{.env.mysecret}}"
+          clientKey: "{{.env.mysecret}}"`
+
+const webHookContentWithAssertions_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - url: http://local:8080/build
+        method: POST
+        assertions:
+          statusCodes: [201]
+          result: warning
+        outputs:
+          - name: buildID
+            jsonPath: .id
+      - url: http://local:8080/status
+        method: GET
+        assertions:
+          statusCodes: [200]
+          body:
+            - jsonPath: .status
+              equals: succeeded`
+
+const webHookContentWithChainedRequests_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - name: createTicket
+        url: http://local:8080/tickets
+        method: POST
+        payload: '{"project": "{{.data.project}}"}'
+      - url: http://local:8080/tickets/{{.responses.0.body.id}}/comments
+        method: POST
+        headers:
+          - key: x-ticket
+            value: "{{.steps.createTicket.headers.Location}}"
+        payload: '{"status": {{.responses.0.statusCode}}}'`
+
+const webHookContentWithRetry_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+      - url: http://local:8080/tickets
+        method: POST
+      - url: http://local:8080/comments
+        method: POST
+        retry:
+          maxAttempts: 3
+          backoff: 1ms`
+
+const webHookContentWithCondition = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      when:
+        conditions:
+          - jsonPath: .data.stage
+            equals: %s
+        expression: '{{ne .data.service "%s"}}'
+        onSkip: %s
+      requests:
+        - "curl http://local:8080"`
+
+const webHookContentWithSecretSources_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - name: "k8sToken"
+          secretRef:
+            name: "my-secret"
+            key: "token"
+        - name: "fileToken"
+          secretRef:
+            name: "my-vault-secret"
+            key: "token"
+            source: "file"
+      requests:
+        - url: http://local:8080/{{.env.k8sToken}}/{{.env.fileToken}}
+          method: POST`
+
 const webHookContentWithNoMatchingSubscriptionID_ALPHA = `apiVersion: webhookconfig.keptn.sh/v1alpha1
 kind: WebhookConfig
 metadata:
@@ -354,13 +486,14 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_ALPHA})
@@ -392,15 +525,16 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_BETA})
@@ -408,9 +542,9 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
@@ -437,13 +571,14 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStartedEvent_ALPHA})
@@ -469,24 +604,25 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStartedEvent_BETA})
 		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.started", taskHandler, "my-subscription-id")
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.started.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
 	})
@@ -507,13 +643,14 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "", errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -542,15 +679,16 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("oops")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -559,8 +697,8 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.started.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -581,13 +719,14 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -615,15 +754,16 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -632,8 +772,8 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.finished.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -655,13 +795,14 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "", errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -690,15 +831,16 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("oops")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("oops")
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -707,8 +849,8 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.finished.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -733,18 +875,19 @@ func Test_HandleIncomingFinishedEventWithJSON(t *testing.T) {
 		validJSON := true
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
 			if validJSON {
-				return "{\"page\": \"1\", \"fruits\": [\"apple\", \"peach\"]}", nil
+				return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "{\"page\": \"1\", \"fruits\": [\"apple\", \"peach\"]}"}, nil
 			}
-			return "some strange output", nil
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "some strange output"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_BETA})
@@ -752,9 +895,9 @@ func Test_HandleIncomingFinishedEventWithJSON(t *testing.T) {
 		fakeKeptn.SetAutomaticResponse(false)
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
@@ -780,9 +923,9 @@ func Test_HandleIncomingFinishedEventWithJSON(t *testing.T) {
 		// now set wrong json format
 		validJSON = false
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
 		require.Eventually(t, func() bool {
-			return curlExecutorMock.CurlCalls()[0].CurlCmd == "curl --request GET http://local:8080 myproject my-secret-value"
+			return httpExecutorMock.ExecuteCalls()[0].Request.URL == "http://local:8080 myproject my-secret-value"
 		}, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
@@ -821,13 +964,14 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -861,15 +1005,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -879,9 +1024,9 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
 
 		////verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -908,13 +1053,14 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -951,15 +1097,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -969,11 +1116,11 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[2].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[3].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[2].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[3].Request.URL)
 
 		////verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 4)
@@ -998,13 +1145,14 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1036,15 +1184,16 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1054,11 +1203,11 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableStarted(t *tes
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[2].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[3].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 4 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[2].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[3].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 0)
@@ -1079,6 +1228,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			// make the second request fail
 			if len(curlExecutorMock.CurlCalls()) == 2 {
@@ -1089,7 +1239,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1129,19 +1279,20 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
 			// make the second request fail
-			if len(curlExecutorMock.CurlCalls()) == 2 {
-				return "", errors.New("oops")
+			if len(httpExecutorMock.ExecuteCalls()) == 2 {
+				return nil, errors.New("oops")
 			}
-			return "success", nil
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1151,9 +1302,9 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
-		assert.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[1].CurlCmd)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 2 }, 30*time.Second, time.Millisecond*10)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		assert.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[1].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 7)
@@ -1184,13 +1335,14 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1222,15 +1374,16 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		}
 
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1240,7 +1393,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 
 		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
 
-		require.Eventually(t, func() bool { return len(curlExecutorMock.CurlCalls()) == 0 }, 30*time.Second, time.Millisecond*10)
+		require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 0 }, 30*time.Second, time.Millisecond*10)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1256,9 +1409,10 @@ func TestTaskHandler_Execute_WebhookCannotBeRetrieved(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1280,9 +1434,10 @@ func TestTaskHandler_Execute_NotATriggeredEventWebhookCannotBeRetrieved(t *testi
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1301,9 +1456,10 @@ func TestTaskHandler_Execute_NoSubscriptionIDInEvent(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1321,9 +1477,10 @@ func TestTaskHandler_Execute_InvalidEvent(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1347,9 +1504,10 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 			return "", errors.New("unable to read secret :(")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1374,11 +1532,12 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 			return "", errors.New("unable to read secret :(")
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1409,10 +1568,11 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithMissingTemplateData_ALPHA})
@@ -1445,12 +1605,13 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
 		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithMissingTemplateData_BETA})
@@ -1463,7 +1624,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Empty(t, curlExecutorMock.CurlCalls())
+		require.Empty(t, httpExecutorMock.ExecuteCalls())
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1487,11 +1648,12 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "", errors.New("unable to execute curl call")
 		}
 		requestValidatorMock := &fake.RequestValidatorMock{}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1525,13 +1687,14 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("unable to execute curl call")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("unable to execute curl call")
 		}
 		requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1545,7 +1708,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1567,6 +1730,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 		return "my-secret-value", nil
 	}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 		return "", errors.New("unable to execute curl call")
 	}
@@ -1574,7 +1738,7 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 	requestValidatorMock.ValidateFunc = func(request lib.Request) error {
 		return errors.New("validation failed")
 	}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -1597,6 +1761,256 @@ func TestTaskHandler_RequestValidatorFails(t *testing.T) {
 	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
 }
 
+func TestTaskHandler_Execute_ParsesRequestProperties(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
+	}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithRequestProperties_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Eventually(t, func() bool { return len(httpExecutorMock.ExecuteCalls()) == 1 }, 30*time.Second, time.Millisecond*10)
+
+	require.Equal(t, lib.Request{
+		URL:     "https://local:8080/myproject",
+		Method:  "POST",
+		Headers: []lib.Header{{Key: "x-token", Value: "my-secret-value"}},
+		Payload: `{"stage": "mystage"}`,
+		Proxy:   "http://proxy:3128",
+		TLS: &lib.TLSOptions{
+			ClientCert: "my-secret-value",
+			ClientKey:  "my-secret-value",
+		},
+		Project: "myproject",
+	}, httpExecutorMock.ExecuteCalls()[0].Request)
+	require.Empty(t, curlExecutorMock.CurlCalls())
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+}
+
+func TestTaskHandler_Execute_Assertions(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithAssertions_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	t.Run("assertions are met", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			if request.Method == "POST" {
+				return &lib.HTTPResponse{StatusCode: http.StatusCreated, Body: `{"id": "build-1"}`}, nil
+			}
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "succeeded"}`}, nil
+		}
+		fakeKeptn.SentEvents = nil
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		require.Equal(t, map[string]interface{}{
+			"responses": []interface{}{
+				map[string]interface{}{"id": "build-1"},
+				map[string]interface{}{"status": "succeeded"},
+			},
+			"outputs": map[string]interface{}{"buildID": "build-1"},
+		}, fakeKeptn.SentEvents[1].Data.(map[string]interface{})["webhook"])
+	})
+
+	t.Run("assertion with result warning is not met", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			if request.Method == "POST" {
+				return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"id": "build-1"}`}, nil
+			}
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "succeeded"}`}, nil
+		}
+		fakeKeptn.SentEvents = nil
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultWarning)
+		eventData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData))
+		require.Equal(t, "assertions of request 'POST http://local:8080/build' failed: status code 200 is not one of [201]", eventData.Message)
+	})
+
+	t.Run("error status code is checked by assertions", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			if request.Method == "POST" {
+				return &lib.HTTPResponse{StatusCode: http.StatusCreated, Body: `{"id": "build-1"}`}, nil
+			}
+			return &lib.HTTPResponse{StatusCode: http.StatusInternalServerError, Body: `{"status": "failed"}`}, errors.New("request failed with status code 500")
+		}
+		fakeKeptn.SentEvents = nil
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+		eventData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData))
+		require.Equal(t, "assertions of request 'GET http://local:8080/status' failed: status code 500 is not one of [200], value at '.status' is 'failed' instead of 'succeeded'", eventData.Message)
+	})
+}
+
+func TestTaskHandler_Execute_ChainedRequests(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		if request.URL == "http://local:8080/tickets" {
+			return &lib.HTTPResponse{StatusCode: http.StatusCreated, Headers: map[string]string{"Location": "/tickets/42"}, Body: `{"id": 42}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"comment": "ok"}`}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithChainedRequests_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+	fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	require.Equal(t, `{"project": "myproject"}`, httpExecutorMock.ExecuteCalls()[0].Request.Payload)
+
+	secondRequest := httpExecutorMock.ExecuteCalls()[1].Request
+	require.Equal(t, "http://local:8080/tickets/42/comments", secondRequest.URL)
+	require.Equal(t, []lib.Header{{Key: "x-ticket", Value: "/tickets/42"}}, secondRequest.Headers)
+	require.Equal(t, `{"status": 201}`, secondRequest.Payload)
+
+	require.Equal(t, map[string]interface{}{
+		"responses": []interface{}{
+			map[string]interface{}{"id": float64(42)},
+			map[string]interface{}{"comment": "ok"},
+		},
+	}, fakeKeptn.SentEvents[1].Data.(map[string]interface{})["webhook"])
+}
+
+func TestTaskHandler_Execute_Retry(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		return templateStr, nil
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithRetry_BETA})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	newExecuteFunc := func(failedCommentAttempts int) func(request lib.Request) (*lib.HTTPResponse, error) {
+		commentAttempts := 0
+		return func(request lib.Request) (*lib.HTTPResponse, error) {
+			if request.URL == "http://local:8080/comments" {
+				commentAttempts++
+				if commentAttempts <= failedCommentAttempts {
+					return &lib.HTTPResponse{StatusCode: http.StatusBadGateway, Body: "bad gateway"}, lib.NewCurlError(errors.New("request failed with status code 502"), lib.RequestError)
+				}
+			}
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "ok"}, nil
+		}
+	}
+
+	t.Run("request succeeds after retries", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = newExecuteFunc(2)
+		fakeKeptn.SentEvents = nil
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusSucceeded)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		require.Equal(t, map[string]interface{}{
+			"responses": []interface{}{"ok", "ok"},
+			"attempts":  []interface{}{float64(1), float64(3)},
+		}, fakeKeptn.SentEvents[1].Data.(map[string]interface{})["webhook"])
+	})
+
+	t.Run("request fails after all attempts", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = newExecuteFunc(3)
+		fakeKeptn.SentEvents = nil
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultFailed)
+		eventData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData))
+		require.Equal(t, "could not execute request 'POST http://local:8080/comments' after 3 attempts: request failed with status code 502", eventData.Message)
+	})
+
+	t.Run("waiting for a retry is stopped on shutdown", func(t *testing.T) {
+		httpExecutorMock.ExecuteFunc = newExecuteFunc(3)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		stoppedTaskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock, handler.WithContext(ctx))
+		stoppedKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		stoppedKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: strings.Replace(webHookContentWithRetry_BETA, "backoff: 1ms", "backoff: 1h", 1)})
+		stoppedKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", stoppedTaskHandler, "my-subscription-id")
+		stoppedKeptn.SetAutomaticResponse(false)
+
+		stoppedKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		stoppedKeptn.AssertNumberOfEventSent(t, 2)
+		stoppedKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		eventData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(stoppedKeptn.SentEvents[1], eventData))
+		require.Equal(t, "could not execute request 'POST http://local:8080/comments' after 1 attempts: "+handler.ErrWebhookServiceStopped.Error(), eventData.Message)
+	})
+}
+
 func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 	t.Run("TestTaskHandler_CurlExecutorFailsHideSecret - ALPHA", func(t *testing.T) {
 		templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
@@ -1608,11 +2022,12 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "", errors.New("unable to execute curl call containing secret my-secret-value")
 		}
 		requestValidatorMock := fake.RequestValidatorMock{}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1650,13 +2065,14 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "", errors.New("unable to execute curl call containing secret my-secret-value")
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return nil, errors.New("unable to execute curl call containing secret my-secret-value")
 		}
 		requestValidatorMock := fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
 			return nil
 		}}
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1670,7 +2086,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 			return len(secretReaderMock.ReadSecretCalls()) > 0
 		}, 30*time.Second, 10*time.Millisecond)
 		require.NotEmpty(t, templateEngineMock.ParseTemplateCalls())
-		require.Equal(t, "curl --request GET http://local:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+		require.Equal(t, "http://local:8080 myproject my-secret-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
 
 		//verify sent events
 		fakeKeptn.AssertNumberOfEventSent(t, 2)
@@ -1698,6 +2114,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
@@ -1713,7 +2130,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1751,8 +2168,9 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -1768,7 +2186,7 @@ func TestTaskHandler_Execute_WebhookConfigInService(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1810,6 +2228,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
@@ -1832,7 +2251,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1875,8 +2294,9 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -1899,7 +2319,7 @@ func TestTaskHandler_Execute_WebhookConfigInStage(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -1945,6 +2365,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
 		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 			return "success", nil
 		}
@@ -1967,7 +2388,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 
 		requestValidatorMock := &fake.RequestValidatorMock{}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -2016,8 +2437,9 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -2040,7 +2462,7 @@ func TestTaskHandler_Execute_WebhookConfigInProject(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -2093,8 +2515,9 @@ func TestTaskHandler_Execute_WebhookConfigInProjectFailsEmptyURL(t *testing.T) {
 			return "my-secret-value", nil
 		}
 		curlExecutorMock := &fake.ICurlExecutorMock{}
-		curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
-			return "success", nil
+		httpExecutorMock := &fake.IHTTPExecutorMock{}
+		httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "success"}, nil
 		}
 
 		resourceHandlerMock := &fake2.IResourceHandlerMock{}
@@ -2117,7 +2540,7 @@ func TestTaskHandler_Execute_WebhookConfigInProjectFailsEmptyURL(t *testing.T) {
 			return nil
 		}}
 
-		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 		fakeKeptn := sdk.NewFakeKeptn(
 			"test-webhook-svc")
@@ -2168,17 +2591,18 @@ func Test_createRequest(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{}
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
 	requestValidatorMock := &fake.RequestValidatorMock{}
 	requestValidatorMock.ValidateFunc = func(request lib.Request) error {
 		return nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, requestValidatorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, requestValidatorMock, secretReaderMock)
 
 	tests := []struct {
 		name    string
 		data    interface{}
-		want    string
+		want    interface{}
 		wantErr bool
 	}{
 		{
@@ -2190,13 +2614,13 @@ func Test_createRequest(t *testing.T) {
 		{
 			name:    "invalid alpha input #1",
 			data:    "curl http:localhost",
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 		{
 			name:    "invalid alpha input #2",
 			data:    "curl kubernetes.svc",
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -2213,7 +2637,18 @@ func Test_createRequest(t *testing.T) {
 				Payload: "some payload",
 				URL:     "http://local:8080",
 			},
-			want:    "curl --request POST --header 'key: value' --data 'some payload' --some-options http://local:8080",
+			want: lib.Request{
+				Headers: []lib.Header{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Method:  "POST",
+				Options: "--some-options",
+				Payload: "some payload",
+				URL:     "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
@@ -2228,7 +2663,16 @@ func Test_createRequest(t *testing.T) {
 				Method: "POST",
 				URL:    "http://local:8080",
 			},
-			want:    "curl --request POST --header 'key: value' http://local:8080",
+			want: lib.Request{
+				Headers: []lib.Header{
+					{
+						Key:   "key",
+						Value: "value",
+					},
+				},
+				Method: "POST",
+				URL:    "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
@@ -2237,13 +2681,16 @@ func Test_createRequest(t *testing.T) {
 				Method: "POST",
 				URL:    "http://local:8080",
 			},
-			want:    "curl --request POST http://local:8080",
+			want: lib.Request{
+				Method: "POST",
+				URL:    "http://local:8080",
+			},
 			wantErr: false,
 		},
 		{
 			name:    "invalid input",
 			data:    1,
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -2291,3 +2738,236 @@ func TestUnmarshalResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskHandler_Execute_SecretSources(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	k8sSecretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "k8s-value", nil
+	}}
+	fileSecretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "file-value", nil
+	}}
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "ok"}, nil
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+
+	t.Run("secrets are read from their sources", func(t *testing.T) {
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, k8sSecretReaderMock, handler.WithSecretReader(lib.SecretSourceFile, fileSecretReaderMock))
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithSecretSources_BETA})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventResult(t, 1, keptnv2.ResultPass)
+		require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+		require.Equal(t, "http://local:8080/k8s-value/file-value", httpExecutorMock.ExecuteCalls()[0].Request.URL)
+		require.Equal(t, "my-secret", k8sSecretReaderMock.ReadSecretCalls()[0].Name)
+		require.Equal(t, "my-vault-secret", fileSecretReaderMock.ReadSecretCalls()[0].Name)
+	})
+
+	t.Run("secret source is not enabled", func(t *testing.T) {
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, k8sSecretReaderMock)
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithSecretSources_BETA})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventStatus(t, 1, keptnv2.StatusErrored)
+		eventData := &keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], eventData))
+		require.Equal(t, "could not read secret my-vault-secret.token: secret source 'file' is not enabled", eventData.Message)
+	})
+}
+
+func TestTaskHandler_Execute_Condition(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{}
+
+	tests := []struct {
+		name              string
+		stage             string
+		excludedService   string
+		onSkip            string
+		wantRequests      int
+		wantEvents        int
+		wantFinishedEvent keptnv2.EventData
+	}{
+		{
+			name:            "condition met",
+			stage:           "mystage",
+			excludedService: "otherservice",
+			onSkip:          "ignore",
+			wantRequests:    1,
+			wantEvents:      2,
+			wantFinishedEvent: keptnv2.EventData{
+				Project: "myproject", Stage: "mystage", Service: "myservice", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass,
+			},
+		},
+		{
+			name:            "condition not met - ignore",
+			stage:           "otherstage",
+			excludedService: "otherservice",
+			onSkip:          "ignore",
+			wantRequests:    0,
+			wantEvents:      0,
+		},
+		{
+			name:            "condition not met - pass",
+			stage:           "otherstage",
+			excludedService: "otherservice",
+			onSkip:          "pass",
+			wantRequests:    0,
+			wantEvents:      2,
+			wantFinishedEvent: keptnv2.EventData{
+				Project: "myproject", Stage: "mystage", Service: "myservice", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass,
+				Message: "webhook skipped, condition not met: value at '.data.stage' is 'mystage' instead of 'otherstage'",
+			},
+		},
+		{
+			name:            "expression not met - pass",
+			stage:           "mystage",
+			excludedService: "myservice",
+			onSkip:          "pass",
+			wantRequests:    0,
+			wantEvents:      2,
+			wantFinishedEvent: keptnv2.EventData{
+				Project: "myproject", Stage: "mystage", Service: "myservice", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultPass,
+				Message: `webhook skipped, expression '{{ne .data.service "myservice"}}' evaluated to 'false'`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			curlExecutorMock := &fake.ICurlExecutorMock{CurlFunc: func(curlCmd string) (string, error) {
+				return "ok", nil
+			}}
+			taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock)
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithCondition, tt.stage, tt.excludedService, tt.onSkip)})
+			fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+			fakeKeptn.SetAutomaticResponse(false)
+
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+			require.Len(t, curlExecutorMock.CurlCalls(), tt.wantRequests)
+			fakeKeptn.AssertNumberOfEventSent(t, tt.wantEvents)
+			if tt.wantEvents == 0 {
+				return
+			}
+			fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+			fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+			eventData := keptnv2.EventData{}
+			require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+			require.Equal(t, tt.wantFinishedEvent, eventData)
+		})
+	}
+}
+
+func TestTaskHandler_Execute_ConditionCanNotBeEvaluated(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	webhookContent := strings.Replace(fmt.Sprintf(webHookContentWithCondition, "mystage", "otherservice", "ignore"), ".data.service", ".data.unknown", 1)
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, &fake.ISecretReaderMock{})
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webhookContent})
+	fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+	fakeKeptn.SetAutomaticResponse(false)
+
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	// an expression that can not be evaluated fails the task instead of skipping the webhook
+	require.Empty(t, curlExecutorMock.CurlCalls())
+	fakeKeptn.AssertNumberOfEventSent(t, 2)
+	fakeKeptn.AssertSentEventType(t, 0, keptnv2.GetStartedEventType("webhook"))
+	fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+	eventData := keptnv2.EventData{}
+	require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+	require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	require.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	require.Contains(t, eventData.Message, `expression '{{ne .data.unknown "otherservice"}}' of the condition can not be evaluated`)
+}
+
+func TestTaskHandler_Execute_AllowList(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	secretReaderMock := &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}}
+	allowListValidator := lib.NewAllowListValidator(
+		fake.AllowListProviderMock{
+			GetFunc: func(project string) (*lib.AllowList, error) {
+				return &lib.AllowList{Entries: []string{"local"}}, nil
+			},
+		},
+		fake.IPResolverMock{},
+		false,
+	)
+
+	t.Run("v1alpha1 webhook", func(t *testing.T) {
+		curlExecutorMock := &fake.ICurlExecutorMock{}
+		taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, requestValidatorMock, secretReaderMock, handler.WithAllowListValidator(allowListValidator))
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_ALPHA})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		// curl requests can not be restricted to the allow-list, and are therefore not executed
+		require.Empty(t, curlExecutorMock.CurlCalls())
+		fakeKeptn.AssertNumberOfEventSent(t, 2)
+		fakeKeptn.AssertSentEventType(t, 1, keptnv2.GetFinishedEventType("webhook"))
+		eventData := keptnv2.EventData{}
+		require.Nil(t, keptnv2.EventDataAs(fakeKeptn.SentEvents[1], &eventData))
+		require.Equal(t, keptnv2.ResultFailed, eventData.Result)
+		require.Contains(t, eventData.Message, "requests of webhooks of version v1alpha1 are not allowed, since project 'myproject' has an allow-list")
+		require.NotContains(t, eventData.Message, "my-secret-value")
+	})
+
+	t.Run("v1beta1 webhook", func(t *testing.T) {
+		httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+			return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "ok"}, nil
+		}}
+		taskHandler := handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock, handler.WithAllowListValidator(allowListValidator))
+		fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+		fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContent1_BETA})
+		fakeKeptn.AddTaskHandlerWithSubscriptionID("sh.keptn.event.webhook.triggered", taskHandler, "my-subscription-id")
+		fakeKeptn.SetAutomaticResponse(false)
+
+		fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+		// the targets of v1beta1 requests are checked by the HTTP executor against the allow-list of their project
+		require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+		require.Equal(t, "myproject", httpExecutorMock.ExecuteCalls()[0].Request.Project)
+	})
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	"github.com/keptn/go-utils/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+)
+
+// maxValidationBodySize limits the size of the payload of a validation request
+const maxValidationBodySize = 1024 * 1024
+
+// WebhookConfigValidationRequest contains a webhook configuration, and optionally an event the webhooks are tested against
+type WebhookConfigValidationRequest struct {
+	WebhookConfig  string                         `json:"webhookConfig"`
+	SubscriptionID string                         `json:"subscriptionID,omitempty"`
+	Event          *models.KeptnContextExtendedCE `json:"event,omitempty"`
+	Execute        bool                           `json:"execute,omitempty"`
+}
+
+// WebhookConfigValidationResponse contains the result of the validation, and the results of the tested webhooks
+type WebhookConfigValidationResponse struct {
+	Valid    bool                `json:"valid"`
+	Error    string              `json:"error,omitempty"`
+	Webhooks []WebhookTestResult `json:"webhooks,omitempty"`
+}
+
+// ValidationHandler validates webhook configurations, and renders their requests against an event without sending any events
+type ValidationHandler struct {
+	taskHandler *TaskHandler
+}
+
+func NewValidationHandler(taskHandler *TaskHandler) *ValidationHandler {
+	return &ValidationHandler{
+		taskHandler: taskHandler,
+	}
+}
+
+func (vh *ValidationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	validationRequest := WebhookConfigValidationRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxValidationBodySize)).Decode(&validationRequest); err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(vh.validate(validationRequest))
+}
+
+func (vh *ValidationHandler) validate(validationRequest WebhookConfigValidationRequest) WebhookConfigValidationResponse {
+	webhookConfig, err := lib.DecodeWebHookConfigYAML([]byte(validationRequest.WebhookConfig))
+	if err != nil {
+		return WebhookConfigValidationResponse{Valid: false, Error: err.Error()}
+	}
+
+	response := WebhookConfigValidationResponse{Valid: true, Webhooks: []WebhookTestResult{}}
+	for _, webhook := range webhookConfig.Spec.Webhooks {
+		if validationRequest.SubscriptionID != "" && webhook.SubscriptionID != validationRequest.SubscriptionID {
+			continue
+		}
+		if validationRequest.Event == nil {
+			response.Webhooks = append(response.Webhooks, WebhookTestResult{Type: webhook.Type, SubscriptionID: webhook.SubscriptionID})
+			continue
+		}
+		event := sdk.KeptnEvent(*validationRequest.Event)
+		// sample events do not need to define a type, in which case the type of the webhook is used
+		if event.Type == nil || *event.Type == "" {
+			eventType := webhook.Type
+			event.Type = &eventType
+		}
+		response.Webhooks = append(response.Webhooks, vh.taskHandler.TestWebhook(webhook, event, validationRequest.Execute))
+	}
+	return response
+}
@@ -0,0 +1,252 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+const webHookContentToValidate_BETA = `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: token
+          secretRef:
+            name: my-secret
+            key: token
+      requests:
+      - name: createTicket
+        url: http://local:8080/tickets
+        method: POST
+        headers:
+          - key: Authorization
+            value: "Bearer {{.env.token}}"
+        payload: '{"project": "{{.data.project}}"}'
+      - url: http://local:8080/tickets/{{.steps.createTicket.body.id}}
+        method: GET
+    - type: "sh.keptn.event.deployment.triggered"
+      subscriptionID: "my-other-subscription-id"
+      requests:
+      - url: http://local:8080/deployments
+        method: POST`
+
+func newValidationHandler(httpExecutorMock *fake.IHTTPExecutorMock) *handler.ValidationHandler {
+	return newValidationHandlerWithSecretReader(httpExecutorMock, &fake.ISecretReaderMock{ReadSecretFunc: func(name string, key string) (string, error) {
+		return "my-token", nil
+	}})
+}
+
+func newValidationHandlerWithSecretReader(httpExecutorMock *fake.IHTTPExecutorMock, secretReaderMock *fake.ISecretReaderMock) *handler.ValidationHandler {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+	requestValidatorMock := &fake.RequestValidatorMock{ValidateFunc: func(request lib.Request) error {
+		return nil
+	}}
+	return handler.NewValidationHandler(handler.NewTaskHandler(templateEngineMock, &fake.ICurlExecutorMock{}, httpExecutorMock, requestValidatorMock, secretReaderMock))
+}
+
+func validateWebhookConfig(t *testing.T, validationHandler *handler.ValidationHandler, validationRequest handler.WebhookConfigValidationRequest) map[string]interface{} {
+	payload, err := json.Marshal(validationRequest)
+	require.Nil(t, err)
+
+	recorder := httptest.NewRecorder()
+	validationHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/webhook-config/validate", bytes.NewReader(payload)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	response := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestValidationHandler_InvalidConfig(t *testing.T) {
+	response := validateWebhookConfig(t, newValidationHandler(&fake.IHTTPExecutorMock{}), handler.WebhookConfigValidationRequest{
+		WebhookConfig: webHookMalformedContent_BETA,
+	})
+
+	require.Equal(t, false, response["valid"])
+	require.NotEmpty(t, response["error"])
+}
+
+func TestValidationHandler_ValidConfigWithoutEvent(t *testing.T) {
+	response := validateWebhookConfig(t, newValidationHandler(&fake.IHTTPExecutorMock{}), handler.WebhookConfigValidationRequest{
+		WebhookConfig: webHookContentToValidate_BETA,
+	})
+
+	require.Equal(t, map[string]interface{}{
+		"valid": true,
+		"webhooks": []interface{}{
+			map[string]interface{}{"type": "sh.keptn.event.webhook.triggered", "subscriptionID": "my-subscription-id"},
+			map[string]interface{}{"type": "sh.keptn.event.deployment.triggered", "subscriptionID": "my-other-subscription-id"},
+		},
+	}, response)
+}
+
+func TestValidationHandler_DryRun(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+
+	response := validateWebhookConfig(t, newValidationHandlerWithSecretReader(httpExecutorMock, secretReaderMock), handler.WebhookConfigValidationRequest{
+		WebhookConfig:  webHookContentToValidate_BETA,
+		SubscriptionID: "my-subscription-id",
+		Event:          &event,
+	})
+
+	// the second request references the response of the first one, which is only available if the requests are executed
+	require.Equal(t, true, response["valid"])
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+	require.Empty(t, secretReaderMock.ReadSecretCalls())
+	webhooks := response["webhooks"].([]interface{})
+	require.Len(t, webhooks, 1)
+	requests := webhooks[0].(map[string]interface{})["requests"].([]interface{})
+	require.Len(t, requests, 2)
+	require.Equal(t, map[string]interface{}{
+		"request": map[string]interface{}{
+			"method":  "POST",
+			"url":     "http://local:8080/tickets",
+			"headers": []interface{}{map[string]interface{}{"key": "Authorization", "value": "Bearer <secret:my-secret.token>"}},
+			"payload": `{"project": "myproject"}`,
+		},
+	}, requests[0])
+	require.Contains(t, requests[1].(map[string]interface{})["error"], "could not parse request 'GET http://local:8080/tickets/{{.steps.createTicket.body.id}}'")
+}
+
+func TestValidationHandler_Execute(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		if request.Method == http.MethodPost {
+			return &lib.HTTPResponse{StatusCode: http.StatusCreated, Body: `{"id": "42", "token": "my-token"}`}, nil
+		}
+		return &lib.HTTPResponse{StatusCode: http.StatusOK, Body: `{"status": "open"}`}, nil
+	}}
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+
+	response := validateWebhookConfig(t, newValidationHandler(httpExecutorMock), handler.WebhookConfigValidationRequest{
+		WebhookConfig:  webHookContentToValidate_BETA,
+		SubscriptionID: "my-subscription-id",
+		Event:          &event,
+		Execute:        true,
+	})
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 2)
+	require.Equal(t, "Bearer my-token", httpExecutorMock.ExecuteCalls()[0].Request.Headers[0].Value)
+	require.Equal(t, "http://local:8080/tickets/42", httpExecutorMock.ExecuteCalls()[1].Request.URL)
+	// the project of the event is not verified, therefore it must have an allow-list that allows the requests
+	require.True(t, httpExecutorMock.ExecuteCalls()[0].Request.RequireAllowList)
+
+	requests := response["webhooks"].([]interface{})[0].(map[string]interface{})["requests"].([]interface{})
+	require.Len(t, requests, 2)
+	// neither the rendered requests nor the bodies of the responses are returned, since they might contain secrets
+	require.Equal(t, map[string]interface{}{"response": map[string]interface{}{"statusCode": float64(201)}}, requests[0])
+	require.Equal(t, map[string]interface{}{"response": map[string]interface{}{"statusCode": float64(200)}}, requests[1])
+}
+
+func TestValidationHandler_ExecuteFails(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: http.StatusUnauthorized, Body: `{"token": "bXktdG9rZW4="}`}, lib.NewCurlError(errors.New(`request failed with status code 401: {"token": "bXktdG9rZW4="}`), lib.RequestError)
+	}}
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+
+	response := validateWebhookConfig(t, newValidationHandler(httpExecutorMock), handler.WebhookConfigValidationRequest{
+		WebhookConfig:  webHookContentToValidate_BETA,
+		SubscriptionID: "my-subscription-id",
+		Event:          &event,
+		Execute:        true,
+	})
+
+	requests := response["webhooks"].([]interface{})[0].(map[string]interface{})["requests"].([]interface{})
+	require.Len(t, requests, 1)
+	require.Equal(t, map[string]interface{}{
+		"response": map[string]interface{}{"statusCode": float64(401)},
+		"error":    "could not execute request: request failed with status code 401",
+	}, requests[0])
+}
+
+func TestValidationHandler_InvalidRequest(t *testing.T) {
+	validationHandler := newValidationHandler(&fake.IHTTPExecutorMock{})
+
+	recorder := httptest.NewRecorder()
+	validationHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/webhook-config/validate", bytes.NewReader([]byte("not a JSON"))))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	validationHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/webhook-config/validate", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestValidationHandler_SkippedWebhook(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+
+	response := validateWebhookConfig(t, newValidationHandler(httpExecutorMock), handler.WebhookConfigValidationRequest{
+		WebhookConfig: `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      when:
+        conditions:
+          - jsonPath: .data.stage
+            equals: production
+      requests:
+      - url: http://local:8080/deployments
+        method: POST`,
+		Event:   &event,
+		Execute: true,
+	})
+
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+	require.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type":           "sh.keptn.event.webhook.triggered",
+			"subscriptionID": "my-subscription-id",
+			"skipReason":     "condition not met: value at '.data.stage' is 'mystage' instead of 'production'",
+		},
+	}, response["webhooks"])
+}
+
+func TestValidationHandler_ConditionCanNotBeEvaluated(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	event := newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json")
+
+	response := validateWebhookConfig(t, newValidationHandler(httpExecutorMock), handler.WebhookConfigValidationRequest{
+		WebhookConfig: `apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      when:
+        expression: '{{eq .data.unknown "production"}}'
+      requests:
+      - url: http://local:8080/deployments
+        method: POST`,
+		Event:   &event,
+		Execute: true,
+	})
+
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+	webhooks := response["webhooks"].([]interface{})
+	require.Len(t, webhooks, 1)
+	require.Empty(t, webhooks[0].(map[string]interface{})["skipReason"])
+	require.Contains(t, webhooks[0].(map[string]interface{})["error"], `expression '{{eq .data.unknown "production"}}' of the condition can not be evaluated`)
+}
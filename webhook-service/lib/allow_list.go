@@ -0,0 +1,76 @@
+package lib
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// allowListHostPattern matches host names and wildcard domains, e.g. "api.example.com" or "*.example.com"
+var allowListHostPattern = regexp.MustCompile(`^(\*\.)?[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// AllowList contains the targets the webhooks of a project may send requests to. Each entry is either a host name,
+// a wildcard domain matching all of its subdomains, e.g. "*.example.com", an IP address or a network in CIDR notation
+type AllowList struct {
+	Entries []string `json:"entries"`
+}
+
+// Validate returns an error if one of the entries is invalid
+func (a AllowList) Validate() error {
+	for _, entry := range a.Entries {
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			continue
+		}
+		if net.ParseIP(entry) != nil {
+			continue
+		}
+		if !allowListHostPattern.MatchString(strings.ToLower(entry)) {
+			return fmt.Errorf("invalid allow-list entry '%s': must be a host name, a wildcard domain, an IP address or a CIDR", entry)
+		}
+	}
+	return nil
+}
+
+// AllowsHost returns whether the host name matches one of the host names or wildcard domains
+func (a AllowList) AllowsHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, entry := range a.Entries {
+		entry = strings.ToLower(entry)
+		if entry == host {
+			return true
+		}
+		if strings.HasPrefix(entry, "*.") && strings.HasSuffix(host, entry[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsIPs returns whether each of the IP addresses is contained in one of the IP addresses or networks
+func (a AllowList) AllowsIPs(ipAddresses AdrDomainNameMapping) bool {
+	if len(ipAddresses) == 0 {
+		return false
+	}
+	for ip := range ipAddresses {
+		if !a.allowsIP(net.ParseIP(ip)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a AllowList) allowsIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, entry := range a.Entries {
+		if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(ip) {
+			return true
+		}
+		if entryIP := net.ParseIP(entry); entryIP != nil && entryIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package lib
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// AllowListProvider stores the allow-lists of projects
+type AllowListProvider interface {
+	// Get returns the allow-list of the project, or nil if the project does not have one
+	Get(project string) (*AllowList, error)
+	Set(project string, allowList AllowList) error
+	Delete(project string) error
+}
+
+type allowListProvider struct {
+	kubeClient kubernetes.Interface
+}
+
+// NewAllowListProvider creates an AllowListProvider that stores the entries of the allow-list of each project
+// in the ConfigMap keptn-webhook-allow-lists, separated by whitespace
+func NewAllowListProvider(kubeClient kubernetes.Interface) AllowListProvider {
+	return allowListProvider{kubeClient: kubeClient}
+}
+
+func (p allowListProvider) Get(project string) (*AllowList, error) {
+	configMap, err := p.kubeClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Get(context.TODO(), WebhookAllowListsMap, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entries, ok := configMap.Data[project]
+	if !ok {
+		return nil, nil
+	}
+	return &AllowList{Entries: strings.Fields(entries)}, nil
+}
+
+func (p allowListProvider) Set(project string, allowList AllowList) error {
+	return p.update(func(data map[string]string) {
+		data[project] = strings.Join(allowList.Entries, " ")
+	})
+}
+
+func (p allowListProvider) Delete(project string) error {
+	return p.update(func(data map[string]string) {
+		delete(data, project)
+	})
+}
+
+// update modifies the data of the ConfigMap, which is created if it does not exist yet. Concurrent modifications
+// are detected by the resource version of the ConfigMap, in which case the update is repeated
+func (p allowListProvider) update(modify func(data map[string]string)) error {
+	configMaps := p.kubeClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar())
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(context.TODO(), WebhookAllowListsMap, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   WebhookAllowListsMap,
+					Labels: map[string]string{"app.kubernetes.io/managed-by": "webhook-service"},
+				},
+				Data: map[string]string{},
+			}
+			modify(configMap.Data)
+			_, err = configMaps.Create(context.TODO(), configMap, metav1.CreateOptions{})
+			if k8serrors.IsAlreadyExists(err) {
+				// the ConfigMap has been created concurrently, which is retried as a conflict
+				return k8serrors.NewConflict(corev1.Resource("configmaps"), WebhookAllowListsMap, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		modify(configMap.Data)
+		_, err = configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAllowListProvider(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	provider := NewAllowListProvider(client)
+
+	// the ConfigMap does not exist yet
+	allowList, err := provider.Get("my-project")
+	require.Nil(t, err)
+	require.Nil(t, allowList)
+
+	require.Nil(t, provider.Set("my-project", AllowList{Entries: []string{"api.example.com", "10.0.0.0/8"}}))
+	require.Nil(t, provider.Set("other-project", AllowList{Entries: []string{}}))
+
+	allowList, err = provider.Get("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &AllowList{Entries: []string{"api.example.com", "10.0.0.0/8"}}, allowList)
+
+	// an empty allow-list denies all requests, and is therefore not the same as no allow-list
+	allowList, err = provider.Get("other-project")
+	require.Nil(t, err)
+	require.Equal(t, &AllowList{Entries: []string{}}, allowList)
+
+	configMap, err := client.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Get(context.TODO(), WebhookAllowListsMap, metav1.GetOptions{})
+	require.Nil(t, err)
+	require.Equal(t, map[string]string{"my-project": "api.example.com 10.0.0.0/8", "other-project": ""}, configMap.Data)
+
+	require.Nil(t, provider.Delete("my-project"))
+
+	allowList, err = provider.Get("my-project")
+	require.Nil(t, err)
+	require.Nil(t, allowList)
+}
+
+func TestAllowListProvider_ExistingConfigMapWithoutData(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: WebhookAllowListsMap, Namespace: GetNamespaceFromEnvVar()},
+	})
+	provider := NewAllowListProvider(client)
+
+	require.Nil(t, provider.Set("my-project", AllowList{Entries: []string{"*.example.com"}}))
+
+	allowList, err := provider.Get("my-project")
+	require.Nil(t, err)
+	require.Equal(t, &AllowList{Entries: []string{"*.example.com"}}, allowList)
+}
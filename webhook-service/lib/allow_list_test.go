@@ -0,0 +1,43 @@
+package lib_test
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowList_Validate(t *testing.T) {
+	valid := lib.AllowList{Entries: []string{"api.example.com", "*.example.com", "localhost", "10.0.0.1", "10.0.0.0/8", "2001:db8::/32"}}
+	require.Nil(t, valid.Validate())
+
+	for _, entry := range []string{"https://api.example.com", "api.example.com:443", "*", "*.*.example.com", "10.0.0.0/33", "-example.com"} {
+		t.Run(entry, func(t *testing.T) {
+			invalid := lib.AllowList{Entries: []string{"api.example.com", entry}}
+			require.ErrorContains(t, invalid.Validate(), "invalid allow-list entry '"+entry+"'")
+		})
+	}
+}
+
+func TestAllowList_AllowsHost(t *testing.T) {
+	allowList := lib.AllowList{Entries: []string{"api.example.com", "*.example.org", "10.0.0.1"}}
+
+	require.True(t, allowList.AllowsHost("api.example.com"))
+	require.True(t, allowList.AllowsHost("API.Example.com."))
+	require.True(t, allowList.AllowsHost("hooks.example.org"))
+	require.True(t, allowList.AllowsHost("a.b.example.org"))
+
+	require.False(t, allowList.AllowsHost("example.org"))
+	require.False(t, allowList.AllowsHost("myexample.org"))
+	require.False(t, allowList.AllowsHost("other.example.com"))
+	require.False(t, allowList.AllowsHost("api.example.com.evil.com"))
+}
+
+func TestAllowList_AllowsIPs(t *testing.T) {
+	allowList := lib.AllowList{Entries: []string{"api.example.com", "10.0.0.0/8", "192.168.1.1"}}
+
+	require.True(t, allowList.AllowsIPs(lib.AdrDomainNameMapping{"10.1.2.3": nil, "192.168.1.1": nil}))
+
+	require.False(t, allowList.AllowsIPs(lib.AdrDomainNameMapping{"10.1.2.3": nil, "192.168.1.2": nil}))
+	require.False(t, allowList.AllowsIPs(lib.AdrDomainNameMapping{}))
+}
@@ -0,0 +1,105 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	neturl "net/url"
+)
+
+// AllowListValidator checks the targets of webhook requests against the allow-list of their project. Denied
+// requests are recorded in the audit log
+type AllowListValidator interface {
+	// ValidateURL checks the host of the URL
+	ValidateURL(project string, url string) error
+	// ValidateAddress checks a host that has already been resolved to the given IP addresses
+	ValidateAddress(project string, host string, ipAddresses AdrDomainNameMapping) error
+	// ValidateCurlRequest checks whether requests of v1alpha1 webhooks can be executed in the project
+	ValidateCurlRequest(project string) error
+	// Required returns a validator that also denies all requests of projects without an allow-list
+	Required() AllowListValidator
+}
+
+type allowListValidator struct {
+	allowListProvider AllowListProvider
+	ipResolver        IPResolver
+	required          bool
+}
+
+// NewAllowListValidator creates an AllowListValidator. If required is set, projects without an allow-list can
+// not send any requests
+func NewAllowListValidator(allowListProvider AllowListProvider, ipResolver IPResolver, required bool) AllowListValidator {
+	return allowListValidator{
+		allowListProvider: allowListProvider,
+		ipResolver:        ipResolver,
+		required:          required,
+	}
+}
+
+func (v allowListValidator) Required() AllowListValidator {
+	v.required = true
+	return v
+}
+
+func (v allowListValidator) ValidateURL(project string, url string) error {
+	allowList, err := v.getAllowList(project)
+	if err != nil || allowList == nil {
+		return err
+	}
+	parsedURL, err := neturl.Parse(url)
+	if err != nil {
+		return err
+	}
+	if allowList.AllowsHost(parsedURL.Hostname()) {
+		return nil
+	}
+	ipAddresses, err := v.ipResolver.Resolve(url)
+	if err != nil {
+		return err
+	}
+	return v.checkIPs(project, *allowList, parsedURL.Hostname(), ipAddresses)
+}
+
+func (v allowListValidator) ValidateAddress(project string, host string, ipAddresses AdrDomainNameMapping) error {
+	allowList, err := v.getAllowList(project)
+	if err != nil || allowList == nil {
+		return err
+	}
+	if allowList.AllowsHost(host) {
+		return nil
+	}
+	return v.checkIPs(project, *allowList, host, ipAddresses)
+}
+
+func (v allowListValidator) ValidateCurlRequest(project string) error {
+	allowList, err := v.getAllowList(project)
+	if err != nil || allowList == nil {
+		return err
+	}
+	// curl resolves and connects to the targets of a request on its own, therefore they can not be checked reliably
+	AuditLog(project, AuditActionRequestDenied, "request of v1alpha1 webhook denied")
+	return fmt.Errorf("requests of webhooks of version v1alpha1 are not allowed, since project '%s' has an allow-list", project)
+}
+
+// getAllowList returns the allow-list of the project, or nil if the requests of the project are not restricted
+func (v allowListValidator) getAllowList(project string) (*AllowList, error) {
+	if project == "" {
+		return nil, errors.New("the allow-list can not be checked for requests without a project")
+	}
+	allowList, err := v.allowListProvider.Get(project)
+	if err != nil {
+		return nil, fmt.Errorf("could not read allow-list of project '%s': %w", project, err)
+	}
+	if allowList == nil && v.required {
+		// an empty allow-list denies all requests
+		return &AllowList{}, nil
+	}
+	return allowList, nil
+}
+
+func (v allowListValidator) checkIPs(project string, allowList AllowList, host string, ipAddresses AdrDomainNameMapping) error {
+	if allowList.AllowsIPs(ipAddresses) {
+		return nil
+	}
+	AuditLog(project, AuditActionRequestDenied, fmt.Sprintf("request to host '%s' denied", host))
+	return fmt.Errorf("host '%s' is not on the allow-list of project '%s'", host, project)
+}
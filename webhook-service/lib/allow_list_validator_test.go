@@ -0,0 +1,93 @@
+package lib_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAllowListValidator(allowLists map[string][]string, required bool) lib.AllowListValidator {
+	return lib.NewAllowListValidator(
+		fake.AllowListProviderMock{
+			GetFunc: func(project string) (*lib.AllowList, error) {
+				entries, ok := allowLists[project]
+				if !ok {
+					return nil, nil
+				}
+				return &lib.AllowList{Entries: entries}, nil
+			},
+		},
+		fake.IPResolverMock{
+			ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+				return lib.AdrDomainNameMapping{"10.0.0.1": []string{"internal.example.com."}}, nil
+			},
+		},
+		required,
+	)
+}
+
+func TestAllowListValidator_ValidateURL(t *testing.T) {
+	validator := newTestAllowListValidator(map[string][]string{
+		"my-project":    {"api.example.com"},
+		"cidr-project":  {"10.0.0.0/24"},
+		"empty-project": {},
+	}, false)
+
+	require.Nil(t, validator.ValidateURL("my-project", "https://api.example.com/tickets"))
+	require.ErrorContains(t, validator.ValidateURL("my-project", "https://other.example.com/tickets"), "host 'other.example.com' is not on the allow-list of project 'my-project'")
+
+	// host names that are not on the allow-list are allowed if they resolve to allowed addresses
+	require.Nil(t, validator.ValidateURL("cidr-project", "https://other.example.com/tickets"))
+
+	require.NotNil(t, validator.ValidateURL("empty-project", "https://api.example.com/tickets"))
+
+	// projects without an allow-list are not restricted
+	require.Nil(t, validator.ValidateURL("other-project", "https://other.example.com/tickets"))
+	require.NotNil(t, validator.ValidateURL("", "https://other.example.com/tickets"))
+}
+
+func TestAllowListValidator_ValidateAddress(t *testing.T) {
+	validator := newTestAllowListValidator(map[string][]string{"my-project": {"api.example.com", "192.168.0.0/16"}}, false)
+
+	require.Nil(t, validator.ValidateAddress("my-project", "api.example.com", lib.AdrDomainNameMapping{"10.0.0.1": nil}))
+	require.Nil(t, validator.ValidateAddress("my-project", "other.example.com", lib.AdrDomainNameMapping{"192.168.1.1": nil}))
+	require.NotNil(t, validator.ValidateAddress("my-project", "other.example.com", lib.AdrDomainNameMapping{"192.168.1.1": nil, "10.0.0.1": nil}))
+}
+
+func TestAllowListValidator_Required(t *testing.T) {
+	validator := newTestAllowListValidator(map[string][]string{"my-project": {"api.example.com"}}, true)
+
+	require.Nil(t, validator.ValidateURL("my-project", "https://api.example.com/tickets"))
+	require.ErrorContains(t, validator.ValidateURL("other-project", "https://api.example.com/tickets"), "host 'api.example.com' is not on the allow-list of project 'other-project'")
+	require.NotNil(t, validator.ValidateCurlRequest("other-project"))
+
+	validator = newTestAllowListValidator(map[string][]string{"my-project": {"api.example.com"}}, false).Required()
+
+	require.Nil(t, validator.ValidateURL("my-project", "https://api.example.com/tickets"))
+	require.NotNil(t, validator.ValidateURL("other-project", "https://api.example.com/tickets"))
+}
+
+func TestAllowListValidator_ValidateCurlRequest(t *testing.T) {
+	validator := newTestAllowListValidator(map[string][]string{"my-project": {"api.example.com"}}, false)
+
+	require.ErrorContains(t, validator.ValidateCurlRequest("my-project"), "requests of webhooks of version v1alpha1 are not allowed, since project 'my-project' has an allow-list")
+	require.Nil(t, validator.ValidateCurlRequest("other-project"))
+}
+
+func TestAllowListValidator_ProviderError(t *testing.T) {
+	validator := lib.NewAllowListValidator(
+		fake.AllowListProviderMock{
+			GetFunc: func(project string) (*lib.AllowList, error) {
+				return nil, errors.New("configmaps is forbidden")
+			},
+		},
+		fake.IPResolverMock{},
+		false,
+	)
+
+	require.ErrorContains(t, validator.ValidateURL("my-project", "https://api.example.com"), "could not read allow-list of project 'my-project'")
+	require.NotNil(t, validator.ValidateCurlRequest("my-project"))
+}
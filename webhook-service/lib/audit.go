@@ -0,0 +1,40 @@
+package lib
+
+import (
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	AuditActionAllowListUpdated = "allow-list-updated"
+	AuditActionAllowListDeleted = "allow-list-deleted"
+	AuditActionRequestDenied    = "request-denied"
+)
+
+// AuditPrincipal is the authenticated principal that has performed an action recorded in the audit log
+type AuditPrincipal struct {
+	Subject string
+	Email   string
+}
+
+// AuditLog writes an entry to the audit log of the webhook service, i.e. a log entry with the field 'audit',
+// recording security relevant actions of a project
+func AuditLog(project string, action string, message string) {
+	auditLogEntry(project, action).Info(message)
+}
+
+// AuditLogChange writes an entry to the audit log recording a change made by the principal, whose subject and email
+// are added as the fields 'principal' and 'principalEmail'
+func AuditLogChange(project string, action string, principal AuditPrincipal, message string) {
+	auditLogEntry(project, action).WithFields(logger.Fields{
+		"principal":      principal.Subject,
+		"principalEmail": principal.Email,
+	}).Info(message)
+}
+
+func auditLogEntry(project string, action string) *logger.Entry {
+	return logger.WithFields(logger.Fields{
+		"audit":   true,
+		"project": project,
+		"action":  action,
+	})
+}
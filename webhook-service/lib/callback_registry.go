@@ -0,0 +1,146 @@
+package lib
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const callbackPath = "/v1/callback/"
+
+var ErrInvalidCallbackSignature = errors.New("invalid callback signature")
+var ErrCallbackNotFound = errors.New("callback not found, it might have been completed or timed out already")
+var ErrCallbackTimeout = errors.New("no callback received before the timeout")
+
+// CallbackResult is the payload of a request to the callback URL of an asynchronous webhook
+type CallbackResult struct {
+	Result  string                 `json:"result,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// Validate checks that the result of the callback is supported
+func (c CallbackResult) Validate() error {
+	switch keptnv2.ResultType(c.Result) {
+	case "", keptnv2.ResultPass, keptnv2.ResultWarning, keptnv2.ResultFailed:
+		return nil
+	}
+	return fmt.Errorf("unsupported result '%s', must be one of 'pass', 'warning' or 'fail'", c.Result)
+}
+
+// GetResult returns the result of the task, which is 'pass' if the callback does not define a result
+func (c CallbackResult) GetResult() keptnv2.ResultType {
+	if c.Result == "" {
+		return keptnv2.ResultPass
+	}
+	return keptnv2.ResultType(c.Result)
+}
+
+// CallbackRegistry keeps track of the callback URLs of asynchronous webhooks that have not been completed yet.
+// Each callback URL contains a signature of its ID, and can only be used once.
+// The callbacks are kept in memory, i.e., callbacks that are pending when the service is restarted are lost
+type CallbackRegistry struct {
+	baseURL   string
+	key       []byte
+	callbacks map[string]*pendingCallback
+	mutex     sync.Mutex
+}
+
+type pendingCallback struct {
+	timer      *time.Timer
+	onComplete func(result *CallbackResult, err error)
+}
+
+// NewCallbackRegistry creates a registry for callback URLs that start with the given base URL.
+// The key for signing the callback URLs is generated randomly
+func NewCallbackRegistry(baseURL string) (*CallbackRegistry, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("could not generate callback signing key: %w", err)
+	}
+	return &CallbackRegistry{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		key:       key,
+		callbacks: map[string]*pendingCallback{},
+	}, nil
+}
+
+// Register creates a new callback and returns its ID and URL. onComplete is called once, either with the
+// result of the request to the callback URL, or with ErrCallbackTimeout if no request is received before the timeout
+func (r *CallbackRegistry) Register(timeout time.Duration, onComplete func(result *CallbackResult, err error)) (string, string, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", fmt.Errorf("could not generate callback ID: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.callbacks[id] = &pendingCallback{
+		timer: time.AfterFunc(timeout, func() {
+			if r.remove(id) != nil {
+				onComplete(nil, ErrCallbackTimeout)
+			}
+		}),
+		onComplete: onComplete,
+	}
+
+	callbackURL := r.baseURL + callbackPath + id + "?" + url.Values{"signature": []string{r.sign(id)}}.Encode()
+	return id, callbackURL, nil
+}
+
+// Complete verifies the signature of the callback and completes it with the given result
+func (r *CallbackRegistry) Complete(id string, signature string, result CallbackResult) error {
+	if !hmac.Equal([]byte(signature), []byte(r.sign(id))) {
+		return ErrInvalidCallbackSignature
+	}
+	callback := r.remove(id)
+	if callback == nil {
+		return ErrCallbackNotFound
+	}
+	callback.timer.Stop()
+	callback.onComplete(&result, nil)
+	return nil
+}
+
+// Cancel removes a callback without completing it, e.g. if the requests of the webhook have failed
+func (r *CallbackRegistry) Cancel(id string) {
+	if callback := r.remove(id); callback != nil {
+		callback.timer.Stop()
+	}
+}
+
+// CallbackID returns the ID of the callback from the path of a callback URL
+func CallbackID(path string) (string, bool) {
+	if !strings.HasPrefix(path, callbackPath) {
+		return "", false
+	}
+	id := strings.TrimPrefix(path, callbackPath)
+	return id, id != "" && !strings.Contains(id, "/")
+}
+
+func (r *CallbackRegistry) remove(id string) *pendingCallback {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	callback, ok := r.callbacks[id]
+	if !ok {
+		return nil
+	}
+	delete(r.callbacks, id)
+	return callback
+}
+
+func (r *CallbackRegistry) sign(id string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))
+}
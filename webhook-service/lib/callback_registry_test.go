@@ -0,0 +1,118 @@
+package lib_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+type callbackOutcome struct {
+	result *lib.CallbackResult
+	err    error
+}
+
+func registerTestCallback(t *testing.T, registry *lib.CallbackRegistry, timeout time.Duration) (string, string, chan callbackOutcome) {
+	done := make(chan callbackOutcome, 1)
+	id, callbackURL, err := registry.Register(timeout, func(result *lib.CallbackResult, err error) {
+		done <- callbackOutcome{result: result, err: err}
+	})
+	require.Nil(t, err)
+
+	parsedURL, err := url.Parse(callbackURL)
+	require.Nil(t, err)
+	require.Equal(t, "http://webhook-service:8081/v1/callback/"+id, parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+	return id, parsedURL.Query().Get("signature"), done
+}
+
+func TestCallbackRegistry_Complete(t *testing.T) {
+	registry, err := lib.NewCallbackRegistry("http://webhook-service:8081/")
+	require.Nil(t, err)
+
+	id, signature, done := registerTestCallback(t, registry, time.Minute)
+
+	err = registry.Complete(id, signature, lib.CallbackResult{Result: "warning", Message: "tests are flaky"})
+	require.Nil(t, err)
+
+	outcome := <-done
+	require.Nil(t, outcome.err)
+	require.Equal(t, &lib.CallbackResult{Result: "warning", Message: "tests are flaky"}, outcome.result)
+
+	// callbacks can only be completed once
+	err = registry.Complete(id, signature, lib.CallbackResult{})
+	require.ErrorIs(t, err, lib.ErrCallbackNotFound)
+}
+
+func TestCallbackRegistry_Complete_InvalidSignature(t *testing.T) {
+	registry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+
+	id, signature, done := registerTestCallback(t, registry, time.Minute)
+
+	err = registry.Complete(id, strings.Repeat("0", len(signature)), lib.CallbackResult{})
+	require.ErrorIs(t, err, lib.ErrInvalidCallbackSignature)
+
+	// the signatures of other registries are not accepted either
+	otherRegistry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+	err = otherRegistry.Complete(id, signature, lib.CallbackResult{})
+	require.ErrorIs(t, err, lib.ErrInvalidCallbackSignature)
+
+	require.Nil(t, registry.Complete(id, signature, lib.CallbackResult{}))
+	require.Nil(t, (<-done).err)
+}
+
+func TestCallbackRegistry_Timeout(t *testing.T) {
+	registry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+
+	id, signature, done := registerTestCallback(t, registry, 10*time.Millisecond)
+
+	outcome := <-done
+	require.ErrorIs(t, outcome.err, lib.ErrCallbackTimeout)
+	require.Nil(t, outcome.result)
+
+	err = registry.Complete(id, signature, lib.CallbackResult{})
+	require.ErrorIs(t, err, lib.ErrCallbackNotFound)
+}
+
+func TestCallbackRegistry_Cancel(t *testing.T) {
+	registry, err := lib.NewCallbackRegistry("http://webhook-service:8081")
+	require.Nil(t, err)
+
+	id, signature, done := registerTestCallback(t, registry, 10*time.Millisecond)
+	registry.Cancel(id)
+
+	err = registry.Complete(id, signature, lib.CallbackResult{})
+	require.ErrorIs(t, err, lib.ErrCallbackNotFound)
+
+	// cancelled callbacks do not time out
+	select {
+	case <-done:
+		t.Error("cancelled callback must not be completed")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCallbackResult_Validate(t *testing.T) {
+	require.Nil(t, lib.CallbackResult{}.Validate())
+	require.Nil(t, lib.CallbackResult{Result: "pass"}.Validate())
+	require.Nil(t, lib.CallbackResult{Result: "fail"}.Validate())
+	require.NotNil(t, lib.CallbackResult{Result: "failed"}.Validate())
+}
+
+func TestCallbackID(t *testing.T) {
+	id, ok := lib.CallbackID("/v1/callback/abc")
+	require.True(t, ok)
+	require.Equal(t, "abc", id)
+
+	_, ok = lib.CallbackID("/v1/callback/")
+	require.False(t, ok)
+	_, ok = lib.CallbackID("/v1/callback/abc/def")
+	require.False(t, ok)
+	_, ok = lib.CallbackID("/health")
+	require.False(t, ok)
+}
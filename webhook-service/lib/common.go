@@ -7,6 +7,7 @@ import (
 
 const (
 	WebhookConfigMap        = "keptn-webhook-config"
+	WebhookAllowListsMap    = "keptn-webhook-allow-lists"
 	KubernetesSvcHostEnvVar = "KUBERNETES_SERVICE_HOST"
 	KubernetesAPIPortEnvVar = "KUBERNETES_SERVICE_PORT"
 )
@@ -0,0 +1,110 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	CompletionModeCallback = "callback"
+	CompletionModePolling  = "polling"
+)
+
+const defaultCompletionTimeout = time.Hour
+const defaultPollingInterval = 30 * time.Second
+
+// Completion lets a webhook complete its task asynchronously, either by a request of the remote system to a
+// callback URL, or by polling a status URL until a condition is met
+type Completion struct {
+	Mode    string   `yaml:"mode"`
+	Timeout string   `yaml:"timeout,omitempty"`
+	Polling *Polling `yaml:"polling,omitempty"`
+}
+
+// Polling executes Request every Interval until the assertions of Until are met, or the assertions of FailWhen
+// are met, in which case the task fails
+type Polling struct {
+	Request  Request     `yaml:"request"`
+	Interval string      `yaml:"interval,omitempty"`
+	Until    Assertions  `yaml:"until"`
+	FailWhen *Assertions `yaml:"failWhen,omitempty"`
+}
+
+// GetTimeout returns the maximum duration until the task is completed
+func (c Completion) GetTimeout() time.Duration {
+	// the duration has been verified when the webhook configuration was decoded
+	return parseDurationOrDefault(c.Timeout, defaultCompletionTimeout)
+}
+
+// GetInterval returns the duration between two requests to the status URL
+func (p Polling) GetInterval() time.Duration {
+	return parseDurationOrDefault(p.Interval, defaultPollingInterval)
+}
+
+func verifyCompletion(completion Completion) error {
+	if err := verifyDuration("timeout", completion.Timeout); err != nil {
+		return err
+	}
+	switch completion.Mode {
+	case CompletionModeCallback:
+		if completion.Polling != nil {
+			return errors.New(webhookConfInvalid + "polling can not be configured for completion mode 'callback'")
+		}
+		return nil
+	case CompletionModePolling:
+		if completion.Polling == nil {
+			return errors.New(webhookConfInvalid + "missing polling configuration for completion mode 'polling'")
+		}
+		return verifyPolling(*completion.Polling)
+	default:
+		return fmt.Errorf(webhookConfInvalid+"unsupported completion mode '%s'", completion.Mode)
+	}
+}
+
+func verifyPolling(polling Polling) error {
+	if err := verifyDuration("polling interval", polling.Interval); err != nil {
+		return err
+	}
+	if err := verifyBeta1Request(polling.Request); err != nil {
+		return err
+	}
+	if polling.Request.Retry != nil {
+		return errors.New(webhookConfInvalid + "retry is not supported by the polling request, which is repeated until the timeout")
+	}
+	if polling.Request.Assertions != nil {
+		return errors.New(webhookConfInvalid + "assertions of the polling request must be defined by 'until' and 'failWhen'")
+	}
+	if len(polling.Until.StatusCodes) == 0 && len(polling.Until.Headers) == 0 && len(polling.Until.Body) == 0 {
+		return errors.New(webhookConfInvalid + "missing 'until' condition of polling")
+	}
+	if err := verifyAssertions(polling.Until); err != nil {
+		return err
+	}
+	if polling.FailWhen != nil {
+		return verifyAssertions(*polling.FailWhen)
+	}
+	return nil
+}
+
+func verifyDuration(name string, value string) error {
+	if value == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf(webhookConfInvalid+"invalid %s '%s': %s", name, value, err.Error())
+	}
+	if duration <= 0 {
+		return fmt.Errorf(webhookConfInvalid+"%s '%s' must be positive", name, value)
+	}
+	return nil
+}
+
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
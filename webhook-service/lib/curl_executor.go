@@ -18,6 +18,8 @@ const (
 type CurlError struct {
 	err    error
 	reason errType
+	// notSent is set for request errors that occurred before the request has been sent, e.g. connection errors
+	notSent bool
 }
 
 func (c *CurlError) Error() string {
@@ -63,6 +65,15 @@ func IsRequestError(err error) bool {
 	return false
 }
 
+// IsNotSentError returns whether the request has failed before it has been sent to the remote system
+func IsNotSentError(err error) bool {
+	var curlErr *CurlError
+	if errors.As(err, &curlErr) {
+		return curlErr.reason == RequestError && curlErr.notSent
+	}
+	return false
+}
+
 //go:generate moq  -pkg fake -out ./fake/curl_executor_mock.go . ICurlExecutor
 type ICurlExecutor interface {
 	Curl(curlCmd string) (string, error)
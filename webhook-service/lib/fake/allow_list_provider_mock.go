@@ -0,0 +1,30 @@
+package fake
+
+import "github.com/keptn/keptn/webhook-service/lib"
+
+type AllowListProviderMock struct {
+	GetFunc    func(project string) (*lib.AllowList, error)
+	SetFunc    func(project string, allowList lib.AllowList) error
+	DeleteFunc func(project string) error
+}
+
+func (r AllowListProviderMock) Get(project string) (*lib.AllowList, error) {
+	if r.GetFunc != nil {
+		return r.GetFunc(project)
+	}
+	panic("implement me")
+}
+
+func (r AllowListProviderMock) Set(project string, allowList lib.AllowList) error {
+	if r.SetFunc != nil {
+		return r.SetFunc(project, allowList)
+	}
+	panic("implement me")
+}
+
+func (r AllowListProviderMock) Delete(project string) error {
+	if r.DeleteFunc != nil {
+		return r.DeleteFunc(project)
+	}
+	panic("implement me")
+}
@@ -0,0 +1,409 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const defaultRequestTimeout = 60 * time.Second
+const defaultDialTimeout = 10 * time.Second
+const tlsHandshakeTimeout = 10 * time.Second
+
+// maxRedirects is the number of redirects the HTTP client of Go follows by default
+const maxRedirects = 10
+
+// maxResponseBodySize limits the size of response bodies that are read into the memory of the webhook service
+const maxResponseBodySize = 10 * 1024 * 1024
+
+//go:generate moq  -pkg fake -out ./fake/http_executor_mock.go . IHTTPExecutor
+type IHTTPExecutor interface {
+	Execute(request Request) (*HTTPResponse, error)
+}
+
+// HTTPResponse contains the status code, headers and body of the response to a webhook request
+type HTTPResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       string
+}
+
+// HTTPExecutor executes the requests of v1beta1 webhooks using the HTTP client of Go.
+// The addresses of all connections, including redirects, are checked against the deny list when they are dialed.
+// The targets of requests and redirects that are sent via a proxy are additionally checked before they are sent.
+// If an AllowListValidator is set, the targets of requests and redirects are also checked against the allow-list
+// of their project
+type HTTPExecutor struct {
+	denyListProvider   DenyListProvider
+	allowListValidator AllowListValidator
+	ipResolver         IPResolver
+	timeout            time.Duration
+	dialer             *net.Dialer
+	rateLimiters       map[rateLimiterKey]*rate.Limiter
+	rateLimitersLock   sync.Mutex
+}
+
+// rateLimiterKey identifies the rate limiter that is shared by all requests to the same host with the same rate limit
+type rateLimiterKey struct {
+	host      string
+	rateLimit RateLimit
+}
+
+type HTTPExecutorOption func(executor *HTTPExecutor)
+
+// allowListContextKey is the key of the allowListTarget of a request in the context of its connections, whose
+// addresses are checked against the allow-list of the project if they are established without a proxy
+type allowListContextKey struct{}
+
+// allowListTarget contains the project of a request and the validator of its allow-list
+type allowListTarget struct {
+	project   string
+	validator AllowListValidator
+}
+
+// WithAllowListValidator restricts the targets of requests to the allow-list of their project
+func WithAllowListValidator(allowListValidator AllowListValidator) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.allowListValidator = allowListValidator
+	}
+}
+
+// WithRequestTimeout sets the maximum duration of a request, including reading the response
+func WithRequestTimeout(timeout time.Duration) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.timeout = timeout
+	}
+}
+
+func NewHTTPExecutor(denyListProvider DenyListProvider, ipResolver IPResolver, opts ...HTTPExecutorOption) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		denyListProvider: denyListProvider,
+		ipResolver:       ipResolver,
+		timeout:          defaultRequestTimeout,
+		dialer:           &net.Dialer{Timeout: defaultDialTimeout},
+		rateLimiters:     map[rateLimiterKey]*rate.Limiter{},
+	}
+	for _, o := range opts {
+		o(executor)
+	}
+	return executor
+}
+
+func (e *HTTPExecutor) Execute(request Request) (*HTTPResponse, error) {
+	transport, err := e.newTransport(request)
+	if err != nil {
+		return nil, &CurlError{err: err, reason: InvalidCommandError}
+	}
+	defer transport.CloseIdleConnections()
+
+	timeout := e.timeout
+	if request.Timeout != "" {
+		// the duration has been verified when the webhook configuration was decoded
+		timeout = parseDurationOrDefault(request.Timeout, e.timeout)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, request.Method, request.URL, strings.NewReader(request.Payload))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not create request: %w", err), reason: InvalidCommandError}
+	}
+
+	if request.RateLimit != nil {
+		// waiting for the rate limit counts towards the timeout of the request
+		if err := e.waitForRateLimit(ctx, httpRequest.URL.Host, *request.RateLimit); err != nil {
+			return nil, &CurlError{err: fmt.Errorf("rate limit of host '%s' exceeded: %w", httpRequest.URL.Host, err), reason: RequestError, notSent: true}
+		}
+	}
+	for _, header := range request.Headers {
+		httpRequest.Header.Add(header.Key, header.Value)
+	}
+
+	proxyURL, err := transport.Proxy(httpRequest)
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("invalid proxy URL: %w", err), reason: InvalidCommandError}
+	}
+	// only the address of the proxy is checked when the connection is dialed, since the proxy resolves the target.
+	// The target is therefore checked before the request is sent
+	if proxyURL != nil {
+		if _, err := e.checkDenyList(httpRequest.URL.Host); err != nil {
+			return nil, newRequestExecutionError(&notSentError{err: err})
+		}
+	}
+
+	client := &http.Client{Transport: transport}
+	allowListValidator := e.getAllowListValidator(request)
+	if allowListValidator != nil {
+		if err := validateAllowList(allowListValidator, request); err != nil {
+			return nil, &CurlError{err: err, reason: DeniedURLError}
+		}
+		if proxyURL == nil {
+			httpRequest = httpRequest.WithContext(context.WithValue(ctx, allowListContextKey{}, allowListTarget{project: request.Project, validator: allowListValidator}))
+		}
+	}
+	client.CheckRedirect = func(redirect *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		if redirectProxyURL, err := transport.Proxy(redirect); err == nil && redirectProxyURL != nil {
+			if _, err := e.checkDenyList(redirect.URL.Host); err != nil {
+				return err
+			}
+		}
+		if allowListValidator != nil {
+			if err := allowListValidator.ValidateURL(request.Project, redirect.URL.String()); err != nil {
+				return &deniedAddressError{err: err}
+			}
+		}
+		return nil
+	}
+	resp, err := client.Do(httpRequest)
+	if err != nil {
+		return nil, newRequestExecutionError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not read response: %w", err), reason: RequestError}
+	}
+
+	response := &HTTPResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+		Body:       string(body),
+	}
+	for key := range resp.Header {
+		response.Headers[key] = resp.Header.Get(key)
+	}
+
+	// same as curl with --fail-with-body, responses with an error status fail the request
+	if resp.StatusCode >= http.StatusBadRequest {
+		return response, &CurlError{err: fmt.Errorf("request failed with status code %d.\nResponse: \n%s", resp.StatusCode, response.Body), reason: RequestError}
+	}
+	return response, nil
+}
+
+// getAllowListValidator returns the validator of the allow-list of the request, or nil if the targets of requests
+// are not restricted
+func (e *HTTPExecutor) getAllowListValidator(request Request) AllowListValidator {
+	if e.allowListValidator == nil || !request.RequireAllowList {
+		return e.allowListValidator
+	}
+	return e.allowListValidator.Required()
+}
+
+// validateAllowList checks the target of the request and, if the request defines one, its proxy against the
+// allow-list of the project. Proxies configured by the environment of the webhook service are not checked
+func validateAllowList(allowListValidator AllowListValidator, request Request) error {
+	if err := allowListValidator.ValidateURL(request.Project, request.URL); err != nil {
+		return err
+	}
+	if request.Proxy != "" {
+		return allowListValidator.ValidateURL(request.Project, request.Proxy)
+	}
+	return nil
+}
+
+// waitForRateLimit waits until the rate limit of the host allows the request to be sent, or fails immediately if the
+// request would have to wait beyond the deadline of the context
+func (e *HTTPExecutor) waitForRateLimit(ctx context.Context, host string, rateLimit RateLimit) error {
+	reservation := e.reserveRateLimit(host, rateLimit)
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		reservation.Cancel()
+		return fmt.Errorf("waiting for %s would exceed the timeout of the request", delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// reserveRateLimit reserves a request of the rate limiter of the host and rate limit. Rate limiters that have been
+// refilled completely are equivalent to new ones, and are removed, so that the rate limiters of hosts that are no
+// longer requested do not accumulate
+func (e *HTTPExecutor) reserveRateLimit(host string, rateLimit RateLimit) *rate.Reservation {
+	e.rateLimitersLock.Lock()
+	defer e.rateLimitersLock.Unlock()
+
+	now := time.Now()
+	for key, limiter := range e.rateLimiters {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(e.rateLimiters, key)
+		}
+	}
+
+	key := rateLimiterKey{host: host, rateLimit: RateLimit{RequestsPerMinute: rateLimit.RequestsPerMinute, Burst: rateLimit.GetBurst()}}
+	limiter, ok := e.rateLimiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(rateLimit.RequestsPerMinute)/time.Minute.Seconds()), rateLimit.GetBurst())
+		e.rateLimiters[key] = limiter
+	}
+	return limiter.ReserveN(now, 1)
+}
+
+func (e *HTTPExecutor) newTransport(request Request) (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         e.dialContext,
+		TLSHandshakeTimeout: tlsHandshakeTimeout,
+		ForceAttemptHTTP2:   true,
+	}
+
+	if request.Proxy != "" {
+		proxyURL, err := neturl.Parse(request.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if request.TLS != nil {
+		tlsConfig, err := newTLSConfig(*request.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport, nil
+}
+
+func newTLSConfig(options TLSOptions) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+
+	if options.CACert != "" {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(options.CACert)) {
+			return nil, errors.New("could not parse CA certificate")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if options.ClientCert != "" || options.ClientKey != "" {
+		clientCert, err := tls.X509KeyPair([]byte(options.ClientCert), []byte(options.ClientKey))
+		if err != nil {
+			// the error of X509KeyPair does not contain the key, and can therefore be returned
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// dialContext resolves the address and checks the IP addresses and their host names against the deny list.
+// The connection is then established to one of the checked IP addresses, so that the check can not be bypassed
+// by a DNS response that changes between the check and the connection. All errors are returned as notSentError
+func (e *HTTPExecutor) dialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, &notSentError{err: err}
+	}
+
+	ipAddresses, err := e.checkDenyList(address)
+	if err != nil {
+		return nil, &notSentError{err: err}
+	}
+
+	// the resolved addresses of direct connections are checked again, since they might differ from the addresses
+	// the target of the request resolved to when it was checked
+	if target, ok := ctx.Value(allowListContextKey{}).(allowListTarget); ok {
+		host, _, _ := net.SplitHostPort(address)
+		if err := target.validator.ValidateAddress(target.project, host, ipAddresses); err != nil {
+			return nil, &notSentError{err: &deniedAddressError{err: err}}
+		}
+	}
+
+	ips := make([]string, 0, len(ipAddresses))
+	for ip := range ipAddresses {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+
+	var dialErr error
+	for _, ip := range ips {
+		conn, err := e.dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+	return nil, &notSentError{err: dialErr}
+}
+
+// checkDenyList resolves the address and checks it, as well as the resolved IP addresses and their host names,
+// against the deny list. The address may omit the port
+func (e *HTTPExecutor) checkDenyList(address string) (AdrDomainNameMapping, error) {
+	ipAddresses, err := e.ipResolver.Resolve((&neturl.URL{Host: address}).String())
+	if err != nil {
+		return nil, err
+	}
+	if len(ipAddresses) == 0 {
+		return nil, fmt.Errorf("could not resolve address '%s'", address)
+	}
+
+	for _, deniedURL := range e.denyListProvider.Get() {
+		if strings.Contains(address, deniedURL) {
+			return nil, &deniedAddressError{err: fmt.Errorf("request contains denied URL '%s'", deniedURL)}
+		}
+		if err := validateIPDomain(ipAddresses, deniedURL); err != nil {
+			return nil, &deniedAddressError{err: err}
+		}
+	}
+	return ipAddresses, nil
+}
+
+// newRequestExecutionError returns the CurlError of a request that could not be executed, e.g. due to a denied address
+func newRequestExecutionError(err error) *CurlError {
+	var deniedErr *deniedAddressError
+	if errors.As(err, &deniedErr) {
+		return &CurlError{err: deniedErr, reason: DeniedURLError}
+	}
+	var notSentErr *notSentError
+	return &CurlError{err: fmt.Errorf("error during request execution: %w", err), reason: RequestError, notSent: errors.As(err, &notSentErr)}
+}
+
+// notSentError is the error of a request that has not been sent, e.g. since the connection could not be established
+type notSentError struct {
+	err error
+}
+
+func (n *notSentError) Error() string {
+	return n.err.Error()
+}
+
+func (n *notSentError) Unwrap() error {
+	return n.err
+}
+
+type deniedAddressError struct {
+	err error
+}
+
+func (d *deniedAddressError) Error() string {
+	return d.err.Error()
+}
@@ -0,0 +1,378 @@
+package lib_test
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHTTPExecutor returns an executor that resolves all host names to the loopback address
+func newTestHTTPExecutor(denyList ...string) *lib.HTTPExecutor {
+	return lib.NewHTTPExecutor(
+		fake.DenyListProviderMock{
+			GetDenyListFunc: func() []string {
+				return denyList
+			},
+		},
+		fake.IPResolverMock{
+			ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+				return lib.AdrDomainNameMapping{"127.0.0.1": []string{"my-host."}}, nil
+			},
+		},
+	)
+}
+
+func TestHTTPExecutor_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/my-path", r.URL.Path)
+		require.Equal(t, "my-token", r.Header.Get("x-token"))
+		require.Equal(t, `{"project":"my-project"}`, string(body))
+
+		w.Header().Set("x-request-id", "my-request")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer server.Close()
+
+	response, err := newTestHTTPExecutor().Execute(lib.Request{
+		URL:     server.URL + "/my-path",
+		Method:  http.MethodPost,
+		Headers: []lib.Header{{Key: "x-token", Value: "my-token"}},
+		Payload: `{"project":"my-project"}`,
+	})
+
+	require.Nil(t, err)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	require.Equal(t, "my-request", response.Headers["X-Request-Id"])
+	require.Equal(t, `{"id":"1"}`, response.Body)
+}
+
+func TestHTTPExecutor_Execute_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("something went wrong"))
+	}))
+	defer server.Close()
+
+	response, err := newTestHTTPExecutor().Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.Contains(t, err.Error(), "something went wrong")
+	require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+}
+
+func TestHTTPExecutor_Execute_ResolvedAddressIsUsed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Host, "my-webhook.example:"))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.Nil(t, err)
+
+	// the host name is never resolved by the HTTP client, but only by the IP resolver
+	response, err := newTestHTTPExecutor().Execute(lib.Request{URL: "http://my-webhook.example:" + serverURL.Port(), Method: http.MethodGet})
+
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+}
+
+func TestHTTPExecutor_Execute_DeniedIP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to denied address must not be sent")
+	}))
+	defer server.Close()
+
+	_, err := newTestHTTPExecutor("127.0.0.1").Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_DeniedHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to denied address must not be sent")
+	}))
+	defer server.Close()
+
+	// the address resolves to a host name that is denied
+	_, err := newTestHTTPExecutor("my-host").Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_DeniedRedirect(t *testing.T) {
+	deniedServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to denied address must not be sent")
+	}))
+	defer deniedServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, deniedServer.URL, http.StatusFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestHTTPExecutor(deniedServer.Listener.Addr().String()).Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_Proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "http://my-webhook.example/my-path", r.URL.String())
+		_, _ = w.Write([]byte("proxied"))
+	}))
+	defer proxy.Close()
+
+	response, err := newTestHTTPExecutor().Execute(lib.Request{URL: "http://my-webhook.example/my-path", Method: http.MethodGet, Proxy: proxy.URL})
+
+	require.Nil(t, err)
+	require.Equal(t, "proxied", response.Body)
+}
+
+func TestHTTPExecutor_Execute_ProxyDeniedTarget(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to denied address must not be sent")
+	}))
+	defer proxy.Close()
+
+	// the target is checked although the connection is established to the proxy
+	_, err := newTestHTTPExecutor("my-webhook.example").Execute(lib.Request{URL: "http://my-webhook.example/my-path", Method: http.MethodGet, Proxy: proxy.URL})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_ProxyDeniedRedirect(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "denied.example" {
+			t.Error("request to denied address must not be sent")
+		}
+		http.Redirect(w, r, "http://denied.example/my-path", http.StatusFound)
+	}))
+	defer proxy.Close()
+
+	_, err := newTestHTTPExecutor("denied.example").Execute(lib.Request{URL: "http://my-webhook.example/my-path", Method: http.MethodGet, Proxy: proxy.URL})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+
+	// the certificate of the test server is not trusted by default
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, TLS: &lib.TLSOptions{CACert: string(caCert)}})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+
+	response, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, TLS: &lib.TLSOptions{InsecureSkipVerify: true}})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+}
+
+func TestHTTPExecutor_Execute_InvalidClientCertificate(t *testing.T) {
+	_, err := newTestHTTPExecutor().Execute(lib.Request{
+		URL:    "https://my-webhook.example",
+		Method: http.MethodGet,
+		TLS:    &lib.TLSOptions{ClientCert: "invalid", ClientKey: "invalid"},
+	})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsInvalidCommandError(err))
+}
+
+func TestHTTPExecutor_Execute_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+
+	_, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "10ms"})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	// the request might have been received by the server
+	require.False(t, lib.IsNotSentError(err))
+
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "5s"})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+}
+
+func TestHTTPExecutor_Execute_ConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	_, err := newTestHTTPExecutor().Execute(lib.Request{URL: serverURL, Method: http.MethodGet})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.True(t, lib.IsNotSentError(err))
+}
+
+func TestHTTPExecutor_Execute_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+	request := lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "100ms", RateLimit: &lib.RateLimit{RequestsPerMinute: 1, Burst: 2}}
+
+	for i := 0; i < 2; i++ {
+		_, err := executor.Execute(request)
+		require.Nil(t, err)
+	}
+
+	// the next request would have to wait for about a minute, which exceeds its timeout
+	_, err := executor.Execute(request)
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.Contains(t, err.Error(), "rate limit")
+
+	// requests without a rate limit are not limited
+	_, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet})
+	require.Nil(t, err)
+	// requests with a different rate limit use their own limiter
+	_, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Timeout: "100ms", RateLimit: &lib.RateLimit{RequestsPerMinute: 2}})
+	require.Nil(t, err)
+	_, err = executor.Execute(request)
+	require.NotNil(t, err)
+}
+
+func newTestHTTPExecutorWithAllowList(allowList ...string) *lib.HTTPExecutor {
+	ipResolver := fake.IPResolverMock{
+		ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+			return lib.AdrDomainNameMapping{"127.0.0.1": []string{"my-host."}}, nil
+		},
+	}
+	allowListValidator := lib.NewAllowListValidator(
+		fake.AllowListProviderMock{
+			GetFunc: func(project string) (*lib.AllowList, error) {
+				return &lib.AllowList{Entries: allowList}, nil
+			},
+		},
+		ipResolver,
+		false,
+	)
+	return lib.NewHTTPExecutor(
+		fake.DenyListProviderMock{
+			GetDenyListFunc: func() []string {
+				return nil
+			},
+		},
+		ipResolver,
+		lib.WithAllowListValidator(allowListValidator),
+	)
+}
+
+func TestHTTPExecutor_Execute_AllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.Nil(t, err)
+
+	response, err := newTestHTTPExecutorWithAllowList("my-webhook.example").Execute(lib.Request{URL: "http://my-webhook.example:" + serverURL.Port(), Method: http.MethodGet, Project: "my-project"})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+
+	response, err = newTestHTTPExecutorWithAllowList("127.0.0.0/8").Execute(lib.Request{URL: "http://my-webhook.example:" + serverURL.Port(), Method: http.MethodGet, Project: "my-project"})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+}
+
+func TestHTTPExecutor_Execute_NotOnAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to address that is not on the allow-list must not be sent")
+	}))
+	defer server.Close()
+
+	_, err := newTestHTTPExecutorWithAllowList("api.example.com").Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Project: "my-project"})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+	require.Contains(t, err.Error(), "host '127.0.0.1' is not on the allow-list of project 'my-project'")
+}
+
+func TestHTTPExecutor_Execute_RequireAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ipResolver := fake.IPResolverMock{
+		ResolveIPAdressesFunc: func(curlURL string) (lib.AdrDomainNameMapping, error) {
+			return lib.AdrDomainNameMapping{"127.0.0.1": []string{"my-host."}}, nil
+		},
+	}
+	allowListValidator := lib.NewAllowListValidator(fake.AllowListProviderMock{
+		GetFunc: func(project string) (*lib.AllowList, error) {
+			return nil, nil
+		},
+	}, ipResolver, false)
+	executor := lib.NewHTTPExecutor(fake.DenyListProviderMock{
+		GetDenyListFunc: func() []string {
+			return nil
+		},
+	}, ipResolver, lib.WithAllowListValidator(allowListValidator))
+
+	// projects without an allow-list are not restricted, unless the request requires one
+	response, err := executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Project: "my-project"})
+	require.Nil(t, err)
+	require.Equal(t, "ok", response.Body)
+
+	_, err = executor.Execute(lib.Request{URL: server.URL, Method: http.MethodGet, Project: "my-project", RequireAllowList: true})
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+}
+
+func TestHTTPExecutor_Execute_RedirectNotOnAllowList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Host, "other-webhook.example:") {
+			t.Error("request to address that is not on the allow-list must not be sent")
+			return
+		}
+		http.Redirect(w, r, "http://other-webhook.example:"+strings.Split(r.Host, ":")[1], http.StatusFound)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.Nil(t, err)
+
+	_, err = newTestHTTPExecutorWithAllowList("my-webhook.example").Execute(lib.Request{URL: "http://my-webhook.example:" + serverURL.Port(), Method: http.MethodGet, Project: "my-project"})
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsDeniedURLError(err))
+	require.Contains(t, err.Error(), "host 'other-webhook.example' is not on the allow-list of project 'my-project'")
+}
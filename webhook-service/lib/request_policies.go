@@ -0,0 +1,105 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultRetryBackoff = time.Second
+const maxRetryAttempts = 10
+
+// maxRetryBackoff limits the delay between two attempts, and maxTotalRetryBackoff the delays of all attempts of a request
+const maxRetryBackoff = time.Minute
+const maxTotalRetryBackoff = 5 * time.Minute
+
+// defaultRetryStatusCodes are the status codes of responses that are retried if the retry policy does not define any
+var defaultRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryPolicy defines how often a request is executed if it fails with a connection error or one of the StatusCodes.
+// Other errors without a response, e.g. timeouts, are only retried if the request is Idempotent, since the remote
+// system might have received the request already. The delay between two attempts starts at Backoff and is doubled
+// after each attempt, up to maxRetryBackoff. Once the delays add up to maxTotalRetryBackoff, the request is not retried
+type RetryPolicy struct {
+	MaxAttempts int    `yaml:"maxAttempts"`
+	Backoff     string `yaml:"backoff,omitempty"`
+	StatusCodes []int  `yaml:"statusCodes,omitempty"`
+	Idempotent  bool   `yaml:"idempotent,omitempty"`
+}
+
+// RateLimit limits the number of requests per minute to the host of a request. The limit is shared by all
+// requests to the same host that define the same limit, and requests exceeding the limit wait until they are allowed
+type RateLimit struct {
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	Burst             int `yaml:"burst,omitempty"`
+}
+
+// GetBackoff returns the delay after the given failed attempt, starting at 1, or 0 if the delays of the previous
+// attempts already add up to maxTotalRetryBackoff
+func (p RetryPolicy) GetBackoff(attempt int) time.Duration {
+	// the duration has been verified when the webhook configuration was decoded
+	backoff := parseDurationOrDefault(p.Backoff, defaultRetryBackoff)
+	waited := time.Duration(0)
+	for i := 1; i < attempt; i++ {
+		waited += capDuration(backoff, maxRetryBackoff)
+		if backoff < maxRetryBackoff {
+			backoff *= 2
+		}
+	}
+	return capDuration(capDuration(backoff, maxRetryBackoff), maxTotalRetryBackoff-capDuration(waited, maxTotalRetryBackoff))
+}
+
+func capDuration(duration time.Duration, limit time.Duration) time.Duration {
+	if duration > limit {
+		return limit
+	}
+	return duration
+}
+
+// ShouldRetry returns whether a request that failed with the given response and error is executed again
+func (p RetryPolicy) ShouldRetry(response *HTTPResponse, err error) bool {
+	if err == nil || !IsRequestError(err) {
+		return false
+	}
+	// requests that failed without a response are only retried if they have not been sent, e.g. due to a connection
+	// error, unless sending them again is safe
+	if response == nil {
+		return p.Idempotent || IsNotSentError(err)
+	}
+	statusCodes := p.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = defaultRetryStatusCodes
+	}
+	for _, code := range statusCodes {
+		if code == response.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBurst returns the number of requests that can be executed at once before the rate limit applies
+func (l RateLimit) GetBurst() int {
+	if l.Burst <= 0 {
+		return 1
+	}
+	return l.Burst
+}
+
+func verifyRetryPolicy(retry RetryPolicy) error {
+	if retry.MaxAttempts < 1 || retry.MaxAttempts > maxRetryAttempts {
+		return fmt.Errorf(webhookConfInvalid+"retry maxAttempts must be between 1 and %d", maxRetryAttempts)
+	}
+	return verifyDuration("retry backoff", retry.Backoff)
+}
+
+func verifyRateLimit(rateLimit RateLimit) error {
+	if rateLimit.RequestsPerMinute < 1 {
+		return errors.New(webhookConfInvalid + "rate limit requestsPerMinute must be positive")
+	}
+	if rateLimit.Burst < 0 {
+		return errors.New(webhookConfInvalid + "rate limit burst must not be negative")
+	}
+	return nil
+}
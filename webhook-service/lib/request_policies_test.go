@@ -0,0 +1,96 @@
+package lib
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy_GetBackoff(t *testing.T) {
+	require.Equal(t, time.Second, RetryPolicy{MaxAttempts: 3}.GetBackoff(1))
+	require.Equal(t, 2*time.Second, RetryPolicy{MaxAttempts: 3}.GetBackoff(2))
+	require.Equal(t, 400*time.Millisecond, RetryPolicy{MaxAttempts: 3, Backoff: "100ms"}.GetBackoff(3))
+
+	// each delay is limited, as well as the sum of all delays
+	require.Equal(t, maxRetryBackoff, RetryPolicy{MaxAttempts: 10, Backoff: "10s"}.GetBackoff(4))
+	require.Equal(t, maxRetryBackoff, RetryPolicy{MaxAttempts: 10, Backoff: "2h"}.GetBackoff(1))
+	require.Equal(t, 50*time.Second, RetryPolicy{MaxAttempts: 10, Backoff: "10s"}.GetBackoff(7))
+	require.Equal(t, time.Duration(0), RetryPolicy{MaxAttempts: 10, Backoff: "10s"}.GetBackoff(8))
+	require.Equal(t, time.Duration(0), RetryPolicy{MaxAttempts: 10, Backoff: "2h"}.GetBackoff(9))
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	requestErr := NewCurlError(errors.New("request failed"), RequestError)
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		response *HTTPResponse
+		err      error
+		want     bool
+	}{
+		{
+			name:     "successful request",
+			response: &HTTPResponse{StatusCode: 200},
+			want:     false,
+		},
+		{
+			name: "connection error",
+			err:  &CurlError{err: errors.New("connection refused"), reason: RequestError, notSent: true},
+			want: true,
+		},
+		{
+			name: "error after the request has been sent",
+			err:  requestErr,
+			want: false,
+		},
+		{
+			name:   "error after an idempotent request has been sent",
+			policy: RetryPolicy{Idempotent: true},
+			err:    requestErr,
+			want:   true,
+		},
+		{
+			name: "denied URL",
+			err:  NewCurlError(errors.New("denied"), DeniedURLError),
+			want: false,
+		},
+		{
+			name:     "default status code",
+			response: &HTTPResponse{StatusCode: 502},
+			err:      requestErr,
+			want:     true,
+		},
+		{
+			name:     "status code not retried by default",
+			response: &HTTPResponse{StatusCode: 500},
+			err:      requestErr,
+			want:     false,
+		},
+		{
+			name:     "configured status code",
+			policy:   RetryPolicy{StatusCodes: []int{500}},
+			response: &HTTPResponse{StatusCode: 500},
+			err:      requestErr,
+			want:     true,
+		},
+		{
+			name:     "status code not configured",
+			policy:   RetryPolicy{StatusCodes: []int{500}},
+			response: &HTTPResponse{StatusCode: 502},
+			err:      requestErr,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.policy.ShouldRetry(tt.response, tt.err))
+		})
+	}
+}
+
+func TestRateLimit_GetBurst(t *testing.T) {
+	require.Equal(t, 1, RateLimit{RequestsPerMinute: 10}.GetBurst())
+	require.Equal(t, 5, RateLimit{RequestsPerMinute: 10, Burst: 5}.GetBurst())
+}
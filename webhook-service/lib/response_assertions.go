@@ -0,0 +1,238 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	AssertionResultWarning = "warning"
+	AssertionResultFail    = "fail"
+)
+
+// Assertions are checked against the response of a request. If one of them is not met, the result of the task is set to Result
+type Assertions struct {
+	StatusCodes []int             `yaml:"statusCodes,omitempty"`
+	Headers     []HeaderAssertion `yaml:"headers,omitempty"`
+	Body        []BodyAssertion   `yaml:"body,omitempty"`
+	Result      string            `yaml:"result,omitempty"`
+}
+
+// HeaderAssertion checks that a header of the response exists, and optionally that its value is equal to or matches the given value
+type HeaderAssertion struct {
+	Key     string `yaml:"key"`
+	Equals  string `yaml:"equals,omitempty"`
+	Matches string `yaml:"matches,omitempty"`
+}
+
+// BodyAssertion checks the body of the response. If JSONPath is set, the value at that path must exist and is compared,
+// otherwise the whole body is
+type BodyAssertion struct {
+	JSONPath string `yaml:"jsonPath,omitempty"`
+	Equals   string `yaml:"equals,omitempty"`
+	Matches  string `yaml:"matches,omitempty"`
+}
+
+// Output extracts the value at a JSONPath of the response body, or the value of a header, into a named output of the task
+type Output struct {
+	Name     string `yaml:"name"`
+	JSONPath string `yaml:"jsonPath,omitempty"`
+	Header   string `yaml:"header,omitempty"`
+}
+
+// GetResult returns the result of the task if one of the assertions is not met
+func (a Assertions) GetResult() string {
+	if a.Result == "" {
+		return AssertionResultFail
+	}
+	return a.Result
+}
+
+func (a Assertions) acceptsStatusCode(statusCode int) bool {
+	for _, code := range a.StatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// ChecksStatusCode returns whether the status code of responses is asserted, in which case responses with an error
+// status code do not fail the request, but are checked by the assertions
+func (a Assertions) ChecksStatusCode() bool {
+	return len(a.StatusCodes) > 0
+}
+
+// Check returns a description of each assertion that is not met by the response
+func (a Assertions) Check(response HTTPResponse) []string {
+	failures := []string{}
+	if len(a.StatusCodes) > 0 && !a.acceptsStatusCode(response.StatusCode) {
+		failures = append(failures, fmt.Sprintf("status code %d is not one of %v", response.StatusCode, a.StatusCodes))
+	}
+
+	for _, header := range a.Headers {
+		value, ok := getHeader(response, header.Key)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("header '%s' is missing", header.Key))
+			continue
+		}
+		if failure := checkValue(value, header.Equals, header.Matches); failure != "" {
+			failures = append(failures, fmt.Sprintf("header '%s' %s", header.Key, failure))
+		}
+	}
+
+	for _, body := range a.Body {
+		if body.JSONPath == "" {
+			if failure := checkValue(response.Body, body.Equals, body.Matches); failure != "" {
+				failures = append(failures, "body "+failure)
+			}
+			continue
+		}
+		value, err := getJSONPathValue(response.Body, body.JSONPath)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("value at '%s' can not be read: %s", body.JSONPath, err.Error()))
+			continue
+		}
+		if failure := checkValue(fmt.Sprint(value), body.Equals, body.Matches); failure != "" {
+			failures = append(failures, fmt.Sprintf("value at '%s' %s", body.JSONPath, failure))
+		}
+	}
+	return failures
+}
+
+// Extract returns the value of the output in the response
+func (o Output) Extract(response HTTPResponse) (interface{}, error) {
+	if o.Header != "" {
+		value, ok := getHeader(response, o.Header)
+		if !ok {
+			return nil, fmt.Errorf("header '%s' is missing", o.Header)
+		}
+		return value, nil
+	}
+	return getJSONPathValue(response.Body, o.JSONPath)
+}
+
+func verifyAssertions(assertions Assertions) error {
+	if assertions.Result != "" && assertions.Result != AssertionResultWarning && assertions.Result != AssertionResultFail {
+		return fmt.Errorf(webhookConfInvalid+"unsupported assertion result '%s'", assertions.Result)
+	}
+	for _, header := range assertions.Headers {
+		if header.Key == "" {
+			return errors.New(webhookConfInvalid + "assertion header key empty")
+		}
+		if err := verifyRegex(header.Matches); err != nil {
+			return err
+		}
+	}
+	for _, body := range assertions.Body {
+		if body.JSONPath == "" && body.Equals == "" && body.Matches == "" {
+			return errors.New(webhookConfInvalid + "body assertion empty")
+		}
+		if err := verifyJSONPath(body.JSONPath); err != nil {
+			return err
+		}
+		if err := verifyRegex(body.Matches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyOutputs(outputs []Output) error {
+	for _, output := range outputs {
+		if output.Name == "" {
+			return errors.New(webhookConfInvalid + "output name empty")
+		}
+		if (output.JSONPath == "") == (output.Header == "") {
+			return fmt.Errorf(webhookConfInvalid+"output '%s' must either define a jsonPath or a header", output.Name)
+		}
+		if err := verifyJSONPath(output.JSONPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func verifyRegex(expr string) error {
+	if expr == "" {
+		return nil
+	}
+	if _, err := regexp.Compile(expr); err != nil {
+		return fmt.Errorf(webhookConfInvalid+"invalid regular expression '%s': %s", expr, err.Error())
+	}
+	return nil
+}
+
+func verifyJSONPath(expr string) error {
+	if expr == "" {
+		return nil
+	}
+	if err := jsonpath.New("").Parse(normalizeJSONPath(expr)); err != nil {
+		return fmt.Errorf(webhookConfInvalid+"invalid jsonPath '%s': %s", expr, err.Error())
+	}
+	return nil
+}
+
+// checkValue returns a description of the failure if the value is not equal to or does not match the expected value
+func checkValue(value string, equals string, matches string) string {
+	if equals != "" && value != equals {
+		return fmt.Sprintf("is '%s' instead of '%s'", value, equals)
+	}
+	if matches != "" {
+		// the expression has been verified when the webhook configuration was decoded
+		if !regexp.MustCompile(matches).MatchString(value) {
+			return fmt.Sprintf("'%s' does not match '%s'", value, matches)
+		}
+	}
+	return ""
+}
+
+func getHeader(response HTTPResponse, key string) (string, bool) {
+	for k, v := range response.Headers {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// getJSONPathValue returns the value at the given path of a JSON document. If the path matches multiple values, they are returned as list
+func getJSONPathValue(body string, path string) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("response is not a valid JSON document: %w", err)
+	}
+
+	jp := jsonpath.New("")
+	if err := jp.Parse(normalizeJSONPath(path)); err != nil {
+		return nil, err
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// normalizeJSONPath allows paths to be defined without the enclosing braces of the kubectl JSONPath syntax, e.g. ".items[0].id"
+func normalizeJSONPath(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
@@ -0,0 +1,117 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testResponse = HTTPResponse{
+	StatusCode: 201,
+	Headers:    map[string]string{"Content-Type": "application/json", "Location": "/builds/1"},
+	Body:       `{"id": 1, "status": "running", "items": [{"name": "a"}, {"name": "b"}]}`,
+}
+
+func TestAssertions_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		assertions Assertions
+		want       []string
+	}{
+		{
+			name:       "no assertions",
+			assertions: Assertions{},
+			want:       []string{},
+		},
+		{
+			name: "all assertions met",
+			assertions: Assertions{
+				StatusCodes: []int{200, 201},
+				Headers:     []HeaderAssertion{{Key: "content-type", Matches: "^application/json"}, {Key: "Location"}},
+				Body: []BodyAssertion{
+					{JSONPath: ".status", Equals: "running"},
+					{JSONPath: "{.id}", Equals: "1"},
+					{JSONPath: "$.items[1].name", Matches: "^b$"},
+					{Matches: `"status":\s*"running"`},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "assertions not met",
+			assertions: Assertions{
+				StatusCodes: []int{200},
+				Headers:     []HeaderAssertion{{Key: "x-request-id"}, {Key: "Content-Type", Equals: "text/plain"}},
+				Body: []BodyAssertion{
+					{JSONPath: ".status", Equals: "finished"},
+					{JSONPath: ".result"},
+					{Matches: "error"},
+				},
+			},
+			want: []string{
+				"status code 201 is not one of [200]",
+				"header 'x-request-id' is missing",
+				"header 'Content-Type' is 'application/json' instead of 'text/plain'",
+				"value at '.status' is 'running' instead of 'finished'",
+				"value at '.result' can not be read: result is not found",
+				`body '{"id": 1, "status": "running", "items": [{"name": "a"}, {"name": "b"}]}' does not match 'error'`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.assertions.Check(testResponse))
+		})
+	}
+}
+
+func TestOutput_Extract(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  Output
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "value of body",
+			output: Output{Name: "id", JSONPath: ".id"},
+			want:   float64(1),
+		},
+		{
+			name:   "multiple values of body",
+			output: Output{Name: "names", JSONPath: ".items[*].name"},
+			want:   []interface{}{"a", "b"},
+		},
+		{
+			name:   "value of header",
+			output: Output{Name: "location", Header: "location"},
+			want:   "/builds/1",
+		},
+		{
+			name:    "missing value",
+			output:  Output{Name: "result", JSONPath: ".result"},
+			wantErr: true,
+		},
+		{
+			name:    "missing header",
+			output:  Output{Name: "requestID", Header: "x-request-id"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.output.Extract(testResponse)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOutput_Extract_InvalidJSON(t *testing.T) {
+	_, err := Output{Name: "id", JSONPath: ".id"}.Extract(HTTPResponse{Body: "not a JSON"})
+	require.NotNil(t, err)
+}
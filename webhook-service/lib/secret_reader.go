@@ -3,10 +3,35 @@ package lib
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+// Sources of the secrets referenced by the envFrom property of webhooks
+const (
+	SecretSourceK8s      = "k8s"
+	SecretSourceFile     = "file"
+	SecretSourceEnv      = "env"
+	SecretSourceK8sScope = "k8s-scope"
+)
+
+// SecretEnvVarPrefix is the prefix of env vars that can be read as secrets of the source 'env'
+const SecretEnvVarPrefix = "WEBHOOK_SECRET_"
+
+const secretServiceName = "keptn-secret-service"
+const secretScopeLabel = "app.kubernetes.io/scope"
+
+// secretPathElementPattern matches names and keys of secrets that can be used as a single element of a file path
+var secretPathElementPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`)
+
+var envVarNameReplacer = regexp.MustCompile(`[^A-Z0-9_]`)
+
 //go:generate moq  -pkg fake -out ./fake/secret_reader_mock.go . ISecretReader
 type ISecretReader interface {
 	ReadSecret(name, key string) (string, error)
@@ -26,8 +51,104 @@ func (sr *K8sSecretReater) ReadSecret(name, key string) (string, error) {
 		return "", err
 	}
 	// only allow reading from secrets that are managed by Keptn's secret-service
-	if secret.Labels["app.kubernetes.io/managed-by"] != "keptn-secret-service" {
+	if secret.Labels["app.kubernetes.io/managed-by"] != secretServiceName {
 		return "", errors.New("only secrets managed by Keptn's secret-service can be referenced")
 	}
 	return string(secret.Data[key]), nil
 }
+
+// ScopedSecretReader reads the Kubernetes secrets managed by Keptn's secret-service that belong to one of the given
+// scopes. Like K8sSecretReader, it requires the permission to get Kubernetes secrets, since the API of the
+// secret-service does not return the values of secrets. The secret-service only grants this permission to the service
+// account of the webhook service for the scope keptn-webhook-service; secrets of other scopes need an additional
+// RoleBinding of the service account to the Role of the capability of that scope
+type ScopedSecretReader struct {
+	k8sClient kubernetes.Interface
+	scopes    []string
+}
+
+func NewScopedSecretReader(k8sClient kubernetes.Interface, scopes []string) *ScopedSecretReader {
+	return &ScopedSecretReader{k8sClient: k8sClient, scopes: scopes}
+}
+
+func (sr *ScopedSecretReader) ReadSecret(name, key string) (string, error) {
+	secret, err := sr.k8sClient.CoreV1().Secrets(GetNamespaceFromEnvVar()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	if secret.Labels["app.kubernetes.io/managed-by"] != secretServiceName {
+		return "", errors.New("only secrets managed by Keptn's secret-service can be referenced")
+	}
+	if !sr.isAllowedScope(secret.Labels[secretScopeLabel]) {
+		return "", fmt.Errorf("secret %s does not belong to any of the scopes %s", name, strings.Join(sr.scopes, ", "))
+	}
+	value, ok := secret.Data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s does not contain key %s", name, key)
+	}
+	return string(value), nil
+}
+
+func (sr *ScopedSecretReader) isAllowedScope(scope string) bool {
+	for _, allowed := range sr.scopes {
+		if allowed == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// FileSecretReader reads secrets from files that are mounted into the webhook service, e.g. by the Secrets Store CSI
+// driver. The key of a secret is read from the file <dir>/<name>/<key>
+type FileSecretReader struct {
+	dir string
+}
+
+func NewFileSecretReader(dir string) *FileSecretReader {
+	return &FileSecretReader{dir: dir}
+}
+
+func (sr *FileSecretReader) ReadSecret(name, key string) (string, error) {
+	// names and keys must not be able to reference files outside the secrets directory
+	if !secretPathElementPattern.MatchString(name) || !secretPathElementPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid name or key of secret %s.%s", name, key)
+	}
+	value, err := os.ReadFile(filepath.Join(sr.dir, name, key))
+	if err != nil {
+		return "", err
+	}
+	// files that are created manually usually end with a newline, which is not part of the secret
+	return strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r"), nil
+}
+
+// EnvSecretReader reads secrets from env vars of the webhook service. The key of a secret is read from the env var
+// WEBHOOK_SECRET_<NAME>_<KEY>, where name and key are upper case and all other characters than letters and digits
+// are replaced by underscores. Other env vars of the webhook service can not be read
+type EnvSecretReader struct{}
+
+func NewEnvSecretReader() *EnvSecretReader {
+	return &EnvSecretReader{}
+}
+
+func (sr *EnvSecretReader) ReadSecret(name, key string) (string, error) {
+	envVarName := SecretEnvVarName(name, key)
+	value, ok := os.LookupEnv(envVarName)
+	if !ok {
+		return "", fmt.Errorf("env var %s is not set", envVarName)
+	}
+	return value, nil
+}
+
+// SecretEnvVarName returns the name of the env var that contains the key of a secret of the source 'env'
+func SecretEnvVarName(name, key string) string {
+	return SecretEnvVarPrefix + envVarNameReplacer.ReplaceAllString(strings.ToUpper(name+"_"+key), "_")
+}
+
+func isSecretSourceSupported(source string) bool {
+	switch source {
+	case SecretSourceK8s, SecretSourceFile, SecretSourceEnv, SecretSourceK8sScope:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,6 +1,8 @@
 package lib_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
@@ -54,3 +56,75 @@ func getK8sSecret(labels map[string]string) *corev1.Secret {
 		Type: corev1.SecretTypeOpaque,
 	}
 }
+
+func TestScopedSecretReader_ReadSecret(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	secretReader := lib.NewScopedSecretReader(fake.NewSimpleClientset(
+		getK8sSecret(map[string]string{
+			"app.kubernetes.io/managed-by": "keptn-secret-service",
+			"app.kubernetes.io/scope":      "keptn-webhook-service",
+		}),
+	), []string{"keptn-webhook-service"})
+
+	secret, err := secretReader.ReadSecret("my-secret", "foo")
+
+	require.Nil(t, err)
+	require.Equal(t, "bar", secret)
+
+	secret, err = secretReader.ReadSecret("my-secret", "missing-key")
+
+	require.EqualError(t, err, "secret my-secret does not contain key missing-key")
+	require.Empty(t, secret)
+}
+
+func TestScopedSecretReader_ReadSecretOfOtherScope(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "keptn")
+	secretReader := lib.NewScopedSecretReader(fake.NewSimpleClientset(
+		getK8sSecret(map[string]string{
+			"app.kubernetes.io/managed-by": "keptn-secret-service",
+			"app.kubernetes.io/scope":      "dynatrace-service",
+		}),
+	), []string{"keptn-webhook-service"})
+
+	secret, err := secretReader.ReadSecret("my-secret", "foo")
+
+	require.EqualError(t, err, "secret my-secret does not belong to any of the scopes keptn-webhook-service")
+	require.Empty(t, secret)
+}
+
+func TestFileSecretReader_ReadSecret(t *testing.T) {
+	dir := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(dir, "my-secret"), 0700))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "my-secret", "foo"), []byte("bar\n"), 0600))
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "outside"), []byte("bar"), 0600))
+	secretReader := lib.NewFileSecretReader(dir)
+
+	secret, err := secretReader.ReadSecret("my-secret", "foo")
+
+	require.Nil(t, err)
+	require.Equal(t, "bar", secret)
+
+	_, err = secretReader.ReadSecret("my-secret", "missing-key")
+	require.NotNil(t, err)
+
+	_, err = secretReader.ReadSecret("..", "outside")
+	require.EqualError(t, err, "invalid name or key of secret ...outside")
+
+	_, err = secretReader.ReadSecret("my-secret", "../../outside")
+	require.NotNil(t, err)
+}
+
+func TestEnvSecretReader_ReadSecret(t *testing.T) {
+	t.Setenv("WEBHOOK_SECRET_MY_SECRET_API_TOKEN", "bar")
+	t.Setenv("POD_NAMESPACE", "keptn")
+	secretReader := lib.NewEnvSecretReader()
+
+	secret, err := secretReader.ReadSecret("my-secret", "api.token")
+
+	require.Nil(t, err)
+	require.Equal(t, "bar", secret)
+
+	// only env vars with the prefix of secrets can be read
+	_, err = secretReader.ReadSecret("pod", "namespace")
+	require.EqualError(t, err, "env var WEBHOOK_SECRET_POD_NAMESPACE is not set")
+}
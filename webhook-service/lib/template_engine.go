@@ -2,18 +2,29 @@ package lib
 
 import (
 	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"text/template"
 )
 
+// templateActionPattern matches the actions of a template, i.e. the expressions within {{ and }}
+var templateActionPattern = regexp.MustCompile(`(?s)\{\{.*?\}\}`)
+
+// fieldChainPattern matches chains of fields that are not preceded by a variable, function call or index, e.g. ".responses.0.body"
+var fieldChainPattern = regexp.MustCompile(`(^|[^\w$)\]])(\.[A-Za-z_]\w*(?:\.\w+)*)`)
+
 //go:generate moq  -pkg fake -out ./fake/template_engine_mock.go . ITemplateEngine
 type ITemplateEngine interface {
 	ParseTemplate(data interface{}, templateStr string) (string, error)
 }
 
+// TemplateEngine renders the templates of webhook requests, which can use the functions of templateFunctions
 type TemplateEngine struct{}
 
 func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (string, error) {
-	tmpl, err := template.New("").Option("missingkey=error").Parse(templateStr)
+	tmpl, err := template.New("").Option("missingkey=error").Funcs(templateFunctions).Parse(resolveIndexedFields(templateStr))
 	if err != nil {
 		return "", err
 	}
@@ -23,3 +34,26 @@ func (t *TemplateEngine) ParseTemplate(data interface{}, templateStr string) (st
 	}
 	return tpl.String(), nil
 }
+
+// resolveIndexedFields allows elements of lists to be referenced like fields, e.g. {{.responses.0.body.id}}, which is
+// not supported by the template syntax, by replacing them with the index function, e.g. {{(index .responses 0).body.id}}
+func resolveIndexedFields(templateStr string) string {
+	return templateActionPattern.ReplaceAllStringFunc(templateStr, func(action string) string {
+		return fieldChainPattern.ReplaceAllStringFunc(action, func(match string) string {
+			submatches := fieldChainPattern.FindStringSubmatch(match)
+			return submatches[1] + resolveIndexedFieldChain(submatches[2])
+		})
+	})
+}
+
+func resolveIndexedFieldChain(chain string) string {
+	expression := ""
+	for _, segment := range strings.Split(strings.TrimPrefix(chain, "."), ".") {
+		if _, err := strconv.Atoi(segment); err == nil {
+			expression = fmt.Sprintf("(index %s %s)", expression, segment)
+			continue
+		}
+		expression += "." + segment
+	}
+	return expression
+}
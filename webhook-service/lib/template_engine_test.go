@@ -75,6 +75,37 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 			wantErr: true,
 			errMsg:  ".env.barz",
 		},
+		{
+			name: "element of list",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{
+						map[string]interface{}{
+							"body": map[string]interface{}{
+								"id":    "ticket-1",
+								"items": []interface{}{"a", "b"},
+							},
+						},
+					},
+				},
+				templateStr: "{{.responses.0.body.id}} {{ .responses.0.body.items.1 }} {{ printf \"%.1f\" 1.25 }}",
+			},
+			want:    "ticket-1 b 1.2",
+			wantErr: false,
+			errMsg:  "",
+		},
+		{
+			name: "element of list out of range",
+			args: args{
+				data: map[string]interface{}{
+					"responses": []interface{}{},
+				},
+				templateStr: "{{.responses.0.body.id}}",
+			},
+			want:    "",
+			wantErr: true,
+			errMsg:  "index out of range",
+		},
 	}
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
@@ -93,3 +124,63 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTemplateEngine_ParseTemplate_Functions(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"project": "my project",
+			"message": "line \"1\"\nline 2",
+			"labels":  map[string]interface{}{"owner": "team-a"},
+			"tags":    []interface{}{"a", "b", 3},
+			"empty":   "",
+			"time":    "2022-03-01T13:37:00.000Z",
+			"epoch":   float64(1646141820),
+		},
+		"env": map[string]string{"token": "dG9rZW4="},
+	}
+
+	tests := []struct {
+		name        string
+		templateStr string
+		want        string
+		errMsg      string
+	}{
+		{name: "jsonEscape", templateStr: `{"text": "{{jsonEscape .data.message}}"}`, want: `{"text": "line \"1\"\nline 2"}`},
+		{name: "quote", templateStr: `{"text": {{.data.message | quote}}}`, want: `{"text": "line \"1\"\nline 2"}`},
+		{name: "toJson", templateStr: `{{toJson .data.labels}} {{toJson .data.tags}}`, want: `{"owner":"team-a"} ["a","b",3]`},
+		{name: "toPrettyJson", templateStr: `{{toPrettyJson .data.labels}}`, want: "{\n  \"owner\": \"team-a\"\n}"},
+		{name: "urlEncode", templateStr: `https://local?q={{urlEncode .data.project}}&p={{urlPathEncode .data.project}}`, want: "https://local?q=my+project&p=my%20project"},
+		{name: "base64", templateStr: `{{b64enc "token"}} {{b64dec .env.token}}`, want: "dG9rZW4= token"},
+		{name: "invalid base64", templateStr: `{{b64dec .data.project}}`, errMsg: "could not decode base64 value"},
+		{name: "date of string", templateStr: `{{date "2006-01-02 15:04" .data.time}}`, want: "2022-03-01 13:37"},
+		{name: "date of epoch", templateStr: `{{date "2006-01-02" .data.epoch}}`, want: "2022-03-01"},
+		{name: "invalid date", templateStr: `{{date "2006-01-02" .data.project}}`, errMsg: "could not parse date 'my project'"},
+		{name: "string functions", templateStr: `{{upper .data.project}} {{title .data.project}} {{.data.project | replace " " "-"}} {{truncate 2 .data.project}} {{trimPrefix "my " .data.project}}`, want: "MY PROJECT My Project my-project my project"},
+		{name: "split and join", templateStr: `{{join "," .data.tags}} {{split " " .data.project | join "_"}}`, want: "a,b,3 my_project"},
+		{name: "contains", templateStr: `{{if contains "proj" .data.project}}yes{{end}}`, want: "yes"},
+		{name: "default", templateStr: `{{.data.empty | default "none"}} {{.data.project | default "none"}}`, want: "none my project"},
+		{name: "get optional value", templateStr: `{{get .data "missing" | default "none"}} {{get .env "token"}} {{hasKey .data.labels "owner"}}`, want: "none dG9rZW4= true"},
+		{name: "coalesce and ternary", templateStr: `{{coalesce .data.empty .data.project}} {{empty .data.empty | ternary "empty" "set"}}`, want: "my project empty"},
+		{name: "functions with indexed fields", templateStr: `{{upper .data.tags.0}}`, want: "A"},
+		{name: "env function is not available", templateStr: `{{env "HOME"}}`, errMsg: `function "env" not defined`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := &lib.TemplateEngine{}
+			got, err := engine.ParseTemplate(data, tt.templateStr)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("ParseTemplate() error = %v, want %v", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ParseTemplate() unexpected error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseTemplate() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package lib
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// templateFunctions are the functions available in the templates of webhook requests, in addition to the predefined
+// functions of text/template. The functions only transform their arguments, none of them has access to the
+// filesystem or the environment of the webhook service
+var templateFunctions = template.FuncMap{
+	// escaping
+	"jsonEscape":    jsonEscape,
+	"urlEncode":     url.QueryEscape,
+	"urlPathEncode": url.PathEscape,
+	"b64enc":        b64enc,
+	"b64dec":        b64dec,
+	"toJson":        toJSON,
+	"toPrettyJson":  toPrettyJSON,
+	"quote":         quote,
+
+	// dates
+	"now":  now,
+	"date": formatDate,
+
+	// strings
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"title":      title,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old string, new string, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr string, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix string, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix string, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      func(sep string, s string) []string { return strings.Split(s, sep) },
+	"join":       join,
+	"truncate":   truncate,
+
+	// conditions
+	"default":  defaultValue,
+	"empty":    isEmpty,
+	"coalesce": coalesce,
+	"ternary":  ternary,
+	"get":      get,
+	"hasKey":   hasKey,
+}
+
+// jsonEscape escapes the value so that it can be used within a string of a JSON payload, e.g. "{{jsonEscape .data.message}}"
+func jsonEscape(value interface{}) (string, error) {
+	escaped, err := json.Marshal(toString(value))
+	if err != nil {
+		return "", err
+	}
+	return string(escaped[1 : len(escaped)-1]), nil
+}
+
+func b64enc(value interface{}) string {
+	return base64.StdEncoding.EncodeToString([]byte(toString(value)))
+}
+
+func b64dec(value interface{}) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(toString(value))
+	if err != nil {
+		return "", fmt.Errorf("could not decode base64 value: %w", err)
+	}
+	return string(decoded), nil
+}
+
+func toJSON(value interface{}) (string, error) {
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("could not convert value to JSON: %w", err)
+	}
+	return string(marshalled), nil
+}
+
+func toPrettyJSON(value interface{}) (string, error) {
+	marshalled, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not convert value to JSON: %w", err)
+	}
+	return string(marshalled), nil
+}
+
+// quote returns the value as a quoted JSON string
+func quote(value interface{}) (string, error) {
+	quoted, err := json.Marshal(toString(value))
+	if err != nil {
+		return "", err
+	}
+	return string(quoted), nil
+}
+
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// formatDate formats the date with the given Go layout, e.g. "2006-01-02". The date can be a time, an RFC 3339
+// string, as used by the time of events, or the seconds since the Unix epoch
+func formatDate(layout string, date interface{}) (string, error) {
+	switch d := date.(type) {
+	case time.Time:
+		return d.Format(layout), nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, d)
+		if err != nil {
+			return "", fmt.Errorf("could not parse date '%s': %w", d, err)
+		}
+		return parsed.Format(layout), nil
+	case int:
+		return time.Unix(int64(d), 0).UTC().Format(layout), nil
+	case int64:
+		return time.Unix(d, 0).UTC().Format(layout), nil
+	case float64:
+		return time.Unix(int64(d), 0).UTC().Format(layout), nil
+	default:
+		return "", fmt.Errorf("unsupported date '%v'", date)
+	}
+}
+
+func title(s string) string {
+	runes := []rune(s)
+	for i := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) {
+			runes[i] = unicode.ToUpper(runes[i])
+		}
+	}
+	return string(runes)
+}
+
+func join(sep string, list interface{}) (string, error) {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return "", fmt.Errorf("can not join value of type %T", list)
+	}
+	elements := make([]string, value.Len())
+	for i := range elements {
+		elements[i] = toString(value.Index(i).Interface())
+	}
+	return strings.Join(elements, sep), nil
+}
+
+// truncate shortens the string to at most length characters
+func truncate(length int, s string) string {
+	runes := []rune(s)
+	if length < 0 || len(runes) <= length {
+		return s
+	}
+	return string(runes[:length])
+}
+
+// defaultValue returns the given value, or defaultVal if the value is empty, e.g. {{.data.message | default "none"}}
+func defaultValue(defaultVal interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || isEmpty(given[0]) {
+		return defaultVal
+	}
+	return given[0]
+}
+
+// isEmpty returns whether the value is nil, or the zero value or an empty collection of its type
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+// coalesce returns the first value that is not empty
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+func ternary(whenTrue interface{}, whenFalse interface{}, condition bool) interface{} {
+	if condition {
+		return whenTrue
+	}
+	return whenFalse
+}
+
+// get returns the value of the key, or an empty string if the map does not contain it. Unlike fields, which cause
+// an error if they are missing, it can be used for optional values, e.g. {{get .data "message" | default "none"}}
+func get(m interface{}, key string) (interface{}, error) {
+	value, ok, err := lookup(m, key)
+	if err != nil || !ok {
+		return "", err
+	}
+	return value, nil
+}
+
+func hasKey(m interface{}, key string) (bool, error) {
+	_, ok, err := lookup(m, key)
+	return ok, err
+}
+
+func lookup(m interface{}, key string) (interface{}, bool, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false, fmt.Errorf("can not look up key '%s' in value of type %T", key, m)
+	}
+	value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+	if !value.IsValid() {
+		return nil, false, nil
+	}
+	return value.Interface(), true, nil
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
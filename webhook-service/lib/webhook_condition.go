@@ -0,0 +1,80 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	WhenOnSkipIgnore = "ignore"
+	WhenOnSkipPass   = "pass"
+)
+
+// When restricts the events a webhook is executed for. The webhook is only executed if all Conditions are met by the
+// event, and Expression, a template rendered with the event, evaluates to "true". Otherwise, the event is handled
+// as defined by OnSkip
+type When struct {
+	Conditions []BodyAssertion `yaml:"conditions,omitempty"`
+	Expression string          `yaml:"expression,omitempty"`
+	OnSkip     string          `yaml:"onSkip,omitempty"`
+}
+
+// GetOnSkip returns how an event is handled if the webhook is not executed for it
+func (w When) GetOnSkip() string {
+	if w.OnSkip == "" {
+		return WhenOnSkipIgnore
+	}
+	return w.OnSkip
+}
+
+// Check returns a description of each condition that is not met by the event, which is given as the data of an
+// EventDataAdapter. The Expression is not checked, since it needs to be rendered by the template engine
+func (w When) Check(eventData map[string]interface{}) []string {
+	failures := []string{}
+	if len(w.Conditions) == 0 {
+		return failures
+	}
+	event, err := json.Marshal(eventData)
+	if err != nil {
+		return append(failures, fmt.Sprintf("event can not be checked: %s", err.Error()))
+	}
+	for _, condition := range w.Conditions {
+		if condition.JSONPath == "" {
+			if failure := checkValue(string(event), condition.Equals, condition.Matches); failure != "" {
+				failures = append(failures, "event "+failure)
+			}
+			continue
+		}
+		value, err := getJSONPathValue(string(event), condition.JSONPath)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("value at '%s' can not be read: %s", condition.JSONPath, err.Error()))
+			continue
+		}
+		if failure := checkValue(fmt.Sprint(value), condition.Equals, condition.Matches); failure != "" {
+			failures = append(failures, fmt.Sprintf("value at '%s' %s", condition.JSONPath, failure))
+		}
+	}
+	return failures
+}
+
+func verifyWhen(when When) error {
+	if len(when.Conditions) == 0 && when.Expression == "" {
+		return errors.New(webhookConfInvalid + "'when' must define conditions or an expression")
+	}
+	if when.OnSkip != "" && when.OnSkip != WhenOnSkipIgnore && when.OnSkip != WhenOnSkipPass {
+		return fmt.Errorf(webhookConfInvalid+"unsupported onSkip '%s' of 'when'", when.OnSkip)
+	}
+	for _, condition := range when.Conditions {
+		if condition.JSONPath == "" && condition.Equals == "" && condition.Matches == "" {
+			return errors.New(webhookConfInvalid + "condition of 'when' empty")
+		}
+		if err := verifyJSONPath(condition.JSONPath); err != nil {
+			return err
+		}
+		if err := verifyRegex(condition.Matches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
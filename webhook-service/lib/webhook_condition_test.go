@@ -0,0 +1,74 @@
+package lib_test
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhen_Check(t *testing.T) {
+	eventData := map[string]interface{}{
+		"type": "sh.keptn.event.evaluation.finished",
+		"data": map[string]interface{}{
+			"project": "myproject",
+			"result":  "fail",
+			"labels": map[string]interface{}{
+				"team": "payments-backend",
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []lib.BodyAssertion
+		want       []string
+	}{
+		{
+			name:       "no conditions",
+			conditions: nil,
+			want:       []string{},
+		},
+		{
+			name: "all conditions met",
+			conditions: []lib.BodyAssertion{
+				{JSONPath: ".data.result", Equals: "fail"},
+				{JSONPath: ".data.labels.team", Matches: "^payments"},
+				{JSONPath: ".data.project"},
+			},
+			want: []string{},
+		},
+		{
+			name: "value not equal",
+			conditions: []lib.BodyAssertion{
+				{JSONPath: ".data.result", Equals: "pass"},
+			},
+			want: []string{"value at '.data.result' is 'fail' instead of 'pass'"},
+		},
+		{
+			name: "value does not match",
+			conditions: []lib.BodyAssertion{
+				{JSONPath: ".type", Matches: `\.triggered$`},
+			},
+			want: []string{`value at '.type' 'sh.keptn.event.evaluation.finished' does not match '\.triggered$'`},
+		},
+		{
+			name: "missing label",
+			conditions: []lib.BodyAssertion{
+				{JSONPath: ".data.labels.owner"},
+			},
+			want: []string{"value at '.data.labels.owner' can not be read: owner is not found"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			when := lib.When{Conditions: tt.conditions}
+			require.Equal(t, tt.want, when.Check(eventData))
+		})
+	}
+}
+
+func TestWhen_GetOnSkip(t *testing.T) {
+	require.Equal(t, lib.WhenOnSkipIgnore, lib.When{}.GetOnSkip())
+	require.Equal(t, lib.WhenOnSkipPass, lib.When{OnSkip: lib.WhenOnSkipPass}.GetOnSkip())
+}
@@ -3,6 +3,7 @@ package lib
 import (
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/mitchellh/mapstructure"
 	"gopkg.in/yaml.v3"
@@ -31,6 +32,8 @@ type Webhook struct {
 	SendStarted    *bool         `yaml:"sendStarted,omitempty"`
 	EnvFrom        []EnvFrom     `yaml:"envFrom"`
 	Requests       []interface{} `yaml:"requests"`
+	Completion     *Completion   `yaml:"completion,omitempty"`
+	When           *When         `yaml:"when,omitempty"`
 }
 
 type EnvFrom struct {
@@ -39,21 +42,53 @@ type EnvFrom struct {
 }
 
 type Request struct {
-	URL     string   `yaml:"url"`
-	Method  string   `yaml:"method"`
-	Headers []Header `yaml:"headers,omitempty"`
-	Payload string   `yaml:"payload,omitempty"`
-	Options string   `yaml:"options,omitempty"`
+	Name       string       `yaml:"name,omitempty"`
+	URL        string       `yaml:"url"`
+	Method     string       `yaml:"method"`
+	Headers    []Header     `yaml:"headers,omitempty"`
+	Payload    string       `yaml:"payload,omitempty"`
+	Options    string       `yaml:"options,omitempty"`
+	Proxy      string       `yaml:"proxy,omitempty"`
+	TLS        *TLSOptions  `yaml:"tls,omitempty"`
+	Assertions *Assertions  `yaml:"assertions,omitempty"`
+	Outputs    []Output     `yaml:"outputs,omitempty"`
+	Retry      *RetryPolicy `yaml:"retry,omitempty"`
+	Timeout    string       `yaml:"timeout,omitempty"`
+	RateLimit  *RateLimit   `yaml:"rateLimit,omitempty"`
+	// Project is set by the webhook service to the project of the event, and determines the allow-list of the request
+	Project string `yaml:"-" mapstructure:"-"`
+	// RequireAllowList is set by the webhook service for requests whose project is not verified, e.g. requests of
+	// tested webhooks, which are denied if the project does not have an allow-list
+	RequireAllowList bool `yaml:"-" mapstructure:"-"`
+}
+
+// TLSOptions configure the TLS connection of a request. Certificates and keys are PEM encoded, and are
+// usually referenced from secrets, e.g. "{{.env.clientCert}}"
+type TLSOptions struct {
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty"`
+	CACert             string `yaml:"caCert,omitempty"`
+	ClientCert         string `yaml:"clientCert,omitempty"`
+	ClientKey          string `yaml:"clientKey,omitempty"`
 }
 
 type Header struct {
-	Key   string `yaml:"key"`
-	Value string `yaml:"value"`
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
 }
 
+// WebHookSecretRef references the key of a secret. The Source of the secret defaults to SecretSourceK8s
 type WebHookSecretRef struct {
-	Key  string `yaml:"key"`
-	Name string `yaml:"name"`
+	Key    string `yaml:"key"`
+	Name   string `yaml:"name"`
+	Source string `yaml:"source,omitempty"`
+}
+
+// GetSource returns the source the secret is read from
+func (r WebHookSecretRef) GetSource() string {
+	if r.Source == "" {
+		return SecretSourceK8s
+	}
+	return r.Source
 }
 
 const webhookConfInvalid = "Webhook configuration invalid: "
@@ -62,6 +97,9 @@ const alphaApiVersion = "webhookconfig.keptn.sh/v1alpha1"
 
 var supportedCurlMethods = [4]string{"POST", "PUT", "GET", "HEAD"}
 
+// requestNamePattern ensures that the responses of named requests can be referenced in templates, e.g. {{.steps.createTicket.body.id}}
+var requestNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
 // DecodeWebHookConfigYAML takes a webhook config string formatted as YAML and decodes it to
 // Shipyard value
 func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
@@ -91,6 +129,27 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 		if len(webhook.Requests) == 0 {
 			return nil, errors.New(webhookConfInvalid + "missing 'webhooks[].Requests[]' part")
 		}
+
+		for _, envFrom := range webhook.EnvFrom {
+			if !isSecretSourceSupported(envFrom.SecretRef.GetSource()) {
+				return nil, fmt.Errorf(webhookConfInvalid+"unsupported source '%s' of secret %s", envFrom.SecretRef.Source, envFrom.SecretRef.Name)
+			}
+		}
+
+		if webhook.When != nil {
+			if err := verifyWhen(*webhook.When); err != nil {
+				return nil, err
+			}
+		}
+
+		if webhook.Completion != nil {
+			if webHookConfig.ApiVersion != betaApiVersion {
+				return nil, errors.New(webhookConfInvalid + "'webhooks[].Completion' is only supported by webhooks of version v1beta1")
+			}
+			if err := verifyCompletion(*webhook.Completion); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if webHookConfig.ApiVersion == betaApiVersion {
@@ -104,11 +163,18 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 
 func normalizeBeta1Requests(webhooks []Webhook) error {
 	for i, webhook := range webhooks {
+		requestNames := map[string]bool{}
 		for j, request := range webhook.Requests {
 			convertedRequest := ConvertToRequest(request)
 			if err := verifyBeta1Request(convertedRequest); err != nil {
 				return err
 			}
+			if convertedRequest.Name != "" {
+				if requestNames[convertedRequest.Name] {
+					return fmt.Errorf(webhookConfInvalid+"duplicate webhook request name '%s'", convertedRequest.Name)
+				}
+				requestNames[convertedRequest.Name] = true
+			}
 			webhooks[i].Requests[j] = convertedRequest
 		}
 	}
@@ -116,6 +182,9 @@ func normalizeBeta1Requests(webhooks []Webhook) error {
 }
 
 func verifyBeta1Request(request Request) error {
+	if request.Name != "" && !requestNamePattern.MatchString(request.Name) {
+		return fmt.Errorf(webhookConfInvalid+"webhook request name '%s' must start with a letter and only contain letters, digits and underscores", request.Name)
+	}
 	if request.URL == "" {
 		return fmt.Errorf(webhookConfInvalid + "webhook request URL empty")
 	}
@@ -132,7 +201,28 @@ func verifyBeta1Request(request Request) error {
 			}
 		}
 	}
-	return nil
+	if request.TLS != nil && (request.TLS.ClientCert == "") != (request.TLS.ClientKey == "") {
+		return fmt.Errorf(webhookConfInvalid + "webhook request client certificate and key must be set together")
+	}
+	if request.Assertions != nil {
+		if err := verifyAssertions(*request.Assertions); err != nil {
+			return err
+		}
+	}
+	if err := verifyOutputs(request.Outputs); err != nil {
+		return err
+	}
+	if request.Retry != nil {
+		if err := verifyRetryPolicy(*request.Retry); err != nil {
+			return err
+		}
+	}
+	if request.RateLimit != nil {
+		if err := verifyRateLimit(*request.RateLimit); err != nil {
+			return err
+		}
+	}
+	return verifyDuration("request timeout", request.Timeout)
 }
 
 func isMethodSupported(method string) bool {
@@ -151,8 +241,15 @@ func (wh Webhook) ShouldSendStartedEvent() bool {
 	return *wh.SendStarted
 }
 
+// ShouldSendFinishedEvent returns whether the webhook service sends the .finished event of the task, which is always
+// the case for asynchronous webhooks
 func (wh Webhook) ShouldSendFinishedEvent() bool {
-	return wh.SendFinished
+	return wh.SendFinished || wh.IsAsync()
+}
+
+// IsAsync returns whether the task of the webhook is completed asynchronously, after the requests have been executed
+func (wh Webhook) IsAsync() bool {
+	return wh.Completion != nil
 }
 
 func ConvertToRequest(data interface{}) Request {
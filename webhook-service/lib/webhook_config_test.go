@@ -2,6 +2,7 @@ package lib
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -309,6 +310,476 @@ spec:
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "valid Beta1 version input - TLS and proxy",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          proxy: http://my-proxy:3128
+          tls:
+            insecureSkipVerify: true
+            caCert: "{{.env.caCert}}"
+            clientCert: "{{.env.clientCert}}"
+            clientKey: "{{.env.clientKey}}"`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method: "GET",
+									URL:    "https://localhost:8080",
+									Proxy:  "http://my-proxy:3128",
+									TLS: &TLSOptions{
+										InsecureSkipVerify: true,
+										CACert:             "{{.env.caCert}}",
+										ClientCert:         "{{.env.clientCert}}",
+										ClientKey:          "{{.env.clientKey}}",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - client certificate without key",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          tls:
+            clientCert: "{{.env.clientCert}}"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "valid Beta1 version input - assertions and outputs",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          assertions:
+            statusCodes: [200, 404]
+            headers:
+              - key: content-type
+                matches: "^application/json"
+            body:
+              - jsonPath: .status
+                equals: succeeded
+            result: warning
+          outputs:
+            - name: buildID
+              jsonPath: .id
+            - name: location
+              header: Location`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1beta1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []interface{}{
+								Request{
+									Method: "GET",
+									URL:    "https://localhost:8080",
+									Assertions: &Assertions{
+										StatusCodes: []int{200, 404},
+										Headers:     []HeaderAssertion{{Key: "content-type", Matches: "^application/json"}},
+										Body:        []BodyAssertion{{JSONPath: ".status", Equals: "succeeded"}},
+										Result:      "warning",
+									},
+									Outputs: []Output{
+										{Name: "buildID", JSONPath: ".id"},
+										{Name: "location", Header: "Location"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Beta1 version input - invalid assertion result",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          assertions:
+            result: error`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid assertion regex",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          assertions:
+            body:
+              - matches: "[a-"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid assertion jsonPath",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          assertions:
+            body:
+              - jsonPath: "{.items["`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - output without value",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: GET
+          outputs:
+            - name: buildID`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid request name",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - name: create-ticket
+          url: https://localhost:8080
+          method: POST`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - duplicate request names",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - name: createTicket
+          url: https://localhost:8080
+          method: POST
+        - name: createTicket
+          url: https://localhost:8080
+          method: POST`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "unsupported secret source",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: "token"
+          secretRef:
+            name: "my-secret"
+            key: "token"
+            source: "vault"
+      requests:
+        - url: https://localhost:8080
+          method: POST`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - unsupported completion mode",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+      completion:
+        mode: webhook`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid completion timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+      completion:
+        mode: callback
+        timeout: 1 hour`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - polling without condition",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+      completion:
+        mode: polling
+        polling:
+          request:
+            url: https://localhost:8080/status
+            method: GET`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - polling with invalid request",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+      completion:
+        mode: polling
+        polling:
+          request:
+            url: https://localhost:8080/status
+            method: DELETE
+          until:
+            statusCodes: [200]`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Alpha1 version input - completion not supported",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - "curl http://localhost:8080"
+      completion:
+        mode: callback`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid retry attempts",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+          retry:
+            maxAttempts: 0`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid retry backoff",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+          retry:
+            maxAttempts: 3
+            backoff: -1s`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid request timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+          timeout: soon`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Beta1 version input - invalid rate limit",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+          rateLimit:
+            burst: 5`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "invalid input",
 			args: args{
@@ -529,3 +1000,151 @@ func TestWebhook_ShouldSendFinishedEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeWebHookConfigYAML_Completion(t *testing.T) {
+	webhookConfig, err := DecodeWebHookConfigYAML([]byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080/builds
+          method: POST
+      completion:
+        mode: polling
+        timeout: 2h
+        polling:
+          request:
+            url: https://localhost:8080/builds/{{.responses.0.body.id}}
+            method: GET
+          interval: 1m
+          until:
+            body:
+              - jsonPath: .status
+                equals: finished
+          failWhen:
+            body:
+              - jsonPath: .status
+                equals: failed`))
+
+	require.Nil(t, err)
+	webhook := webhookConfig.Spec.Webhooks[0]
+	require.True(t, webhook.IsAsync())
+	require.True(t, webhook.ShouldSendFinishedEvent())
+	require.Equal(t, 2*time.Hour, webhook.Completion.GetTimeout())
+	require.Equal(t, time.Minute, webhook.Completion.Polling.GetInterval())
+	require.Equal(t, Request{URL: "https://localhost:8080/builds/{{.responses.0.body.id}}", Method: "GET"}, webhook.Completion.Polling.Request)
+	require.Equal(t, []BodyAssertion{{JSONPath: ".status", Equals: "finished"}}, webhook.Completion.Polling.Until.Body)
+	require.Equal(t, []BodyAssertion{{JSONPath: ".status", Equals: "failed"}}, webhook.Completion.Polling.FailWhen.Body)
+}
+
+func TestCompletion_Defaults(t *testing.T) {
+	completion := Completion{Mode: CompletionModePolling, Polling: &Polling{}}
+	require.Equal(t, time.Hour, completion.GetTimeout())
+	require.Equal(t, 30*time.Second, completion.Polling.GetInterval())
+}
+
+func TestDecodeWebHookConfigYAML_RequestPolicies(t *testing.T) {
+	webhookConfig, err := DecodeWebHookConfigYAML([]byte(`apiVersion: webhookconfig.keptn.sh/v1beta1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: https://localhost:8080
+          method: POST
+          retry:
+            maxAttempts: 3
+            backoff: 2s
+            statusCodes: [502]
+          timeout: 10s
+          rateLimit:
+            requestsPerMinute: 30`))
+
+	require.Nil(t, err)
+	request := webhookConfig.Spec.Webhooks[0].Requests[0].(Request)
+	require.Equal(t, &RetryPolicy{MaxAttempts: 3, Backoff: "2s", StatusCodes: []int{502}}, request.Retry)
+	require.Equal(t, "10s", request.Timeout)
+	require.Equal(t, &RateLimit{RequestsPerMinute: 30}, request.RateLimit)
+}
+
+func TestDecodeWebHookConfigYAML_When(t *testing.T) {
+	tests := []struct {
+		name    string
+		when    string
+		wantErr bool
+	}{
+		{
+			name: "conditions and expression",
+			when: `
+      when:
+        conditions:
+          - jsonPath: .data.result
+            equals: fail
+        expression: '{{ne .data.stage "dev"}}'
+        onSkip: pass`,
+		},
+		{
+			name: "empty",
+			when: `
+      when:
+        onSkip: pass`,
+			wantErr: true,
+		},
+		{
+			name: "unsupported onSkip",
+			when: `
+      when:
+        expression: "true"
+        onSkip: fail`,
+			wantErr: true,
+		},
+		{
+			name: "empty condition",
+			when: `
+      when:
+        conditions:
+          - equals: ""`,
+			wantErr: true,
+		},
+		{
+			name: "invalid jsonPath",
+			when: `
+      when:
+        conditions:
+          - jsonPath: .data[
+            equals: fail`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookConfig, err := DecodeWebHookConfigYAML([]byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.evaluation.finished"
+      subscriptionID: "my-subscription-id"` + tt.when + `
+      requests:
+        - "curl http://localhost:8080"`))
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, &When{
+				Conditions: []BodyAssertion{{JSONPath: ".data.result", Equals: "fail"}},
+				Expression: `{{ne .data.stage "dev"}}`,
+				OnSkip:     WhenOnSkipPass,
+			}, webhookConfig.Spec.Webhooks[0].When)
+		})
+	}
+}
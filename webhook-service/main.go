@@ -1,7 +1,14 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/keptn/go-utils/pkg/sdk"
 	"github.com/keptn/keptn/webhook-service/handler"
@@ -13,7 +20,20 @@ import (
 
 const eventTypeWildcard = "*"
 const serviceName = "webhook-service"
+const apiGatewayServiceName = "api-gateway-nginx"
 const envVarLogLevel = "LOG_LEVEL"
+const envVarRequestTimeout = "REQUEST_TIMEOUT"
+const envVarAPIPort = "API_PORT"
+const envVarCallbackBaseURL = "CALLBACK_BASE_URL"
+const envVarSecretSources = "SECRET_SOURCES"
+const envVarSecretFilesDir = "SECRET_FILES_DIR"
+const envVarSecretScopes = "SECRET_SCOPES"
+const envVarRequireAllowList = "REQUIRE_ALLOW_LIST"
+const envVarAllowListAdminToken = "ALLOW_LIST_ADMIN_TOKEN"
+const defaultAPIPort = "8081"
+const defaultSecretSources = lib.SecretSourceK8s
+const defaultSecretFilesDir = "/keptn/secrets"
+const defaultSecretScopes = "keptn-webhook-service"
 
 func main() {
 	if os.Getenv(envVarLogLevel) != "" {
@@ -28,7 +48,7 @@ func main() {
 	if err != nil {
 		log.Fatalf("could not create kubernetes client: %v", err)
 	}
-	secretReader := lib.NewK8sSecretReader(kubeAPI)
+	secretReader, secretReaderOpts := getSecretReaders(kubeAPI)
 
 	curlExecutor := lib.NewCmdCurlExecutor(
 		&lib.OSCmdExecutor{},
@@ -37,9 +57,28 @@ func main() {
 	ipResolver := lib.NewIPResolver()
 	denyListProvider := lib.NewDenyListProvider(kubeAPI)
 	requestValidator := lib.NewRequestValidator(denyListProvider, ipResolver)
-	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, requestValidator, secretReader)
+	allowListProvider := lib.NewAllowListProvider(kubeAPI)
+	allowListValidator := lib.NewAllowListValidator(allowListProvider, ipResolver, os.Getenv(envVarRequireAllowList) == "true")
+	httpExecutorOpts := append(getHTTPExecutorOptions(), lib.WithAllowListValidator(allowListValidator))
+	httpExecutor := lib.NewHTTPExecutor(denyListProvider, ipResolver, httpExecutorOpts...)
 
-	log.Fatal(sdk.NewKeptn(
+	apiPort := getEnvOrDefault(envVarAPIPort, defaultAPIPort)
+	// by default, callbacks are served by the API gateway, which forwards /api/webhook-service/v1/callback/ to the API port
+	callbackRegistry, err := lib.NewCallbackRegistry(getEnvOrDefault(envVarCallbackBaseURL, fmt.Sprintf("http://%s.%s/api/%s", apiGatewayServiceName, os.Getenv("POD_NAMESPACE"), serviceName)))
+	if err != nil {
+		log.Fatalf("could not create callback registry: %v", err)
+	}
+
+	// the SDK only returns once all events have been handled, therefore the task handler must stop waiting for
+	// retries and pending completions as soon as the service is terminated
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	taskHandlerOpts := append(secretReaderOpts, handler.WithCallbackRegistry(callbackRegistry), handler.WithAllowListValidator(allowListValidator), handler.WithContext(ctx))
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, httpExecutor, requestValidator, secretReader, taskHandlerOpts...)
+	go startAPIServer(apiPort, callbackRegistry, taskHandler, allowListProvider)
+
+	err = sdk.NewKeptn(
 		serviceName,
 		sdk.WithTaskHandler(
 			eventTypeWildcard,
@@ -47,7 +86,78 @@ func main() {
 		),
 		sdk.WithAutomaticResponse(false),
 		sdk.WithLogger(log.StandardLogger()),
-	).Start())
+	).Start()
+	// pending asynchronous webhooks can not be completed after a restart, their tasks are therefore finished with an error
+	taskHandler.Shutdown()
+	log.Fatal(err)
+}
+
+func getHTTPExecutorOptions() []lib.HTTPExecutorOption {
+	opts := []lib.HTTPExecutorOption{}
+	if os.Getenv(envVarRequestTimeout) != "" {
+		timeout, err := time.ParseDuration(os.Getenv(envVarRequestTimeout))
+		if err != nil {
+			log.WithError(err).Error("could not parse request timeout provided by 'REQUEST_TIMEOUT' env var")
+		} else {
+			opts = append(opts, lib.WithRequestTimeout(timeout))
+		}
+	}
+	return opts
+}
+
+// getSecretReaders returns the reader of the source 'k8s', which is nil if the source is disabled, and the options
+// enabling the other secret sources listed by the 'SECRET_SOURCES' env var
+func getSecretReaders(kubeAPI kubernetes.Interface) (lib.ISecretReader, []handler.TaskHandlerOption) {
+	var k8sSecretReader lib.ISecretReader
+	opts := []handler.TaskHandlerOption{}
+	for _, source := range splitAndTrim(getEnvOrDefault(envVarSecretSources, defaultSecretSources)) {
+		switch source {
+		case lib.SecretSourceK8s:
+			k8sSecretReader = lib.NewK8sSecretReader(kubeAPI)
+		case lib.SecretSourceFile:
+			opts = append(opts, handler.WithSecretReader(source, lib.NewFileSecretReader(getEnvOrDefault(envVarSecretFilesDir, defaultSecretFilesDir))))
+		case lib.SecretSourceEnv:
+			opts = append(opts, handler.WithSecretReader(source, lib.NewEnvSecretReader()))
+		case lib.SecretSourceK8sScope:
+			scopes := splitAndTrim(getEnvOrDefault(envVarSecretScopes, defaultSecretScopes))
+			opts = append(opts, handler.WithSecretReader(source, lib.NewScopedSecretReader(kubeAPI, scopes)))
+		default:
+			log.Errorf("unsupported secret source '%s' provided by 'SECRET_SOURCES' env var", source)
+		}
+	}
+	return k8sSecretReader, opts
+}
+
+func splitAndTrim(value string) []string {
+	result := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// startAPIServer serves the callback URLs that complete asynchronous webhooks, the validation of webhook configurations
+// and the allow-lists of projects
+func startAPIServer(port string, callbackRegistry *lib.CallbackRegistry, taskHandler *handler.TaskHandler, allowListProvider lib.AllowListProvider) {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/callback/", handler.NewCallbackHandler(callbackRegistry))
+	mux.Handle("/v1/webhook-config/validate", handler.NewValidationHandler(taskHandler))
+	mux.Handle("/v1/project/", handler.NewAllowListHandler(allowListProvider, os.Getenv(envVarAllowListAdminToken)))
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Fatal(server.ListenAndServe())
+}
+
+func getEnvOrDefault(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
 }
 
 func createKubeAPI() (*kubernetes.Clientset, error) {